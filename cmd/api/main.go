@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/holmes89/archaea/server"
@@ -11,19 +14,26 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
 	conversationsvc "github.com/holmes89/grey-seal/lib/greyseal/conversation"
 	conversationgrpc "github.com/holmes89/grey-seal/lib/greyseal/conversation/grpc"
+	conversationopenai "github.com/holmes89/grey-seal/lib/greyseal/conversation/openai"
+	modelsvc "github.com/holmes89/grey-seal/lib/greyseal/model"
+	modelgrpc "github.com/holmes89/grey-seal/lib/greyseal/model/grpc"
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
 	resourcesvc "github.com/holmes89/grey-seal/lib/greyseal/resource"
 	resourcegrpc "github.com/holmes89/grey-seal/lib/greyseal/resource/grpc"
 	rolesvc "github.com/holmes89/grey-seal/lib/greyseal/role"
 	rolegrpc "github.com/holmes89/grey-seal/lib/greyseal/role/grpc"
+	workspacesvc "github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	workspacegrpc "github.com/holmes89/grey-seal/lib/greyseal/workspace/grpc"
 	"github.com/holmes89/grey-seal/lib/repo"
 	"github.com/holmes89/grey-seal/lib/repo/cache"
 	"github.com/holmes89/grey-seal/lib/repo/ollama"
 	"github.com/holmes89/grey-seal/lib/repo/transcript"
 	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
-	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1/services"
 	shrikeconnect "github.com/holmes89/shrike/lib/schemas/shrike/v1/services/servicesv1connect"
 )
 
@@ -32,6 +42,10 @@ func main() {
 	defer cancel()
 
 	logger, _ := zap.NewProduction()
+	redaction := redactionPipeline(logger)
+	logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return redact.NewCore(core, redaction)
+	}))
 	defer logger.Sync() //nolint:errcheck
 
 	shutdown, err := initOTel(ctx, "grey-seal", logger)
@@ -49,13 +63,17 @@ func main() {
 	defer store.Close()
 
 	ollamaLLM := ollama.NewLLM()
+	// Check, pull and warm the configured models in the background so a cold
+	// Ollama container does not block the API from serving.
+	go ollamaLLM.EnsureModels(ctx, logger)
 
 	shrikeURL := os.Getenv("SHRIKE_URL")
 	if shrikeURL == "" {
 		shrikeURL = "http://shrike:9000"
 	}
 	shrikeClient := shrikeconnect.NewSearchServiceClient(&http.Client{}, shrikeURL)
-	searcher := &shrikeSearcher{client: shrikeClient}
+	resourceRepo := &repo.ResourceRepo{Conn: store}
+	searcher := newShrikeSearcher(shrikeClient, resourceRepo)
 
 	srv := server.New(":9000",
 		func(h http.Handler) http.Handler {
@@ -73,10 +91,34 @@ func main() {
 		func(h http.Handler) http.Handler { return otelhttp.NewHandler(h, "grey-seal") },
 	)
 
+	// Authentication: API keys are always accepted; JWTs only when a JWKS file
+	// is configured. AUTH_DISABLED=true serves every RPC unauthenticated.
+	var handlerOpts []connect.HandlerOption
+	var authenticate func(*http.Request) (context.Context, error)
+	if os.Getenv("AUTH_DISABLED") == "true" {
+		logger.Warn("authentication disabled; all conversations are visible to every caller")
+	} else {
+		var tokens auth.Authenticator
+		if jwksFile := os.Getenv("AUTH_JWKS_FILE"); jwksFile != "" {
+			tokens, err = auth.NewJWKSAuthenticator(jwksFile, os.Getenv("AUTH_JWT_ISSUER"), os.Getenv("AUTH_JWT_AUDIENCE"))
+			if err != nil {
+				logger.Fatal("failed to load jwks", zap.Error(err))
+			}
+			logger.Info("jwt authentication enabled", zap.String("jwks_file", jwksFile))
+		}
+		apiKeys := auth.NewAPIKeyAuthenticator(&repo.APIKeyRepo{Conn: store})
+		interceptor := auth.NewInterceptor(apiKeys, tokens, logger,
+			servicesconnect.ModelServiceName,
+			servicesconnect.WorkspaceServiceName,
+		)
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(interceptor))
+		authenticate = interceptor.AuthenticateRequest
+	}
+
 	// Role service
 	roleRepo := &repo.RoleRepo{Conn: store}
 	roleSvc := rolesvc.NewRoleService(roleRepo, logger)
-	rolePath, roleHandler := servicesconnect.NewRoleServiceHandler(rolegrpc.NewRoleHandler(roleSvc))
+	rolePath, roleHandler := servicesconnect.NewRoleServiceHandler(rolegrpc.NewRoleHandler(roleSvc), handlerOpts...)
 	logger.Info("registering role service route", zap.String("path", rolePath))
 	srv.Handle(rolePath, roleHandler)
 
@@ -85,9 +127,8 @@ func main() {
 	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
 		indexer = resourcesvc.NewKafkaIndexer(brokers, logger)
 	}
-	resourceRepo := &repo.ResourceRepo{Conn: store}
 	resSvc := resourcesvc.NewResourceService(resourceRepo, indexer, logger)
-	resourcePath, resourceHandler := servicesconnect.NewResourceServiceHandler(resourcegrpc.NewResourceHandler(resSvc))
+	resourcePath, resourceHandler := servicesconnect.NewResourceServiceHandler(resourcegrpc.NewResourceHandler(resSvc), handlerOpts...)
 	logger.Info("registering resource service route", zap.String("path", resourcePath))
 	srv.Handle(resourcePath, resourceHandler)
 
@@ -107,11 +148,19 @@ func main() {
 		if err != nil {
 			logger.Warn("failed to create transcript writer", zap.Error(err))
 		} else {
+			tw.MarkdownTurns = os.Getenv("TRANSCRIPT_MARKDOWN") == "true"
 			transcriptWriter = tw
 			logger.Info("transcript writer enabled", zap.String("dir", dir))
 		}
 	}
 
+	// Deleted conversations stay restorable for CONVERSATION_RESTORE_WINDOW;
+	// CONVERSATION_STALE_AFTER additionally trashes inactive ones.
+	retention := conversationsvc.RetentionPolicy{
+		RestoreWindow: durationEnv("CONVERSATION_RESTORE_WINDOW", conversationsvc.DefaultRestoreWindow, logger),
+		StaleAfter:    durationEnv("CONVERSATION_STALE_AFTER", 0, logger),
+	}
+
 	convSvc := conversationsvc.NewConversationService(
 		convRepo,
 		messageRepo,
@@ -120,12 +169,47 @@ func main() {
 		ollamaLLM,
 		resourceCache,
 		logger,
-		transcriptWriter,
+		&repo.FeedbackRepo{Conn: store},
+		conversationsvc.WithTranscriptWriter(transcriptWriter),
+		conversationsvc.WithResources(resourceRepo),
+		conversationsvc.WithRetention(retention),
+		conversationsvc.WithTraces(&repo.TraceRepo{Conn: store}),
+		conversationsvc.WithRedaction(redaction),
+		conversationsvc.WithPromptBudget(intEnv("PROMPT_BUDGET_TOKENS", 0, logger)),
+		conversationsvc.WithSanitizer(contextSanitizer(logger)),
 	)
-	convPath, convHandler := servicesconnect.NewConversationServiceHandler(conversationgrpc.NewConversationHandler(convSvc))
+	if interval := durationEnv("RETENTION_INTERVAL", time.Hour, logger); interval > 0 {
+		go conversationsvc.RunRetention(ctx, convSvc, interval, logger)
+	}
+	convPath, convHandler := servicesconnect.NewConversationServiceHandler(conversationgrpc.NewConversationHandler(convSvc).WithLogger(logger), handlerOpts...)
 	logger.Info("registering conversation service route", zap.String("path", convPath))
 	srv.Handle(convPath, convHandler)
 
+	// ChatSession over a WebSocket for browsers.
+	logger.Info("registering chat session websocket route", zap.String("path", "/ws/chat"))
+	chatSocket := conversationgrpc.NewChatSessionWebSocket(convSvc, authenticate, logger)
+	if origins := os.Getenv("WS_ALLOWED_ORIGINS"); origins != "" {
+		chatSocket = chatSocket.WithAllowedOrigins(strings.Split(origins, ",")...)
+	}
+	srv.Handle("/ws/chat", chatSocket)
+
+	// OpenAI-compatible chat completions over the same conversations; each
+	// role is a model.
+	logger.Info("registering openai-compatible routes", zap.String("path", "/v1/"))
+	srv.Handle("/v1/", conversationopenai.NewHandler(convSvc, roleRepo, authenticate, logger))
+
+	// Model admin service
+	modelSvc := modelsvc.NewModelService(ollamaLLM, logger)
+	modelPath, modelHandler := servicesconnect.NewModelServiceHandler(modelgrpc.NewModelHandler(modelSvc), handlerOpts...)
+	logger.Info("registering model service route", zap.String("path", modelPath))
+	srv.Handle(modelPath, modelHandler)
+
+	// Workspace admin service
+	workspaceSvc := workspacesvc.NewWorkspaceService(&repo.WorkspaceRepo{Conn: store}, logger)
+	workspacePath, workspaceHandler := servicesconnect.NewWorkspaceServiceHandler(workspacegrpc.NewWorkspaceHandler(workspaceSvc), handlerOpts...)
+	logger.Info("registering workspace service route", zap.String("path", workspacePath))
+	srv.Handle(workspacePath, workspaceHandler)
+
 	srv.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("ok")) //nolint:errcheck
 	})
@@ -135,33 +219,90 @@ func main() {
 	}
 }
 
-// shrikeSearcher adapts the shrike SearchServiceClient to conversation.Searcher.
-type shrikeSearcher struct {
-	client shrikeconnect.SearchServiceClient
+// durationEnv parses a duration such as "720h" from the environment, falling
+// back to def when the variable is unset or invalid.
+func durationEnv(name string, def time.Duration, logger *zap.Logger) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logger.Warn("invalid duration, using default", zap.String("name", name), zap.String("value", v), zap.Duration("default", def))
+		return def
+	}
+	return d
 }
 
-func (s *shrikeSearcher) Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversationsvc.SearchResult, error) {
-	req := &shrikev1.SearchRequest{
-		Query: query,
-		Limit: limit,
-		Mode:  "hybrid",
-	}
-	if len(resourceUUIDs) > 0 {
-		req.Filter = &shrikev1.SearchFilter{EntityUuids: resourceUUIDs}
+// intEnv parses an integer from the environment, falling back to def when
+// the variable is unset or invalid.
+func intEnv(name string, def int, logger *zap.Logger) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
 	}
-	resp, err := s.client.Search(ctx, connect.NewRequest(req))
+	n, err := strconv.Atoi(v)
 	if err != nil {
-		return nil, err
+		logger.Warn("invalid integer, using default", zap.String("name", name), zap.String("value", v), zap.Int("default", def))
+		return def
+	}
+	return n
+}
+
+// contextSanitizer builds the retrieved-context sanitizer from
+// CONTEXT_SANITIZER (on or off), INJECTION_FLAG_RISK and INJECTION_DROP_RISK.
+// Invalid thresholds fall back to their defaults.
+func contextSanitizer(logger *zap.Logger) *conversationsvc.ContextSanitizer {
+	if strings.EqualFold(os.Getenv("CONTEXT_SANITIZER"), "off") {
+		return nil
+	}
+	risk := func(name string, def float32) float32 {
+		v := os.Getenv(name)
+		if v == "" {
+			return def
+		}
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil || f <= 0 || f > 1 {
+			logger.Warn("invalid risk threshold, using default", zap.String("name", name), zap.String("value", v), zap.Float32("default", def))
+			return def
+		}
+		return float32(f)
 	}
+	return conversationsvc.NewContextSanitizer(
+		risk("INJECTION_FLAG_RISK", conversationsvc.DefaultInjectionFlagRisk),
+		risk("INJECTION_DROP_RISK", conversationsvc.DefaultInjectionDropRisk),
+	)
+}
 
-	results := make([]conversationsvc.SearchResult, 0, len(resp.Msg.GetResults()))
-	for _, r := range resp.Msg.GetResults() {
-		results = append(results, conversationsvc.SearchResult{
-			EntityUUID: r.GetEntityUuid(),
-			Title:      r.GetTitle(),
-			Snippet:    r.GetSnippet(),
-			Score:      r.GetScore(),
-		})
+// redactionPipeline builds the redaction policy from REDACT_PROMPT,
+// REDACT_MESSAGES, REDACT_TRANSCRIPTS and REDACT_LOGS (off, mask or token;
+// token only for the prompt) plus custom patterns from REDACT_PATTERNS_FILE.
+// Invalid modes fall back to mask rather than leaking data.
+func redactionPipeline(logger *zap.Logger) *redact.Pipeline {
+	mode := func(name string, tokens bool) redact.Mode {
+		m, err := redact.ParseMode(os.Getenv(name))
+		if err != nil || (m == redact.ModeToken && !tokens) {
+			logger.Warn("invalid redaction mode, using mask", zap.String("name", name), zap.String("value", os.Getenv(name)))
+			return redact.ModeMask
+		}
+		return m
+	}
+	policy := redact.Policy{
+		Prompt:      mode("REDACT_PROMPT", true),
+		Messages:    mode("REDACT_MESSAGES", false),
+		Transcripts: mode("REDACT_TRANSCRIPTS", false),
+		Logs:        mode("REDACT_LOGS", false),
+	}
+	var custom []redact.Detector
+	if path := os.Getenv("REDACT_PATTERNS_FILE"); path != "" {
+		var err error
+		if custom, err = redact.LoadPatterns(path); err != nil {
+			logger.Fatal("failed to load redaction patterns", zap.String("path", path), zap.Error(err))
+		}
+	}
+	pipeline, err := redact.NewPipeline(policy, custom)
+	if err != nil {
+		logger.Fatal("invalid redaction policy", zap.Error(err))
 	}
-	return results, nil
+	return pipeline
 }
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	conversationsvc "github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1/services"
+	shrikeconnect "github.com/holmes89/shrike/lib/schemas/shrike/v1/services/servicesv1connect"
+)
+
+const (
+	// workspaceResourceTTL is how long an unused workspace's resources stay
+	// cached.
+	workspaceResourceTTL = time.Minute
+	// maxFilterUUIDs caps the entity filter of one shrike request. Larger
+	// scopes are searched in batches and the results merged by score.
+	maxFilterUUIDs = 500
+)
+
+// resourceLister lists the resources visible to the caller's workspace.
+// Version changes whenever one of them is created or deleted.
+type resourceLister interface {
+	List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Resource, error)
+	Version(ctx context.Context) (string, error)
+}
+
+// shrikeSearcher adapts the shrike SearchServiceClient to conversation.Searcher.
+// Shrike knows nothing of workspaces, so scoped searches are restricted to
+// the workspace's resources, which are cached per workspace.
+type shrikeSearcher struct {
+	client    shrikeconnect.SearchServiceClient
+	resources resourceLister
+	now       func() time.Time
+
+	mu        sync.Mutex
+	workspace map[string]workspaceResources
+}
+
+// workspaceResources is the cached set of a workspace's resources at
+// version.
+type workspaceResources struct {
+	uuids   map[string]bool
+	version string
+	expires time.Time
+}
+
+func newShrikeSearcher(client shrikeconnect.SearchServiceClient, resources resourceLister) *shrikeSearcher {
+	return &shrikeSearcher{client: client, resources: resources, now: time.Now, workspace: map[string]workspaceResources{}}
+}
+
+func (s *shrikeSearcher) Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversationsvc.SearchResult, error) {
+	ws, scoped := auth.WorkspaceFromContext(ctx)
+	if !scoped {
+		return s.search(ctx, query, limit, resourceUUIDs)
+	}
+	allowed, err := s.workspaceResources(ctx, ws, resourceUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing indexed in this scope: never fall back to an unscoped search.
+	if len(allowed) == 0 {
+		return []conversationsvc.SearchResult{}, nil
+	}
+	uuids := slices.Sorted(maps.Keys(allowed))
+	if len(uuids) <= maxFilterUUIDs {
+		return s.search(ctx, query, limit, uuids)
+	}
+
+	batches := slices.Collect(slices.Chunk(uuids, maxFilterUUIDs))
+	found := make([][]conversationsvc.SearchResult, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Go(func() {
+			found[i], errs[i] = s.search(ctx, query, limit, batch)
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	results := slices.Concat(found...)
+	slices.SortStableFunc(results, func(a, b conversationsvc.SearchResult) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if limit > 0 && len(results) > int(limit) {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// search runs one shrike search, filtered to resourceUUIDs when any are
+// given.
+func (s *shrikeSearcher) search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversationsvc.SearchResult, error) {
+	req := &shrikev1.SearchRequest{
+		Query: query,
+		Limit: limit,
+		Mode:  "hybrid",
+	}
+	if len(resourceUUIDs) > 0 {
+		req.Filter = &shrikev1.SearchFilter{EntityUuids: resourceUUIDs}
+	}
+	resp, err := s.client.Search(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]conversationsvc.SearchResult, 0, len(resp.Msg.GetResults()))
+	for _, r := range resp.Msg.GetResults() {
+		results = append(results, conversationsvc.SearchResult{
+			EntityUUID: r.GetEntityUuid(),
+			Title:      r.GetTitle(),
+			Snippet:    r.GetSnippet(),
+			Score:      r.GetScore(),
+		})
+	}
+	return results, nil
+}
+
+// workspaceResources returns the resources of workspace ws that a
+// search may return. An empty request means every one of them; otherwise
+// requested UUIDs outside the workspace are dropped.
+func (s *shrikeSearcher) workspaceResources(ctx context.Context, ws string, requested []string) (map[string]bool, error) {
+	visible, err := s.visible(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	if len(requested) == 0 {
+		return visible, nil
+	}
+	uuids := make(map[string]bool, len(requested))
+	for _, id := range requested {
+		if visible[id] {
+			uuids[id] = true
+		}
+	}
+	return uuids, nil
+}
+
+// visible returns the resources of workspace ws. The cached list is reused
+// while the workspace's resource version is unchanged, so resources created
+// or deleted by any process are seen by the next search; workspaces not
+// searched for workspaceResourceTTL are evicted.
+func (s *shrikeSearcher) visible(ctx context.Context, ws string) (map[string]bool, error) {
+	version, err := s.resources.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.workspace[ws]
+	if ok && cached.version == version {
+		cached.expires = now.Add(workspaceResourceTTL)
+		s.workspace[ws] = cached
+		s.mu.Unlock()
+		return cached.uuids, nil
+	}
+	s.mu.Unlock()
+
+	resources, err := s.resources.List(ctx, "", 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	uuids := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		uuids[r.GetUuid()] = true
+	}
+	s.mu.Lock()
+	maps.DeleteFunc(s.workspace, func(_ string, cached workspaceResources) bool {
+		return !now.Before(cached.expires)
+	})
+	s.workspace[ws] = workspaceResources{uuids: uuids, version: version, expires: now.Add(workspaceResourceTTL)}
+	s.mu.Unlock()
+	return uuids, nil
+}
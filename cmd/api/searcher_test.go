@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1/services"
+)
+
+// fakeResources lists the same resources for every workspace.
+type fakeResources struct {
+	uuids []string
+	calls int
+}
+
+func (f *fakeResources) Version(context.Context) (string, error) {
+	return fmt.Sprint(len(f.uuids)), nil
+}
+
+func (f *fakeResources) List(context.Context, string, uint, map[string][]any) ([]*greysealv1.Resource, error) {
+	f.calls++
+	resources := make([]*greysealv1.Resource, 0, len(f.uuids))
+	for _, id := range f.uuids {
+		resources = append(resources, &greysealv1.Resource{Uuid: id})
+	}
+	return resources, nil
+}
+
+// fakeShrike records requests and answers with the results that pass the
+// filter, scored by scores.
+type fakeShrike struct {
+	mu       sync.Mutex
+	requests []*shrikev1.SearchRequest
+	results  []string
+	scores   map[string]float32
+}
+
+func (f *fakeShrike) Search(_ context.Context, req *connect.Request[shrikev1.SearchRequest]) (*connect.Response[shrikev1.SearchResponse], error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req.Msg)
+	f.mu.Unlock()
+	resp := &shrikev1.SearchResponse{}
+	for _, id := range f.results {
+		if filter := req.Msg.Filter; filter != nil && !slices.Contains(filter.EntityUuids, id) {
+			continue
+		}
+		resp.Results = append(resp.Results, &shrikev1.SearchResult{EntityUuid: id, Score: f.scores[id]})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func workspaceCtx(ws string) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", WorkspaceUUID: ws})
+}
+
+func TestSearch_EmptyWorkspace(t *testing.T) {
+	shrike := &fakeShrike{results: []string{"res-other"}}
+	s := newShrikeSearcher(shrike, &fakeResources{})
+
+	results, err := s.Search(workspaceCtx("ws-1"), "refunds", 5, nil)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, shrike.requests, "an empty workspace must not search unscoped")
+}
+
+func TestSearch_FiltersByWorkspace(t *testing.T) {
+	shrike := &fakeShrike{results: []string{"res-1"}}
+	resources := &fakeResources{uuids: []string{"res-2", "res-1"}}
+	s := newShrikeSearcher(shrike, resources)
+
+	_, err := s.Search(workspaceCtx("ws-1"), "refunds", 5, nil)
+	require.NoError(t, err)
+	_, err = s.Search(workspaceCtx("ws-1"), "refunds", 5, []string{"res-2", "res-other"})
+	require.NoError(t, err)
+
+	require.Len(t, shrike.requests, 2)
+	assert.Equal(t, []string{"res-1", "res-2"}, shrike.requests[0].Filter.EntityUuids)
+	assert.Equal(t, []string{"res-2"}, shrike.requests[1].Filter.EntityUuids)
+	assert.Equal(t, 1, resources.calls, "the workspace's resources are cached")
+}
+
+func TestSearch_NewResourceInvalidatesCache(t *testing.T) {
+	shrike := &fakeShrike{results: []string{"res-1", "res-2"}}
+	resources := &fakeResources{uuids: []string{"res-1"}}
+	s := newShrikeSearcher(shrike, resources)
+
+	_, err := s.Search(workspaceCtx("ws-1"), "refunds", 5, nil)
+	require.NoError(t, err)
+	resources.uuids = append(resources.uuids, "res-2")
+	results, err := s.Search(workspaceCtx("ws-1"), "refunds", 5, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, resources.calls)
+	assert.Equal(t, []string{"res-1", "res-2"}, shrike.requests[1].Filter.EntityUuids)
+	assert.Len(t, results, 2)
+}
+
+func TestSearch_EvictsIdleWorkspaces(t *testing.T) {
+	now := time.Now()
+	s := newShrikeSearcher(&fakeShrike{}, &fakeResources{uuids: []string{"res-1"}})
+	s.now = func() time.Time { return now }
+
+	_, err := s.Search(workspaceCtx("ws-1"), "refunds", 5, nil)
+	require.NoError(t, err)
+	now = now.Add(workspaceResourceTTL)
+	_, err = s.Search(workspaceCtx("ws-2"), "refunds", 5, nil)
+	require.NoError(t, err)
+
+	assert.NotContains(t, s.workspace, "ws-1")
+	assert.Contains(t, s.workspace, "ws-2")
+}
+
+func TestSearch_LargeWorkspaceSearchesInBatches(t *testing.T) {
+	resources := &fakeResources{}
+	for i := range maxFilterUUIDs + 1 {
+		resources.uuids = append(resources.uuids, fmt.Sprintf("res-%04d", i))
+	}
+	last := resources.uuids[maxFilterUUIDs]
+	shrike := &fakeShrike{
+		results: []string{"res-other", "res-0001", "res-0002", last},
+		scores:  map[string]float32{"res-other": 0.99, "res-0001": 0.5, "res-0002": 0.2, last: 0.9},
+	}
+	s := newShrikeSearcher(shrike, resources)
+
+	results, err := s.Search(workspaceCtx("ws-1"), "refunds", 2, nil)
+	require.NoError(t, err)
+
+	require.Len(t, shrike.requests, 2)
+	var filtered int
+	for _, req := range shrike.requests {
+		require.NotNil(t, req.Filter, "every batch is filtered to the workspace")
+		assert.Equal(t, int32(2), req.Limit)
+		filtered += len(req.Filter.EntityUuids)
+	}
+	assert.Equal(t, maxFilterUUIDs+1, filtered)
+	require.Len(t, results, 2)
+	assert.Equal(t, last, results[0].EntityUUID)
+	assert.Equal(t, "res-0001", results[1].EntityUUID)
+}
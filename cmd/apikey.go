@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	"github.com/holmes89/grey-seal/lib/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiKeyOwner     string
+	apiKeyName      string
+	apiKeyScopes    []string
+	apiKeyWorkspace string
+	apiKeyDatabase  string
+)
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage API keys",
+	Long: `Create and revoke API keys. These commands talk to the database directly
+so the first admin key can be issued before any credentials exist.`,
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key and print it once",
+	RunE:  runAPIKeyCreate,
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <uuid>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAPIKeyRevoke,
+}
+
+func runAPIKeyCreate(cmd *cobra.Command, args []string) error {
+	if apiKeyOwner == "" {
+		return fmt.Errorf("--owner is required")
+	}
+	store, err := repo.NewDatabase(apiKeyDatabase)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if _, err := (&repo.WorkspaceRepo{Conn: store}).Get(context.Background(), apiKeyWorkspace); err != nil {
+		return fmt.Errorf("unknown workspace %q: %w", apiKeyWorkspace, err)
+	}
+
+	key, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	record := &auth.APIKey{
+		Uuid:          uuid.New().String(),
+		Name:          apiKeyName,
+		Owner:         apiKeyOwner,
+		KeyHash:       hash,
+		Scopes:        apiKeyScopes,
+		WorkspaceUUID: apiKeyWorkspace,
+		CreatedAt:     time.Now(),
+	}
+	if err := (&repo.APIKeyRepo{Conn: store}).Create(context.Background(), record); err != nil {
+		return fmt.Errorf("failed to store key: %w", err)
+	}
+
+	fmt.Printf("API key UUID: %s\n", record.Uuid)
+	fmt.Printf("API key:      %s\n", key)
+	fmt.Println("Store this key now; it cannot be shown again.")
+	return nil
+}
+
+func runAPIKeyRevoke(cmd *cobra.Command, args []string) error {
+	store, err := repo.NewDatabase(apiKeyDatabase)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := (&repo.APIKeyRepo{Conn: store}).Revoke(context.Background(), args[0]); err != nil {
+		return fmt.Errorf("failed to revoke key: %w", err)
+	}
+	fmt.Printf("Revoked API key %s\n", args[0])
+	return nil
+}
+
+func init() {
+	apiKeyCmd.PersistentFlags().StringVar(&apiKeyDatabase, "database-url", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyOwner, "owner", "", "Subject that owns conversations created with this key (required)")
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyName, "name", "", "Human-readable label for the key")
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyScopes, "scope", nil, "Scope to grant; repeatable (e.g. --scope admin)")
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyWorkspace, "workspace", workspace.DefaultUUID, "UUID of the workspace the key is bound to")
+
+	apiKeyCmd.AddCommand(apiKeyCreateCmd, apiKeyRevokeCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+}
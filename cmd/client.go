@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"connectrpc.com/connect"
+)
+
+// apiKey authenticates CLI calls to the API server. Defaults to $GREY_SEAL_API_KEY.
+var apiKey string
+
+// clientOptions returns the Connect options shared by every CLI client.
+func clientOptions() []connect.ClientOption {
+	opts := []connect.ClientOption{connect.WithGRPCWeb()}
+	if apiKey != "" {
+		opts = append(opts, connect.WithInterceptors(&bearerInterceptor{token: apiKey}))
+	}
+	return opts
+}
+
+// bearerInterceptor attaches an Authorization header to outgoing requests.
+type bearerInterceptor struct {
+	token string
+}
+
+func (b *bearerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+b.token)
+		return next(ctx, req)
+	}
+}
+
+func (b *bearerInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+b.token)
+		return conn
+	}
+}
+
+func (b *bearerInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", os.Getenv("GREY_SEAL_API_KEY"), "API key used to authenticate with the server")
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/importer"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conversationServer          string
+	conversationExportFormat    string
+	conversationExportOutput    string
+	conversationImportFrom      string
+	conversationImportDryRun    bool
+	conversationImportSummarize bool
+)
+
+var conversationCmd = &cobra.Command{
+	Use:   "conversation",
+	Short: "Export, import, replay and trace conversations",
+}
+
+var conversationExportCmd = &cobra.Command{
+	Use:   "export <uuid>",
+	Short: "Export a conversation as JSON, JSONL or Markdown",
+	Long: `Export a conversation with its messages, feedback, a snapshot of its role
+and the resources it cites. JSON and JSONL exports can be imported again;
+Markdown is a read-only transcript.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConversationExport,
+}
+
+var conversationImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import conversations from grey-seal, ChatGPT or Open WebUI exports",
+	Long: `Import a grey-seal JSON or JSONL export, a ChatGPT conversations.json or an
+Open WebUI chat export. Only the active branch of ChatGPT and Open WebUI
+conversations is kept. Use --dry-run to see what would be imported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConversationImport,
+}
+
+func runConversationExport(cmd *cobra.Command, args []string) error {
+	var format services.ExportFormat
+	switch conversation.ExportFormat(conversationExportFormat) {
+	case conversation.FormatJSON:
+		format = services.ExportFormat_EXPORT_FORMAT_JSON
+	case conversation.FormatJSONL:
+		format = services.ExportFormat_EXPORT_FORMAT_JSONL
+	case conversation.FormatMarkdown:
+		format = services.ExportFormat_EXPORT_FORMAT_MARKDOWN
+	default:
+		return fmt.Errorf("--format must be one of json, jsonl or markdown")
+	}
+
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	resp, err := client.ExportConversation(context.Background(), connect.NewRequest(&services.ExportConversationRequest{
+		Uuid:   args[0],
+		Format: format,
+	}))
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	if conversationExportOutput == "" || conversationExportOutput == "-" {
+		_, err = os.Stdout.Write(resp.Msg.GetContent())
+		return err
+	}
+	if err := os.WriteFile(conversationExportOutput, resp.Msg.GetContent(), 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported conversation %s to %s\n", args[0], conversationExportOutput)
+	return nil
+}
+
+func runConversationImport(cmd *cobra.Command, args []string) error {
+	content, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	var results []importer.Result
+	switch conversationImportFrom {
+	case "grey-seal":
+		export, err := conversation.ParseExport(content)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+		results = []importer.Result{{SourceID: export.GetConversation().GetUuid(), Export: export}}
+	case "auto":
+		if export, err := conversation.ParseExport(content); err == nil {
+			results = []importer.Result{{SourceID: export.GetConversation().GetUuid(), Export: export}}
+			break
+		}
+		fallthrough
+	default:
+		format := importer.Format(conversationImportFrom)
+		if format == "auto" {
+			format = ""
+		}
+		if results, err = importer.Parse(content, format); err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+	}
+
+	if conversationImportDryRun {
+		return printImportReport(results)
+	}
+
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	var failed int
+	for _, r := range results {
+		resp, err := client.ImportConversation(context.Background(), connect.NewRequest(&services.ImportConversationRequest{
+			Data:      r.Export,
+			Summarize: conversationImportSummarize,
+		}))
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "failed to import %s: %v\n", r.SourceID, err)
+			continue
+		}
+
+		fmt.Printf("Imported conversation UUID: %s (%d messages)\n", resp.Msg.GetData().GetUuid(), len(resp.Msg.GetData().GetMessages()))
+		remapped := resp.Msg.GetRemapped()
+		ids := make([]string, 0, len(remapped))
+		for id := range remapped {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Printf("  remapped %s -> %s\n", id, remapped[id])
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d conversations failed to import", failed, len(results))
+	}
+	return nil
+}
+
+// printImportReport describes what an import would create without contacting the server.
+func printImportReport(results []importer.Result) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE ID\tTITLE\tMESSAGES\tDROPPED\tCREATED")
+	var messages, dropped, empty int
+	for _, r := range results {
+		conv := r.Export.GetConversation()
+		created := "-"
+		if conv.GetCreatedAt() != nil {
+			created = conv.GetCreatedAt().AsTime().Format(time.DateOnly)
+		}
+		if len(conv.GetMessages()) == 0 {
+			empty++
+		}
+		messages += len(conv.GetMessages())
+		dropped += r.Dropped
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", r.SourceID, conv.GetTitle(), len(conv.GetMessages()), r.Dropped, created)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nDry run: %d conversations, %d messages would be imported; %d messages dropped (other branches, system/tool or non-text); %d conversations have no messages.\n",
+		len(results), messages, dropped, empty)
+	return nil
+}
+
+func init() {
+	conversationCmd.PersistentFlags().StringVar(&conversationServer, "server", "localhost:9000", "API server address")
+	conversationExportCmd.Flags().StringVar(&conversationExportFormat, "format", "json", "Export format: json, jsonl or markdown")
+	conversationExportCmd.Flags().StringVarP(&conversationExportOutput, "output", "o", "", "File to write (default stdout)")
+	conversationImportCmd.Flags().StringVar(&conversationImportFrom, "from", "auto", "Export format: auto, grey-seal, chatgpt or openwebui")
+	conversationImportCmd.Flags().BoolVar(&conversationImportDryRun, "dry-run", false, "Report what would be imported without importing")
+	conversationImportCmd.Flags().BoolVar(&conversationImportSummarize, "summarize", false, "Regenerate each conversation's summary with the LLM")
+
+	conversationCmd.AddCommand(conversationExportCmd, conversationImportCmd)
+	rootCmd.AddCommand(conversationCmd)
+}
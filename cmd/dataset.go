@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	datasetServer   string
+	datasetRating   int32
+	datasetRole     string
+	datasetAfter    string
+	datasetBefore   string
+	datasetScrubPII bool
+	datasetLimit    int32
+	datasetOutput   string
+)
+
+var datasetCmd = &cobra.Command{
+	Use:   "dataset",
+	Short: "Build evaluation and fine-tuning datasets from rated answers",
+}
+
+var datasetExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export rated turns as JSONL",
+	Long: `Export every rated assistant answer as one JSON object per line, with the
+user query, the retrieved snippets and the exact messages sent to the model
+when a transcript of the turn was recorded, the response and its latest
+rating, reasons and comment. Use --scrub-pii before sharing the file.`,
+	Args: cobra.NoArgs,
+	RunE: runDatasetExport,
+}
+
+func runDatasetExport(cmd *cobra.Command, _ []string) error {
+	req := &services.ExportDatasetRequest{ScrubPii: datasetScrubPII}
+	if cmd.Flags().Changed("rating") {
+		req.Rating = &datasetRating
+	}
+	if datasetRole != "" {
+		req.RoleUuid = &datasetRole
+	}
+	if datasetLimit > 0 {
+		req.Count = &datasetLimit
+	}
+	var err error
+	if req.After, err = parseDatasetTime("after", datasetAfter); err != nil {
+		return err
+	}
+	if req.Before, err = parseDatasetTime("before", datasetBefore); err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if datasetOutput != "" && datasetOutput != "-" {
+		f, err := os.OpenFile(datasetOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+		out = f
+	}
+	w := bufio.NewWriter(out)
+
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+datasetServer, clientOptions()...)
+	stream, err := client.ExportDataset(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	defer stream.Close() //nolint:errcheck
+
+	var records int
+	for stream.Receive() {
+		line, err := protojson.Marshal(stream.Msg().GetRecord())
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		records++
+	}
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if out != os.Stdout {
+		fmt.Fprintf(os.Stderr, "Exported %d records to %s\n", records, datasetOutput)
+	}
+	return nil
+}
+
+// parseDatasetTime accepts an RFC 3339 timestamp or a date.
+func parseDatasetTime(flag, value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	return nil, fmt.Errorf("--%s must be a date (2006-01-02) or an RFC 3339 timestamp", flag)
+}
+
+func init() {
+	datasetCmd.PersistentFlags().StringVar(&datasetServer, "server", "localhost:9000", "API server address")
+	datasetExportCmd.Flags().Int32Var(&datasetRating, "rating", 0, "Only export answers whose latest rating is this value (-1, 0 or 1)")
+	datasetExportCmd.Flags().StringVar(&datasetRole, "role", "", "Only export conversations using this role UUID")
+	datasetExportCmd.Flags().StringVar(&datasetAfter, "after", "", "Only export answers created at or after this date or timestamp")
+	datasetExportCmd.Flags().StringVar(&datasetBefore, "before", "", "Only export answers created before this date or timestamp")
+	datasetExportCmd.Flags().BoolVar(&datasetScrubPII, "scrub-pii", false, "Replace emails, phone numbers, card numbers and API keys with placeholders")
+	datasetExportCmd.Flags().Int32Var(&datasetLimit, "limit", 0, "Maximum number of records (default all)")
+	datasetExportCmd.Flags().StringVarP(&datasetOutput, "output", "o", "", "File to write (default stdout)")
+
+	datasetCmd.AddCommand(datasetExportCmd)
+	rootCmd.AddCommand(datasetCmd)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/eval"
+	"github.com/holmes89/grey-seal/lib/repo/ollama"
+	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1/services"
+	shrikeconnect "github.com/holmes89/shrike/lib/schemas/shrike/v1/services/servicesv1connect"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	evalFixtures         string
+	evalShrikeURL        string
+	evalRecord           string
+	evalScript           string
+	evalOllama           bool
+	evalK                int
+	evalOutput           string
+	evalBaseline         string
+	evalFailOnRegression bool
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval <golden-set>",
+	Short: "Score retrieval and answers against a golden set",
+	Long: `Run every question in a golden set (YAML, JSON or JSONL) through the chat
+pipeline and report recall@k, MRR, key-fact coverage, citation precision and
+latency. Search results come from recorded --fixtures or a live --shrike-url;
+answers come from a --script of canned responses or --ollama. With neither,
+only retrieval is scored. Conversations are kept in memory; no database or
+API server is needed.
+
+Use --record with --shrike-url to capture fixtures for offline runs, -o to
+save the run and --baseline to compare with a saved run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEval,
+}
+
+func runEval(cmd *cobra.Command, args []string) error {
+	set, err := eval.LoadGoldenSet(args[0])
+	if err != nil {
+		return err
+	}
+
+	var searcher conversation.Searcher
+	var recorder *eval.Recorder
+	switch {
+	case evalFixtures != "":
+		fixtures, err := eval.LoadFixtures(evalFixtures)
+		if err != nil {
+			return err
+		}
+		searcher = fixtures
+	case evalShrikeURL != "":
+		searcher = &evalShrikeSearcher{client: shrikeconnect.NewSearchServiceClient(http.DefaultClient, evalShrikeURL)}
+		if evalRecord != "" {
+			recorder = eval.NewRecorder(searcher)
+			searcher = recorder
+		}
+	}
+	if evalRecord != "" && recorder == nil {
+		return errors.New("--record needs --shrike-url")
+	}
+
+	var llm conversation.LLM
+	switch {
+	case evalScript != "" && evalOllama:
+		return errors.New("use either --script or --ollama")
+	case evalScript != "":
+		script, err := eval.LoadScript(evalScript)
+		if err != nil {
+			return err
+		}
+		llm = script
+	case evalOllama:
+		llm = ollama.NewLLM()
+	}
+
+	run, err := eval.NewRunner(searcher, llm, evalK, zap.NewNop()).Run(context.Background(), set)
+	if err != nil {
+		return err
+	}
+	if recorder != nil {
+		if err := recorder.Fixtures().Save(evalRecord); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Recorded search fixtures to %s\n", evalRecord)
+	}
+	if evalOutput != "" {
+		if err := run.Save(evalOutput); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Saved run to %s\n", evalOutput)
+	}
+
+	if err := printEvalCases(run); err != nil {
+		return err
+	}
+	if evalBaseline == "" {
+		return printEvalSummary(run, nil)
+	}
+	baseline, err := eval.LoadRun(evalBaseline)
+	if err != nil {
+		return err
+	}
+	comparison := eval.Compare(baseline, run)
+	if err := printEvalSummary(run, comparison); err != nil {
+		return err
+	}
+	if evalFailOnRegression && len(comparison.Regressions) > 0 {
+		return fmt.Errorf("%d case metrics regressed against %s", len(comparison.Regressions), evalBaseline)
+	}
+	return nil
+}
+
+func printEvalCases(run *eval.Run) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "CASE\tRECALL@%d\tRR\tCOVERAGE\tPRECISION\tLATENCY\tERROR\n", run.K)
+	for _, c := range run.Cases {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.1fms\t%s\n", c.ID,
+			formatMetric(c.RecallAtK), formatMetric(c.ReciprocalRank), formatMetric(c.KeyFactCoverage), formatMetric(c.CitationPrecision),
+			c.LatencyMS, c.Error)
+	}
+	return w.Flush()
+}
+
+// printEvalSummary prints the run's averages and, given a comparison, the
+// baseline values and per-case changes.
+func printEvalSummary(run *eval.Run, comparison *eval.Comparison) error {
+	fmt.Printf("\n%d cases, %d errors, mean latency %.1fms (p95 %.1fms)\n",
+		run.Summary.Cases, run.Summary.Errors, run.Summary.LatencyMeanMS, run.Summary.LatencyP95MS)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if comparison == nil {
+		fmt.Fprintln(w, "METRIC\tVALUE")
+		for _, m := range []struct {
+			name  string
+			value *float64
+		}{
+			{eval.MetricRecall, run.Summary.RecallAtK},
+			{eval.MetricMRR, run.Summary.MRR},
+			{eval.MetricCoverage, run.Summary.KeyFactCoverage},
+			{eval.MetricPrecision, run.Summary.CitationPrecision},
+		} {
+			fmt.Fprintf(w, "%s\t%s\n", m.name, formatMetric(m.value))
+		}
+		return w.Flush()
+	}
+
+	fmt.Fprintln(w, "METRIC\tBASELINE\tRUN\tCHANGE")
+	for _, m := range comparison.Metrics {
+		change := "-"
+		if m.Before != nil && m.After != nil {
+			change = fmt.Sprintf("%+.3f", *m.After-*m.Before)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, formatMetric(m.Before), formatMetric(m.After), change)
+	}
+	fmt.Fprintf(w, "latency_mean_ms\t%.1f\t%.1f\t%+.1f\n", comparison.LatencyMeanMS[0], comparison.LatencyMeanMS[1], comparison.LatencyMeanMS[1]-comparison.LatencyMeanMS[0])
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, section := range []struct {
+		title  string
+		deltas []eval.CaseDelta
+	}{{"Regressions", comparison.Regressions}, {"Improvements", comparison.Improvements}} {
+		if len(section.deltas) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", section.title)
+		for _, d := range section.deltas {
+			fmt.Printf("  %s %s %.3f -> %.3f\n", d.ID, d.Metric, d.Before, d.After)
+		}
+	}
+	if len(comparison.Added) > 0 {
+		fmt.Printf("\nNew cases: %v\n", comparison.Added)
+	}
+	if len(comparison.Removed) > 0 {
+		fmt.Printf("\nCases missing from this run: %v\n", comparison.Removed)
+	}
+	return nil
+}
+
+func formatMetric(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", *v)
+}
+
+// evalShrikeSearcher queries shrike directly, without the workspace scoping
+// the API server applies.
+type evalShrikeSearcher struct {
+	client shrikeconnect.SearchServiceClient
+}
+
+func (s *evalShrikeSearcher) Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversation.SearchResult, error) {
+	req := &shrikev1.SearchRequest{Query: query, Limit: limit, Mode: "hybrid"}
+	if len(resourceUUIDs) > 0 {
+		req.Filter = &shrikev1.SearchFilter{EntityUuids: resourceUUIDs}
+	}
+	resp, err := s.client.Search(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]conversation.SearchResult, 0, len(resp.Msg.GetResults()))
+	for _, r := range resp.Msg.GetResults() {
+		results = append(results, conversation.SearchResult{
+			EntityUUID: r.GetEntityUuid(),
+			Title:      r.GetTitle(),
+			Snippet:    r.GetSnippet(),
+			Score:      r.GetScore(),
+		})
+	}
+	return results, nil
+}
+
+func init() {
+	evalCmd.Flags().StringVar(&evalFixtures, "fixtures", "", "Replay search results recorded with --record")
+	evalCmd.Flags().StringVar(&evalShrikeURL, "shrike-url", "", "Search a live shrike instance instead of fixtures")
+	evalCmd.Flags().StringVar(&evalRecord, "record", "", "Save the live search results to this fixtures file")
+	evalCmd.Flags().StringVar(&evalScript, "script", "", "YAML or JSON file mapping each question to a canned response")
+	evalCmd.Flags().BoolVar(&evalOllama, "ollama", false, "Answer with Ollama (OLLAMA_HOST, OLLAMA_CHAT_MODEL)")
+	evalCmd.Flags().IntVarP(&evalK, "k", "k", eval.DefaultK, "Retrieval cut-off for recall@k and MRR")
+	evalCmd.Flags().StringVarP(&evalOutput, "output", "o", "", "Save the run as JSON")
+	evalCmd.Flags().StringVar(&evalBaseline, "baseline", "", "Compare with a run saved with -o")
+	evalCmd.Flags().BoolVar(&evalFailOnRegression, "fail-on-regression", false, "Exit non-zero if any case metric is worse than the baseline")
+
+	rootCmd.AddCommand(evalCmd)
+}
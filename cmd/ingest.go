@@ -13,10 +13,12 @@ import (
 )
 
 var (
-	ingestURL    string
-	ingestText   string
-	ingestName   string
-	ingestServer string
+	ingestURL        string
+	ingestText       string
+	ingestName       string
+	ingestServer     string
+	ingestCollection string
+	ingestTags       []string
 )
 
 var ingestCmd = &cobra.Command{
@@ -38,7 +40,9 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	}
 
 	r := &greysealv1.Resource{
-		Name: ingestName,
+		Name:       ingestName,
+		Collection: ingestCollection,
+		Tags:       ingestTags,
 	}
 	if ingestURL != "" {
 		r.Source = greysealv1.Source_SOURCE_WEBSITE
@@ -49,7 +53,7 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	}
 
 	baseURL := "http://" + ingestServer
-	client := servicesconnect.NewResourceServiceClient(http.DefaultClient, baseURL, connect.WithGRPCWeb())
+	client := servicesconnect.NewResourceServiceClient(http.DefaultClient, baseURL, clientOptions()...)
 
 	req := connect.NewRequest(&services.IngestResourceRequest{Data: r})
 	resp, err := client.IngestResource(context.Background(), req)
@@ -65,6 +69,8 @@ func init() {
 	ingestCmd.Flags().StringVar(&ingestURL, "url", "", "URL of a website to ingest")
 	ingestCmd.Flags().StringVar(&ingestText, "text", "", "Literal text content to ingest")
 	ingestCmd.Flags().StringVar(&ingestName, "name", "", "Human-readable name for the resource (required)")
+	ingestCmd.Flags().StringVar(&ingestCollection, "collection", "", "Collection to add the resource to, e.g. hr")
+	ingestCmd.Flags().StringSliceVar(&ingestTags, "tag", nil, "Tag for the resource (repeatable)")
 	ingestCmd.Flags().StringVar(&ingestServer, "server", "localhost:9000", "API server address")
 
 	rootCmd.AddCommand(ingestCmd)
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"connectrpc.com/connect"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	replaySystemPrompt     string
+	replaySystemPromptFile string
+	replayRole             string
+	replayModel            string
+	replaySnippets         string
+	replayRetrievalLimit   int32
+	replayWidth            int
+	replayJSON             bool
+)
+
+var conversationReplayCmd = &cobra.Command{
+	Use:   "replay <message-uuid>",
+	Short: "Re-run a recorded assistant turn with a different prompt, model or snippets",
+	Long: `Re-run the turn that produced an assistant message and print the original
+and new responses side by side. The turn is rebuilt from its recorded
+transcript: the same history, summary and question, with the system prompt,
+model and snippets overridden as requested. Nothing is saved to the
+conversation.
+
+Snippets are the recorded ones by default; --snippets fresh searches again
+with the recorded query and --snippets none drops them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConversationReplay,
+}
+
+func runConversationReplay(cmd *cobra.Command, args []string) error {
+	req := &services.ReplayTurnRequest{MessageUuid: args[0]}
+	switch replaySnippets {
+	case "original":
+		req.Snippets = services.SnippetSource_SNIPPET_SOURCE_ORIGINAL
+	case "fresh":
+		req.Snippets = services.SnippetSource_SNIPPET_SOURCE_FRESH
+	case "none":
+		req.Snippets = services.SnippetSource_SNIPPET_SOURCE_NONE
+	default:
+		return fmt.Errorf("--snippets must be one of original, fresh or none")
+	}
+	if replaySystemPrompt != "" && replaySystemPromptFile != "" {
+		return fmt.Errorf("use either --system-prompt or --system-prompt-file")
+	}
+	if replaySystemPromptFile != "" {
+		content, err := os.ReadFile(replaySystemPromptFile)
+		if err != nil {
+			return err
+		}
+		replaySystemPrompt = string(content)
+	}
+	if replaySystemPrompt != "" {
+		req.SystemPrompt = &replaySystemPrompt
+	}
+	if replayRole != "" {
+		req.RoleUuid = &replayRole
+	}
+	if replayModel != "" {
+		req.Model = &replayModel
+	}
+	if cmd.Flags().Changed("retrieval-limit") {
+		req.RetrievalLimit = &replayRetrievalLimit
+	}
+
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	resp, err := client.ReplayTurn(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	if replayJSON {
+		content, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp.Msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(content))
+		return err
+	}
+	printSideBySide(resp.Msg.GetOriginal(), resp.Msg.GetReplay(), replayWidth)
+	return nil
+}
+
+// printSideBySide prints two turn runs in columns: a short header of the
+// settings each ran with, then the wrapped responses.
+func printSideBySide(original, replay *services.TurnRun, width int) {
+	column := max((width-3)/2, 20)
+	left := append(turnHeader("ORIGINAL", original), "")
+	right := append(turnHeader("REPLAY", replay), "")
+	left = append(left, wrap(original.GetResponse(), column)...)
+	right = append(right, wrap(replay.GetResponse(), column)...)
+
+	for i := range max(len(left), len(right)) {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		fmt.Println(strings.TrimRight(fmt.Sprintf("%-*s | %s", column, l, r), " "))
+	}
+}
+
+func turnHeader(title string, run *services.TurnRun) []string {
+	model := run.GetModel()
+	if model == "" {
+		model = "-"
+	}
+	header := []string{
+		title,
+		"model: " + model,
+		fmt.Sprintf("snippets: %d", len(run.GetSnippets())),
+	}
+	if run.GetLatencyMs() > 0 {
+		header = append(header, fmt.Sprintf("latency: %dms", run.GetLatencyMs()))
+	}
+	return header
+}
+
+// wrap breaks text into lines of at most width runes, keeping the text's own
+// line breaks. Words longer than width are split.
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(paragraph) {
+			w := []rune(word)
+			if len(line) > 0 && len(line)+1+len(w) > width {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			for len(w) > width {
+				lines = append(lines, string(w[:width]))
+				w = w[width:]
+			}
+			if len(line) > 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, w...)
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+func init() {
+	conversationReplayCmd.Flags().StringVar(&replaySystemPrompt, "system-prompt", "", "System prompt to replay with")
+	conversationReplayCmd.Flags().StringVar(&replaySystemPromptFile, "system-prompt-file", "", "Read the system prompt to replay with from a file")
+	conversationReplayCmd.Flags().StringVar(&replayRole, "role", "", "Replay with this role's system prompt")
+	conversationReplayCmd.Flags().StringVar(&replayModel, "model", "", "Replay with this chat model")
+	conversationReplayCmd.Flags().StringVar(&replaySnippets, "snippets", "original", "Snippets to replay with: original, fresh or none")
+	conversationReplayCmd.Flags().Int32Var(&replayRetrievalLimit, "retrieval-limit", 0, "Maximum number of snippets")
+	conversationReplayCmd.Flags().IntVar(&replayWidth, "width", 120, "Total width of the side-by-side output")
+	conversationReplayCmd.Flags().BoolVar(&replayJSON, "json", false, "Print the full original and replayed turns as JSON")
+
+	conversationCmd.AddCommand(conversationReplayCmd)
+}
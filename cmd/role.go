@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+)
+
+var (
+	roleServer       string
+	roleFile         string
+	roleName         string
+	roleSystemPrompt string
+	roleApplyPrune   bool
+	roleApplyDryRun  bool
+	roleExportOutput string
+)
+
+var roleCmd = &cobra.Command{
+	Use:   "role",
+	Short: "Manage roles and apply role manifests",
+}
+
+var roleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List roles",
+	Args:  cobra.NoArgs,
+	RunE:  runRoleList,
+}
+
+var roleGetCmd = &cobra.Command{
+	Use:   "get <uuid>",
+	Short: "Print a role as a manifest definition",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRoleGet,
+}
+
+var roleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a role from a definition file or flags",
+	Long: `Create a role from a manifest file holding one role (-f), or from --name and
+--system-prompt.`,
+	Args: cobra.NoArgs,
+	RunE: runRoleCreate,
+}
+
+var roleUpdateCmd = &cobra.Command{
+	Use:   "update <uuid>",
+	Short: "Replace a role with a definition file, writing a new version",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRoleUpdate,
+}
+
+var roleDeleteCmd = &cobra.Command{
+	Use:   "delete <uuid>",
+	Short: "Delete a role and its versions",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRoleDelete,
+}
+
+var roleApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create and update roles to match a manifest",
+	Long: `Read role definitions from a YAML file or a directory of YAML files (-f) and
+match them to the server's roles by name. The plan is printed first: roles
+missing on the server are created, roles that differ are updated (writing a
+new version) and, with --prune, server roles missing from the manifest are
+deleted. Use --dry-run to print the plan without applying it.`,
+	Args: cobra.NoArgs,
+	RunE: runRoleApply,
+}
+
+var roleExportCmd = &cobra.Command{
+	Use:   "export [name...]",
+	Short: "Write the server's roles as a manifest",
+	Long: `Write every role, or only the named ones, in the format read by apply, so
+existing roles can be moved into git.`,
+	RunE: runRoleExport,
+}
+
+func roleClient() servicesconnect.RoleServiceClient {
+	return servicesconnect.NewRoleServiceClient(http.DefaultClient, "http://"+roleServer, clientOptions()...)
+}
+
+// listAllRoles pages through every role the caller can see.
+func listAllRoles(ctx context.Context, client servicesconnect.RoleServiceClient) ([]*greysealv1.Role, error) {
+	var roles []*greysealv1.Role
+	req := &services.ListRolesRequest{}
+	for {
+		resp, err := client.ListRoles(ctx, connect.NewRequest(req))
+		if err != nil {
+			return nil, fmt.Errorf("list roles failed: %w", err)
+		}
+		roles = append(roles, resp.Msg.GetData()...)
+		if resp.Msg.GetCursor() == "" {
+			return roles, nil
+		}
+		cursor := resp.Msg.GetCursor()
+		req.Cursor = &cursor
+	}
+}
+
+func runRoleList(cmd *cobra.Command, args []string) error {
+	roles, err := listAllRoles(context.Background(), roleClient())
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "UUID\tNAME\tVERSION\tCREATED")
+	for _, r := range roles {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", r.GetUuid(), r.GetName(), r.GetVersion(), r.GetCreatedAt().AsTime().Format(time.DateOnly))
+	}
+	return w.Flush()
+}
+
+func runRoleGet(cmd *cobra.Command, args []string) error {
+	resp, err := roleClient().GetRole(context.Background(), connect.NewRequest(&services.GetRoleRequest{Uuid: args[0]}))
+	if err != nil {
+		return fmt.Errorf("get role failed: %w", err)
+	}
+	r := resp.Msg.GetData()
+	content, err := (&role.Manifest{Roles: []role.Definition{role.DefinitionOf(r)}}).Marshal()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("# uuid: %s\n# version: %d\n%s", r.GetUuid(), r.GetVersion(), content)
+	return nil
+}
+
+// roleFromFile reads a manifest that must hold exactly one role.
+func roleFromFile(path string) (*greysealv1.Role, error) {
+	m, err := role.LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Roles) != 1 {
+		return nil, fmt.Errorf("%s holds %d roles; use role apply for more than one", path, len(m.Roles))
+	}
+	return m.Roles[0].Role(), nil
+}
+
+func runRoleCreate(cmd *cobra.Command, args []string) error {
+	var data *greysealv1.Role
+	switch {
+	case roleFile != "" && roleName != "":
+		return errors.New("use either -f or --name")
+	case roleFile != "":
+		r, err := roleFromFile(roleFile)
+		if err != nil {
+			return err
+		}
+		data = r
+	case roleName != "":
+		data = &greysealv1.Role{Name: roleName, SystemPrompt: roleSystemPrompt}
+	default:
+		return errors.New("-f or --name is required")
+	}
+	resp, err := roleClient().CreateRole(context.Background(), connect.NewRequest(&services.CreateRoleRequest{Data: data}))
+	if err != nil {
+		return fmt.Errorf("create role failed: %w", err)
+	}
+	fmt.Printf("Created role %s (%s)\n", resp.Msg.GetData().GetName(), resp.Msg.GetData().GetUuid())
+	return nil
+}
+
+func runRoleUpdate(cmd *cobra.Command, args []string) error {
+	if roleFile == "" {
+		return errors.New("-f is required")
+	}
+	data, err := roleFromFile(roleFile)
+	if err != nil {
+		return err
+	}
+	resp, err := roleClient().UpdateRole(context.Background(), connect.NewRequest(&services.UpdateRoleRequest{Uuid: args[0], Data: data}))
+	if err != nil {
+		return fmt.Errorf("update role failed: %w", err)
+	}
+	fmt.Printf("Updated role %s to version %d\n", args[0], resp.Msg.GetData().GetVersion())
+	return nil
+}
+
+func runRoleDelete(cmd *cobra.Command, args []string) error {
+	if _, err := roleClient().DeleteRole(context.Background(), connect.NewRequest(&services.DeleteRoleRequest{Uuid: args[0]})); err != nil {
+		return fmt.Errorf("delete role failed: %w", err)
+	}
+	fmt.Printf("Deleted role %s\n", args[0])
+	return nil
+}
+
+func runRoleApply(cmd *cobra.Command, args []string) error {
+	if roleFile == "" {
+		return errors.New("-f is required")
+	}
+	m, err := role.LoadManifest(roleFile)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	client := roleClient()
+	existing, err := listAllRoles(ctx, client)
+	if err != nil {
+		return err
+	}
+	changes, err := role.Plan(m, existing, roleApplyPrune)
+	if err != nil {
+		return err
+	}
+
+	printRolePlan(changes)
+	if roleApplyDryRun {
+		return nil
+	}
+
+	var failed, applied int
+	for _, c := range changes {
+		var err error
+		switch c.Action {
+		case role.ActionCreate:
+			var resp *connect.Response[services.CreateRoleResponse]
+			resp, err = client.CreateRole(ctx, connect.NewRequest(&services.CreateRoleRequest{Data: c.Role}))
+			if err == nil {
+				fmt.Printf("Created role %s (%s)\n", c.Name, resp.Msg.GetData().GetUuid())
+			}
+		case role.ActionUpdate:
+			var resp *connect.Response[services.UpdateRoleResponse]
+			resp, err = client.UpdateRole(ctx, connect.NewRequest(&services.UpdateRoleRequest{Uuid: c.UUID, Data: c.Role}))
+			if err == nil {
+				fmt.Printf("Updated role %s to version %d\n", c.Name, resp.Msg.GetData().GetVersion())
+			}
+		case role.ActionDelete:
+			_, err = client.DeleteRole(ctx, connect.NewRequest(&services.DeleteRoleRequest{Uuid: c.UUID}))
+			if err == nil {
+				fmt.Printf("Deleted role %s (%s)\n", c.Name, c.UUID)
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "failed to %s role %s: %v\n", c.Action, c.Name, err)
+			continue
+		}
+		applied++
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d changes failed", failed, failed+applied)
+	}
+	return nil
+}
+
+// printRolePlan prints each change, the diff of every update and a summary.
+func printRolePlan(changes []role.Change) {
+	counts := map[role.Action]int{}
+	for _, c := range changes {
+		counts[c.Action]++
+		switch c.Action {
+		case role.ActionCreate:
+			fmt.Printf("+ create %s\n", c.Name)
+		case role.ActionUpdate:
+			fmt.Printf("~ update %s (%s)\n", c.Name, c.UUID)
+			for _, line := range strings.Split(strings.TrimSuffix(c.Diff, "\n"), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		case role.ActionDelete:
+			fmt.Printf("- delete %s (%s)\n", c.Name, c.UUID)
+		}
+	}
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[role.ActionCreate], counts[role.ActionUpdate], counts[role.ActionDelete], counts[role.ActionUnchanged])
+}
+
+func runRoleExport(cmd *cobra.Command, args []string) error {
+	roles, err := listAllRoles(context.Background(), roleClient())
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		var named []*greysealv1.Role
+		for _, name := range args {
+			found := false
+			for _, r := range roles {
+				if r.GetName() == name {
+					named = append(named, r)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("no role named %q", name)
+			}
+		}
+		roles = named
+	}
+	content, err := role.Export(roles).Marshal()
+	if err != nil {
+		return err
+	}
+
+	if roleExportOutput == "" || roleExportOutput == "-" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(roleExportOutput, content, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d roles to %s\n", len(roles), roleExportOutput)
+	return nil
+}
+
+func init() {
+	roleCmd.PersistentFlags().StringVar(&roleServer, "server", "localhost:9000", "API server address")
+	for _, c := range []*cobra.Command{roleCreateCmd, roleUpdateCmd, roleApplyCmd} {
+		c.Flags().StringVarP(&roleFile, "file", "f", "", "Role manifest: a YAML file, or for apply a directory of them")
+	}
+	roleCreateCmd.Flags().StringVar(&roleName, "name", "", "Role name")
+	roleCreateCmd.Flags().StringVar(&roleSystemPrompt, "system-prompt", "", "Role system prompt")
+	roleApplyCmd.Flags().BoolVar(&roleApplyPrune, "prune", false, "Delete server roles that are not in the manifest")
+	roleApplyCmd.Flags().BoolVar(&roleApplyDryRun, "dry-run", false, "Print the plan without applying it")
+	roleExportCmd.Flags().StringVarP(&roleExportOutput, "output", "o", "", "File to write (default stdout)")
+
+	roleCmd.AddCommand(roleListCmd, roleGetCmd, roleCreateCmd, roleUpdateCmd, roleDeleteCmd, roleApplyCmd, roleExportCmd)
+	rootCmd.AddCommand(roleCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var traceJSON bool
+
+var conversationTraceCmd = &cobra.Command{
+	Use:   "trace <message-uuid>",
+	Short: "Show what went into the prompt that produced an assistant message",
+	Long: `Print the trace recorded for an assistant message: the search query and
+results with their scores, the summary and history depth, the model and its
+options, how long each phase took and the exact messages sent to the model.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConversationTrace,
+}
+
+func runConversationTrace(cmd *cobra.Command, args []string) error {
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	resp, err := client.GetMessageTrace(context.Background(), connect.NewRequest(&services.GetMessageTraceRequest{MessageUuid: args[0]}))
+	if err != nil {
+		return fmt.Errorf("trace failed: %w", err)
+	}
+	trace := resp.Msg.GetTrace()
+
+	if traceJSON {
+		content, err := protojson.MarshalOptions{Multiline: true}.Marshal(trace)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(content))
+		return err
+	}
+	return printTrace(trace)
+}
+
+func printTrace(trace *greysealv1.TurnTrace) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Message:\t%s\n", trace.GetMessageUuid())
+	fmt.Fprintf(w, "Conversation:\t%s\n", trace.GetConversationUuid())
+	fmt.Fprintf(w, "Role:\t%s\n", trace.GetRoleUuid())
+	fmt.Fprintf(w, "Model:\t%s\n", trace.GetModel())
+	for _, key := range slices.Sorted(maps.Keys(trace.GetOptions())) {
+		fmt.Fprintf(w, "  %s:\t%s\n", key, trace.GetOptions()[key])
+	}
+	fmt.Fprintf(w, "Query:\t%s\n", trace.GetSearchQuery())
+	fmt.Fprintf(w, "History depth:\t%d\n", trace.GetHistoryDepth())
+	fmt.Fprintf(w, "Summary:\t%s\n", trace.GetSummary())
+	t := trace.GetTimings()
+	fmt.Fprintf(w, "Timings:\tload %dms, summarize %dms, retrieval %dms, first token %dms, generation %dms, total %dms\n",
+		t.GetLoadMs(), t.GetSummarizeMs(), t.GetRetrievalMs(), t.GetFirstTokenMs(), t.GetGenerationMs(), t.GetTotalMs())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println("\nResults:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  #\tSCORE\tRESOURCE\tTITLE")
+	for i, r := range trace.GetResults() {
+		fmt.Fprintf(w, "  %d\t%.3f\t%s\t%s\n", i+1, r.GetScore(), r.GetResourceUuid(), r.GetTitle())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println("\nPrompt:")
+	for _, m := range trace.GetMessages() {
+		fmt.Printf("--- %s\n%s\n", m.GetRole(), m.GetContent())
+	}
+	return nil
+}
+
+func init() {
+	conversationTraceCmd.Flags().BoolVar(&traceJSON, "json", false, "Print the trace as JSON")
+
+	conversationCmd.AddCommand(conversationTraceCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	transcriptJSON   bool
+	transcriptsCount int32
+)
+
+var conversationTranscriptCmd = &cobra.Command{
+	Use:   "transcript <uuid>",
+	Short: "Print a conversation's recorded transcript as Markdown",
+	Long: `Print the combined Markdown transcript of a conversation, rendered from its
+recorded turns. --json prints every turn in full instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConversationTranscript,
+}
+
+var conversationTranscriptsCmd = &cobra.Command{
+	Use:   "transcripts",
+	Short: "List conversations with recorded transcripts",
+	Args:  cobra.NoArgs,
+	RunE:  runConversationTranscripts,
+}
+
+func runConversationTranscript(cmd *cobra.Command, args []string) error {
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	resp, err := client.GetTranscript(context.Background(), connect.NewRequest(&services.GetTranscriptRequest{ConversationUuid: args[0]}))
+	if err != nil {
+		return fmt.Errorf("transcript failed: %w", err)
+	}
+	if !transcriptJSON {
+		_, err = fmt.Print(resp.Msg.GetTranscript().GetMarkdown())
+		return err
+	}
+	content, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp.Msg.GetTranscript())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(content))
+	return err
+}
+
+func runConversationTranscripts(cmd *cobra.Command, args []string) error {
+	client := servicesconnect.NewConversationServiceClient(http.DefaultClient, "http://"+conversationServer, clientOptions()...)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONVERSATION\tTITLE\tTURNS\tUPDATED")
+	req := &services.ListTranscriptsRequest{Count: &transcriptsCount}
+	for {
+		resp, err := client.ListTranscripts(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			return fmt.Errorf("list transcripts failed: %w", err)
+		}
+		for _, t := range resp.Msg.GetData() {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", t.GetConversationUuid(), t.GetTitle(), t.GetTurnCount(), t.GetUpdatedAt().AsTime().Format(time.RFC3339))
+		}
+		cursor := resp.Msg.GetCursor()
+		if cursor == "" {
+			break
+		}
+		req.Cursor = &cursor
+	}
+	return w.Flush()
+}
+
+func init() {
+	conversationTranscriptCmd.Flags().BoolVar(&transcriptJSON, "json", false, "Print the recorded turns as JSON")
+	conversationTranscriptsCmd.Flags().Int32Var(&transcriptsCount, "page-size", 50, "Transcripts fetched per request")
+
+	conversationCmd.AddCommand(conversationTranscriptCmd, conversationTranscriptsCmd)
+}
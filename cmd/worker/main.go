@@ -9,16 +9,19 @@ import (
 
 	"github.com/holmes89/archaea/kafka"
 	"github.com/holmes89/archaea/worker"
+	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/holmes89/grey-seal/lib/greyseal/resource"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	"github.com/holmes89/grey-seal/lib/repo"
+	"github.com/holmes89/grey-seal/lib/repo/kafkasync"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
-	shrikev1 "github.com/holmes89/shrike/lib/schemas/shrike/v1"
-	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func main() {
@@ -70,6 +73,39 @@ func main() {
 
 	go processResources(resourceConsumer, textProducer, resourceRepo)
 
+	// Definition sync consumers, enabled by setting their topics.
+	syncGroup := os.Getenv("SYNC_GROUP")
+	if syncGroup == "" {
+		syncGroup = "greyseal-sync"
+	}
+	syncs := []struct {
+		topic   string
+		handler kafkasync.Handler
+		invalid error
+	}{
+		{os.Getenv("ROLE_SYNC_TOPIC"), role.NewSyncHandler(&repo.RoleRepo{Conn: store}, logger), role.ErrInvalidEvent},
+		{os.Getenv("RESOURCE_SYNC_TOPIC"), resource.NewSyncHandler(resourceRepo, resource.NewKafkaIndexer(kBrokers, logger), logger), resource.ErrInvalidEvent},
+	}
+	for _, s := range syncs {
+		if s.topic == "" {
+			continue
+		}
+		c, err := kafkasync.NewConsumer(kafkasync.Config{
+			Brokers:         brokers,
+			Topic:           s.topic,
+			Group:           syncGroup + "-" + s.topic,
+			DeadLetterTopic: os.Getenv("SYNC_DEAD_LETTER_TOPIC"),
+			Handler:         s.handler,
+			Invalid:         s.invalid,
+			Logger:          logger,
+		})
+		if err != nil {
+			log.Fatalf("failed to create sync consumer for %s: %v", s.topic, err)
+		}
+		defer c.Close()
+		go c.Run(ctx)
+	}
+
 	log.Println("worker started, consuming resources...")
 	worker.Run(ctx, cancel, resourceConsumer)
 }
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix marks a bearer credential as an API key rather than a JWT.
+const APIKeyPrefix = "gsk_"
+
+var _ Authenticator = (*apiKeyAuthenticator)(nil)
+
+type apiKeyAuthenticator struct {
+	repo APIKeyRepository
+}
+
+// NewAPIKeyAuthenticator validates API keys against their stored hashes.
+func NewAPIKeyAuthenticator(repo APIKeyRepository) Authenticator {
+	return &apiKeyAuthenticator{repo: repo}
+}
+
+func (a *apiKeyAuthenticator) Authenticate(ctx context.Context, credential string) (*Principal, error) {
+	if !strings.HasPrefix(credential, APIKeyPrefix) {
+		return nil, ErrUnauthenticated
+	}
+	key, err := a.repo.GetByHash(ctx, HashAPIKey(credential))
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: look up api key: %v", ErrUnavailable, err)
+	}
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: api key revoked", ErrUnauthenticated)
+	}
+	return &Principal{Subject: key.Owner, Scopes: key.Scopes, Method: "api_key", WorkspaceUUID: key.WorkspaceUUID}, nil
+}
+
+// GenerateAPIKey returns a new random API key and the hash to persist for it.
+func GenerateAPIKey() (key string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 digest stored for key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth/mocks"
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyAuthenticatorTestSuite struct {
+	suite.Suite
+	repo          *mocks.MockAPIKeyRepository
+	authenticator auth.Authenticator
+}
+
+func (s *APIKeyAuthenticatorTestSuite) SetupTest() {
+	s.repo = mocks.NewMockAPIKeyRepository(s.T())
+	s.authenticator = auth.NewAPIKeyAuthenticator(s.repo)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestGenerateAPIKey() {
+	key, hash, err := auth.GenerateAPIKey()
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(key, auth.APIKeyPrefix))
+	s.Equal(auth.HashAPIKey(key), hash)
+	s.NotContains(hash, key)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_ValidKey() {
+	key, hash, _ := auth.GenerateAPIKey()
+	s.repo.On("GetByHash", mock.Anything, hash).Return(&auth.APIKey{Owner: "alice", Scopes: []string{"admin"}}, nil)
+
+	p, err := s.authenticator.Authenticate(context.Background(), key)
+	s.Require().NoError(err)
+	s.Equal("alice", p.Subject)
+	s.True(p.IsAdmin())
+	s.Equal("api_key", p.Method)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_UnknownKey() {
+	s.repo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, auth.ErrAPIKeyNotFound)
+
+	_, err := s.authenticator.Authenticate(context.Background(), auth.APIKeyPrefix+"nope")
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_LookupFailure() {
+	s.repo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, errors.New("dial tcp: connection refused"))
+
+	_, err := s.authenticator.Authenticate(context.Background(), auth.APIKeyPrefix+"abc")
+	s.ErrorIs(err, auth.ErrUnavailable)
+	s.NotErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_RevokedKey() {
+	revoked := time.Now()
+	s.repo.On("GetByHash", mock.Anything, mock.Anything).Return(&auth.APIKey{Owner: "alice", RevokedAt: &revoked}, nil)
+
+	_, err := s.authenticator.Authenticate(context.Background(), auth.APIKeyPrefix+"old")
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) TestAuthenticate_WrongPrefixSkipsLookup() {
+	_, err := s.authenticator.Authenticate(context.Background(), "eyJhbGciOi")
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func TestAPIKeyAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyAuthenticatorTestSuite))
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	if err := auth.Authorize(ctx, "bob"); err != nil {
+		t.Fatalf("no principal should be unrestricted, got %v", err)
+	}
+
+	alice := auth.WithPrincipal(ctx, &auth.Principal{Subject: "alice"})
+	if err := auth.Authorize(alice, "alice"); err != nil {
+		t.Fatalf("owner should be allowed, got %v", err)
+	}
+	if err := auth.Authorize(alice, "bob"); !errors.Is(err, auth.ErrPermissionDenied) {
+		t.Fatalf("non-owner should be denied, got %v", err)
+	}
+
+	admin := auth.WithPrincipal(ctx, &auth.Principal{Subject: "root", Scopes: []string{auth.ScopeAdmin}})
+	if err := auth.Authorize(admin, "bob"); err != nil {
+		t.Fatalf("admin should bypass ownership, got %v", err)
+	}
+}
+
+func TestWorkspaceFromContext(t *testing.T) {
+	if _, ok := auth.WorkspaceFromContext(context.Background()); ok {
+		t.Fatal("no principal should be unscoped")
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice"})
+	if ws, ok := auth.WorkspaceFromContext(ctx); !ok || ws != workspace.DefaultUUID {
+		t.Fatalf("principal without workspace should use the default, got %q %v", ws, ok)
+	}
+
+	ctx = auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice", WorkspaceUUID: "team-a"})
+	if ws, ok := auth.WorkspaceFromContext(ctx); !ok || ws != "team-a" {
+		t.Fatalf("expected team-a, got %q %v", ws, ok)
+	}
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated principal, or nil when the
+// request did not pass through the auth interceptor (auth disabled or an
+// internal caller).
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// OwnerScope returns the owner that reads and writes must be restricted to.
+// It is empty when the caller is unrestricted: either no principal is present
+// or the principal holds the admin scope.
+func OwnerScope(ctx context.Context) string {
+	p := PrincipalFromContext(ctx)
+	if p == nil || p.IsAdmin() {
+		return ""
+	}
+	return p.Subject
+}
+
+// Authorize returns ErrPermissionDenied if the caller may not act on a record
+// owned by owner.
+func Authorize(ctx context.Context, owner string) error {
+	if scope := OwnerScope(ctx); scope != "" && scope != owner {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// WorkspaceFromContext returns the workspace every read and write must be
+// restricted to. ok is false when there is no principal (auth disabled or an
+// internal caller), in which case queries are not scoped.
+func WorkspaceFromContext(ctx context.Context) (id string, ok bool) {
+	p := PrincipalFromContext(ctx)
+	if p == nil {
+		return "", false
+	}
+	if p.WorkspaceUUID == "" {
+		return workspace.DefaultUUID, true
+	}
+	return p.WorkspaceUUID, true
+}
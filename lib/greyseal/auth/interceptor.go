@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+)
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// Interceptor authenticates every Connect handler call and stores the resulting
+// Principal in the request context. Credentials are read from
+// "Authorization: Bearer <token>" or "X-API-Key". Bearer tokens carrying
+// APIKeyPrefix are checked as API keys; anything else is treated as a JWT.
+// Admins may act in another workspace by sending "X-Workspace-UUID".
+type Interceptor struct {
+	apiKeys       Authenticator // optional
+	tokens        Authenticator // optional; JWTs are rejected when nil
+	adminServices []string
+	logger        *zap.Logger
+}
+
+// NewInterceptor creates an auth interceptor. Calls to any of adminServices
+// (fully-qualified service names, e.g. servicesconnect.ModelServiceName)
+// additionally require the admin scope.
+func NewInterceptor(apiKeys, tokens Authenticator, logger *zap.Logger, adminServices ...string) *Interceptor {
+	return &Interceptor{
+		apiKeys:       apiKeys,
+		tokens:        tokens,
+		adminServices: adminServices,
+		logger:        logger,
+	}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		ctx, err := i.authenticate(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authenticate(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// AuthenticateRequest authenticates a plain HTTP request the way Connect calls
+// are, for handlers served outside Connect. The request path stands in for
+// the procedure. Errors are *connect.Error.
+func (i *Interceptor) AuthenticateRequest(r *http.Request) (context.Context, error) {
+	return i.authenticate(r.Context(), r.URL.Path, r.Header)
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	credential := credentialFromHeader(header)
+	if credential == "" {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, ErrUnauthenticated)
+	}
+
+	authenticator := i.tokens
+	if strings.HasPrefix(credential, APIKeyPrefix) {
+		authenticator = i.apiKeys
+	}
+	if authenticator == nil {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, ErrUnauthenticated)
+	}
+
+	principal, err := authenticator.Authenticate(ctx, credential)
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		i.logger.Info("authentication failed", zap.String("procedure", procedure), zap.Error(err))
+		return ctx, connect.NewError(connect.CodeUnauthenticated, ErrUnauthenticated)
+	case errors.Is(err, ErrUnavailable):
+		i.logger.Error("authentication unavailable", zap.String("procedure", procedure), zap.Error(err))
+		return ctx, connect.NewError(connect.CodeUnavailable, ErrUnavailable)
+	case err != nil:
+		i.logger.Error("authentication error", zap.String("procedure", procedure), zap.Error(err))
+		return ctx, connect.NewError(connect.CodeInternal, errors.New("internal error"))
+	}
+
+	if i.requiresAdmin(procedure) && !principal.IsAdmin() {
+		return ctx, connect.NewError(connect.CodePermissionDenied, ErrPermissionDenied)
+	}
+	current := principal.WorkspaceUUID
+	if current == "" {
+		current = workspace.DefaultUUID
+	}
+	if ws := header.Get("X-Workspace-UUID"); ws != "" && ws != current {
+		if !principal.IsAdmin() {
+			return ctx, connect.NewError(connect.CodePermissionDenied, ErrPermissionDenied)
+		}
+		switched := *principal
+		switched.WorkspaceUUID = ws
+		principal = &switched
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+func (i *Interceptor) requiresAdmin(procedure string) bool {
+	for _, svc := range i.adminServices {
+		if strings.HasPrefix(procedure, "/"+svc+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func credentialFromHeader(header http.Header) string {
+	if key := header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
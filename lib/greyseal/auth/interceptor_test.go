@@ -0,0 +1,168 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth/mocks"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// principalRecorder is a ModelService handler that records the principal seen by each call.
+type principalRecorder struct {
+	servicesconnect.UnimplementedModelServiceHandler
+	seen *auth.Principal
+}
+
+func (r *principalRecorder) ListModels(ctx context.Context, _ *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error) {
+	r.seen = auth.PrincipalFromContext(ctx)
+	return connect.NewResponse(&services.ListModelsResponse{}), nil
+}
+
+type InterceptorTestSuite struct {
+	suite.Suite
+	apiKeys  *mocks.MockAuthenticator
+	tokens   *mocks.MockAuthenticator
+	recorder *principalRecorder
+	server   *httptest.Server
+}
+
+func (s *InterceptorTestSuite) SetupTest() {
+	s.apiKeys = mocks.NewMockAuthenticator(s.T())
+	s.tokens = mocks.NewMockAuthenticator(s.T())
+	s.recorder = &principalRecorder{}
+}
+
+func (s *InterceptorTestSuite) TearDownTest() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+func (s *InterceptorTestSuite) client(adminServices ...string) servicesconnect.ModelServiceClient {
+	interceptor := auth.NewInterceptor(s.apiKeys, s.tokens, zap.NewNop(), adminServices...)
+	mux := http.NewServeMux()
+	mux.Handle(servicesconnect.NewModelServiceHandler(s.recorder, connect.WithInterceptors(interceptor)))
+	s.server = httptest.NewServer(mux)
+	return servicesconnect.NewModelServiceClient(s.server.Client(), s.server.URL)
+}
+
+func request(header, value string) *connect.Request[services.ListModelsRequest] {
+	req := connect.NewRequest(&services.ListModelsRequest{})
+	if header != "" {
+		req.Header().Set(header, value)
+	}
+	return req
+}
+
+func (s *InterceptorTestSuite) TestMissingCredentials() {
+	_, err := s.client().ListModels(context.Background(), request("", ""))
+	s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func (s *InterceptorTestSuite) TestAPIKeyBearer() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice"}, nil)
+
+	_, err := s.client().ListModels(context.Background(), request("Authorization", "Bearer gsk_abc"))
+	s.Require().NoError(err)
+	s.Equal("alice", s.recorder.seen.Subject)
+}
+
+func (s *InterceptorTestSuite) TestAPIKeyHeader() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice"}, nil)
+
+	_, err := s.client().ListModels(context.Background(), request("X-API-Key", "gsk_abc"))
+	s.Require().NoError(err)
+}
+
+func (s *InterceptorTestSuite) TestJWTBearer() {
+	s.tokens.On("Authenticate", mock.Anything, "eyJ.x.y").Return(&auth.Principal{Subject: "bob"}, nil)
+
+	_, err := s.client().ListModels(context.Background(), request("Authorization", "Bearer eyJ.x.y"))
+	s.Require().NoError(err)
+	s.Equal("bob", s.recorder.seen.Subject)
+}
+
+func (s *InterceptorTestSuite) TestInvalidCredentials() {
+	s.tokens.On("Authenticate", mock.Anything, mock.Anything).Return(nil, auth.ErrUnauthenticated)
+
+	_, err := s.client().ListModels(context.Background(), request("Authorization", "Bearer bad"))
+	s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func (s *InterceptorTestSuite) TestLookupUnavailable() {
+	s.apiKeys.On("Authenticate", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: connection refused", auth.ErrUnavailable))
+
+	_, err := s.client().ListModels(context.Background(), request("Authorization", "Bearer gsk_abc"))
+	s.Equal(connect.CodeUnavailable, connect.CodeOf(err))
+	s.NotContains(err.Error(), "connection refused")
+}
+
+func (s *InterceptorTestSuite) TestAuthenticateRequest() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice"}, nil)
+	interceptor := auth.NewInterceptor(s.apiKeys, s.tokens, zap.NewNop())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set("Authorization", "Bearer gsk_abc")
+	ctx, err := interceptor.AuthenticateRequest(req)
+	s.Require().NoError(err)
+	s.Equal("alice", auth.PrincipalFromContext(ctx).Subject)
+
+	_, err = interceptor.AuthenticateRequest(httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	s.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func (s *InterceptorTestSuite) TestAdminServiceRequiresAdminScope() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice"}, nil)
+
+	_, err := s.client(servicesconnect.ModelServiceName).ListModels(context.Background(), request("Authorization", "Bearer gsk_abc"))
+	s.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func (s *InterceptorTestSuite) TestAdminServiceAllowsAdmin() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "root", Scopes: []string{auth.ScopeAdmin}}, nil)
+
+	_, err := s.client(servicesconnect.ModelServiceName).ListModels(context.Background(), request("Authorization", "Bearer gsk_abc"))
+	s.Require().NoError(err)
+}
+
+func (s *InterceptorTestSuite) TestWorkspaceSwitchAllowsAdmin() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "root", Scopes: []string{auth.ScopeAdmin}}, nil)
+
+	req := request("Authorization", "Bearer gsk_abc")
+	req.Header().Set("X-Workspace-UUID", "team-b")
+	_, err := s.client().ListModels(context.Background(), req)
+	s.Require().NoError(err)
+	s.Equal("team-b", s.recorder.seen.WorkspaceUUID)
+}
+
+func (s *InterceptorTestSuite) TestWorkspaceSwitchDeniedForUser() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice", WorkspaceUUID: "team-a"}, nil)
+
+	req := request("Authorization", "Bearer gsk_abc")
+	req.Header().Set("X-Workspace-UUID", "team-b")
+	_, err := s.client().ListModels(context.Background(), req)
+	s.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func (s *InterceptorTestSuite) TestWorkspaceHeaderMatchingOwnWorkspace() {
+	s.apiKeys.On("Authenticate", mock.Anything, "gsk_abc").Return(&auth.Principal{Subject: "alice", WorkspaceUUID: "team-a"}, nil)
+
+	req := request("Authorization", "Bearer gsk_abc")
+	req.Header().Set("X-Workspace-UUID", "team-a")
+	_, err := s.client().ListModels(context.Background(), req)
+	s.Require().NoError(err)
+}
+
+func TestInterceptorTestSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ScopeAdmin grants access to every conversation regardless of owner, and to
+// admin-only services.
+const ScopeAdmin = "admin"
+
+var (
+	ErrUnauthenticated  = errors.New("missing or invalid credentials")
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrAPIKeyNotFound is returned by APIKeyRepository.GetByHash when no key
+	// has the hash. Any other error means the key could not be looked up.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrUnavailable means credentials could not be checked, not that they
+	// are invalid.
+	ErrUnavailable = errors.New("authentication is temporarily unavailable")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject       string   // stable user identifier; stored as the owner of records
+	Scopes        []string // granted scopes, e.g. "admin"
+	Method        string   // "api_key" or "jwt"
+	WorkspaceUUID string   // tenant the caller acts in; empty means the default workspace
+	// Profile holds optional user details, such as name and email, for
+	// templated prompts. Keys follow the OIDC standard claims.
+	Profile map[string]string
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// IsAdmin reports whether the principal bypasses ownership checks.
+func (p *Principal) IsAdmin() bool {
+	return p.HasScope(ScopeAdmin)
+}
+
+// Authenticator resolves a bearer credential to a Principal.
+// Implementations return ErrUnauthenticated (possibly wrapped) for bad credentials.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*Principal, error)
+}
+
+// APIKey is a long-lived credential issued to a user or service.
+// Only the SHA-256 hash of the key is persisted.
+type APIKey struct {
+	Uuid          string
+	Name          string
+	Owner         string
+	KeyHash       string
+	Scopes        []string
+	WorkspaceUUID string
+	CreatedAt     time.Time
+	RevokedAt     *time.Time
+}
+
+// APIKeyRepository persists API keys.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	Revoke(ctx context.Context, id string) error
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+var _ Authenticator = (*jwksAuthenticator)(nil)
+
+// signatureAlgorithms are the asymmetric algorithms accepted for bearer tokens.
+// HMAC algorithms are deliberately excluded: a JWKS file only holds public keys.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+type jwksAuthenticator struct {
+	keys     jose.JSONWebKeySet
+	issuer   string // optional; matched against "iss"
+	audience string // optional; must appear in "aud"
+}
+
+// jwtExtraClaims holds the non-registered claims grey-seal reads. Both the
+// OAuth2 space-separated "scope" string and the "scp" array are recognised.
+type jwtExtraClaims struct {
+	Scope         string   `json:"scope"`
+	Scp           []string `json:"scp"`
+	WorkspaceUUID string   `json:"workspace_uuid"`
+}
+
+// profileClaims are the OIDC standard claims copied to Principal.Profile when
+// present.
+var profileClaims = []string{"name", "given_name", "family_name", "preferred_username", "email", "locale", "zoneinfo"}
+
+// NewJWKSAuthenticator validates JWT bearer tokens against the public keys in
+// the JWKS file at path.
+func NewJWKSAuthenticator(path, issuer, audience string) (Authenticator, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s contains no keys", path)
+	}
+	// Only ever verify with public material, even if the file holds private keys.
+	public := jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(set.Keys))}
+	for _, k := range set.Keys {
+		pub := k.Public()
+		if !pub.Valid() {
+			return nil, fmt.Errorf("jwks key %q is not a valid asymmetric key", k.KeyID)
+		}
+		public.Keys = append(public.Keys, pub)
+	}
+	return &jwksAuthenticator{keys: public, issuer: issuer, audience: audience}, nil
+}
+
+func (a *jwksAuthenticator) Authenticate(_ context.Context, credential string) (*Principal, error) {
+	tok, err := jwt.ParseSigned(credential, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	// Tokens without a kid are accepted only when the set is unambiguous.
+	var key any = &a.keys
+	if len(a.keys.Keys) == 1 && (len(tok.Headers) == 0 || tok.Headers[0].KeyID == "") {
+		key = a.keys.Keys[0].Key
+	}
+
+	var claims jwt.Claims
+	var extra jwtExtraClaims
+	var all map[string]any
+	if err := tok.Claims(key, &claims, &extra, &all); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	expected := jwt.Expected{Issuer: a.issuer}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := claims.Validate(expected); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: token has no expiry", ErrUnauthenticated)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+
+	var profile map[string]string
+	for _, name := range profileClaims {
+		if v, ok := all[name].(string); ok && v != "" {
+			if profile == nil {
+				profile = map[string]string{}
+			}
+			profile[name] = v
+		}
+	}
+	return &Principal{
+		Subject:       claims.Subject,
+		Scopes:        append(strings.Fields(extra.Scope), extra.Scp...),
+		Method:        "jwt",
+		WorkspaceUUID: extra.WorkspaceUUID,
+		Profile:       profile,
+	}, nil
+}
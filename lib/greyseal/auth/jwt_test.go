@@ -0,0 +1,108 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/stretchr/testify/suite"
+)
+
+type JWKSAuthenticatorTestSuite struct {
+	suite.Suite
+	key           *ecdsa.PrivateKey
+	authenticator auth.Authenticator
+}
+
+func (s *JWKSAuthenticatorTestSuite) SetupTest() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.key = key
+
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "k1", Algorithm: string(jose.ES256), Use: "sig"}}}
+	raw, err := json.Marshal(set)
+	s.Require().NoError(err)
+	path := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(path, raw, 0o600))
+
+	s.authenticator, err = auth.NewJWKSAuthenticator(path, "https://issuer.example", "grey-seal")
+	s.Require().NoError(err)
+}
+
+func (s *JWKSAuthenticatorTestSuite) sign(key *ecdsa.PrivateKey, claims jwt.Claims, extra map[string]any) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader(jose.HeaderKey("kid"), "k1"))
+	s.Require().NoError(err)
+	token, err := jwt.Signed(signer).Claims(claims).Claims(extra).Serialize()
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *JWKSAuthenticatorTestSuite) validClaims() jwt.Claims {
+	return jwt.Claims{
+		Subject:  "alice",
+		Issuer:   "https://issuer.example",
+		Audience: jwt.Audience{"grey-seal"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestValidToken() {
+	token := s.sign(s.key, s.validClaims(), map[string]any{"scope": "read admin"})
+
+	p, err := s.authenticator.Authenticate(context.Background(), token)
+	s.Require().NoError(err)
+	s.Equal("alice", p.Subject)
+	s.True(p.IsAdmin())
+	s.Equal("jwt", p.Method)
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestProfileClaims() {
+	token := s.sign(s.key, s.validClaims(), map[string]any{"name": "Alice Smith", "email": "alice@example.com", "zoneinfo": "Europe/London", "groups": []string{"ops"}})
+
+	p, err := s.authenticator.Authenticate(context.Background(), token)
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"name": "Alice Smith", "email": "alice@example.com", "zoneinfo": "Europe/London"}, p.Profile)
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestExpiredToken() {
+	claims := s.validClaims()
+	claims.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	_, err := s.authenticator.Authenticate(context.Background(), s.sign(s.key, claims, nil))
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestWrongAudience() {
+	claims := s.validClaims()
+	claims.Audience = jwt.Audience{"someone-else"}
+
+	_, err := s.authenticator.Authenticate(context.Background(), s.sign(s.key, claims, nil))
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestUnknownSigningKey() {
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	_, err = s.authenticator.Authenticate(context.Background(), s.sign(other, s.validClaims(), nil))
+	s.ErrorIs(err, auth.ErrUnauthenticated)
+}
+
+func (s *JWKSAuthenticatorTestSuite) TestMissingJWKSFile() {
+	_, err := auth.NewJWKSAuthenticator(filepath.Join(s.T().TempDir(), "missing.json"), "", "")
+	s.Error(err)
+}
+
+func TestJWKSAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(JWKSAuthenticatorTestSuite))
+}
@@ -0,0 +1,53 @@
+package conversation
+
+import (
+	"context"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+// messageOverhead approximates the tokens a chat template adds around each
+// message.
+const messageOverhead = 4
+
+// estimateTokens approximates the size of a prompt at four characters per
+// token. It only needs to be close enough to keep prompts inside the model's
+// context window.
+func estimateTokens(messages []LLMMessage) int {
+	n := 0
+	for _, m := range messages {
+		n += (len(m.Content)+3)/4 + messageOverhead
+	}
+	return n
+}
+
+// buildPrompt assembles a turn's prompt and fits it into the prompt budget.
+// The system prompt, the role's examples and instructions, the summary and
+// the question always go in and count against the budget; the oldest history
+// exchanges are dropped first, then the lowest-ranked snippets. It returns the
+// prompt with the snippets and history it kept.
+func (srv *conversationService) buildPrompt(ctx context.Context, conv *greysealv1.Conversation, ins roleInstructions, parts promptParts, snippets []SearchResult) ([]LLMMessage, []SearchResult, []LLMMessage) {
+	formatContext := srv.contextFormatter(ctx, conv, ins)
+	parts.Examples = ins.Examples
+	for {
+		parts.Context = formatContext(snippets)
+		messages := assemblePrompt(parts)
+		if srv.promptBudget <= 0 || estimateTokens(messages) <= srv.promptBudget {
+			return messages, snippets, parts.History
+		}
+		switch {
+		case len(parts.History) > 0:
+			parts.History = parts.History[min(2, len(parts.History)):]
+		case len(snippets) > 0:
+			snippets = snippets[:len(snippets)-1]
+		default:
+			srv.logger.Warn("prompt is over budget without history or context",
+				zap.String("conversation_uuid", conv.GetUuid()),
+				zap.Int("estimated_tokens", estimateTokens(messages)),
+				zap.Int("budget", srv.promptBudget),
+			)
+			return messages, snippets, parts.History
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package conversation
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportVersion is the ConversationExport format version written by Export.
+// Import rejects exports from newer versions.
+const ExportVersion = 1
+
+// ExportFormat selects how an export is serialised.
+type ExportFormat string
+
+const (
+	// FormatJSON is a single ConversationExport JSON document.
+	FormatJSON ExportFormat = "json"
+	// FormatJSONL is a header line (the export without messages) followed by
+	// one message per line.
+	FormatJSONL ExportFormat = "jsonl"
+	// FormatMarkdown is a human-readable transcript. It cannot be imported.
+	FormatMarkdown ExportFormat = "markdown"
+)
+
+// ErrUnsupportedFormat is returned for unknown export formats.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// RenderExport serialises an export and returns the content with its MIME type.
+func RenderExport(export *greysealv1.ConversationExport, format ExportFormat) ([]byte, string, error) {
+	switch format {
+	case FormatJSON, "":
+		b, err := protojson.MarshalOptions{Multiline: true}.Marshal(export)
+		return b, "application/json", err
+	case FormatJSONL:
+		b, err := renderJSONL(export)
+		return b, "application/x-ndjson", err
+	case FormatMarkdown:
+		return renderMarkdown(export), "text/markdown", nil
+	default:
+		return nil, "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// ParseExport reads an export written in the JSON or JSONL format.
+func ParseExport(data []byte) (*greysealv1.ConversationExport, error) {
+	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
+	export := &greysealv1.ConversationExport{}
+	// Unknown fields are discarded for forward compatibility, so any JSON object
+	// parses; only one with a conversation is an export.
+	if err := opts.Unmarshal(data, export); err == nil && export.Conversation != nil {
+		return export, nil
+	}
+
+	// Not a single document; try JSONL.
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var header bool
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if !header {
+			if err := opts.Unmarshal(text, export); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+			}
+			header = true
+			continue
+		}
+		msg := &greysealv1.Message{}
+		if err := opts.Unmarshal(text, msg); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidExport, line, err)
+		}
+		if export.Conversation == nil {
+			export.Conversation = &greysealv1.Conversation{}
+		}
+		export.Conversation.Messages = append(export.Conversation.Messages, msg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header || export.Conversation == nil {
+		return nil, ErrInvalidExport
+	}
+	return export, nil
+}
+
+func renderJSONL(export *greysealv1.ConversationExport) ([]byte, error) {
+	// The header carries everything except the messages, which follow it one per line.
+	header := proto.Clone(export).(*greysealv1.ConversationExport)
+	var messages []*greysealv1.Message
+	if header.Conversation != nil {
+		messages = header.Conversation.Messages
+		header.Conversation.Messages = nil
+	}
+
+	var buf bytes.Buffer
+	b, err := protojson.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+	buf.WriteByte('\n')
+	for _, msg := range messages {
+		b, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func renderMarkdown(export *greysealv1.ConversationExport) []byte {
+	conv := export.GetConversation()
+	var b strings.Builder
+
+	title := conv.GetTitle()
+	if title == "" {
+		title = "Untitled conversation"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "- Conversation: `%s`\n", conv.GetUuid())
+	if role := export.GetRole(); role != nil {
+		fmt.Fprintf(&b, "- Role: %s (`%s`)\n", role.GetName(), role.GetUuid())
+	}
+	if conv.GetCreatedAt() != nil {
+		fmt.Fprintf(&b, "- Created: %s\n", conv.GetCreatedAt().AsTime().Format(time.RFC3339))
+	}
+	if export.GetExportedAt() != nil {
+		fmt.Fprintf(&b, "- Exported: %s\n", export.GetExportedAt().AsTime().Format(time.RFC3339))
+	}
+	if conv.GetSummary() != "" {
+		fmt.Fprintf(&b, "\n## Summary\n\n%s\n", conv.GetSummary())
+	}
+
+	citations := map[string]*greysealv1.Resource{}
+	for _, res := range export.GetCitations() {
+		citations[res.GetUuid()] = res
+	}
+
+	b.WriteString("\n## Messages\n")
+	for _, msg := range conv.GetMessages() {
+		speaker := "User"
+		if msg.GetRole() == greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT {
+			speaker = "Assistant"
+		}
+		fmt.Fprintf(&b, "\n### %s", speaker)
+		if msg.GetCreatedAt() != nil {
+			fmt.Fprintf(&b, " (%s)", msg.GetCreatedAt().AsTime().Format(time.RFC3339))
+		}
+		fmt.Fprintf(&b, "\n\n%s\n", msg.GetContent())
+
+		if len(msg.GetResourceUuids()) > 0 {
+			b.WriteString("\nSources:\n")
+			for _, id := range msg.GetResourceUuids() {
+				if res, ok := citations[id]; ok && res.GetPath() != "" {
+					fmt.Fprintf(&b, "- %s (%s)\n", res.GetName(), res.GetPath())
+				} else if ok {
+					fmt.Fprintf(&b, "- %s\n", res.GetName())
+				} else {
+					fmt.Fprintf(&b, "- `%s`\n", id)
+				}
+			}
+		}
+		switch {
+		case msg.GetFeedback() > 0:
+			b.WriteString("\nFeedback: helpful\n")
+		case msg.GetFeedback() < 0:
+			b.WriteString("\nFeedback: not helpful\n")
+		}
+	}
+	return []byte(b.String())
+}
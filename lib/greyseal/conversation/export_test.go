@@ -0,0 +1,92 @@
+package conversation_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+	export *v1.ConversationExport
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	created := timestamppb.New(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	s.export = &v1.ConversationExport{
+		Version: conversation.ExportVersion,
+		Conversation: &v1.Conversation{
+			Uuid:      "c1",
+			Title:     "Kafka tuning",
+			Summary:   "Discussed rebalances.",
+			CreatedAt: created,
+			Messages: []*v1.Message{
+				{Uuid: "m1", Role: v1.MessageRole_MESSAGE_ROLE_USER, Content: "How do rebalances work?", CreatedAt: created},
+				{Uuid: "m2", Role: v1.MessageRole_MESSAGE_ROLE_ASSISTANT, Content: "Consumers rejoin the group.", ResourceUuids: []string{"res-1", "res-2"}, Feedback: 1, CreatedAt: created},
+			},
+		},
+		Role:      &v1.Role{Uuid: "r1", Name: "Analyst"},
+		Citations: []*v1.Resource{{Uuid: "res-1", Name: "Kafka docs", Path: "https://kafka.apache.org"}},
+	}
+}
+
+func (s *ExportTestSuite) TestJSONRoundTrip() {
+	content, contentType, err := conversation.RenderExport(s.export, conversation.FormatJSON)
+	s.Require().NoError(err)
+	s.Equal("application/json", contentType)
+
+	parsed, err := conversation.ParseExport(content)
+	s.Require().NoError(err)
+	s.True(proto.Equal(s.export, parsed))
+}
+
+func (s *ExportTestSuite) TestJSONLRoundTrip() {
+	content, contentType, err := conversation.RenderExport(s.export, conversation.FormatJSONL)
+	s.Require().NoError(err)
+	s.Equal("application/x-ndjson", contentType)
+	s.Equal(3, bytes.Count(content, []byte("\n")), "header plus one line per message")
+
+	parsed, err := conversation.ParseExport(content)
+	s.Require().NoError(err)
+	s.True(proto.Equal(s.export, parsed))
+	// Rendering must not strip messages from the caller's export.
+	s.Len(s.export.GetConversation().GetMessages(), 2)
+}
+
+func (s *ExportTestSuite) TestMarkdown() {
+	content, contentType, err := conversation.RenderExport(s.export, conversation.FormatMarkdown)
+	s.Require().NoError(err)
+	s.Equal("text/markdown", contentType)
+
+	md := string(content)
+	s.Contains(md, "# Kafka tuning")
+	s.Contains(md, "- Role: Analyst (`r1`)")
+	s.Contains(md, "## Summary\n\nDiscussed rebalances.")
+	s.Contains(md, "### Assistant (2024-05-01T12:00:00Z)")
+	s.Contains(md, "- Kafka docs (https://kafka.apache.org)")
+	s.Contains(md, "- `res-2`")
+	s.Contains(md, "Feedback: helpful")
+
+	_, err = conversation.ParseExport(content)
+	s.ErrorIs(err, conversation.ErrInvalidExport)
+}
+
+func (s *ExportTestSuite) TestParseRejectsOtherJSON() {
+	_, err := conversation.ParseExport([]byte(`{"id": "c1", "chat": {"messages": []}}`))
+	s.ErrorIs(err, conversation.ErrInvalidExport)
+}
+
+func (s *ExportTestSuite) TestUnsupportedFormat() {
+	_, _, err := conversation.RenderExport(s.export, "pdf")
+	s.ErrorIs(err, conversation.ErrUnsupportedFormat)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
@@ -0,0 +1,222 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// MaxFeedbackComment is the longest comment, in characters, SubmitFeedback accepts.
+	MaxFeedbackComment = 2000
+	// DefaultReportWindow is the period a feedback report covers when the
+	// query does not bound it.
+	DefaultReportWindow = 30 * 24 * time.Hour
+	// reportResourceLimit caps the resources listed in a report.
+	reportResourceLimit = 50
+)
+
+// FeedbackReasons lists the reasons a rating can carry, in report order.
+var FeedbackReasons = []greysealv1.FeedbackReason{
+	greysealv1.FeedbackReason_FEEDBACK_REASON_HALLUCINATION,
+	greysealv1.FeedbackReason_FEEDBACK_REASON_WRONG_SOURCE,
+	greysealv1.FeedbackReason_FEEDBACK_REASON_INCOMPLETE,
+	greysealv1.FeedbackReason_FEEDBACK_REASON_TONE,
+}
+
+func (srv *conversationService) SubmitFeedback(ctx context.Context, feedback *greysealv1.Feedback) (*greysealv1.Feedback, error) {
+	if r := feedback.GetRating(); r < -1 || r > 1 {
+		return nil, fmt.Errorf("%w: rating must be -1, 0 or 1", ErrInvalidFeedback)
+	}
+	comment := strings.TrimSpace(feedback.GetComment())
+	if utf8.RuneCountInString(comment) > MaxFeedbackComment {
+		return nil, fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidFeedback, MaxFeedbackComment)
+	}
+	var reasons []greysealv1.FeedbackReason
+	for _, reason := range feedback.GetReasons() {
+		if !slices.Contains(FeedbackReasons, reason) {
+			return nil, fmt.Errorf("%w: unknown reason %v", ErrInvalidFeedback, reason)
+		}
+		if !slices.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	slices.Sort(reasons)
+
+	msg, err := srv.messageRepo.Get(ctx, feedback.GetMessageUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, msg.GetOwner()); err != nil {
+		return nil, err
+	}
+	if msg.GetRole() != greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT {
+		return nil, fmt.Errorf("%w: only assistant messages can be rated", ErrInvalidFeedback)
+	}
+
+	record := &greysealv1.Feedback{
+		Uuid:             uuid.New().String(),
+		MessageUuid:      msg.GetUuid(),
+		ConversationUuid: msg.GetConversationUuid(),
+		Rating:           feedback.GetRating(),
+		Reasons:          reasons,
+		Comment:          comment,
+		WorkspaceUuid:    msg.GetWorkspaceUuid(),
+		CreatedAt:        timestamppb.Now(),
+	}
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		record.Owner = p.Subject
+	}
+	if err := srv.feedback.Create(ctx, record); err != nil {
+		srv.logger.Error("failed to save feedback", zap.String("message", msg.GetUuid()), zap.Error(err))
+		return nil, err
+	}
+	if err := srv.messageRepo.UpdateFeedback(ctx, msg.GetUuid(), record.Rating); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (srv *conversationService) ListFeedback(ctx context.Context, lis base.ListRequest, filter FeedbackFilter) (base.ListResponse[*greysealv1.Feedback], error) {
+	f := map[string][]any{}
+	if filter.MessageUUID != "" {
+		f["message_uuid"] = []any{filter.MessageUUID}
+	}
+	if owner := auth.OwnerScope(ctx); owner != "" {
+		f["owner"] = []any{owner}
+	} else if filter.Owner != "" {
+		f["owner"] = []any{filter.Owner}
+	}
+
+	limit := pagination.Limit(lis.GetCount())
+	data, err := srv.feedback.List(ctx, lis.GetCursor(), limit+1, f)
+	if err != nil {
+		srv.logger.Error("failed to list feedback", zap.Error(err))
+		return nil, err
+	}
+	data, cursor := pagination.Page(data, limit, func(f *greysealv1.Feedback) (time.Time, string) {
+		return f.GetCreatedAt().AsTime(), f.GetUuid()
+	})
+	return &base.ListGenericResponse[*greysealv1.Feedback]{
+		Cursor: cursor,
+		Count:  int32(len(data)),
+		Data:   data,
+	}, nil
+}
+
+func (srv *conversationService) FeedbackReport(ctx context.Context, query ReportQuery) (*greysealv1.FeedbackReport, error) {
+	if query.Before.IsZero() {
+		query.Before = time.Now()
+	}
+	if query.After.IsZero() {
+		query.After = query.Before.Add(-DefaultReportWindow)
+	}
+	if !query.After.Before(query.Before) {
+		return nil, ErrInvalidReportWindow
+	}
+	if query.Interval == greysealv1.FeedbackInterval_FEEDBACK_INTERVAL_UNSPECIFIED {
+		query.Interval = greysealv1.FeedbackInterval_FEEDBACK_INTERVAL_DAY
+	}
+	query.Owner = auth.OwnerScope(ctx)
+	query.ResourceLimit = reportResourceLimit
+
+	report, err := srv.feedback.Report(ctx, query)
+	if err != nil {
+		srv.logger.Error("failed to build feedback report", zap.Error(err))
+		return nil, err
+	}
+	report.After = timestamppb.New(query.After)
+	report.Before = timestamppb.New(query.Before)
+	report.Interval = query.Interval
+	return report, nil
+}
+
+// datasetPageSize is how many labelled messages ExportDataset loads at a time.
+const datasetPageSize = 100
+
+func (srv *conversationService) ExportDataset(ctx context.Context, query DatasetQuery, emit func(*greysealv1.DatasetRecord) error) error {
+	query.Owner = auth.OwnerScope(ctx)
+
+	var cursor string
+	var sent uint
+	for {
+		page := uint(datasetPageSize)
+		if query.Limit > 0 && query.Limit-sent < page {
+			page = query.Limit - sent
+		}
+		labelled, err := srv.feedback.ListLabelled(ctx, cursor, page, query)
+		if err != nil {
+			srv.logger.Error("failed to list labelled messages", zap.Error(err))
+			return err
+		}
+		for _, l := range labelled {
+			if err := emit(srv.datasetRecord(ctx, l, query.ScrubPII)); err != nil {
+				return err
+			}
+		}
+		sent += uint(len(labelled))
+		if uint(len(labelled)) < page || (query.Limit > 0 && sent >= query.Limit) {
+			return nil
+		}
+		last := labelled[len(labelled)-1].Message
+		cursor = pagination.Encode(last.GetCreatedAt().AsTime(), last.GetUuid())
+	}
+}
+
+// datasetRecord builds the record for a labelled message, adding the prompt
+// context from its transcript turn if one was recorded.
+func (srv *conversationService) datasetRecord(ctx context.Context, l *LabelledMessage, scrub bool) *greysealv1.DatasetRecord {
+	msg := l.Message
+	record := &greysealv1.DatasetRecord{
+		MessageUuid:      msg.GetUuid(),
+		ConversationUuid: msg.GetConversationUuid(),
+		RoleUuid:         l.RoleUUID,
+		Model:            msg.GetModel(),
+		Query:            l.Query,
+		Response:         msg.GetContent(),
+		ResourceUuids:    msg.GetResourceUuids(),
+		Rating:           l.Feedback.GetRating(),
+		Reasons:          l.Feedback.GetReasons(),
+		Comment:          l.Feedback.GetComment(),
+		CreatedAt:        msg.GetCreatedAt(),
+	}
+
+	if srv.transcriptWriter != nil {
+		turn, err := srv.transcriptWriter.ReadTurn(ctx, msg.GetConversationUuid(), msg.GetUuid())
+		switch {
+		case err == nil:
+			record.HasContext = true
+			record.Query = turn.UserMessage
+			record.Snippets = datasetSnippets(turn.SearchResults)
+			record.Messages = datasetMessages(turn.AssembledMessages)
+		case !errors.Is(err, ErrTurnNotFound):
+			srv.logger.Warn("failed to read transcript turn", zap.String("message_uuid", msg.GetUuid()), zap.Error(err))
+		}
+	}
+
+	if scrub {
+		record.Query = redact.Scrub(record.Query)
+		record.Response = redact.Scrub(record.Response)
+		record.Comment = redact.Scrub(record.Comment)
+		for _, s := range record.Snippets {
+			s.Snippet = redact.Scrub(s.Snippet)
+		}
+		for _, m := range record.Messages {
+			m.Content = redact.Scrub(m.Content)
+		}
+	}
+	return record
+}
@@ -2,11 +2,15 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"connectrpc.com/connect"
+	"go.uber.org/zap"
 
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
 	entity "github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
 	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
@@ -14,22 +18,31 @@ import (
 
 type ConversationHandler struct {
 	servicesconnect.UnimplementedConversationServiceHandler
-	svc entity.ConversationService
+	svc    entity.ConversationService
+	logger *zap.Logger
 }
 
 func NewConversationHandler(svc entity.ConversationService) *ConversationHandler {
-	return &ConversationHandler{svc: svc}
+	return &ConversationHandler{svc: svc, logger: zap.NewNop()}
+}
+
+// WithLogger logs the errors ChatSession reports to clients as internal.
+func (h *ConversationHandler) WithLogger(logger *zap.Logger) *ConversationHandler {
+	h.logger = logger
+	return h
 }
 
 func (h *ConversationHandler) CreateConversation(ctx context.Context, req *connect.Request[services.CreateConversationRequest]) (*connect.Response[services.CreateConversationResponse], error) {
 	conv := &greysealv1.Conversation{
 		Title:         req.Msg.GetTitle(),
 		RoleUuid:      req.Msg.GetRoleUuid(),
+		RoleVersion:   req.Msg.GetRoleVersion(),
 		ResourceUuids: req.Msg.GetResourceUuids(),
+		Variables:     req.Msg.GetVariables(),
 	}
 	result, err := h.svc.Create(ctx, conv)
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.CreateConversationResponse{Data: result}), nil
 }
@@ -37,16 +50,27 @@ func (h *ConversationHandler) CreateConversation(ctx context.Context, req *conne
 func (h *ConversationHandler) GetConversation(ctx context.Context, req *connect.Request[services.GetConversationRequest]) (*connect.Response[services.GetConversationResponse], error) {
 	result, err := h.svc.Get(ctx, req.Msg)
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.GetConversationResponse{Data: result.GetData()}), nil
 }
 
 func (h *ConversationHandler) ListConversations(ctx context.Context, req *connect.Request[services.ListConversationsRequest]) (*connect.Response[services.ListConversationsResponse], error) {
-	result, err := h.svc.List(ctx, req.Msg)
+	filter := entity.ListFilter{
+		RoleUUID:     req.Msg.GetRoleUuid(),
+		ResourceUUID: req.Msg.GetResourceUuid(),
+		Status:       req.Msg.GetStatus(),
+	}
+	if req.Msg.UpdatedAfter != nil {
+		filter.UpdatedAfter = req.Msg.GetUpdatedAfter().AsTime()
+	}
+	if req.Msg.UpdatedBefore != nil {
+		filter.UpdatedBefore = req.Msg.GetUpdatedBefore().AsTime()
+	}
+	result, err := h.svc.List(ctx, req.Msg, filter)
 	if err != nil {
 		log.Printf("error listing conversations: %v", err)
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.ListConversationsResponse{
 		Data:   result.GetData(),
@@ -60,39 +84,303 @@ func (h *ConversationHandler) UpdateConversation(ctx context.Context, req *conne
 		Uuid:          req.Msg.GetUuid(),
 		Title:         req.Msg.GetTitle(),
 		RoleUuid:      req.Msg.GetRoleUuid(),
+		RoleVersion:   req.Msg.GetRoleVersion(),
 		ResourceUuids: req.Msg.GetResourceUuids(),
+		Variables:     req.Msg.GetVariables(),
 	}
-	result, err := h.svc.Update(ctx, req.Msg.GetUuid(), conv)
+	result, err := h.svc.Update(ctx, req.Msg.GetUuid(), conv, updatePaths(req.Msg)...)
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.UpdateConversationResponse{Data: result}), nil
 }
 
 func (h *ConversationHandler) DeleteConversation(ctx context.Context, req *connect.Request[services.DeleteConversationRequest]) (*connect.Response[services.DeleteConversationResponse], error) {
 	if err := h.svc.Delete(ctx, req.Msg.GetUuid()); err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.DeleteConversationResponse{}), nil
 }
 
+func (h *ConversationHandler) RestoreConversation(ctx context.Context, req *connect.Request[services.RestoreConversationRequest]) (*connect.Response[services.RestoreConversationResponse], error) {
+	result, err := h.svc.Restore(ctx, req.Msg.GetUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.RestoreConversationResponse{Data: result}), nil
+}
+
+func (h *ConversationHandler) ArchiveConversation(ctx context.Context, req *connect.Request[services.ArchiveConversationRequest]) (*connect.Response[services.ArchiveConversationResponse], error) {
+	result, err := h.svc.Archive(ctx, req.Msg.GetUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ArchiveConversationResponse{Data: result}), nil
+}
+
+func (h *ConversationHandler) UnarchiveConversation(ctx context.Context, req *connect.Request[services.UnarchiveConversationRequest]) (*connect.Response[services.UnarchiveConversationResponse], error) {
+	result, err := h.svc.Unarchive(ctx, req.Msg.GetUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.UnarchiveConversationResponse{Data: result}), nil
+}
+
+func (h *ConversationHandler) SearchConversations(ctx context.Context, req *connect.Request[services.SearchConversationsRequest]) (*connect.Response[services.SearchConversationsResponse], error) {
+	query := entity.SearchQuery{
+		Query:    req.Msg.GetQuery(),
+		RoleUUID: req.Msg.GetRoleUuid(),
+		Limit:    uint(req.Msg.GetCount()),
+	}
+	if req.Msg.After != nil {
+		query.After = req.Msg.GetAfter().AsTime()
+	}
+	if req.Msg.Before != nil {
+		query.Before = req.Msg.GetBefore().AsTime()
+	}
+	if req.Msg.Feedback != nil {
+		feedback := req.Msg.GetFeedback()
+		query.Feedback = &feedback
+	}
+	results, err := h.svc.Search(ctx, query)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.SearchConversationsResponse{Data: results}), nil
+}
+
 // Chat streams assistant tokens back to the client as they are generated.
+// A turn refused by a guardrail ends with the refusal and its reason.
 func (h *ConversationHandler) Chat(ctx context.Context, req *connect.Request[services.ChatRequest], stream *connect.ServerStream[services.ChatResponse]) error {
 	finalMsg, err := h.svc.Chat(ctx, req.Msg.GetConversationUuid(), req.Msg.GetContent(),
 		func(token string) error {
 			return stream.Send(&services.ChatResponse{Token: token})
 		},
 	)
+	var blocked *entity.BlockedError
+	if errors.As(err, &blocked) {
+		// A guardrail refused the turn: the refusal is the final message.
+		return stream.Send(&services.ChatResponse{FinalMessage: blocked.Message, Blocked: blocked.Block})
+	}
 	if err != nil {
-		return err
+		return connectError(err)
 	}
 	// Send a final message with the fully-populated Message (uuid, references, etc.)
 	return stream.Send(&services.ChatResponse{FinalMessage: finalMsg})
 }
 
 func (h *ConversationHandler) SubmitFeedback(ctx context.Context, req *connect.Request[services.SubmitFeedbackRequest]) (*connect.Response[services.SubmitFeedbackResponse], error) {
-	if err := h.svc.SubmitFeedback(ctx, req.Msg.GetMessageUuid(), req.Msg.GetFeedback()); err != nil {
-		return nil, err
+	result, err := h.svc.SubmitFeedback(ctx, &greysealv1.Feedback{
+		MessageUuid: req.Msg.GetMessageUuid(),
+		Rating:      req.Msg.GetFeedback(),
+		Reasons:     req.Msg.GetReasons(),
+		Comment:     req.Msg.GetComment(),
+	})
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.SubmitFeedbackResponse{Data: result}), nil
+}
+
+func (h *ConversationHandler) ListFeedback(ctx context.Context, req *connect.Request[services.ListFeedbackRequest]) (*connect.Response[services.ListFeedbackResponse], error) {
+	result, err := h.svc.ListFeedback(ctx, req.Msg, entity.FeedbackFilter{
+		MessageUUID: req.Msg.GetMessageUuid(),
+		Owner:       req.Msg.GetOwner(),
+	})
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ListFeedbackResponse{
+		Data:   result.GetData(),
+		Cursor: result.GetCursor(),
+		Count:  result.GetCount(),
+	}), nil
+}
+
+func (h *ConversationHandler) GetFeedbackReport(ctx context.Context, req *connect.Request[services.GetFeedbackReportRequest]) (*connect.Response[services.GetFeedbackReportResponse], error) {
+	query := entity.ReportQuery{Interval: req.Msg.GetInterval()}
+	if req.Msg.After != nil {
+		query.After = req.Msg.GetAfter().AsTime()
+	}
+	if req.Msg.Before != nil {
+		query.Before = req.Msg.GetBefore().AsTime()
+	}
+	report, err := h.svc.FeedbackReport(ctx, query)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.GetFeedbackReportResponse{Data: report}), nil
+}
+
+func (h *ConversationHandler) ExportConversation(ctx context.Context, req *connect.Request[services.ExportConversationRequest]) (*connect.Response[services.ExportConversationResponse], error) {
+	export, err := h.svc.Export(ctx, req.Msg.GetUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	content, contentType, err := entity.RenderExport(export, exportFormat(req.Msg.GetFormat()))
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ExportConversationResponse{
+		Data:        export,
+		Content:     content,
+		ContentType: contentType,
+	}), nil
+}
+
+func (h *ConversationHandler) ImportConversation(ctx context.Context, req *connect.Request[services.ImportConversationRequest]) (*connect.Response[services.ImportConversationResponse], error) {
+	conv, remapped, err := h.svc.Import(ctx, req.Msg.GetData(), entity.ImportOptions{Summarize: req.Msg.GetSummarize()})
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ImportConversationResponse{Data: conv, Remapped: remapped}), nil
+}
+
+func (h *ConversationHandler) ExportDataset(ctx context.Context, req *connect.Request[services.ExportDatasetRequest], stream *connect.ServerStream[services.ExportDatasetResponse]) error {
+	query := entity.DatasetQuery{
+		Rating:   req.Msg.Rating,
+		RoleUUID: req.Msg.GetRoleUuid(),
+		ScrubPII: req.Msg.GetScrubPii(),
+	}
+	if req.Msg.After != nil {
+		query.After = req.Msg.GetAfter().AsTime()
+	}
+	if req.Msg.Before != nil {
+		query.Before = req.Msg.GetBefore().AsTime()
+	}
+	if c := req.Msg.GetCount(); c > 0 {
+		query.Limit = uint(c)
+	}
+	err := h.svc.ExportDataset(ctx, query, func(record *greysealv1.DatasetRecord) error {
+		return stream.Send(&services.ExportDatasetResponse{Record: record})
+	})
+	if err != nil {
+		return connectError(err)
+	}
+	return nil
+}
+
+func (h *ConversationHandler) ReplayTurn(ctx context.Context, req *connect.Request[services.ReplayTurnRequest]) (*connect.Response[services.ReplayTurnResponse], error) {
+	opts := entity.ReplayOptions{
+		SystemPrompt:   req.Msg.SystemPrompt,
+		RoleUUID:       req.Msg.GetRoleUuid(),
+		Model:          req.Msg.GetModel(),
+		RetrievalLimit: req.Msg.GetRetrievalLimit(),
+	}
+	switch req.Msg.GetSnippets() {
+	case services.SnippetSource_SNIPPET_SOURCE_FRESH:
+		opts.Snippets = entity.SnippetsFresh
+	case services.SnippetSource_SNIPPET_SOURCE_NONE:
+		opts.Snippets = entity.SnippetsNone
+	}
+	result, err := h.svc.ReplayTurn(ctx, req.Msg.GetMessageUuid(), opts)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ReplayTurnResponse{
+		Original: turnRun(result.Original),
+		Replay:   turnRun(result.Replay),
+	}), nil
+}
+
+func (h *ConversationHandler) GetMessageTrace(ctx context.Context, req *connect.Request[services.GetMessageTraceRequest]) (*connect.Response[services.GetMessageTraceResponse], error) {
+	trace, err := h.svc.GetMessageTrace(ctx, req.Msg.GetMessageUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.GetMessageTraceResponse{Trace: trace}), nil
+}
+
+func (h *ConversationHandler) ListTranscripts(ctx context.Context, req *connect.Request[services.ListTranscriptsRequest]) (*connect.Response[services.ListTranscriptsResponse], error) {
+	data, cursor, err := h.svc.ListTranscripts(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ListTranscriptsResponse{
+		Data:   data,
+		Cursor: cursor,
+		Count:  int32(len(data)),
+	}), nil
+}
+
+func (h *ConversationHandler) GetTranscript(ctx context.Context, req *connect.Request[services.GetTranscriptRequest]) (*connect.Response[services.GetTranscriptResponse], error) {
+	transcript, err := h.svc.GetTranscript(ctx, req.Msg.GetConversationUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.GetTranscriptResponse{Transcript: transcript}), nil
+}
+
+// updatePaths lists the fields an UpdateConversationRequest overwrites: its
+// update_mask, or else the fields it sets.
+func updatePaths(req *services.UpdateConversationRequest) []string {
+	if mask := req.GetUpdateMask(); mask != nil {
+		return mask.GetPaths()
+	}
+	var paths []string
+	if req.Title != nil {
+		paths = append(paths, "title")
+	}
+	if req.RoleUuid != nil {
+		paths = append(paths, "role_uuid", "role_version")
+	}
+	if len(req.GetResourceUuids()) > 0 {
+		paths = append(paths, "resource_uuids")
+	}
+	if len(req.GetVariables()) > 0 {
+		paths = append(paths, "variables")
+	}
+	return paths
+}
+
+func turnRun(run entity.TurnRun) *services.TurnRun {
+	out := &services.TurnRun{
+		SystemPrompt: run.SystemPrompt,
+		Model:        run.Model,
+		Response:     run.Response,
+		LatencyMs:    run.Latency.Milliseconds(),
+	}
+	for _, s := range run.Snippets {
+		out.Snippets = append(out.Snippets, &greysealv1.DatasetSnippet{
+			ResourceUuid: s.EntityUUID,
+			Title:        s.Title,
+			Snippet:      s.Snippet,
+			Score:        s.Score,
+		})
+	}
+	for _, m := range run.Messages {
+		out.Messages = append(out.Messages, &greysealv1.DatasetMessage{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
+
+func exportFormat(f services.ExportFormat) entity.ExportFormat {
+	switch f {
+	case services.ExportFormat_EXPORT_FORMAT_MARKDOWN:
+		return entity.FormatMarkdown
+	case services.ExportFormat_EXPORT_FORMAT_JSONL:
+		return entity.FormatJSONL
+	default:
+		return entity.FormatJSON
+	}
+}
+
+// connectError maps domain errors onto Connect status codes.
+func connectError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrPermissionDenied):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, entity.ErrQueryRequired), errors.Is(err, pagination.ErrInvalidCursor),
+		errors.Is(err, entity.ErrInvalidExport), errors.Is(err, entity.ErrUnsupportedFormat),
+		errors.Is(err, entity.ErrInvalidFeedback), errors.Is(err, entity.ErrInvalidReportWindow),
+		errors.Is(err, entity.ErrInvalidUpdatePath):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, entity.ErrConversationDeleted), errors.Is(err, entity.ErrRestoreWindowExpired),
+		errors.Is(err, entity.ErrReplayUnsupported), errors.Is(err, entity.ErrNothingToRegenerate):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, entity.ErrConversationNotFound), errors.Is(err, entity.ErrTurnNotFound),
+		errors.Is(err, entity.ErrTraceNotFound), errors.Is(err, entity.ErrTranscriptNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
 	}
-	return connect.NewResponse(&services.SubmitFeedbackResponse{}), nil
+	return err
 }
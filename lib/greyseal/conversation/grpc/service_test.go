@@ -4,15 +4,21 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	entity "github.com/holmes89/grey-seal/lib/greyseal/conversation"
 	grpchandler "github.com/holmes89/grey-seal/lib/greyseal/conversation/grpc"
 	"github.com/holmes89/grey-seal/lib/greyseal/conversation/mocks"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
 	"github.com/holmes89/archaea/base"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ConversationGRPCHandlerTestSuite struct {
@@ -29,10 +35,11 @@ func (s *ConversationGRPCHandlerTestSuite) SetupTest() {
 func (s *ConversationGRPCHandlerTestSuite) TestListConversations() {
 	convs := []*v1.Conversation{{Uuid: "c1", Title: "Chat"}}
 	listResp := &base.ListGenericResponse[*v1.Conversation]{Data: convs, Count: 1}
-	s.svc.On("List", mock.Anything, mock.Anything).Return(listResp, nil)
+	s.svc.On("List", mock.Anything, mock.Anything, entity.ListFilter{RoleUUID: "r1"}).Return(listResp, nil)
 
 	count := int32(1)
-	req := connect.NewRequest(&services.ListConversationsRequest{Count: &count})
+	roleUUID := "r1"
+	req := connect.NewRequest(&services.ListConversationsRequest{Count: &count, RoleUuid: &roleUUID})
 	resp, err := s.handler.ListConversations(context.Background(), req)
 	s.Require().NoError(err)
 	s.Len(resp.Msg.GetData(), 1)
@@ -64,7 +71,7 @@ func (s *ConversationGRPCHandlerTestSuite) TestCreateConversation() {
 
 func (s *ConversationGRPCHandlerTestSuite) TestUpdateConversation() {
 	updated := &v1.Conversation{Uuid: "u1", Title: "Updated"}
-	s.svc.On("Update", mock.Anything, "u1", mock.Anything).Return(updated, nil)
+	s.svc.On("Update", mock.Anything, "u1", mock.Anything, "title").Return(updated, nil)
 
 	title := "Updated"
 	req := connect.NewRequest(&services.UpdateConversationRequest{Uuid: "u1", Title: &title})
@@ -73,6 +80,20 @@ func (s *ConversationGRPCHandlerTestSuite) TestUpdateConversation() {
 	s.Equal("Updated", resp.Msg.GetData().GetTitle())
 }
 
+func (s *ConversationGRPCHandlerTestSuite) TestUpdateConversation_Mask() {
+	s.svc.On("Update", mock.Anything, "u1", mock.MatchedBy(func(c *v1.Conversation) bool {
+		return c.GetVariables()["team"] == "payments"
+	}), "variables", "resource_uuids").Return(&v1.Conversation{Uuid: "u1"}, nil)
+
+	req := connect.NewRequest(&services.UpdateConversationRequest{
+		Uuid:       "u1",
+		Variables:  map[string]string{"team": "payments"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"variables", "resource_uuids"}},
+	})
+	_, err := s.handler.UpdateConversation(context.Background(), req)
+	s.Require().NoError(err)
+}
+
 func (s *ConversationGRPCHandlerTestSuite) TestDeleteConversation() {
 	s.svc.On("Delete", mock.Anything, "d1").Return(nil)
 
@@ -82,11 +103,41 @@ func (s *ConversationGRPCHandlerTestSuite) TestDeleteConversation() {
 }
 
 func (s *ConversationGRPCHandlerTestSuite) TestSubmitFeedback() {
-	s.svc.On("SubmitFeedback", mock.Anything, "msg-1", int32(1)).Return(nil)
+	s.svc.On("SubmitFeedback", mock.Anything, mock.MatchedBy(func(f *v1.Feedback) bool {
+		return f.GetMessageUuid() == "msg-1" && f.GetRating() == -1 && f.GetComment() == "outdated" &&
+			len(f.GetReasons()) == 1 && f.GetReasons()[0] == v1.FeedbackReason_FEEDBACK_REASON_INCOMPLETE
+	})).Return(&v1.Feedback{Uuid: "f1"}, nil)
 
-	req := connect.NewRequest(&services.SubmitFeedbackRequest{MessageUuid: "msg-1", Feedback: 1})
-	_, err := s.handler.SubmitFeedback(context.Background(), req)
+	req := connect.NewRequest(&services.SubmitFeedbackRequest{
+		MessageUuid: "msg-1",
+		Feedback:    -1,
+		Reasons:     []v1.FeedbackReason{v1.FeedbackReason_FEEDBACK_REASON_INCOMPLETE},
+		Comment:     "outdated",
+	})
+	resp, err := s.handler.SubmitFeedback(context.Background(), req)
 	s.Require().NoError(err)
+	s.Equal("f1", resp.Msg.GetData().GetUuid())
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestGetFeedbackReport() {
+	s.svc.On("FeedbackReport", mock.Anything, entity.ReportQuery{
+		After:    time.Unix(100, 0).UTC(),
+		Interval: v1.FeedbackInterval_FEEDBACK_INTERVAL_WEEK,
+	}).Return(&v1.FeedbackReport{ByRole: []*v1.FeedbackStats{{Key: "r1", Negative: 2}}}, nil)
+
+	resp, err := s.handler.GetFeedbackReport(context.Background(), connect.NewRequest(&services.GetFeedbackReportRequest{
+		After:    timestamppb.New(time.Unix(100, 0)),
+		Interval: v1.FeedbackInterval_FEEDBACK_INTERVAL_WEEK,
+	}))
+	s.Require().NoError(err)
+	s.Len(resp.Msg.GetData().GetByRole(), 1)
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestSubmitFeedback_Invalid() {
+	s.svc.On("SubmitFeedback", mock.Anything, mock.Anything).Return(nil, entity.ErrInvalidFeedback)
+
+	_, err := s.handler.SubmitFeedback(context.Background(), connect.NewRequest(&services.SubmitFeedbackRequest{MessageUuid: "msg-1", Feedback: 5}))
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
 }
 
 func (s *ConversationGRPCHandlerTestSuite) TestDeleteConversation_Error() {
@@ -97,6 +148,161 @@ func (s *ConversationGRPCHandlerTestSuite) TestDeleteConversation_Error() {
 	s.Require().Error(err)
 }
 
+func (s *ConversationGRPCHandlerTestSuite) TestGetConversation_PermissionDenied() {
+	s.svc.On("Get", mock.Anything, mock.Anything).Return(nil, auth.ErrPermissionDenied)
+
+	req := connect.NewRequest(&services.GetConversationRequest{Uuid: "other"})
+	_, err := s.handler.GetConversation(context.Background(), req)
+	s.Require().Error(err)
+	s.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestListConversations_InvalidCursor() {
+	s.svc.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil, pagination.ErrInvalidCursor)
+
+	cursor := "garbage"
+	_, err := s.handler.ListConversations(context.Background(), connect.NewRequest(&services.ListConversationsRequest{Cursor: &cursor}))
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestSearchConversations() {
+	results := []*v1.ConversationSearchResult{{Conversation: &v1.Conversation{Uuid: "c1"}, Rank: 0.5}}
+	s.svc.On("Search", mock.Anything, mock.MatchedBy(func(q entity.SearchQuery) bool {
+		return q.Query == "kafka" && q.Feedback != nil && *q.Feedback == -1 && q.After.Equal(time.Unix(100, 0))
+	})).Return(results, nil)
+
+	feedback := int32(-1)
+	req := connect.NewRequest(&services.SearchConversationsRequest{
+		Query:    "kafka",
+		Feedback: &feedback,
+		After:    timestamppb.New(time.Unix(100, 0)),
+	})
+	resp, err := s.handler.SearchConversations(context.Background(), req)
+	s.Require().NoError(err)
+	s.Len(resp.Msg.GetData(), 1)
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestSearchConversations_EmptyQuery() {
+	s.svc.On("Search", mock.Anything, mock.Anything).Return(nil, entity.ErrQueryRequired)
+
+	_, err := s.handler.SearchConversations(context.Background(), connect.NewRequest(&services.SearchConversationsRequest{}))
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestExportConversation_Markdown() {
+	export := &v1.ConversationExport{Version: 1, Conversation: &v1.Conversation{Uuid: "c1", Title: "Chat"}}
+	s.svc.On("Export", mock.Anything, "c1").Return(export, nil)
+
+	req := connect.NewRequest(&services.ExportConversationRequest{Uuid: "c1", Format: services.ExportFormat_EXPORT_FORMAT_MARKDOWN})
+	resp, err := s.handler.ExportConversation(context.Background(), req)
+	s.Require().NoError(err)
+	s.Equal("text/markdown", resp.Msg.GetContentType())
+	s.Contains(string(resp.Msg.GetContent()), "# Chat")
+	s.Equal("c1", resp.Msg.GetData().GetConversation().GetUuid())
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestImportConversation() {
+	export := &v1.ConversationExport{Version: 1, Conversation: &v1.Conversation{Uuid: "c1"}}
+	s.svc.On("Import", mock.Anything, mock.Anything, entity.ImportOptions{Summarize: true}).Return(&v1.Conversation{Uuid: "c2"}, map[string]string{"c1": "c2"}, nil)
+
+	resp, err := s.handler.ImportConversation(context.Background(), connect.NewRequest(&services.ImportConversationRequest{Data: export, Summarize: true}))
+	s.Require().NoError(err)
+	s.Equal("c2", resp.Msg.GetData().GetUuid())
+	s.Equal("c2", resp.Msg.GetRemapped()["c1"])
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestImportConversation_Invalid() {
+	s.svc.On("Import", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, entity.ErrInvalidExport)
+
+	_, err := s.handler.ImportConversation(context.Background(), connect.NewRequest(&services.ImportConversationRequest{}))
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestArchiveConversation() {
+	s.svc.On("Archive", mock.Anything, "c1").Return(&v1.Conversation{Uuid: "c1", ArchivedAt: timestamppb.Now()}, nil)
+
+	resp, err := s.handler.ArchiveConversation(context.Background(), connect.NewRequest(&services.ArchiveConversationRequest{Uuid: "c1"}))
+	s.Require().NoError(err)
+	s.NotNil(resp.Msg.GetData().GetArchivedAt())
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestRestoreConversation_WindowExpired() {
+	s.svc.On("Restore", mock.Anything, "c1").Return(nil, entity.ErrRestoreWindowExpired)
+
+	_, err := s.handler.RestoreConversation(context.Background(), connect.NewRequest(&services.RestoreConversationRequest{Uuid: "c1"}))
+	s.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestListConversations_Status() {
+	s.svc.On("List", mock.Anything, mock.Anything, entity.ListFilter{Status: v1.ConversationStatus_CONVERSATION_STATUS_DELETED}).
+		Return(&base.ListGenericResponse[*v1.Conversation]{}, nil)
+
+	req := connect.NewRequest(&services.ListConversationsRequest{Status: v1.ConversationStatus_CONVERSATION_STATUS_DELETED})
+	_, err := s.handler.ListConversations(context.Background(), req)
+	s.Require().NoError(err)
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestReplayTurn() {
+	prompt := "be terse"
+	s.svc.On("ReplayTurn", mock.Anything, "m1", entity.ReplayOptions{
+		SystemPrompt:   &prompt,
+		Snippets:       entity.SnippetsFresh,
+		RetrievalLimit: 3,
+	}).Return(&entity.ReplayResult{
+		Original: entity.TurnRun{Response: "long answer", Snippets: []entity.SearchResult{{EntityUUID: "res-1"}}},
+		Replay:   entity.TurnRun{Response: "short", Messages: []entity.LLMMessage{{Role: "system", Content: prompt}}, Latency: 1500 * time.Millisecond},
+	}, nil)
+
+	limit := int32(3)
+	resp, err := s.handler.ReplayTurn(context.Background(), connect.NewRequest(&services.ReplayTurnRequest{
+		MessageUuid:    "m1",
+		SystemPrompt:   &prompt,
+		Snippets:       services.SnippetSource_SNIPPET_SOURCE_FRESH,
+		RetrievalLimit: &limit,
+	}))
+	s.Require().NoError(err)
+	s.Equal("res-1", resp.Msg.GetOriginal().GetSnippets()[0].GetResourceUuid())
+	s.Equal("short", resp.Msg.GetReplay().GetResponse())
+	s.Equal(int64(1500), resp.Msg.GetReplay().GetLatencyMs())
+	s.Equal("system", resp.Msg.GetReplay().GetMessages()[0].GetRole())
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestReplayTurn_NotRecorded() {
+	s.svc.On("ReplayTurn", mock.Anything, "m1", entity.ReplayOptions{}).Return(nil, entity.ErrTurnNotFound)
+
+	_, err := s.handler.ReplayTurn(context.Background(), connect.NewRequest(&services.ReplayTurnRequest{MessageUuid: "m1"}))
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestGetMessageTrace() {
+	s.svc.On("GetMessageTrace", mock.Anything, "m1").Return(&v1.TurnTrace{MessageUuid: "m1", Model: "llama3"}, nil)
+	s.svc.On("GetMessageTrace", mock.Anything, "m2").Return(nil, entity.ErrTraceNotFound)
+
+	resp, err := s.handler.GetMessageTrace(context.Background(), connect.NewRequest(&services.GetMessageTraceRequest{MessageUuid: "m1"}))
+	s.Require().NoError(err)
+	s.Equal("llama3", resp.Msg.GetTrace().GetModel())
+
+	_, err = s.handler.GetMessageTrace(context.Background(), connect.NewRequest(&services.GetMessageTraceRequest{MessageUuid: "m2"}))
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestListTranscripts() {
+	s.svc.On("ListTranscripts", mock.Anything, mock.Anything).Return([]*v1.TranscriptInfo{{ConversationUuid: "c1", TurnCount: 2}}, "next", nil)
+
+	resp, err := s.handler.ListTranscripts(context.Background(), connect.NewRequest(&services.ListTranscriptsRequest{}))
+	s.Require().NoError(err)
+	s.Equal("next", resp.Msg.GetCursor())
+	s.Equal(int32(1), resp.Msg.GetCount())
+	s.Equal(int32(2), resp.Msg.GetData()[0].GetTurnCount())
+}
+
+func (s *ConversationGRPCHandlerTestSuite) TestGetTranscript_NotFound() {
+	s.svc.On("GetTranscript", mock.Anything, "c1").Return(nil, entity.ErrTranscriptNotFound)
+
+	_, err := s.handler.GetTranscript(context.Background(), connect.NewRequest(&services.GetTranscriptRequest{ConversationUuid: "c1"}))
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
 func TestConversationGRPCHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(ConversationGRPCHandlerTestSuite))
 }
@@ -0,0 +1,206 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+
+	entity "github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+)
+
+var (
+	errSessionConversation = errors.New("conversation_uuid is required and cannot change within a session")
+	errTurnRunning         = errors.New("an answer is still being generated")
+	errContentRequired     = errors.New("content is required")
+	errUnknownAction       = errors.New("unknown chat session action")
+	errInvalidFrame        = errors.New("invalid chat session request")
+	errInternal            = errors.New("internal error")
+)
+
+// ChatSession serves a long-lived chat over one bidirectional stream.
+func (h *ConversationHandler) ChatSession(ctx context.Context, stream *connect.BidiStream[services.ChatSessionRequest, services.ChatSessionResponse]) error {
+	return serveChatSession(ctx, h.svc, h.logger, stream.Receive, stream.Send)
+}
+
+// chatSession is the state of one ChatSession stream: the conversation it
+// is bound to and the turn being answered, if any. Turns run in their own
+// goroutine so STOP can be read while tokens are streamed.
+type chatSession struct {
+	svc              entity.ConversationService
+	logger           *zap.Logger
+	conversationUUID string
+
+	sendMu sync.Mutex
+	send   func(*services.ChatSessionResponse) error
+
+	turnMu sync.Mutex
+	stop   context.CancelCauseFunc // nil when no turn is running
+	turns  sync.WaitGroup
+}
+
+// serveChatSession reads requests with recv until the client closes its
+// side, then waits for a running turn to finish. Requests that fail are
+// answered with an ERROR event; only transport errors end the session.
+// Turns outlive ctx's cancellation so that a dropped connection still
+// stores the partial answer.
+func serveChatSession(ctx context.Context, svc entity.ConversationService, logger *zap.Logger, recv func() (*services.ChatSessionRequest, error), send func(*services.ChatSessionResponse) error) error {
+	s := &chatSession{svc: svc, logger: logger, send: send}
+	defer s.turns.Wait()
+	for {
+		req, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err == nil {
+			err = s.handle(ctx, req)
+		}
+		if err != nil {
+			s.stopTurn()
+			return err
+		}
+	}
+}
+
+func (s *chatSession) handle(ctx context.Context, req *services.ChatSessionRequest) error {
+	uuid := req.GetConversationUuid()
+	switch {
+	case s.conversationUUID == "" && uuid == "", s.conversationUUID != "" && uuid != "" && uuid != s.conversationUUID:
+		return s.fail(connect.NewError(connect.CodeInvalidArgument, errSessionConversation))
+	case s.conversationUUID == "":
+		// The first request opens the session.
+		conv, err := s.svc.Get(ctx, &services.GetConversationRequest{Uuid: uuid})
+		if err != nil {
+			return s.fail(err)
+		}
+		s.conversationUUID = uuid
+		if err := s.emit(&services.ChatSessionResponse{Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_READY, Conversation: conv.GetData()}); err != nil {
+			return err
+		}
+	}
+
+	switch req.GetAction() {
+	case services.ChatSessionAction_CHAT_SESSION_ACTION_UNSPECIFIED:
+		return nil
+	case services.ChatSessionAction_CHAT_SESSION_ACTION_SEND:
+		if req.GetContent() == "" {
+			return s.fail(connect.NewError(connect.CodeInvalidArgument, errContentRequired))
+		}
+		return s.startTurn(ctx, func(ctx context.Context, stream func(string) error) (*greysealv1.Message, error) {
+			return s.svc.Chat(ctx, s.conversationUUID, req.GetContent(), stream)
+		})
+	case services.ChatSessionAction_CHAT_SESSION_ACTION_REGENERATE:
+		return s.startTurn(ctx, func(ctx context.Context, stream func(string) error) (*greysealv1.Message, error) {
+			return s.svc.Regenerate(ctx, s.conversationUUID, stream)
+		})
+	case services.ChatSessionAction_CHAT_SESSION_ACTION_STOP:
+		s.stopTurn()
+		return nil
+	case services.ChatSessionAction_CHAT_SESSION_ACTION_CONFIGURE:
+		return s.configure(ctx, req)
+	}
+	return s.fail(connect.NewError(connect.CodeInvalidArgument, errUnknownAction))
+}
+
+// startTurn runs one answer in the background. Only one turn runs at a time.
+func (s *chatSession) startTurn(ctx context.Context, run func(ctx context.Context, stream func(string) error) (*greysealv1.Message, error)) error {
+	s.turnMu.Lock()
+	if s.stop != nil {
+		s.turnMu.Unlock()
+		return s.fail(connect.NewError(connect.CodeFailedPrecondition, errTurnRunning))
+	}
+	turnCtx, stop := context.WithCancelCause(context.WithoutCancel(ctx))
+	s.stop = stop
+	s.turnMu.Unlock()
+
+	s.turns.Add(1)
+	go func() {
+		defer s.turns.Done()
+		defer stop(nil)
+		msg, err := run(turnCtx, func(token string) error {
+			if err := s.emit(&services.ChatSessionResponse{Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_TOKEN, Token: token}); err != nil {
+				// Nobody is listening: keep what was generated.
+				stop(entity.ErrGenerationStopped)
+				return err
+			}
+			return nil
+		})
+		// The turn is over before the client hears so; it may send at once.
+		s.turnMu.Lock()
+		s.stop = nil
+		s.turnMu.Unlock()
+
+		var blocked *entity.BlockedError
+		switch {
+		case errors.As(err, &blocked):
+			_ = s.emit(&services.ChatSessionResponse{Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_DONE, Message: blocked.Message, Blocked: blocked.Block})
+		case err != nil:
+			_ = s.fail(err)
+		default:
+			_ = s.emit(&services.ChatSessionResponse{Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_DONE, Message: msg})
+		}
+	}()
+	return nil
+}
+
+// stopTurn stops the running turn, if any, keeping its partial answer.
+func (s *chatSession) stopTurn() {
+	s.turnMu.Lock()
+	defer s.turnMu.Unlock()
+	if s.stop != nil {
+		s.stop(entity.ErrGenerationStopped)
+	}
+}
+
+// configure switches the conversation's role or resource scope.
+func (s *chatSession) configure(ctx context.Context, req *services.ChatSessionRequest) error {
+	s.turnMu.Lock()
+	running := s.stop != nil
+	s.turnMu.Unlock()
+	if running {
+		return s.fail(connect.NewError(connect.CodeFailedPrecondition, errTurnRunning))
+	}
+	conv := &greysealv1.Conversation{
+		RoleUuid:      req.GetRoleUuid(),
+		RoleVersion:   req.GetRoleVersion(),
+		ResourceUuids: req.GetResourceScope().GetResourceUuids(),
+	}
+	var paths []string
+	if req.RoleUuid != nil {
+		paths = append(paths, "role_uuid", "role_version")
+	}
+	if req.ResourceScope != nil {
+		paths = append(paths, "resource_uuids")
+	}
+	updated, err := s.svc.Update(ctx, s.conversationUUID, conv, paths...)
+	if err != nil {
+		return s.fail(err)
+	}
+	return s.emit(&services.ChatSessionResponse{Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_READY, Conversation: updated})
+}
+
+func (s *chatSession) emit(resp *services.ChatSessionResponse) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.send(resp)
+}
+
+// fail reports err to the client as an ERROR event. Errors without a
+// status are logged and reported as internal, without their text.
+func (s *chatSession) fail(err error) error {
+	var cerr *connect.Error
+	if !errors.As(connectError(err), &cerr) {
+		s.logger.Error("chat session request failed", zap.String("conversation_uuid", s.conversationUUID), zap.Error(err))
+		cerr = connect.NewError(connect.CodeInternal, errInternal)
+	}
+	return s.emit(&services.ChatSessionResponse{
+		Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR,
+		Error: cerr.Message(),
+		Code:  cerr.Code().String(),
+	})
+}
@@ -0,0 +1,234 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/archaea/base"
+	entity "github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	grpchandler "github.com/holmes89/grey-seal/lib/greyseal/conversation/grpc"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type ChatSessionTestSuite struct {
+	suite.Suite
+	svc     *mocks.MockConversationService
+	handler *grpchandler.ChatSessionWebSocket
+	server  *httptest.Server
+}
+
+func (s *ChatSessionTestSuite) SetupTest() {
+	s.svc = mocks.NewMockConversationService(s.T())
+	authenticate := func(r *http.Request) (context.Context, error) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("unauthenticated"))
+		}
+		return r.Context(), nil
+	}
+	s.handler = grpchandler.NewChatSessionWebSocket(s.svc, authenticate, zap.NewNop())
+	s.server = httptest.NewServer(s.handler)
+}
+
+func (s *ChatSessionTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// connect opens a session from a page on origin, offering protocols.
+func (s *ChatSessionTestSuite) connect(origin string, protocols ...string) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(s.server.URL, "http")+"/ws/chat", origin)
+	s.Require().NoError(err)
+	config.Protocol = protocols
+	ws, err := websocket.DialConfig(config)
+	if err == nil {
+		s.T().Cleanup(func() { _ = ws.Close() })
+	}
+	return ws, err
+}
+
+func (s *ChatSessionTestSuite) dial() *websocket.Conn {
+	ws, err := s.connect(s.server.URL, grpchandler.ChatProtocol, grpchandler.TokenProtocolPrefix+"secret")
+	s.Require().NoError(err)
+	return ws
+}
+
+func (s *ChatSessionTestSuite) send(ws *websocket.Conn, req *services.ChatSessionRequest) {
+	data, err := protojson.Marshal(req)
+	s.Require().NoError(err)
+	s.Require().NoError(websocket.Message.Send(ws, string(data)))
+}
+
+func (s *ChatSessionTestSuite) receive(ws *websocket.Conn) *services.ChatSessionResponse {
+	var data []byte
+	s.Require().NoError(websocket.Message.Receive(ws, &data))
+	resp := &services.ChatSessionResponse{}
+	s.Require().NoError(protojson.Unmarshal(data, resp))
+	return resp
+}
+
+func (s *ChatSessionTestSuite) open(ws *websocket.Conn) {
+	s.svc.On("Get", mock.Anything, mock.Anything).Return(&base.GetGenericResponse[*v1.Conversation]{Data: &v1.Conversation{Uuid: "c1"}}, nil)
+	s.send(ws, &services.ChatSessionRequest{ConversationUuid: "c1"})
+	resp := s.receive(ws)
+	s.Require().Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_READY, resp.GetEvent())
+	s.Equal("c1", resp.GetConversation().GetUuid())
+}
+
+func (s *ChatSessionTestSuite) TestSend() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Chat", mock.Anything, "c1", "hello", mock.Anything).Return(&v1.Message{Uuid: "m2", Content: "Hi there"}, nil).Run(func(args mock.Arguments) {
+		stream := args.Get(3).(func(string) error)
+		_ = stream("Hi ")
+		_ = stream("there")
+	})
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_SEND, Content: "hello"})
+	s.Equal("Hi ", s.receive(ws).GetToken())
+	s.Equal("there", s.receive(ws).GetToken())
+	done := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_DONE, done.GetEvent())
+	s.Equal("m2", done.GetMessage().GetUuid())
+}
+
+func (s *ChatSessionTestSuite) TestStop() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Chat", mock.Anything, "c1", "hello", mock.Anything).Return(&v1.Message{Content: "Hi", Stopped: true}, nil).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		_ = args.Get(3).(func(string) error)("Hi")
+		<-ctx.Done()
+		s.ErrorIs(context.Cause(ctx), entity.ErrGenerationStopped)
+	})
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_SEND, Content: "hello"})
+	s.Equal("Hi", s.receive(ws).GetToken())
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_STOP})
+	done := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_DONE, done.GetEvent())
+	s.True(done.GetMessage().GetStopped())
+}
+
+func (s *ChatSessionTestSuite) TestSend_WhileRunning() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Chat", mock.Anything, "c1", "hello", mock.Anything).Return(&v1.Message{Stopped: true}, nil).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		_ = args.Get(3).(func(string) error)("Hi")
+		<-ctx.Done()
+	}).Once()
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_SEND, Content: "hello"})
+	s.Equal("Hi", s.receive(ws).GetToken())
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_REGENERATE})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR, resp.GetEvent())
+	s.Equal("failed_precondition", resp.GetCode())
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_STOP})
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_DONE, s.receive(ws).GetEvent())
+}
+
+func (s *ChatSessionTestSuite) TestRegenerate_NothingToRegenerate() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Regenerate", mock.Anything, "c1", mock.Anything).Return(nil, entity.ErrNothingToRegenerate)
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_REGENERATE})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR, resp.GetEvent())
+	s.Equal("failed_precondition", resp.GetCode())
+}
+
+func (s *ChatSessionTestSuite) TestOpen_MissingConversation() {
+	ws := s.dial()
+	s.svc.On("Get", mock.Anything, mock.Anything).Return(nil, entity.ErrConversationNotFound)
+
+	s.send(ws, &services.ChatSessionRequest{ConversationUuid: "c1"})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR, resp.GetEvent())
+	s.Equal("not_found", resp.GetCode())
+}
+
+func (s *ChatSessionTestSuite) TestSend_InternalErrorHidesDetails() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Chat", mock.Anything, "c1", "hello", mock.Anything).Return(nil, fmt.Errorf("insert message: %w", errors.New("pq: connection to 10.0.0.5 refused")))
+
+	s.send(ws, &services.ChatSessionRequest{Action: services.ChatSessionAction_CHAT_SESSION_ACTION_SEND, Content: "hello"})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR, resp.GetEvent())
+	s.Equal("internal", resp.GetCode())
+	s.Equal("internal error", resp.GetError())
+}
+
+func (s *ChatSessionTestSuite) TestConfigure() {
+	ws := s.dial()
+	s.open(ws)
+	s.svc.On("Update", mock.Anything, "c1", mock.MatchedBy(func(c *v1.Conversation) bool {
+		return c.GetRoleUuid() == "r1" && c.GetRoleVersion() == 2 && len(c.GetResourceUuids()) == 1
+	}), "role_uuid", "role_version", "resource_uuids").Return(&v1.Conversation{Uuid: "c1", RoleUuid: "r1"}, nil)
+
+	role := "r1"
+	s.send(ws, &services.ChatSessionRequest{
+		Action:        services.ChatSessionAction_CHAT_SESSION_ACTION_CONFIGURE,
+		RoleUuid:      &role,
+		RoleVersion:   2,
+		ResourceScope: &services.ResourceScope{ResourceUuids: []string{"res-1"}},
+	})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_READY, resp.GetEvent())
+	s.Equal("r1", resp.GetConversation().GetRoleUuid())
+}
+
+func (s *ChatSessionTestSuite) TestConversationCannotChange() {
+	ws := s.dial()
+	s.open(ws)
+
+	s.send(ws, &services.ChatSessionRequest{ConversationUuid: "c2", Action: services.ChatSessionAction_CHAT_SESSION_ACTION_SEND, Content: "hi"})
+	resp := s.receive(ws)
+	s.Equal(services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR, resp.GetEvent())
+	s.Equal("invalid_argument", resp.GetCode())
+}
+
+func (s *ChatSessionTestSuite) TestUnauthenticated() {
+	_, err := s.connect(s.server.URL, grpchandler.ChatProtocol)
+	s.Error(err)
+}
+
+func (s *ChatSessionTestSuite) TestQueryTokenIgnored() {
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(s.server.URL, "http")+"/ws/chat?access_token=secret", s.server.URL)
+	s.Require().NoError(err)
+	_, err = websocket.DialConfig(config)
+	s.Error(err)
+}
+
+func (s *ChatSessionTestSuite) TestTokenNotEchoed() {
+	ws := s.dial()
+	s.Equal([]string{grpchandler.ChatProtocol}, ws.Config().Protocol)
+}
+
+func (s *ChatSessionTestSuite) TestOrigin() {
+	_, err := s.connect("https://evil.example", grpchandler.ChatProtocol, grpchandler.TokenProtocolPrefix+"secret")
+	s.Error(err)
+
+	s.handler.WithAllowedOrigins("https://chat.example")
+	_, err = s.connect("https://chat.example", grpchandler.ChatProtocol, grpchandler.TokenProtocolPrefix+"secret")
+	s.NoError(err)
+}
+
+func TestChatSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(ChatSessionTestSuite))
+}
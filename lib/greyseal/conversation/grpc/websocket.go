@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	entity "github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+)
+
+const (
+	// ChatProtocol is the WebSocket subprotocol the server selects.
+	ChatProtocol = "greyseal.chat"
+	// TokenProtocolPrefix marks the subprotocol that carries the credential,
+	// since browsers cannot set headers on a WebSocket: a client offers
+	// ChatProtocol and TokenProtocolPrefix+credential.
+	TokenProtocolPrefix = "bearer."
+)
+
+// ChatSessionWebSocket serves ChatSession to browsers, which cannot open
+// bidirectional Connect streams. Each text frame holds one
+// ChatSessionRequest or ChatSessionResponse in protobuf JSON.
+type ChatSessionWebSocket struct {
+	svc entity.ConversationService
+	// authenticate returns the request's context with its principal. Nil
+	// serves every request unauthenticated.
+	authenticate func(*http.Request) (context.Context, error)
+	// origins may connect besides the API's own; "*" allows any origin.
+	origins []string
+	logger  *zap.Logger
+}
+
+// NewChatSessionWebSocket creates the WebSocket handler for ChatSession.
+// Only same-origin pages, and clients that send no Origin, may connect until
+// WithAllowedOrigins adds others.
+func NewChatSessionWebSocket(svc entity.ConversationService, authenticate func(*http.Request) (context.Context, error), logger *zap.Logger) *ChatSessionWebSocket {
+	return &ChatSessionWebSocket{svc: svc, authenticate: authenticate, logger: logger}
+}
+
+// WithAllowedOrigins lets pages on origins, such as "https://chat.example.com",
+// open sessions.
+func (h *ChatSessionWebSocket) WithAllowedOrigins(origins ...string) *ChatSessionWebSocket {
+	h.origins = append(h.origins, origins...)
+	return h
+}
+
+func (h *ChatSessionWebSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.allowedOrigin(r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if h.authenticate != nil {
+		if token := protocolToken(r); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		ctx, err := h.authenticate(r)
+		if err != nil {
+			status := http.StatusUnauthorized
+			switch connect.CodeOf(err) {
+			case connect.CodePermissionDenied:
+				status = http.StatusForbidden
+			case connect.CodeUnavailable:
+				status = http.StatusServiceUnavailable
+			case connect.CodeInternal:
+				status = http.StatusInternalServerError
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		r = r.WithContext(ctx)
+	}
+	server := websocket.Server{
+		// The origin was checked above. Select ChatProtocol so that the
+		// credential is never echoed back.
+		Handshake: func(config *websocket.Config, _ *http.Request) error {
+			if slices.Contains(config.Protocol, ChatProtocol) {
+				config.Protocol = []string{ChatProtocol}
+			} else {
+				config.Protocol = nil
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close() //nolint:errcheck
+			if err := serveChatSession(r.Context(), h.svc, h.logger, receiveFrame(ws), sendFrame(ws)); err != nil {
+				h.logger.Info("chat session closed", zap.Error(err))
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// allowedOrigin reports whether r may open a session. Browsers always send
+// Origin on a WebSocket handshake, so requests without one are not from a page.
+func (h *ChatSessionWebSocket) allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(h.origins, "*") || slices.Contains(h.origins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// protocolToken returns the credential offered as a WebSocket subprotocol.
+func protocolToken(r *http.Request) string {
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if token, ok := strings.CutPrefix(strings.TrimSpace(protocol), TokenProtocolPrefix); ok {
+				return token
+			}
+		}
+	}
+	return ""
+}
+
+// receiveFrame reads requests from ws. Frames that are not a valid request
+// are answered with an ERROR event and skipped.
+func receiveFrame(ws *websocket.Conn) func() (*services.ChatSessionRequest, error) {
+	return func() (*services.ChatSessionRequest, error) {
+		for {
+			var data []byte
+			if err := websocket.Message.Receive(ws, &data); err != nil {
+				return nil, err
+			}
+			req := &services.ChatSessionRequest{}
+			err := protojson.Unmarshal(data, req)
+			if err == nil {
+				return req, nil
+			}
+			if err := sendFrame(ws)(&services.ChatSessionResponse{
+				Event: services.ChatSessionEvent_CHAT_SESSION_EVENT_ERROR,
+				Error: fmt.Errorf("%w: %v", errInvalidFrame, err).Error(),
+				Code:  connect.CodeInvalidArgument.String(),
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// sendFrame writes responses to ws as text frames.
+func sendFrame(ws *websocket.Conn) func(*services.ChatSessionResponse) error {
+	return func(resp *services.ChatSessionResponse) error {
+		data, err := protojson.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		return websocket.Message.Send(ws, string(data))
+	}
+}
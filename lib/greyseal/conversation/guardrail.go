@@ -0,0 +1,378 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrTurnBlocked is wrapped by every BlockedError.
+var ErrTurnBlocked = errors.New("turn blocked by a guardrail")
+
+// GuardrailStage is when a guardrail hook runs in a chat turn.
+type GuardrailStage string
+
+const (
+	// StagePreRetrieval runs on the question before context is searched.
+	StagePreRetrieval GuardrailStage = "pre_retrieval"
+	// StagePreLLM runs on the assembled prompt before the model is called.
+	StagePreLLM GuardrailStage = "pre_llm"
+	// StagePostLLM runs on the answer before it is sent and stored.
+	StagePostLLM GuardrailStage = "post_llm"
+)
+
+// GuardrailAction is a guardrail's verdict on a turn.
+type GuardrailAction string
+
+const (
+	GuardrailAllow GuardrailAction = "allow"
+	// GuardrailModify means the hook changed the GuardrailTurn it was given.
+	GuardrailModify GuardrailAction = "modify"
+	GuardrailBlock  GuardrailAction = "block"
+)
+
+// GuardrailDecision is what a hook returns.
+type GuardrailDecision struct {
+	Action GuardrailAction
+	Reason greysealv1.GuardrailReason
+	// Message is shown to the user when the turn is blocked. Empty uses the
+	// role's refusal message, or a default for the reason.
+	Message string
+	// Detail explains the decision in logs and transcripts. It is never
+	// shown to the user.
+	Detail string
+}
+
+// Allow is the decision of a hook with nothing to do.
+var Allow = GuardrailDecision{Action: GuardrailAllow}
+
+// GuardrailTurn is the turn as guardrails see it. Hooks that return
+// GuardrailModify change it in place.
+type GuardrailTurn struct {
+	Conversation *greysealv1.Conversation
+	// Question is the user's message. A PreRetrieval rewrite is what is
+	// searched and sent to the model; the stored message keeps the original.
+	Question string
+	// Snippets is the retrieved context, set from StagePreLLM on.
+	Snippets []SearchResult
+	// Messages is the prompt, set from StagePreLLM on. A PreLLM rewrite is
+	// what the model is sent.
+	Messages []LLMMessage
+	// Answer is the model's response, set for StagePostLLM. A PostLLM
+	// rewrite is what the user gets.
+	Answer string
+}
+
+// Guardrail is a policy check around chat turns. Each hook can allow the
+// turn, modify it or block it; an error fails the turn. Answers of turns with
+// guardrails are held back until the PostLLM hooks pass, then sent whole.
+type Guardrail interface {
+	Name() string
+	PreRetrieval(ctx context.Context, turn *GuardrailTurn) (GuardrailDecision, error)
+	PreLLM(ctx context.Context, turn *GuardrailTurn) (GuardrailDecision, error)
+	PostLLM(ctx context.Context, turn *GuardrailTurn) (GuardrailDecision, error)
+}
+
+// GuardrailEvent records a hook that did not simply allow a turn. Events are
+// written to the turn's transcript.
+type GuardrailEvent struct {
+	Guardrail string          `json:"guardrail"`
+	Stage     GuardrailStage  `json:"stage"`
+	Action    GuardrailAction `json:"action"`
+	Reason    string          `json:"reason,omitempty"`
+	Detail    string          `json:"detail,omitempty"`
+}
+
+// BlockedError is returned by Chat when a guardrail refuses a turn. The
+// refusal has been streamed and stored as the assistant's message.
+type BlockedError struct {
+	Block   *greysealv1.GuardrailBlock
+	Message *greysealv1.Message
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s: %s at %s (%s)", ErrTurnBlocked, e.Block.GetGuardrail(), e.Block.GetStage(), e.Block.GetReason())
+}
+
+func (e *BlockedError) Unwrap() error { return ErrTurnBlocked }
+
+// defaultRefusals are shown for blocks without a message when the role has
+// no refusal message.
+var defaultRefusals = map[greysealv1.GuardrailReason]string{
+	greysealv1.GuardrailReason_GUARDRAIL_REASON_BLOCKED_TOPIC:  "Sorry, I can't help with that topic.",
+	greysealv1.GuardrailReason_GUARDRAIL_REASON_INPUT_TOO_LONG: "Your message is too long. Please shorten it and try again.",
+	greysealv1.GuardrailReason_GUARDRAIL_REASON_NO_CONTEXT:     "I couldn't find anything in the knowledge base to answer that, so I won't guess.",
+	greysealv1.GuardrailReason_GUARDRAIL_REASON_POLICY:         "Sorry, I can't help with that request.",
+}
+
+const defaultRefusal = "Sorry, I can't help with that."
+
+// KeywordGuardrail enforces a role's rule-based guardrails: blocked topics
+// as regular expressions over the question and answer, a maximum question
+// length, refusing without retrieved context, and a required disclaimer.
+type KeywordGuardrail struct {
+	topics         []*regexp.Regexp
+	maxInputChars  int
+	requireContext bool
+	disclaimer     string
+}
+
+// NewKeywordGuardrail compiles the role's blocked topics, case-insensitively.
+func NewKeywordGuardrail(g *greysealv1.RoleGuardrails) (*KeywordGuardrail, error) {
+	k := &KeywordGuardrail{
+		maxInputChars:  int(g.GetMaxInputChars()),
+		requireContext: g.GetRequireContext(),
+		disclaimer:     strings.TrimSpace(g.GetDisclaimer()),
+	}
+	for _, topic := range g.GetBlockedTopics() {
+		re, err := regexp.Compile("(?i)" + topic)
+		if err != nil {
+			return nil, fmt.Errorf("blocked topic %q: %w", topic, err)
+		}
+		k.topics = append(k.topics, re)
+	}
+	return k, nil
+}
+
+func (k *KeywordGuardrail) Name() string { return "keyword" }
+
+func (k *KeywordGuardrail) PreRetrieval(_ context.Context, turn *GuardrailTurn) (GuardrailDecision, error) {
+	if n := utf8.RuneCountInString(turn.Question); k.maxInputChars > 0 && n > k.maxInputChars {
+		return GuardrailDecision{
+			Action:  GuardrailBlock,
+			Reason:  greysealv1.GuardrailReason_GUARDRAIL_REASON_INPUT_TOO_LONG,
+			Message: fmt.Sprintf("Your message is too long. Please keep it under %d characters.", k.maxInputChars),
+			Detail:  fmt.Sprintf("%d characters", n),
+		}, nil
+	}
+	return k.topic(turn.Question), nil
+}
+
+func (k *KeywordGuardrail) PreLLM(_ context.Context, turn *GuardrailTurn) (GuardrailDecision, error) {
+	if k.requireContext && len(turn.Snippets) == 0 {
+		return GuardrailDecision{Action: GuardrailBlock, Reason: greysealv1.GuardrailReason_GUARDRAIL_REASON_NO_CONTEXT}, nil
+	}
+	return Allow, nil
+}
+
+func (k *KeywordGuardrail) PostLLM(_ context.Context, turn *GuardrailTurn) (GuardrailDecision, error) {
+	if d := k.topic(turn.Answer); d.Action == GuardrailBlock {
+		return d, nil
+	}
+	if k.disclaimer != "" && !strings.Contains(turn.Answer, k.disclaimer) {
+		turn.Answer = strings.TrimRight(turn.Answer, "\n") + "\n\n" + k.disclaimer
+		return GuardrailDecision{Action: GuardrailModify, Detail: "appended disclaimer"}, nil
+	}
+	return Allow, nil
+}
+
+func (k *KeywordGuardrail) topic(text string) GuardrailDecision {
+	for _, re := range k.topics {
+		if re.MatchString(text) {
+			return GuardrailDecision{
+				Action: GuardrailBlock,
+				Reason: greysealv1.GuardrailReason_GUARDRAIL_REASON_BLOCKED_TOPIC,
+				Detail: "matched " + re.String(),
+			}
+		}
+	}
+	return Allow
+}
+
+// judgeInstructions is the judge's system prompt; the policy follows it.
+const judgeInstructions = `You review messages to an assistant against a policy. Reply with ALLOW if the text complies with the policy. Otherwise reply with BLOCK followed by a short reason. Reply with nothing else.
+
+Policy:
+`
+
+var thinkBlock = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// JudgeGuardrail asks a model whether each question, and each question and
+// answer together, comply with a written policy. A reply that is neither
+// ALLOW nor BLOCK blocks the turn.
+type JudgeGuardrail struct {
+	llm    LLM
+	policy string
+}
+
+func NewJudgeGuardrail(llm LLM, policy string) *JudgeGuardrail {
+	return &JudgeGuardrail{llm: llm, policy: policy}
+}
+
+func (j *JudgeGuardrail) Name() string { return "judge" }
+
+func (j *JudgeGuardrail) PreRetrieval(ctx context.Context, turn *GuardrailTurn) (GuardrailDecision, error) {
+	return j.judge(ctx, "Question:\n"+turn.Question)
+}
+
+func (j *JudgeGuardrail) PreLLM(context.Context, *GuardrailTurn) (GuardrailDecision, error) {
+	return Allow, nil
+}
+
+func (j *JudgeGuardrail) PostLLM(ctx context.Context, turn *GuardrailTurn) (GuardrailDecision, error) {
+	return j.judge(ctx, "Question:\n"+turn.Question+"\n\nAnswer:\n"+turn.Answer)
+}
+
+func (j *JudgeGuardrail) judge(ctx context.Context, text string) (GuardrailDecision, error) {
+	reply, err := j.llm.Chat(ctx, []LLMMessage{
+		{Role: "system", Content: judgeInstructions + j.policy},
+		{Role: "user", Content: text},
+	}, func(string) error { return nil })
+	if err != nil {
+		return GuardrailDecision{}, fmt.Errorf("judge: %w", err)
+	}
+	verdict := strings.TrimSpace(thinkBlock.ReplaceAllString(reply, ""))
+	word, rest, _ := strings.Cut(verdict, " ")
+	switch strings.ToUpper(strings.Trim(word, ".:")) {
+	case "ALLOW":
+		return Allow, nil
+	case "BLOCK":
+		return GuardrailDecision{
+			Action: GuardrailBlock,
+			Reason: greysealv1.GuardrailReason_GUARDRAIL_REASON_POLICY,
+			Detail: strings.TrimSpace(strings.TrimLeft(rest, ":-")),
+		}, nil
+	}
+	return GuardrailDecision{
+		Action: GuardrailBlock,
+		Reason: greysealv1.GuardrailReason_GUARDRAIL_REASON_POLICY,
+		Detail: "unclear verdict: " + verdict,
+	}, nil
+}
+
+// redactedLLM sends the judge's messages through the prompt redaction policy
+// like every other prompt.
+type redactedLLM struct{ srv *conversationService }
+
+func (l redactedLLM) Chat(ctx context.Context, messages []LLMMessage, stream func(string) error) (string, error) {
+	vault := redact.NewVault()
+	reply, err := l.srv.llm.Chat(ctx, l.srv.redactPrompt(messages, vault), stream)
+	return vault.Restore(reply), err
+}
+
+// guardrailsFor returns the service's guardrails followed by the role's.
+func (srv *conversationService) guardrailsFor(conv *greysealv1.Conversation, ins roleInstructions) []Guardrail {
+	guards := append([]Guardrail(nil), srv.guardrails...)
+	g := ins.Guardrails
+	if len(g.GetBlockedTopics()) > 0 || g.GetMaxInputChars() > 0 || g.GetRequireContext() || g.GetDisclaimer() != "" {
+		keyword, err := NewKeywordGuardrail(g)
+		if err != nil {
+			srv.logger.Warn("invalid role guardrails, leaving them out",
+				zap.String("conversation_uuid", conv.GetUuid()),
+				zap.String("role_uuid", conv.GetRoleUuid()),
+				zap.Error(err),
+			)
+		} else {
+			guards = append(guards, keyword)
+		}
+	}
+	if g.GetJudgePolicy() != "" && srv.llm != nil {
+		guards = append(guards, NewJudgeGuardrail(redactedLLM{srv}, g.GetJudgePolicy()))
+	}
+	return guards
+}
+
+// runGuardrails runs one stage of every guardrail in order, stopping at the
+// first block. It returns the events of hooks that did not allow the turn
+// and the blocking guardrail, if any.
+func runGuardrails(ctx context.Context, guards []Guardrail, stage GuardrailStage, turn *GuardrailTurn) ([]GuardrailEvent, Guardrail, GuardrailDecision, error) {
+	var events []GuardrailEvent
+	for _, g := range guards {
+		var d GuardrailDecision
+		var err error
+		switch stage {
+		case StagePreRetrieval:
+			d, err = g.PreRetrieval(ctx, turn)
+		case StagePreLLM:
+			d, err = g.PreLLM(ctx, turn)
+		case StagePostLLM:
+			d, err = g.PostLLM(ctx, turn)
+		}
+		if err != nil {
+			return events, nil, GuardrailDecision{}, fmt.Errorf("guardrail %s: %w", g.Name(), err)
+		}
+		if d.Action == GuardrailAllow || d.Action == "" {
+			continue
+		}
+		event := GuardrailEvent{Guardrail: g.Name(), Stage: stage, Action: d.Action, Detail: d.Detail}
+		if d.Reason != greysealv1.GuardrailReason_GUARDRAIL_REASON_UNSPECIFIED {
+			event.Reason = d.Reason.String()
+		}
+		events = append(events, event)
+		if d.Action == GuardrailBlock {
+			return events, g, d, nil
+		}
+	}
+	return events, nil, Allow, nil
+}
+
+// refuse answers a blocked turn: the refusal is streamed, stored as the
+// assistant's message and written to the transcript, and returned as a
+// BlockedError.
+func (srv *conversationService) refuse(ctx context.Context, conv *greysealv1.Conversation, ins roleInstructions, turn TranscriptTurn,
+	stage GuardrailStage, g Guardrail, d GuardrailDecision, stream func(string) error) error {
+	text := d.Message
+	if text == "" {
+		text = ins.Guardrails.GetRefusalMessage()
+	}
+	if text == "" {
+		text = defaultRefusals[d.Reason]
+	}
+	if text == "" {
+		text = defaultRefusal
+	}
+	srv.logger.Info("chat turn blocked by guardrail",
+		zap.String("conversation_uuid", conv.GetUuid()),
+		zap.String("guardrail", g.Name()),
+		zap.String("stage", string(stage)),
+		zap.String("reason", d.Reason.String()),
+		zap.String("detail", d.Detail),
+	)
+	if err := stream(text); err != nil {
+		return err
+	}
+	msg := &greysealv1.Message{
+		Uuid:             uuid.New().String(),
+		ConversationUuid: conv.GetUuid(),
+		Role:             greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT,
+		Content:          text,
+		CreatedAt:        timestamppb.New(time.Now()),
+		Owner:            conv.GetOwner(),
+		WorkspaceUuid:    conv.GetWorkspaceUuid(),
+		RoleUuid:         conv.GetRoleUuid(),
+		RoleVersion:      ins.Version,
+	}
+	if err := srv.messageRepo.Create(ctx, msg); err != nil {
+		return fmt.Errorf("failed to save refusal: %w", err)
+	}
+	if srv.transcriptWriter != nil {
+		turn.MessageUUID = msg.GetUuid()
+		turn.Response = text
+		if err := srv.transcriptWriter.WriteTurn(ctx, srv.redactTurn(turn)); err != nil {
+			srv.logger.Warn("failed to write transcript",
+				zap.String("conversation_uuid", conv.GetUuid()),
+				zap.Error(err),
+			)
+		}
+	}
+	srv.touch(ctx, conv)
+	return &BlockedError{
+		Block: &greysealv1.GuardrailBlock{
+			Reason:    d.Reason,
+			Message:   text,
+			Guardrail: g.Name(),
+			Stage:     string(stage),
+		},
+		Message: msg,
+	}
+}
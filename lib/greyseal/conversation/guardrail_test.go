@@ -0,0 +1,102 @@
+package conversation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeywordGuardrail_PreRetrieval(t *testing.T) {
+	g, err := conversation.NewKeywordGuardrail(&v1.RoleGuardrails{BlockedTopics: []string{`lock ?pick`}, MaxInputChars: 20})
+	require.NoError(t, err)
+
+	d, err := g.PreRetrieval(context.Background(), &conversation.GuardrailTurn{Question: "How do I LOCKPICK?"})
+	require.NoError(t, err)
+	assert.Equal(t, conversation.GuardrailBlock, d.Action)
+	assert.Equal(t, v1.GuardrailReason_GUARDRAIL_REASON_BLOCKED_TOPIC, d.Reason)
+
+	d, err = g.PreRetrieval(context.Background(), &conversation.GuardrailTurn{Question: "Who runs payments this week?"})
+	require.NoError(t, err)
+	assert.Equal(t, v1.GuardrailReason_GUARDRAIL_REASON_INPUT_TOO_LONG, d.Reason)
+	assert.Equal(t, "Your message is too long. Please keep it under 20 characters.", d.Message)
+
+	d, err = g.PreRetrieval(context.Background(), &conversation.GuardrailTurn{Question: "Who runs payments?"})
+	require.NoError(t, err)
+	assert.Equal(t, conversation.Allow, d)
+}
+
+func TestKeywordGuardrail_InvalidTopic(t *testing.T) {
+	_, err := conversation.NewKeywordGuardrail(&v1.RoleGuardrails{BlockedTopics: []string{"("}})
+	assert.Error(t, err)
+}
+
+func TestKeywordGuardrail_RequireContext(t *testing.T) {
+	g, err := conversation.NewKeywordGuardrail(&v1.RoleGuardrails{RequireContext: true})
+	require.NoError(t, err)
+
+	d, err := g.PreLLM(context.Background(), &conversation.GuardrailTurn{})
+	require.NoError(t, err)
+	assert.Equal(t, v1.GuardrailReason_GUARDRAIL_REASON_NO_CONTEXT, d.Reason)
+
+	d, err = g.PreLLM(context.Background(), &conversation.GuardrailTurn{Snippets: []conversation.SearchResult{{EntityUUID: "res-1"}}})
+	require.NoError(t, err)
+	assert.Equal(t, conversation.Allow, d)
+}
+
+func TestKeywordGuardrail_PostLLM(t *testing.T) {
+	g, err := conversation.NewKeywordGuardrail(&v1.RoleGuardrails{BlockedTopics: []string{"password"}, Disclaimer: "Not legal advice."})
+	require.NoError(t, err)
+
+	turn := &conversation.GuardrailTurn{Answer: "You can appeal.\n"}
+	d, err := g.PostLLM(context.Background(), turn)
+	require.NoError(t, err)
+	assert.Equal(t, conversation.GuardrailModify, d.Action)
+	assert.Equal(t, "You can appeal.\n\nNot legal advice.", turn.Answer)
+
+	// The disclaimer is not added twice.
+	d, err = g.PostLLM(context.Background(), turn)
+	require.NoError(t, err)
+	assert.Equal(t, conversation.Allow, d)
+
+	d, err = g.PostLLM(context.Background(), &conversation.GuardrailTurn{Answer: "The admin password is hunter2."})
+	require.NoError(t, err)
+	assert.Equal(t, conversation.GuardrailBlock, d.Action)
+}
+
+func TestJudgeGuardrail(t *testing.T) {
+	for reply, want := range map[string]conversation.GuardrailAction{
+		"ALLOW":                               conversation.GuardrailAllow,
+		"<think>fine</think>\nAllow.":         conversation.GuardrailAllow,
+		"BLOCK: asks for medical dosing":      conversation.GuardrailBlock,
+		"I am not sure what the policy says.": conversation.GuardrailBlock,
+	} {
+		llm := mocks.NewMockLLM(t)
+		llm.On("Chat", mock.Anything, mock.MatchedBy(func(msgs []conversation.LLMMessage) bool {
+			return len(msgs) == 2 && msgs[0].Role == "system" && msgs[1].Content == "Question:\nhow much ibuprofen?"
+		}), mock.Anything).Return(reply, nil)
+		g := conversation.NewJudgeGuardrail(llm, "No medical advice.")
+
+		d, err := g.PreRetrieval(context.Background(), &conversation.GuardrailTurn{Question: "how much ibuprofen?"})
+		require.NoError(t, err)
+		assert.Equal(t, want, d.Action, reply)
+		if want == conversation.GuardrailBlock {
+			assert.Equal(t, v1.GuardrailReason_GUARDRAIL_REASON_POLICY, d.Reason, reply)
+		}
+	}
+}
+
+func TestJudgeGuardrail_Error(t *testing.T) {
+	llm := mocks.NewMockLLM(t)
+	llm.On("Chat", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("unavailable"))
+	g := conversation.NewJudgeGuardrail(llm, "No medical advice.")
+
+	_, err := g.PostLLM(context.Background(), &conversation.GuardrailTurn{Question: "q", Answer: "a"})
+	assert.Error(t, err)
+}
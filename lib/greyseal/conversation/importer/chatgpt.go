@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// chatGPTConversation is one entry of ChatGPT's conversations.json. Messages
+// form a tree in mapping; regenerated and edited turns are sibling branches and
+// current_node is the leaf of the branch the user last saw.
+type chatGPTConversation struct {
+	ID             string                 `json:"id"`
+	ConversationID string                 `json:"conversation_id"`
+	Title          string                 `json:"title"`
+	CreateTime     float64                `json:"create_time"`
+	UpdateTime     float64                `json:"update_time"`
+	CurrentNode    string                 `json:"current_node"`
+	Mapping        map[string]chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	ID       string          `json:"id"`
+	Parent   *string         `json:"parent"`
+	Children []string        `json:"children"`
+	Message  *chatGPTMessage `json:"message"`
+}
+
+type chatGPTMessage struct {
+	ID     string `json:"id"`
+	Author struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	CreateTime *float64 `json:"create_time"`
+	Content    struct {
+		ContentType string `json:"content_type"`
+		Parts       []any  `json:"parts"`
+	} `json:"content"`
+}
+
+// ParseChatGPT reads a ChatGPT conversations.json export, keeping only the
+// active branch of each conversation.
+func ParseChatGPT(data []byte) ([]Result, error) {
+	items, err := decodeList[chatGPTConversation](data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ChatGPT export: %w", err)
+	}
+
+	results := make([]Result, 0, len(items))
+	for _, item := range items {
+		sourceID := item.ConversationID
+		if sourceID == "" {
+			sourceID = item.ID
+		}
+		conv := &greysealv1.Conversation{
+			Uuid:      stableUUID(sourceID),
+			Title:     item.Title,
+			CreatedAt: unixTimestamp(item.CreateTime),
+			UpdatedAt: unixTimestamp(item.UpdateTime),
+		}
+
+		branch := activeBranch(item.leaf(), func(id string) (string, bool) {
+			node, ok := item.Mapping[id]
+			if !ok {
+				return "", false
+			}
+			if node.Parent == nil {
+				return "", true
+			}
+			return *node.Parent, true
+		})
+		for _, id := range branch {
+			if msg := item.Mapping[id].toMessage(id); msg != nil {
+				conv.Messages = append(conv.Messages, msg)
+			}
+		}
+
+		results = append(results, Result{
+			SourceID: sourceID,
+			Export:   newExport(conv),
+			Dropped:  item.messageCount() - len(conv.Messages),
+		})
+	}
+	return results, nil
+}
+
+// leaf returns current_node, or the most recent message when it is missing.
+func (c chatGPTConversation) leaf() string {
+	if _, ok := c.Mapping[c.CurrentNode]; ok {
+		return c.CurrentNode
+	}
+	var leaf string
+	var latest float64 = -1
+	for id, node := range c.Mapping {
+		if node.Message == nil || node.Message.CreateTime == nil {
+			continue
+		}
+		if t := *node.Message.CreateTime; t > latest || (t == latest && id > leaf) {
+			leaf, latest = id, t
+		}
+	}
+	return leaf
+}
+
+func (c chatGPTConversation) messageCount() int {
+	n := 0
+	for _, node := range c.Mapping {
+		if node.Message != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// toMessage converts a node to a Message. System, tool and non-text messages
+// (images, code execution output) are skipped.
+func (n chatGPTNode) toMessage(nodeID string) *greysealv1.Message {
+	if n.Message == nil {
+		return nil
+	}
+	var role greysealv1.MessageRole
+	switch n.Message.Author.Role {
+	case "user":
+		role = greysealv1.MessageRole_MESSAGE_ROLE_USER
+	case "assistant":
+		role = greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT
+	default:
+		return nil
+	}
+	switch n.Message.Content.ContentType {
+	case "text", "multimodal_text":
+	default:
+		return nil
+	}
+
+	var parts []string
+	for _, part := range n.Message.Content.Parts {
+		if s, ok := part.(string); ok && strings.TrimSpace(s) != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+
+	id := n.Message.ID
+	if id == "" {
+		id = nodeID
+	}
+	msg := &greysealv1.Message{
+		Uuid:    stableUUID(id),
+		Role:    role,
+		Content: strings.Join(parts, "\n\n"),
+	}
+	if n.Message.CreateTime != nil {
+		msg.CreatedAt = unixTimestamp(*n.Message.CreateTime)
+	}
+	return msg
+}
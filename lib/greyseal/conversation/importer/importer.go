@@ -0,0 +1,148 @@
+// Package importer converts conversation exports from other chat tools into
+// grey-seal ConversationExports, which ConversationService.Import can load.
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Format identifies the tool an export came from.
+type Format string
+
+const (
+	FormatChatGPT   Format = "chatgpt"
+	FormatOpenWebUI Format = "openwebui"
+)
+
+// ErrUnknownFormat is returned when the format cannot be detected or is not supported.
+var ErrUnknownFormat = errors.New("unknown chat export format")
+
+// Result is one conversation read from an export.
+type Result struct {
+	// SourceID is the conversation's ID in the originating tool.
+	SourceID string
+	Export   *greysealv1.ConversationExport
+	// Dropped counts messages left out: other branches, system and tool
+	// messages, and messages without text.
+	Dropped int
+}
+
+// Parse reads an export in the given format, detecting it when format is empty.
+func Parse(data []byte, format Format) ([]Result, error) {
+	if format == "" {
+		var err error
+		if format, err = Detect(data); err != nil {
+			return nil, err
+		}
+	}
+	switch format {
+	case FormatChatGPT:
+		return ParseChatGPT(data)
+	case FormatOpenWebUI:
+		return ParseOpenWebUI(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Detect guesses the format from the first conversation in the export:
+// ChatGPT conversations carry a "mapping" tree, Open WebUI ones a "chat" object.
+func Detect(data []byte) (Format, error) {
+	var first map[string]json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+		}
+		if len(items) == 0 {
+			return "", fmt.Errorf("%w: export is empty", ErrUnknownFormat)
+		}
+		first = items[0]
+	} else if err := json.Unmarshal(trimmed, &first); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	switch {
+	case first["mapping"] != nil:
+		return FormatChatGPT, nil
+	case first["chat"] != nil:
+		return FormatOpenWebUI, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// decodeList accepts either a JSON array of T or a single T.
+func decodeList[T any](data []byte) ([]T, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []T
+		err := json.Unmarshal(trimmed, &items)
+		return items, err
+	}
+	var item T
+	if err := json.Unmarshal(trimmed, &item); err != nil {
+		return nil, err
+	}
+	return []T{item}, nil
+}
+
+// activeBranch follows parent links from leaf to the root and returns the node
+// IDs root first. Cycles and dangling parents end the walk.
+func activeBranch(leaf string, parent func(id string) (string, bool)) []string {
+	var branch []string
+	seen := map[string]bool{}
+	for id := leaf; id != "" && !seen[id]; {
+		p, ok := parent(id)
+		if !ok {
+			break
+		}
+		seen[id] = true
+		branch = append(branch, id)
+		id = p
+	}
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch
+}
+
+// stableUUID keeps IDs that are already UUIDs so re-imports are detected as
+// collisions, and mints a new UUID otherwise.
+func stableUUID(id string) string {
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String()
+	}
+	return uuid.New().String()
+}
+
+// unixTimestamp converts fractional Unix seconds, treating zero as unset.
+func unixTimestamp(seconds float64) *timestamppb.Timestamp {
+	if seconds <= 0 {
+		return nil
+	}
+	sec := int64(seconds)
+	return timestamppb.New(time.Unix(sec, int64((seconds-float64(sec))*float64(time.Second))).UTC())
+}
+
+func newExport(conv *greysealv1.Conversation) *greysealv1.ConversationExport {
+	if conv.UpdatedAt == nil && len(conv.Messages) > 0 {
+		conv.UpdatedAt = conv.Messages[len(conv.Messages)-1].CreatedAt
+	}
+	if conv.CreatedAt == nil && len(conv.Messages) > 0 {
+		conv.CreatedAt = conv.Messages[0].CreatedAt
+	}
+	return &greysealv1.ConversationExport{
+		Version:      conversation.ExportVersion,
+		Conversation: conv,
+		ExportedAt:   timestamppb.New(time.Now()),
+	}
+}
@@ -0,0 +1,138 @@
+package importer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/importer"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+// chatGPTExport has a regenerated answer: the first assistant reply (a2) was
+// replaced by a3, which is on the current branch.
+const chatGPTExport = `[{
+  "title": "Kafka lag",
+  "create_time": 1714564800.5,
+  "update_time": 1714565000,
+  "conversation_id": "6f1c7a4e-2f55-4c1e-9d61-0f4a8a9b0c11",
+  "current_node": "a3",
+  "mapping": {
+    "root": {"id": "root", "parent": null, "children": ["sys"], "message": null},
+    "sys": {"id": "sys", "parent": "root", "children": ["u1"],
+      "message": {"id": "sys", "author": {"role": "system"}, "create_time": null, "content": {"content_type": "text", "parts": [""]}}},
+    "u1": {"id": "u1", "parent": "sys", "children": ["a2", "a3"],
+      "message": {"id": "0d9b2a61-3c3e-4a0b-8f5e-6b7d1c2e3f40", "author": {"role": "user"}, "create_time": 1714564801, "content": {"content_type": "text", "parts": ["Why is my consumer lagging?"]}}},
+    "a2": {"id": "a2", "parent": "u1", "children": [],
+      "message": {"id": "a2", "author": {"role": "assistant"}, "create_time": 1714564802, "content": {"content_type": "text", "parts": ["First draft."]}}},
+    "a3": {"id": "a3", "parent": "u1", "children": [],
+      "message": {"id": "a3", "author": {"role": "assistant"}, "create_time": 1714564803, "content": {"content_type": "multimodal_text", "parts": [{"asset_pointer": "file-1"}, "Check max.poll.records."]}}}
+  }
+}]`
+
+const openWebUIExport = `[{
+  "id": "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d",
+  "title": "Postgres vacuum",
+  "created_at": 1714564800,
+  "updated_at": 1714565000,
+  "chat": {
+    "history": {
+      "currentId": "m3",
+      "messages": {
+        "m1": {"id": "m1", "parentId": null, "role": "user", "content": "When does autovacuum run?", "timestamp": 1714564801},
+        "m2": {"id": "m2", "parentId": "m1", "role": "assistant", "content": "Old answer", "timestamp": 1714564802},
+        "m3": {"id": "m3", "parentId": "m1", "role": "assistant", "content": "When dead tuples pass the threshold.", "timestamp": 1714564803, "annotation": {"rating": -1}}
+      }
+    },
+    "messages": []
+  }
+}]`
+
+type ImporterTestSuite struct {
+	suite.Suite
+}
+
+func (s *ImporterTestSuite) TestDetect() {
+	format, err := importer.Detect([]byte(chatGPTExport))
+	s.Require().NoError(err)
+	s.Equal(importer.FormatChatGPT, format)
+
+	format, err = importer.Detect([]byte(openWebUIExport))
+	s.Require().NoError(err)
+	s.Equal(importer.FormatOpenWebUI, format)
+
+	_, err = importer.Detect([]byte(`{"version": 1}`))
+	s.ErrorIs(err, importer.ErrUnknownFormat)
+}
+
+func (s *ImporterTestSuite) TestChatGPT_ActiveBranch() {
+	results, err := importer.Parse([]byte(chatGPTExport), "")
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+
+	r := results[0]
+	s.Equal("6f1c7a4e-2f55-4c1e-9d61-0f4a8a9b0c11", r.SourceID)
+	conv := r.Export.GetConversation()
+	s.Equal("6f1c7a4e-2f55-4c1e-9d61-0f4a8a9b0c11", conv.GetUuid())
+	s.Equal("Kafka lag", conv.GetTitle())
+	s.Equal(time.Unix(1714564800, 5e8).UTC(), conv.GetCreatedAt().AsTime())
+
+	msgs := conv.GetMessages()
+	s.Require().Len(msgs, 2)
+	s.Equal(v1.MessageRole_MESSAGE_ROLE_USER, msgs[0].GetRole())
+	s.Equal("0d9b2a61-3c3e-4a0b-8f5e-6b7d1c2e3f40", msgs[0].GetUuid())
+	s.Equal(v1.MessageRole_MESSAGE_ROLE_ASSISTANT, msgs[1].GetRole())
+	s.Equal("Check max.poll.records.", msgs[1].GetContent())
+	s.NotEqual("a3", msgs[1].GetUuid(), "non-UUID ids are replaced")
+	// The system message and the abandoned draft are dropped.
+	s.Equal(2, r.Dropped)
+}
+
+func (s *ImporterTestSuite) TestChatGPT_MissingCurrentNodeUsesLatest() {
+	results, err := importer.ParseChatGPT([]byte(`{
+	  "id": "c1", "title": "t", "current_node": "gone",
+	  "mapping": {
+	    "u1": {"id": "u1", "parent": null, "children": ["a1"], "message": {"id": "u1", "author": {"role": "user"}, "create_time": 1, "content": {"content_type": "text", "parts": ["q"]}}},
+	    "a1": {"id": "a1", "parent": "u1", "children": [], "message": {"id": "a1", "author": {"role": "assistant"}, "create_time": 2, "content": {"content_type": "text", "parts": ["a"]}}}
+	  }
+	}`))
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Len(results[0].Export.GetConversation().GetMessages(), 2)
+}
+
+func (s *ImporterTestSuite) TestOpenWebUI_CurrentBranchAndRatings() {
+	results, err := importer.Parse([]byte(openWebUIExport), importer.FormatOpenWebUI)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+
+	conv := results[0].Export.GetConversation()
+	s.Equal("9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d", conv.GetUuid())
+	s.Equal("Postgres vacuum", conv.GetTitle())
+	msgs := conv.GetMessages()
+	s.Require().Len(msgs, 2)
+	s.Equal("When dead tuples pass the threshold.", msgs[1].GetContent())
+	s.Equal(int32(-1), msgs[1].GetFeedback())
+	s.Equal(time.Unix(1714564803, 0).UTC(), msgs[1].GetCreatedAt().AsTime())
+	s.Equal(1, results[0].Dropped)
+}
+
+func (s *ImporterTestSuite) TestOpenWebUI_FlatMessages() {
+	results, err := importer.ParseOpenWebUI([]byte(`{
+	  "id": "legacy", "created_at": 1714564800000,
+	  "chat": {"title": "Legacy", "messages": [
+	    {"id": "m1", "role": "user", "content": "hi"},
+	    {"id": "m2", "role": "assistant", "content": "hello", "annotation": {"rating": 1}}
+	  ]}
+	}`))
+	s.Require().NoError(err)
+	conv := results[0].Export.GetConversation()
+	s.Equal("Legacy", conv.GetTitle())
+	s.Equal(time.Unix(1714564800, 0).UTC(), conv.GetCreatedAt().AsTime())
+	s.Require().Len(conv.GetMessages(), 2)
+	s.Equal(int32(1), conv.GetMessages()[1].GetFeedback())
+}
+
+func TestImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(ImporterTestSuite))
+}
@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// openWebUIChat is one entry of an Open WebUI chat export. Messages form a tree
+// in chat.history; chat.messages is a flat copy of the current branch that
+// older versions write without a history.
+type openWebUIChat struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	CreatedAt float64 `json:"created_at"`
+	UpdatedAt float64 `json:"updated_at"`
+	Chat      struct {
+		Title   string `json:"title"`
+		History struct {
+			CurrentID string                      `json:"currentId"`
+			Messages  map[string]openWebUIMessage `json:"messages"`
+		} `json:"history"`
+		Messages []openWebUIMessage `json:"messages"`
+	} `json:"chat"`
+}
+
+type openWebUIMessage struct {
+	ID         string  `json:"id"`
+	ParentID   *string `json:"parentId"`
+	Role       string  `json:"role"`
+	Content    string  `json:"content"`
+	Timestamp  float64 `json:"timestamp"`
+	Annotation *struct {
+		Rating float64 `json:"rating"`
+	} `json:"annotation"`
+}
+
+// ParseOpenWebUI reads an Open WebUI chat export, keeping only the branch that
+// was current when it was exported. Thumbs up/down ratings become feedback.
+func ParseOpenWebUI(data []byte) ([]Result, error) {
+	items, err := decodeList[openWebUIChat](data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Open WebUI export: %w", err)
+	}
+
+	results := make([]Result, 0, len(items))
+	for _, item := range items {
+		title := item.Title
+		if title == "" {
+			title = item.Chat.Title
+		}
+		conv := &greysealv1.Conversation{
+			Uuid:      stableUUID(item.ID),
+			Title:     title,
+			CreatedAt: epochTimestamp(item.CreatedAt),
+			UpdatedAt: epochTimestamp(item.UpdatedAt),
+		}
+
+		history := item.Chat.History.Messages
+		var branch []openWebUIMessage
+		if _, ok := history[item.Chat.History.CurrentID]; ok {
+			for _, id := range activeBranch(item.Chat.History.CurrentID, func(id string) (string, bool) {
+				msg, ok := history[id]
+				if !ok {
+					return "", false
+				}
+				if msg.ParentID == nil {
+					return "", true
+				}
+				return *msg.ParentID, true
+			}) {
+				branch = append(branch, history[id])
+			}
+		} else {
+			branch = item.Chat.Messages
+		}
+
+		total := len(history)
+		if total == 0 {
+			total = len(item.Chat.Messages)
+		}
+		for _, m := range branch {
+			if msg := m.toMessage(); msg != nil {
+				conv.Messages = append(conv.Messages, msg)
+			}
+		}
+
+		results = append(results, Result{
+			SourceID: item.ID,
+			Export:   newExport(conv),
+			Dropped:  total - len(conv.Messages),
+		})
+	}
+	return results, nil
+}
+
+func (m openWebUIMessage) toMessage() *greysealv1.Message {
+	var role greysealv1.MessageRole
+	switch m.Role {
+	case "user":
+		role = greysealv1.MessageRole_MESSAGE_ROLE_USER
+	case "assistant":
+		role = greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT
+	default:
+		return nil
+	}
+	if strings.TrimSpace(m.Content) == "" {
+		return nil
+	}
+	msg := &greysealv1.Message{
+		Uuid:      stableUUID(m.ID),
+		Role:      role,
+		Content:   m.Content,
+		CreatedAt: epochTimestamp(m.Timestamp),
+	}
+	if m.Annotation != nil {
+		switch {
+		case m.Annotation.Rating > 0:
+			msg.Feedback = 1
+		case m.Annotation.Rating < 0:
+			msg.Feedback = -1
+		}
+	}
+	return msg
+}
+
+// epochTimestamp converts an Open WebUI timestamp. Depending on the version and
+// field these are seconds, milliseconds or nanoseconds since the epoch.
+func epochTimestamp(v float64) *timestamppb.Timestamp {
+	switch {
+	case v > 1e17:
+		v /= 1e9
+	case v > 1e11:
+		v /= 1e3
+	}
+	return unixTimestamp(v)
+}
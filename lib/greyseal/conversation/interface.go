@@ -2,26 +2,135 @@ package conversation
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/holmes89/archaea/base"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
+var (
+	// ErrQueryRequired is returned by Search when the query text is empty.
+	ErrQueryRequired = errors.New("search query is required")
+	// ErrInvalidExport is returned by Import for exports without a
+	// conversation or from a newer format version.
+	ErrInvalidExport = errors.New("invalid conversation export")
+	// ErrConversationNotFound is returned by ConversationRepository.Get for
+	// conversations that do not exist in the caller's workspace.
+	ErrConversationNotFound = errors.New("conversation not found")
+	// ErrConversationDeleted is returned when acting on a conversation in the trash.
+	ErrConversationDeleted = errors.New("conversation is deleted")
+	// ErrRestoreWindowExpired is returned by Restore once the restore window has passed.
+	ErrRestoreWindowExpired = errors.New("conversation restore window has expired")
+	// ErrInvalidFeedback is returned by SubmitFeedback for out-of-range
+	// ratings, unknown reasons, long comments or non-assistant messages.
+	ErrInvalidFeedback = errors.New("invalid feedback")
+	// ErrInvalidReportWindow is returned by FeedbackReport when after is not before before.
+	ErrInvalidReportWindow = errors.New("feedback report window is empty")
+	// ErrTurnNotFound is returned by TranscriptWriter.ReadTurn for turns that
+	// were not recorded.
+	ErrTurnNotFound = errors.New("transcript turn not found")
+	// ErrReplayUnsupported is returned by ReplayTurn when no LLM is configured
+	// or it cannot switch to the requested model.
+	ErrReplayUnsupported = errors.New("replay is not supported by the configured LLM")
+	// ErrTraceNotFound is returned by GetMessageTrace for messages without a
+	// recorded trace.
+	ErrTraceNotFound = errors.New("turn trace not found")
+	// ErrTranscriptNotFound is returned by GetTranscript for conversations
+	// without a recorded transcript.
+	ErrTranscriptNotFound = errors.New("transcript not found")
+	// ErrGenerationStopped is the cancel cause a caller uses to stop an answer
+	// mid-generation; Chat and Regenerate then persist the partial answer.
+	ErrGenerationStopped = errors.New("generation stopped")
+	// ErrNothingToRegenerate is returned by Regenerate when the conversation
+	// does not end with a question.
+	ErrNothingToRegenerate = errors.New("no answer to regenerate")
+	// ErrInvalidUpdatePath is returned by Update for paths outside UpdatablePaths.
+	ErrInvalidUpdatePath = errors.New("field cannot be updated")
+)
+
+// UpdatablePaths are the conversation fields Update can overwrite.
+var UpdatablePaths = []string{"title", "role_uuid", "role_version", "resource_uuids", "variables"}
+
 type ConversationService interface {
-	List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Conversation], error)
+	List(ctx context.Context, lis base.ListRequest, filter ListFilter) (base.ListResponse[*greysealv1.Conversation], error)
 	Get(ctx context.Context, get base.GetRequest[*greysealv1.Conversation]) (base.GetResponse[*greysealv1.Conversation], error)
 	Create(ctx context.Context, data *greysealv1.Conversation) (*greysealv1.Conversation, error)
-	Update(ctx context.Context, id string, data *greysealv1.Conversation) (*greysealv1.Conversation, error)
+	// Update overwrites the stored conversation's fields named in paths,
+	// proto field names from UpdatablePaths, with those of data. Other
+	// fields, such as the summary, are kept.
+	Update(ctx context.Context, id string, data *greysealv1.Conversation, paths ...string) (*greysealv1.Conversation, error)
+
+	// Delete moves a conversation to the trash. Restore brings it back until
+	// the retention policy's restore window passes; Purge removes it after.
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*greysealv1.Conversation, error)
+
+	// Archive hides a conversation from the default list and exempts it from
+	// stale-conversation retention. Unarchive reverses it.
+	Archive(ctx context.Context, id string) (*greysealv1.Conversation, error)
+	Unarchive(ctx context.Context, id string) (*greysealv1.Conversation, error)
+
+	// Purge applies the retention policy: stale conversations are moved to the
+	// trash and conversations trashed longer than the restore window are
+	// permanently deleted with their transcripts and cached context.
+	Purge(ctx context.Context) (PurgeResult, error)
+
+	// Search runs a full-text search over message history and returns the
+	// matching conversations, most relevant first.
+	Search(ctx context.Context, query SearchQuery) ([]*greysealv1.ConversationSearchResult, error)
+
+	// Export returns a portable copy of a conversation with its messages, a
+	// snapshot of its role and the resources its messages cite.
+	Export(ctx context.Context, id string) (*greysealv1.ConversationExport, error)
+
+	// Import recreates an exported conversation in the caller's workspace,
+	// preserving timestamps and feedback. UUIDs that are already in use are
+	// replaced; the returned map goes from exported to new UUID.
+	Import(ctx context.Context, data *greysealv1.ConversationExport, opts ImportOptions) (*greysealv1.Conversation, map[string]string, error)
 
 	// Chat sends a user message and streams back the assistant response token by token.
 	// The stream callback is invoked once per token; returning an error aborts streaming.
 	// The fully-populated assistant Message is returned when streaming completes.
 	Chat(ctx context.Context, conversationUUID string, content string, stream func(token string) error) (*greysealv1.Message, error)
 
-	// SubmitFeedback records user feedback (-1/0/1) on an assistant message.
-	SubmitFeedback(ctx context.Context, messageUUID string, feedback int32) error
+	// Regenerate replaces the conversation's last assistant answer with a new
+	// one for the same question, streamed like Chat. Cancelling ctx with
+	// ErrGenerationStopped as the cause keeps the partial answer for either.
+	Regenerate(ctx context.Context, conversationUUID string, stream func(token string) error) (*greysealv1.Message, error)
+
+	// SubmitFeedback records a rating (-1/0/1) with optional reasons and a
+	// comment on an assistant message. Every submission is kept; the message's
+	// feedback field is set to the latest rating.
+	SubmitFeedback(ctx context.Context, feedback *greysealv1.Feedback) (*greysealv1.Feedback, error)
+
+	// ListFeedback returns feedback records, newest first. Non-admin callers
+	// only see their own.
+	ListFeedback(ctx context.Context, lis base.ListRequest, filter FeedbackFilter) (base.ListResponse[*greysealv1.Feedback], error)
+
+	// FeedbackReport aggregates each user's latest rating of each message by
+	// role, model, cited resource and time period.
+	FeedbackReport(ctx context.Context, query ReportQuery) (*greysealv1.FeedbackReport, error)
+
+	// ExportDataset calls emit with each rated assistant turn matching the
+	// query, newest first, with its prompt context when a transcript was
+	// recorded. Non-admin callers only export their own conversations.
+	ExportDataset(ctx context.Context, query DatasetQuery, emit func(*greysealv1.DatasetRecord) error) error
+
+	// ReplayTurn re-runs the recorded turn that produced an assistant message
+	// with the given overrides. The replay is not saved.
+	ReplayTurn(ctx context.Context, messageUUID string, opts ReplayOptions) (*ReplayResult, error)
+
+	// GetMessageTrace returns the trace of the turn that produced an
+	// assistant message.
+	GetMessageTrace(ctx context.Context, messageUUID string) (*greysealv1.TurnTrace, error)
+
+	// ListTranscripts returns a page of the conversations with recorded
+	// transcripts that the caller can read, and the cursor of the next page.
+	ListTranscripts(ctx context.Context, lis base.ListRequest) ([]*greysealv1.TranscriptInfo, string, error)
+	// GetTranscript returns a conversation's recorded turns and the combined
+	// Markdown transcript.
+	GetTranscript(ctx context.Context, conversationUUID string) (*greysealv1.Transcript, error)
 }
 
 type MessageRepository interface {
@@ -43,6 +152,153 @@ type ConversationRepository interface {
 	Delete(context.Context, string) error
 	Get(context.Context, string) (*greysealv1.Conversation, error)
 	List(context.Context, string, uint, map[string][]any) ([]*greysealv1.Conversation, error)
+	Search(ctx context.Context, query SearchQuery) ([]*greysealv1.ConversationSearchResult, error)
+	// Taken reports which of ids are already used by a conversation or message
+	// in any workspace.
+	Taken(ctx context.Context, ids []string) (map[string]bool, error)
+	// SetArchivedAt and SetDeletedAt set or, given nil, clear a lifecycle timestamp.
+	SetArchivedAt(ctx context.Context, id string, at *time.Time) error
+	SetDeletedAt(ctx context.Context, id string, at *time.Time) error
+	// TrashStale trashes active, unarchived conversations last updated before
+	// the given time and returns how many were trashed.
+	TrashStale(ctx context.Context, before, deletedAt time.Time) (int64, error)
+	// ListTrashed returns the UUIDs of conversations trashed before the given time.
+	ListTrashed(ctx context.Context, before time.Time) ([]string, error)
+}
+
+// FeedbackRepository stores feedback records and aggregates them for reports.
+type FeedbackRepository interface {
+	Create(ctx context.Context, feedback *greysealv1.Feedback) error
+	List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Feedback, error)
+	Report(ctx context.Context, query ReportQuery) (*greysealv1.FeedbackReport, error)
+	// ListLabelled returns rated assistant messages in active conversations
+	// with their latest feedback, newest first.
+	ListLabelled(ctx context.Context, cursor string, limit uint, query DatasetQuery) ([]*LabelledMessage, error)
+}
+
+var _ base.Entity = (*greysealv1.Feedback)(nil)
+
+// TraceRepository stores one trace per assistant message.
+type TraceRepository interface {
+	Create(ctx context.Context, trace *greysealv1.TurnTrace) error
+	// Get returns ErrTraceNotFound if the message has no trace.
+	Get(ctx context.Context, messageUUID string) (*greysealv1.TurnTrace, error)
+}
+
+// FeedbackFilter narrows ListFeedback. Zero values leave a filter unset.
+type FeedbackFilter struct {
+	MessageUUID string
+	// Owner is only honoured for admins; other callers always get their own.
+	Owner string
+}
+
+// ReportQuery selects the feedback aggregated by FeedbackReport.
+type ReportQuery struct {
+	After    time.Time
+	Before   time.Time
+	Interval greysealv1.FeedbackInterval
+	// Owner restricts the report to one principal's feedback; set by the
+	// service from the caller, never by clients.
+	Owner string
+	// ResourceLimit caps by_resource to the resources with the most negative ratings.
+	ResourceLimit uint
+}
+
+// DatasetQuery filters ExportDataset. Zero values leave a filter unset.
+type DatasetQuery struct {
+	// Rating matches the latest rating of a message.
+	Rating   *int32
+	RoleUUID string
+	After    time.Time
+	Before   time.Time
+	// ScrubPII replaces personal data and secrets in every text field.
+	ScrubPII bool
+	// Limit caps the number of records; zero exports everything.
+	Limit uint
+	// Owner restricts the export to one principal's conversations; set by the
+	// service from the caller, never by clients.
+	Owner string
+}
+
+// LabelledMessage is a rated assistant message with the user message it
+// answered and its latest feedback.
+type LabelledMessage struct {
+	Message  *greysealv1.Message
+	Query    string
+	RoleUUID string
+	Feedback *greysealv1.Feedback
+}
+
+// SnippetSource chooses the context a replayed turn is given.
+type SnippetSource int
+
+const (
+	// SnippetsOriginal reuses the snippets recorded with the turn.
+	SnippetsOriginal SnippetSource = iota
+	// SnippetsFresh searches again with the recorded query.
+	SnippetsFresh
+	// SnippetsNone answers without retrieved context.
+	SnippetsNone
+)
+
+// ReplayOptions overrides parts of a recorded turn. Zero values keep what was
+// recorded.
+type ReplayOptions struct {
+	// SystemPrompt replaces the system prompt; it takes precedence over RoleUUID.
+	SystemPrompt *string
+	// RoleUUID uses another role's system prompt.
+	RoleUUID string
+	Model    string
+	Snippets SnippetSource
+	// RetrievalLimit caps the number of snippets.
+	RetrievalLimit int32
+}
+
+// TurnRun is one execution of a turn.
+type TurnRun struct {
+	SystemPrompt string
+	Model        string
+	Snippets     []SearchResult
+	Messages     []LLMMessage
+	Response     string
+	Latency      time.Duration
+}
+
+// ReplayResult pairs a recorded turn with its replay.
+type ReplayResult struct {
+	Original TurnRun
+	Replay   TurnRun
+}
+
+// ListFilter narrows List. Zero values leave a filter unset.
+type ListFilter struct {
+	RoleUUID      string
+	ResourceUUID  string
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	// Status selects active (the default), archived or trashed conversations.
+	Status greysealv1.ConversationStatus
+}
+
+// ImportOptions controls optional Import behaviour.
+type ImportOptions struct {
+	// Summarize regenerates the conversation summary from the imported
+	// messages with the LLM. The exported summary is kept if that fails.
+	Summarize bool
+}
+
+// SearchQuery filters a full-text search over messages. Zero values leave a
+// filter unset.
+type SearchQuery struct {
+	Query    string
+	RoleUUID string
+	After    time.Time
+	Before   time.Time
+	Feedback *int32
+	// Owner restricts matches to one principal's conversations; set by the
+	// service from the caller, never by clients.
+	Owner string
+	Limit uint
 }
 
 var _ base.Entity = (*greysealv1.Conversation)(nil)
@@ -50,10 +306,13 @@ var _ base.Repository[*greysealv1.Conversation] = (ConversationRepository)(nil)
 
 // SearchResult holds a single search result from shrike.
 type SearchResult struct {
-	EntityUUID string
-	Title      string
-	Snippet    string
-	Score      float32
+	EntityUUID string  `json:"entity_uuid"`
+	Title      string  `json:"title"`
+	Snippet    string  `json:"snippet"`
+	Score      float32 `json:"score"`
+	// Flagged is set by the context sanitizer on snippets kept despite
+	// looking like a prompt injection.
+	Flagged bool `json:"flagged,omitempty"`
 }
 
 // Searcher retrieves relevant results from the search service (shrike).
@@ -62,9 +321,21 @@ type Searcher interface {
 	Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]SearchResult, error)
 }
 
-// RoleRepository fetches role data by UUID.
+// RoleRepository fetches role data by UUID. Create is used to restore role
+// snapshots on import; GetVersion loads the version a conversation is pinned to.
 type RoleRepository interface {
 	Get(ctx context.Context, id string) (*greysealv1.Role, error)
+	GetVersion(ctx context.Context, roleUUID string, version int32) (*greysealv1.RoleVersion, error)
+	Create(ctx context.Context, role *greysealv1.Role) error
+}
+
+// ResourceRepository fetches resource metadata for export citations and
+// resolves the collections and tags of a role's retrieval scope.
+type ResourceRepository interface {
+	Get(ctx context.Context, id string) (*greysealv1.Resource, error)
+	// MatchUUIDs returns the resources in any of the collections or carrying
+	// any of the tags.
+	MatchUUIDs(ctx context.Context, collections, tags []string) ([]string, error)
 }
 
 // CachedResource is a resource snippet stored in the cache for a conversation.
@@ -79,25 +350,67 @@ type CachedResource struct {
 type ResourceCache interface {
 	Merge(ctx context.Context, conversationUUID string, resources []CachedResource) error
 	List(ctx context.Context, conversationUUID string) ([]CachedResource, error)
+	// Delete drops everything cached for the conversation.
+	Delete(ctx context.Context, conversationUUID string) error
 }
 
 // TranscriptTurn captures the full context of one user→assistant exchange.
 type TranscriptTurn struct {
-	ConversationUUID    string
-	TurnIndex           int
-	Timestamp           time.Time
-	UserMessage         string
-	SystemPrompt        string
-	ConversationSummary string
-	HistoryDepth        int
-	SearchQuery         string
-	SearchResults       []SearchResult
-	AssembledMessages   []LLMMessage
-	Response            string
-	ResourceUUIDs       []string
-}
-
-// TranscriptWriter persists a TranscriptTurn for offline review.
+	ConversationUUID string `json:"conversation_uuid"`
+	// MessageUUID is the assistant message the turn produced.
+	MessageUUID         string    `json:"message_uuid"`
+	RoleUUID            string    `json:"role_uuid,omitempty"`
+	RoleVersion         int32     `json:"role_version,omitempty"`
+	Model               string    `json:"model,omitempty"`
+	TurnIndex           int       `json:"turn_index"`
+	Timestamp           time.Time `json:"timestamp"`
+	UserMessage         string    `json:"user_message"`
+	SystemPrompt        string    `json:"system_prompt,omitempty"`
+	ConversationSummary string    `json:"conversation_summary,omitempty"`
+	HistoryDepth        int       `json:"history_depth"`
+	// ExampleMessages is how many of the assembled user and assistant
+	// messages are the role's examples rather than history.
+	ExampleMessages int            `json:"example_messages,omitempty"`
+	SearchQuery     string         `json:"search_query"`
+	SearchResults   []SearchResult `json:"search_results"`
+	// ContextDecisions records what the context sanitizer did with each
+	// retrieved snippet, including those it dropped from SearchResults.
+	ContextDecisions []ContextDecision `json:"context_decisions,omitempty"`
+	// Guardrails records the guardrail hooks that modified or blocked the
+	// turn.
+	Guardrails        []GuardrailEvent `json:"guardrails,omitempty"`
+	AssembledMessages []LLMMessage     `json:"assembled_messages"`
+	Response          string           `json:"response"`
+	ResourceUUIDs     []string         `json:"resource_uuids"`
+}
+
+// TranscriptInfo describes a conversation with recorded turns.
+type TranscriptInfo struct {
+	ConversationUUID string
+	Turns            int
+	UpdatedAt        time.Time
+}
+
+// TranscriptWriter persists each TranscriptTurn as its own object for
+// offline review and reads turns back for dataset exports, replays and the
+// transcript API.
 type TranscriptWriter interface {
 	WriteTurn(ctx context.Context, turn TranscriptTurn) error
+	// ReadTurn returns the turn that produced an assistant message, or
+	// ErrTurnNotFound if it was not recorded.
+	ReadTurn(ctx context.Context, conversationUUID, messageUUID string) (*TranscriptTurn, error)
+	// ListTurns returns a conversation's turns in the order they happened.
+	ListTurns(ctx context.Context, conversationUUID string) ([]*TranscriptTurn, error)
+	// Render returns the combined Markdown transcript of a conversation, or
+	// ErrTranscriptNotFound if nothing was recorded.
+	Render(ctx context.Context, conversationUUID string) ([]byte, error)
+	// List returns conversations with recorded turns a page at a time,
+	// starting after the given cursor. The returned cursor is empty on the
+	// last page.
+	List(ctx context.Context, cursor string, limit uint) ([]TranscriptInfo, string, error)
+	// Delete removes a conversation's transcript. A missing transcript is not an error.
+	Delete(ctx context.Context, conversationUUID string) error
+	// DeleteTurn removes the turn that produced an assistant message. A
+	// missing turn is not an error.
+	DeleteTurn(ctx context.Context, conversationUUID, messageUUID string) error
 }
@@ -5,9 +5,11 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 
+	conversation "github.com/holmes89/grey-seal/lib/greyseal/conversation"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
@@ -47,6 +49,45 @@ func (_m *MockConversationRepository) List(ctx context.Context, cursor string, l
 	return ret.Get(0).([]*v1.Conversation), ret.Error(1)
 }
 
+func (_m *MockConversationRepository) Search(ctx context.Context, query conversation.SearchQuery) ([]*v1.ConversationSearchResult, error) {
+	ret := _m.Called(ctx, query)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]*v1.ConversationSearchResult), ret.Error(1)
+}
+
+func (_m *MockConversationRepository) Taken(ctx context.Context, ids []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, ids)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(map[string]bool), ret.Error(1)
+}
+
+func (_m *MockConversationRepository) SetArchivedAt(ctx context.Context, id string, at *time.Time) error {
+	ret := _m.Called(ctx, id, at)
+	return ret.Error(0)
+}
+
+func (_m *MockConversationRepository) SetDeletedAt(ctx context.Context, id string, at *time.Time) error {
+	ret := _m.Called(ctx, id, at)
+	return ret.Error(0)
+}
+
+func (_m *MockConversationRepository) TrashStale(ctx context.Context, before time.Time, deletedAt time.Time) (int64, error) {
+	ret := _m.Called(ctx, before, deletedAt)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
+func (_m *MockConversationRepository) ListTrashed(ctx context.Context, before time.Time) ([]string, error) {
+	ret := _m.Called(ctx, before)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]string), ret.Error(1)
+}
+
 func NewMockConversationRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
@@ -9,6 +9,7 @@ import (
 	"github.com/holmes89/archaea/base"
 	mock "github.com/stretchr/testify/mock"
 
+	conversation "github.com/holmes89/grey-seal/lib/greyseal/conversation"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
@@ -17,8 +18,8 @@ type MockConversationService struct {
 	mock.Mock
 }
 
-func (_m *MockConversationService) List(ctx context.Context, req base.ListRequest) (base.ListResponse[*v1.Conversation], error) {
-	ret := _m.Called(ctx, req)
+func (_m *MockConversationService) List(ctx context.Context, req base.ListRequest, filter conversation.ListFilter) (base.ListResponse[*v1.Conversation], error) {
+	ret := _m.Called(ctx, req, filter)
 	if ret.Get(0) == nil {
 		return nil, ret.Error(1)
 	}
@@ -41,8 +42,12 @@ func (_m *MockConversationService) Create(ctx context.Context, data *v1.Conversa
 	return ret.Get(0).(*v1.Conversation), ret.Error(1)
 }
 
-func (_m *MockConversationService) Update(ctx context.Context, id string, data *v1.Conversation) (*v1.Conversation, error) {
-	ret := _m.Called(ctx, id, data)
+func (_m *MockConversationService) Update(ctx context.Context, id string, data *v1.Conversation, paths ...string) (*v1.Conversation, error) {
+	_ca := []interface{}{ctx, id, data}
+	for _, p := range paths {
+		_ca = append(_ca, p)
+	}
+	ret := _m.Called(_ca...)
 	if ret.Get(0) == nil {
 		return nil, ret.Error(1)
 	}
@@ -54,6 +59,63 @@ func (_m *MockConversationService) Delete(ctx context.Context, id string) error
 	return ret.Error(0)
 }
 
+func (_m *MockConversationService) Restore(ctx context.Context, id string) (*v1.Conversation, error) {
+	ret := _m.Called(ctx, id)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Conversation), ret.Error(1)
+}
+
+func (_m *MockConversationService) Archive(ctx context.Context, id string) (*v1.Conversation, error) {
+	ret := _m.Called(ctx, id)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Conversation), ret.Error(1)
+}
+
+func (_m *MockConversationService) Unarchive(ctx context.Context, id string) (*v1.Conversation, error) {
+	ret := _m.Called(ctx, id)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Conversation), ret.Error(1)
+}
+
+func (_m *MockConversationService) Purge(ctx context.Context) (conversation.PurgeResult, error) {
+	ret := _m.Called(ctx)
+	return ret.Get(0).(conversation.PurgeResult), ret.Error(1)
+}
+
+func (_m *MockConversationService) Search(ctx context.Context, query conversation.SearchQuery) ([]*v1.ConversationSearchResult, error) {
+	ret := _m.Called(ctx, query)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]*v1.ConversationSearchResult), ret.Error(1)
+}
+
+func (_m *MockConversationService) Export(ctx context.Context, id string) (*v1.ConversationExport, error) {
+	ret := _m.Called(ctx, id)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.ConversationExport), ret.Error(1)
+}
+
+func (_m *MockConversationService) Import(ctx context.Context, data *v1.ConversationExport, opts conversation.ImportOptions) (*v1.Conversation, map[string]string, error) {
+	ret := _m.Called(ctx, data, opts)
+	if ret.Get(0) == nil {
+		return nil, nil, ret.Error(2)
+	}
+	var remapped map[string]string
+	if ret.Get(1) != nil {
+		remapped = ret.Get(1).(map[string]string)
+	}
+	return ret.Get(0).(*v1.Conversation), remapped, ret.Error(2)
+}
+
 func (_m *MockConversationService) Chat(ctx context.Context, conversationUUID string, content string, stream func(token string) error) (*v1.Message, error) {
 	ret := _m.Called(ctx, conversationUUID, content, stream)
 	if ret.Get(0) == nil {
@@ -62,11 +124,75 @@ func (_m *MockConversationService) Chat(ctx context.Context, conversationUUID st
 	return ret.Get(0).(*v1.Message), ret.Error(1)
 }
 
-func (_m *MockConversationService) SubmitFeedback(ctx context.Context, messageUUID string, feedback int32) error {
-	ret := _m.Called(ctx, messageUUID, feedback)
+func (_m *MockConversationService) Regenerate(ctx context.Context, conversationUUID string, stream func(token string) error) (*v1.Message, error) {
+	ret := _m.Called(ctx, conversationUUID, stream)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Message), ret.Error(1)
+}
+
+func (_m *MockConversationService) SubmitFeedback(ctx context.Context, feedback *v1.Feedback) (*v1.Feedback, error) {
+	ret := _m.Called(ctx, feedback)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Feedback), ret.Error(1)
+}
+
+func (_m *MockConversationService) ListFeedback(ctx context.Context, req base.ListRequest, filter conversation.FeedbackFilter) (base.ListResponse[*v1.Feedback], error) {
+	ret := _m.Called(ctx, req, filter)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(base.ListResponse[*v1.Feedback]), ret.Error(1)
+}
+
+func (_m *MockConversationService) FeedbackReport(ctx context.Context, query conversation.ReportQuery) (*v1.FeedbackReport, error) {
+	ret := _m.Called(ctx, query)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.FeedbackReport), ret.Error(1)
+}
+
+func (_m *MockConversationService) ExportDataset(ctx context.Context, query conversation.DatasetQuery, emit func(*v1.DatasetRecord) error) error {
+	ret := _m.Called(ctx, query, emit)
 	return ret.Error(0)
 }
 
+func (_m *MockConversationService) ReplayTurn(ctx context.Context, messageUUID string, opts conversation.ReplayOptions) (*conversation.ReplayResult, error) {
+	ret := _m.Called(ctx, messageUUID, opts)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*conversation.ReplayResult), ret.Error(1)
+}
+
+func (_m *MockConversationService) GetMessageTrace(ctx context.Context, messageUUID string) (*v1.TurnTrace, error) {
+	ret := _m.Called(ctx, messageUUID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.TurnTrace), ret.Error(1)
+}
+
+func (_m *MockConversationService) ListTranscripts(ctx context.Context, lis base.ListRequest) ([]*v1.TranscriptInfo, string, error) {
+	ret := _m.Called(ctx, lis)
+	if ret.Get(0) == nil {
+		return nil, ret.String(1), ret.Error(2)
+	}
+	return ret.Get(0).([]*v1.TranscriptInfo), ret.String(1), ret.Error(2)
+}
+
+func (_m *MockConversationService) GetTranscript(ctx context.Context, conversationUUID string) (*v1.Transcript, error) {
+	ret := _m.Called(ctx, conversationUUID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Transcript), ret.Error(1)
+}
+
 func NewMockConversationService(t interface {
 	mock.TestingT
 	Cleanup(func())
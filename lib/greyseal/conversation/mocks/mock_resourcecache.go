@@ -28,6 +28,11 @@ func (_m *MockResourceCache) List(ctx context.Context, conversationUUID string)
 	return ret.Get(0).([]conversation.CachedResource), ret.Error(1)
 }
 
+func (_m *MockResourceCache) Delete(ctx context.Context, conversationUUID string) error {
+	ret := _m.Called(ctx, conversationUUID)
+	return ret.Error(0)
+}
+
 func NewMockResourceCache(t interface {
 	mock.TestingT
 	Cleanup(func())
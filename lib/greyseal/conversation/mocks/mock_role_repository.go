@@ -24,6 +24,19 @@ func (_m *MockRoleRepository) Get(ctx context.Context, id string) (*v1.Role, err
 	return ret.Get(0).(*v1.Role), ret.Error(1)
 }
 
+func (_m *MockRoleRepository) GetVersion(ctx context.Context, roleUUID string, version int32) (*v1.RoleVersion, error) {
+	ret := _m.Called(ctx, roleUUID, version)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.RoleVersion), ret.Error(1)
+}
+
+func (_m *MockRoleRepository) Create(ctx context.Context, role *v1.Role) error {
+	ret := _m.Called(ctx, role)
+	return ret.Error(0)
+}
+
 func NewMockRoleRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,481 @@
+// Package openai serves grey-seal conversations over the OpenAI chat
+// completions API, for clients that only speak that protocol.
+package openai
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+)
+
+// ConversationHeader names the conversation a request continues. Responses
+// always carry it, so clients can send it back on the next turn.
+const ConversationHeader = "X-Conversation-UUID"
+
+// conversationMetadata is the metadata key that may name the conversation
+// instead of ConversationHeader.
+const conversationMetadata = "conversation_uuid"
+
+// titleLength is the length, in characters, of conversation titles taken
+// from the first user message.
+const titleLength = 60
+
+var errNoUserMessage = errors.New("the last message must be from the user")
+
+// RoleLister looks up roles in the caller's workspace. role.RoleRepository
+// satisfies it; a limit of 0 lists every role.
+type RoleLister interface {
+	List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Role, error)
+}
+
+// Handler serves POST /v1/chat/completions and GET /v1/models. A model is a
+// role, named by its name or UUID. Each completion is a turn of a grey-seal
+// conversation sent through ConversationService.Chat.
+type Handler struct {
+	conversations conversation.ConversationService
+	roles         RoleLister
+	// authenticate returns the request's context with its principal. Nil
+	// serves every request unauthenticated.
+	authenticate func(*http.Request) (context.Context, error)
+	logger       *zap.Logger
+	mux          *http.ServeMux
+}
+
+func NewHandler(conversations conversation.ConversationService, roles RoleLister, authenticate func(*http.Request) (context.Context, error), logger *zap.Logger) *Handler {
+	h := &Handler{
+		conversations: conversations,
+		roles:         roles,
+		authenticate:  authenticate,
+		logger:        logger,
+		mux:           http.NewServeMux(),
+	}
+	h.mux.HandleFunc("POST /v1/chat/completions", h.chatCompletions)
+	h.mux.HandleFunc("GET /v1/models", h.models)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.authenticate != nil {
+		ctx, err := h.authenticate(r)
+		if err != nil {
+			h.fail(w, err)
+			return
+		}
+		r = r.WithContext(ctx)
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+type chatRequest struct {
+	Model    string            `json:"model"`
+	Messages []chatMessage     `json:"messages"`
+	Stream   bool              `json:"stream"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string  `json:"role"`
+	Content content `json:"content"`
+}
+
+// content is a message's text, sent either as a string or as a list of parts
+// of which only text parts are read.
+type content string
+
+func (c *content) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*c = content(text)
+		return nil
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return fmt.Errorf("content must be a string or a list of parts: %w", err)
+	}
+	var texts []string
+	for _, p := range parts {
+		if p.Type == "text" {
+			texts = append(texts, p.Text)
+		}
+	}
+	*c = content(strings.Join(texts, "\n"))
+	return nil
+}
+
+type completion struct {
+	ID               string   `json:"id"`
+	Object           string   `json:"object"`
+	Created          int64    `json:"created"`
+	Model            string   `json:"model"`
+	Choices          []choice `json:"choices"`
+	ConversationUUID string   `json:"conversation_uuid,omitempty"`
+}
+
+type choice struct {
+	Index        int          `json:"index"`
+	Message      *chatMessage `json:"message,omitempty"`
+	Delta        *delta       `json:"delta,omitempty"`
+	FinishReason *string      `json:"finish_reason"`
+}
+
+type delta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+func (h *Handler) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	question, err := lastUserMessage(req.Messages)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := h.role(ctx, req.Model)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	conv, err := h.conversation(ctx, r, req, role)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	w.Header().Set(ConversationHeader, conv.GetUuid())
+
+	resp := completion{
+		ID:               "chatcmpl-" + uuid.New().String(),
+		Created:          time.Now().Unix(),
+		Model:            req.Model,
+		ConversationUUID: conv.GetUuid(),
+	}
+	if req.Stream {
+		h.stream(w, r, resp, conv, question)
+		return
+	}
+
+	msg, err := h.conversations.Chat(ctx, conv.GetUuid(), question, func(string) error { return nil })
+	finish := "stop"
+	var blocked *conversation.BlockedError
+	if errors.As(err, &blocked) {
+		msg, err, finish = blocked.Message, nil, "content_filter"
+	}
+	if err != nil {
+		h.fail(w, err, zap.String("conversation_uuid", conv.GetUuid()))
+		return
+	}
+	resp.Object = "chat.completion"
+	resp.Choices = []choice{{
+		Message:      &chatMessage{Role: "assistant", Content: content(msg.GetContent())},
+		FinishReason: &finish,
+	}}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// stream answers as server-sent events: one chunk per token, a final chunk
+// with the finish reason, then [DONE]. Errors before the first token are
+// plain JSON errors; later ones are sent as an error event.
+func (h *Handler) stream(w http.ResponseWriter, r *http.Request, resp completion, conv *greysealv1.Conversation, question string) {
+	flusher, _ := w.(http.Flusher)
+	resp.Object = "chat.completion.chunk"
+	started := false
+	send := func(v any) error {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+	chunk := func(d delta, finish *string) completion {
+		c := resp
+		c.Choices = []choice{{Delta: &d, FinishReason: finish}}
+		return c
+	}
+
+	first := true
+	_, err := h.conversations.Chat(r.Context(), conv.GetUuid(), question, func(token string) error {
+		d := delta{Content: token}
+		if first {
+			first = false
+			d.Role = "assistant"
+		}
+		return send(chunk(d, nil))
+	})
+	finish := "stop"
+	var blocked *conversation.BlockedError
+	if errors.As(err, &blocked) {
+		err, finish = nil, "content_filter"
+	}
+	if err != nil {
+		if !started {
+			h.fail(w, err, zap.String("conversation_uuid", conv.GetUuid()))
+			return
+		}
+		status := statusOf(err)
+		_ = send(errorBody(status, h.errorMessage(err, status, zap.String("conversation_uuid", conv.GetUuid()))))
+		return
+	}
+	if err := send(chunk(delta{}, &finish)); err != nil {
+		return
+	}
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+func (h *Handler) models(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.roles.List(r.Context(), "", 0, nil)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	data := make([]model, 0, len(roles))
+	for _, role := range roles {
+		data = append(data, model{
+			ID:      role.GetName(),
+			Object:  "model",
+			Created: role.GetCreatedAt().GetSeconds(),
+			OwnedBy: "grey-seal",
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"object": "list", "data": data})
+}
+
+// role resolves a model to a role, by UUID and then by name. An empty model
+// chats without a role.
+func (h *Handler) role(ctx context.Context, name string) (*greysealv1.Role, error) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, field := range []string{"uuid", "name"} {
+		roles, err := h.roles.List(ctx, "", 2, map[string][]any{field: {name}})
+		if err != nil {
+			return nil, err
+		}
+		switch len(roles) {
+		case 0:
+			continue
+		case 1:
+			return roles[0], nil
+		default:
+			return nil, &requestError{http.StatusBadRequest, fmt.Sprintf("model %q matches more than one role; use its uuid", name)}
+		}
+	}
+	return nil, &requestError{http.StatusNotFound, fmt.Sprintf("model %q does not exist", name)}
+}
+
+// conversation returns the conversation the request continues, switching it
+// to the requested role, or creates one. A new conversation is seeded with
+// the earlier messages of the request, since clients without a conversation
+// send the whole history every time.
+func (h *Handler) conversation(ctx context.Context, r *http.Request, req chatRequest, role *greysealv1.Role) (*greysealv1.Conversation, error) {
+	roleUUID := role.GetUuid()
+	if id := cmp.Or(r.Header.Get(ConversationHeader), req.Metadata[conversationMetadata]); id != "" {
+		got, err := h.conversations.Get(ctx, &services.GetConversationRequest{Uuid: id})
+		if errors.Is(err, auth.ErrPermissionDenied) {
+			return nil, err
+		}
+		if err != nil || got.GetData() == nil {
+			return nil, &requestError{http.StatusNotFound, fmt.Sprintf("conversation %q does not exist", id)}
+		}
+		conv := got.GetData()
+		if role == nil || conv.GetRoleUuid() == roleUUID {
+			return conv, nil
+		}
+		conv.RoleUuid = roleUUID
+		conv.RoleVersion = 0
+		return h.conversations.Update(ctx, conv.GetUuid(), conv, "role_uuid", "role_version")
+	}
+
+	conv := &greysealv1.Conversation{Title: title(req.Messages), RoleUuid: roleUUID}
+	history := earlierMessages(req.Messages, time.Now())
+	if len(history) == 0 {
+		return h.conversations.Create(ctx, conv)
+	}
+	conv.Messages = history
+	created, _, err := h.conversations.Import(ctx, &greysealv1.ConversationExport{Version: conversation.ExportVersion, Conversation: conv}, conversation.ImportOptions{})
+	return created, err
+}
+
+// lastUserMessage returns the question the request asks.
+func lastUserMessage(messages []chatMessage) (string, error) {
+	if len(messages) == 0 || messages[len(messages)-1].Role != "user" {
+		return "", errNoUserMessage
+	}
+	question := strings.TrimSpace(string(messages[len(messages)-1].Content))
+	if question == "" {
+		return "", errNoUserMessage
+	}
+	return question, nil
+}
+
+// earlierMessages converts the user and assistant messages before the last
+// one, a millisecond apart and ending before now so they keep their order.
+// System messages are left out: the role supplies the system prompt.
+func earlierMessages(messages []chatMessage, now time.Time) []*greysealv1.Message {
+	var out []*greysealv1.Message
+	earlier := messages[:len(messages)-1]
+	for i, m := range earlier {
+		var role greysealv1.MessageRole
+		switch m.Role {
+		case "user":
+			role = greysealv1.MessageRole_MESSAGE_ROLE_USER
+		case "assistant":
+			role = greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT
+		default:
+			continue
+		}
+		out = append(out, &greysealv1.Message{
+			Role:      role,
+			Content:   string(m.Content),
+			CreatedAt: timestamppb.New(now.Add(time.Duration(i-len(earlier)) * time.Millisecond)),
+		})
+	}
+	return out
+}
+
+// title is the start of the first user message.
+func title(messages []chatMessage) string {
+	for _, m := range messages {
+		if m.Role != "user" {
+			continue
+		}
+		text := strings.Join(strings.Fields(string(m.Content)), " ")
+		if utf8.RuneCountInString(text) > titleLength {
+			text = string([]rune(text)[:titleLength]) + "…"
+		}
+		return text
+	}
+	return ""
+}
+
+// requestError is a client error with its HTTP status.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+
+// statusOf maps errors onto HTTP statuses.
+func statusOf(err error) int {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.status
+	case errors.Is(err, auth.ErrPermissionDenied), connect.CodeOf(err) == connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeOf(err) == connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodeOf(err) == connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case errors.Is(err, conversation.ErrConversationDeleted):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// errorMessages are the messages sent for errors that are not requestErrors,
+// whose own text may describe the server's internals.
+var errorMessages = map[int]string{
+	http.StatusUnauthorized:       "Invalid authentication credentials.",
+	http.StatusForbidden:          "You do not have access to this resource.",
+	http.StatusConflict:           "The conversation has been deleted.",
+	http.StatusServiceUnavailable: "The server is temporarily unavailable. Please retry your request.",
+}
+
+// internalErrorMessage is sent for every other error.
+const internalErrorMessage = "The server had an error while processing your request."
+
+// fail answers with err in the OpenAI format.
+func (h *Handler) fail(w http.ResponseWriter, err error, fields ...zap.Field) {
+	status := statusOf(err)
+	writeError(w, status, h.errorMessage(err, status, fields...))
+}
+
+// errorMessage returns the message the client sees for err. Only requestErrors
+// are sent as they are; other errors are logged and replaced by a generic
+// message for their status.
+func (h *Handler) errorMessage(err error, status int, fields ...zap.Field) string {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.message
+	}
+	fields = append(fields, zap.Int("status", status), zap.Error(err))
+	if status >= http.StatusInternalServerError {
+		h.logger.Error("chat completions request failed", fields...)
+	} else {
+		h.logger.Info("chat completions request rejected", fields...)
+	}
+	if message, ok := errorMessages[status]; ok {
+		return message
+	}
+	return internalErrorMessage
+}
+
+// errorBody is an error in the OpenAI format.
+func errorBody(status int, message string) map[string]any {
+	kind := "invalid_request_error"
+	switch status {
+	case http.StatusUnauthorized:
+		kind = "authentication_error"
+	case http.StatusForbidden:
+		kind = "permission_error"
+	case http.StatusInternalServerError, http.StatusServiceUnavailable:
+		kind = "server_error"
+	}
+	return map[string]any{"error": map[string]any{"message": message, "type": kind}}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody(status, message))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,206 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/mocks"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation/openai"
+	rolemocks "github.com/holmes89/grey-seal/lib/greyseal/role/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+	svc     *mocks.MockConversationService
+	roles   *rolemocks.MockRoleRepository
+	handler *openai.Handler
+}
+
+func (s *HandlerTestSuite) SetupTest() {
+	s.svc = mocks.NewMockConversationService(s.T())
+	s.roles = rolemocks.NewMockRoleRepository(s.T())
+	s.handler = openai.NewHandler(s.svc, s.roles, nil, zap.NewNop())
+}
+
+func (s *HandlerTestSuite) do(method, path, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *HandlerTestSuite) decode(rec *httptest.ResponseRecorder) map[string]any {
+	var out map[string]any
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &out))
+	return out
+}
+
+func (s *HandlerTestSuite) roleNamed(name string, role *v1.Role) {
+	s.roles.On("List", mock.Anything, "", uint(2), map[string][]any{"uuid": {name}}).Return(nil, nil)
+	s.roles.On("List", mock.Anything, "", uint(2), map[string][]any{"name": {name}}).Return([]*v1.Role{role}, nil)
+}
+
+func (s *HandlerTestSuite) TestCompletionCreatesConversation() {
+	s.roleNamed("support", &v1.Role{Uuid: "role-1", Name: "support"})
+	s.svc.On("Create", mock.Anything, mock.MatchedBy(func(c *v1.Conversation) bool {
+		return c.GetRoleUuid() == "role-1" && c.GetTitle() == "Who runs payments?"
+	})).Return(&v1.Conversation{Uuid: "conv-1", RoleUuid: "role-1"}, nil)
+	s.svc.On("Chat", mock.Anything, "conv-1", "Who runs payments?", mock.Anything).
+		Return(&v1.Message{Uuid: "msg-1", Content: "The payments team."}, nil)
+
+	rec := s.do(http.MethodPost, "/v1/chat/completions", `{"model":"support","messages":[
+		{"role":"system","content":"You are helpful."},
+		{"role":"user","content":[{"type":"text","text":"Who runs payments?"}]}]}`)
+
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	s.Equal("conv-1", rec.Header().Get(openai.ConversationHeader))
+	out := s.decode(rec)
+	s.Equal("chat.completion", out["object"])
+	s.Equal("support", out["model"])
+	s.Equal("conv-1", out["conversation_uuid"])
+	choice := out["choices"].([]any)[0].(map[string]any)
+	s.Equal("stop", choice["finish_reason"])
+	s.Equal(map[string]any{"role": "assistant", "content": "The payments team."}, choice["message"])
+}
+
+func (s *HandlerTestSuite) TestCompletionSeedsHistory() {
+	s.roleNamed("support", &v1.Role{Uuid: "role-1", Name: "support"})
+	s.svc.On("Import", mock.Anything, mock.MatchedBy(func(e *v1.ConversationExport) bool {
+		msgs := e.GetConversation().GetMessages()
+		return e.GetConversation().GetTitle() == "Hi" && len(msgs) == 2 &&
+			msgs[0].GetRole() == v1.MessageRole_MESSAGE_ROLE_USER && msgs[1].GetContent() == "Hello!" &&
+			msgs[0].GetCreatedAt().AsTime().Before(msgs[1].GetCreatedAt().AsTime())
+	}), conversation.ImportOptions{}).Return(&v1.Conversation{Uuid: "conv-2"}, nil, nil)
+	s.svc.On("Chat", mock.Anything, "conv-2", "Who runs payments?", mock.Anything).Return(&v1.Message{Content: "Payments."}, nil)
+
+	rec := s.do(http.MethodPost, "/v1/chat/completions", `{"model":"support","messages":[
+		{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello!"},{"role":"user","content":"Who runs payments?"}]}`)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	s.Equal("conv-2", rec.Header().Get(openai.ConversationHeader))
+}
+
+func (s *HandlerTestSuite) TestCompletionContinuesConversation() {
+	s.roleNamed("coder", &v1.Role{Uuid: "role-2", Name: "coder"})
+	s.svc.On("Get", mock.Anything, &services.GetConversationRequest{Uuid: "conv-1"}).
+		Return(&base.GetGenericResponse[*v1.Conversation]{Data: &v1.Conversation{Uuid: "conv-1", RoleUuid: "role-1", RoleVersion: 3}}, nil)
+	s.svc.On("Update", mock.Anything, "conv-1", mock.MatchedBy(func(c *v1.Conversation) bool {
+		return c.GetRoleUuid() == "role-2" && c.GetRoleVersion() == 0
+	}), "role_uuid", "role_version").Return(&v1.Conversation{Uuid: "conv-1", RoleUuid: "role-2"}, nil)
+	s.svc.On("Chat", mock.Anything, "conv-1", "and now?", mock.Anything).Return(&v1.Message{Content: "ok"}, nil)
+
+	rec := s.do(http.MethodPost, "/v1/chat/completions",
+		`{"model":"coder","messages":[{"role":"user","content":"and now?"}],"metadata":{"conversation_uuid":"conv-1"}}`)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func (s *HandlerTestSuite) TestCompletionStreams() {
+	s.roles.On("List", mock.Anything, "", uint(2), map[string][]any{"uuid": {"role-1"}}).Return([]*v1.Role{{Uuid: "role-1", Name: "support"}}, nil)
+	s.svc.On("Get", mock.Anything, &services.GetConversationRequest{Uuid: "conv-1"}).
+		Return(&base.GetGenericResponse[*v1.Conversation]{Data: &v1.Conversation{Uuid: "conv-1", RoleUuid: "role-1"}}, nil)
+	s.svc.On("Chat", mock.Anything, "conv-1", "hi", mock.Anything).Run(func(args mock.Arguments) {
+		stream := args.Get(3).(func(string) error)
+		_ = stream("Hel")
+		_ = stream("lo")
+	}).Return(&v1.Message{Content: "Hello"}, nil)
+
+	rec := s.do(http.MethodPost, "/v1/chat/completions", `{"model":"role-1","stream":true,"messages":[{"role":"user","content":"hi"}]}`,
+		openai.ConversationHeader, "conv-1")
+
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	s.Equal("text/event-stream", rec.Header().Get("Content-Type"))
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	s.Require().Len(events, 4)
+	s.Equal("data: [DONE]", events[3])
+	var first, last map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(events[0], "data: ")), &first))
+	s.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(events[2], "data: ")), &last))
+	s.Equal("chat.completion.chunk", first["object"])
+	s.Equal(map[string]any{"role": "assistant", "content": "Hel"}, first["choices"].([]any)[0].(map[string]any)["delta"])
+	s.Equal("stop", last["choices"].([]any)[0].(map[string]any)["finish_reason"])
+	s.Equal(first["id"], last["id"])
+}
+
+func (s *HandlerTestSuite) TestCompletionBlocked() {
+	s.svc.On("Create", mock.Anything, mock.Anything).Return(&v1.Conversation{Uuid: "conv-1"}, nil)
+	s.svc.On("Chat", mock.Anything, "conv-1", "lockpicking?", mock.Anything).Return(nil, &conversation.BlockedError{
+		Block:   &v1.GuardrailBlock{Reason: v1.GuardrailReason_GUARDRAIL_REASON_BLOCKED_TOPIC},
+		Message: &v1.Message{Content: "Sorry, I can't help with that topic."},
+	})
+
+	rec := s.do(http.MethodPost, "/v1/chat/completions", `{"messages":[{"role":"user","content":"lockpicking?"}]}`)
+	s.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	choice := s.decode(rec)["choices"].([]any)[0].(map[string]any)
+	s.Equal("content_filter", choice["finish_reason"])
+	s.Equal("Sorry, I can't help with that topic.", choice["message"].(map[string]any)["content"])
+}
+
+func (s *HandlerTestSuite) TestCompletionErrors() {
+	rec := s.do(http.MethodPost, "/v1/chat/completions", `{"model":"support","messages":[{"role":"assistant","content":"hi"}]}`)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("invalid_request_error", s.decode(rec)["error"].(map[string]any)["type"])
+
+	s.roles.On("List", mock.Anything, "", uint(2), mock.Anything).Return(nil, nil)
+	rec = s.do(http.MethodPost, "/v1/chat/completions", `{"model":"nope","messages":[{"role":"user","content":"hi"}]}`)
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	s.svc.On("Get", mock.Anything, &services.GetConversationRequest{Uuid: "theirs"}).Return(nil, auth.ErrPermissionDenied)
+	rec = s.do(http.MethodPost, "/v1/chat/completions", `{"messages":[{"role":"user","content":"hi"}]}`, openai.ConversationHeader, "theirs")
+	s.Equal(http.StatusForbidden, rec.Code)
+
+	s.svc.On("Create", mock.Anything, mock.Anything).Return(&v1.Conversation{Uuid: "conv-1"}, nil)
+	s.svc.On("Chat", mock.Anything, "conv-1", "hi", mock.Anything).Return(nil, errors.New("LLM chat failed"))
+	rec = s.do(http.MethodPost, "/v1/chat/completions", `{"stream":true,"messages":[{"role":"user","content":"hi"}]}`)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal(map[string]any{
+		"message": "The server had an error while processing your request.",
+		"type":    "server_error",
+	}, s.decode(rec)["error"])
+}
+
+func (s *HandlerTestSuite) TestModels() {
+	s.roles.On("List", mock.Anything, "", uint(0), map[string][]any(nil)).Return([]*v1.Role{
+		{Uuid: "role-1", Name: "support", CreatedAt: timestamppb.New(time.Unix(100, 0))},
+	}, nil)
+
+	rec := s.do(http.MethodGet, "/v1/models", "")
+	s.Require().Equal(http.StatusOK, rec.Code)
+	out := s.decode(rec)
+	s.Equal("list", out["object"])
+	s.Equal([]any{map[string]any{"id": "support", "object": "model", "created": float64(100), "owned_by": "grey-seal"}}, out["data"])
+}
+
+func (s *HandlerTestSuite) TestAuthentication() {
+	h := openai.NewHandler(s.svc, s.roles, func(r *http.Request) (context.Context, error) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, auth.ErrUnauthenticated)
+	}, zap.NewNop())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.Equal(map[string]any{
+		"message": "Invalid authentication credentials.",
+		"type":    "authentication_error",
+	}, s.decode(rec)["error"])
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
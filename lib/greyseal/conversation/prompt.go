@@ -0,0 +1,232 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+// systemPrompt renders a role's system prompt template for the conversation.
+// An empty prompt, or one that fails to render, gives DefaultSystemPrompt.
+func (srv *conversationService) systemPrompt(ctx context.Context, conv *greysealv1.Conversation, text string) string {
+	if text == "" {
+		return DefaultSystemPrompt
+	}
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	rendered, err := prompt.Render(text, srv.promptData(ctx, conv))
+	if err != nil {
+		srv.logger.Warn("failed to render system prompt, using the default",
+			zap.String("conversation_uuid", conv.GetUuid()),
+			zap.String("role_uuid", conv.GetRoleUuid()),
+			zap.Error(err),
+		)
+		return DefaultSystemPrompt
+	}
+	return rendered
+}
+
+// roleInstructions is what a role version contributes to a turn's prompt.
+type roleInstructions struct {
+	SystemPrompt    string
+	Version         int32
+	Examples        []*greysealv1.RoleExample
+	ContextTemplate string
+	NoContext       string
+	Retrieval       *greysealv1.RoleRetrieval
+	Guardrails      *greysealv1.RoleGuardrails
+}
+
+func instructionsFromRole(r *greysealv1.Role) roleInstructions {
+	return roleInstructions{
+		SystemPrompt:    r.GetSystemPrompt(),
+		Version:         r.GetVersion(),
+		Examples:        r.GetExamples(),
+		ContextTemplate: r.GetContextTemplate(),
+		NoContext:       r.GetNoContextInstruction(),
+		Retrieval:       r.GetRetrieval(),
+		Guardrails:      r.GetGuardrails(),
+	}
+}
+
+func instructionsFromVersion(v *greysealv1.RoleVersion) roleInstructions {
+	return roleInstructions{
+		SystemPrompt:    v.GetSystemPrompt(),
+		Version:         v.GetVersion(),
+		Examples:        v.GetExamples(),
+		ContextTemplate: v.GetContextTemplate(),
+		NoContext:       v.GetNoContextInstruction(),
+		Retrieval:       v.GetRetrieval(),
+		Guardrails:      v.GetGuardrails(),
+	}
+}
+
+// roleInstructions loads the conversation's role: the pinned version when
+// RoleVersion is set, otherwise the latest. A pinned version that cannot be
+// loaded falls back to the latest. It returns empty instructions when the
+// conversation has no role or the role cannot be loaded.
+func (srv *conversationService) roleInstructions(ctx context.Context, conv *greysealv1.Conversation) roleInstructions {
+	if conv.GetRoleUuid() == "" || srv.roleRepo == nil {
+		return roleInstructions{}
+	}
+	if pinned := conv.GetRoleVersion(); pinned > 0 {
+		version, err := srv.roleRepo.GetVersion(ctx, conv.GetRoleUuid(), pinned)
+		if err == nil {
+			return instructionsFromVersion(version)
+		}
+		srv.logger.Warn("failed to load pinned role version, using the latest",
+			zap.String("conversation_uuid", conv.GetUuid()),
+			zap.String("role_uuid", conv.GetRoleUuid()),
+			zap.Int32("role_version", pinned),
+			zap.Error(err),
+		)
+	}
+	role, err := srv.roleRepo.Get(ctx, conv.GetRoleUuid())
+	if err != nil {
+		return roleInstructions{}
+	}
+	return instructionsFromRole(role)
+}
+
+// contextFormatter returns how snippets are written into the prompt: with
+// the role's context template, or as the built-in list when it has none or
+// the template fails to render. With a sanitizer, a template's .Text is the
+// snippet already wrapped in its <untrusted_source> block. Without snippets it gives the role's rendered
+// no-context instruction, which may be empty.
+func (srv *conversationService) contextFormatter(ctx context.Context, conv *greysealv1.Conversation, ins roleInstructions) func([]SearchResult) string {
+	var data *prompt.Data
+	render := func(text string, snippets []SearchResult) (string, error) {
+		if !strings.Contains(text, "{{") {
+			return text, nil
+		}
+		if data == nil {
+			d := srv.promptData(ctx, conv)
+			data = &d
+		}
+		d := *data
+		d.Snippets = promptSnippets(snippets)
+		return prompt.Render(text, d)
+	}
+	warn := func(msg string, err error) {
+		srv.logger.Warn(msg,
+			zap.String("conversation_uuid", conv.GetUuid()),
+			zap.String("role_uuid", conv.GetRoleUuid()),
+			zap.Error(err),
+		)
+	}
+	return func(snippets []SearchResult) string {
+		if len(snippets) == 0 {
+			if ins.NoContext == "" {
+				return ""
+			}
+			text, err := render(ins.NoContext, nil)
+			if err != nil {
+				warn("failed to render no-context instruction, leaving it out", err)
+				return ""
+			}
+			return text
+		}
+		if ins.ContextTemplate != "" {
+			templated, preamble := snippets, ""
+			if srv.sanitizer != nil {
+				// The template lays out the blocks but cannot strip their delimiters.
+				templated, preamble = delimited(snippets), untrustedPreamble(snippets)+"\n"
+			}
+			text, err := render(ins.ContextTemplate, templated)
+			if err == nil {
+				return preamble + text
+			}
+			warn("failed to render context template, using the default", err)
+		}
+		if srv.sanitizer != nil {
+			return untrustedContext(snippets)
+		}
+		return defaultContext(snippets)
+	}
+}
+
+// defaultContext is the built-in snippet list.
+func defaultContext(snippets []SearchResult) string {
+	parts := make([]string, 0, len(snippets))
+	for i, r := range snippets {
+		parts = append(parts, fmt.Sprintf("%d. [%s]: %s", i+1, r.Title, r.Snippet))
+	}
+	return "Here is relevant context:\n" + strings.Join(parts, "\n")
+}
+
+func promptSnippets(results []SearchResult) []prompt.Snippet {
+	snippets := make([]prompt.Snippet, 0, len(results))
+	for i, r := range results {
+		snippets = append(snippets, prompt.Snippet{
+			Index:        i + 1,
+			Title:        r.Title,
+			Text:         r.Snippet,
+			ResourceUUID: r.EntityUUID,
+			Score:        r.Score,
+			Flagged:      r.Flagged,
+		})
+	}
+	return snippets
+}
+
+// promptData collects what a prompt template can refer to. The time zone is
+// the caller's zoneinfo profile field if it names a known zone, otherwise the
+// server's. Resources that cannot be loaded are left out.
+func (srv *conversationService) promptData(ctx context.Context, conv *greysealv1.Conversation) prompt.Data {
+	user := map[string]string{}
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		for k, v := range p.Profile {
+			user[k] = v
+		}
+		user["subject"] = p.Subject
+	}
+	loc := time.Local
+	if zone := user["zoneinfo"]; zone != "" {
+		if l, err := time.LoadLocation(zone); err == nil {
+			loc = l
+		}
+	}
+	now := time.Now().In(loc)
+	zone := loc.String()
+	if loc == time.Local {
+		zone, _ = now.Zone()
+	}
+
+	var resources []prompt.Resource
+	if srv.resources != nil {
+		for _, id := range conv.GetResourceUuids() {
+			res, err := srv.resources.Get(ctx, id)
+			if err != nil {
+				continue
+			}
+			resources = append(resources, prompt.Resource{
+				UUID:   res.GetUuid(),
+				Name:   res.GetName(),
+				Source: prompt.SourceName(res.GetSource().String()),
+				Path:   res.GetPath(),
+			})
+		}
+	}
+
+	vars := conv.GetVariables()
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	return prompt.Data{
+		Now:              now,
+		Date:             now.Format(time.DateOnly),
+		TimeZone:         zone,
+		ConversationUUID: conv.GetUuid(),
+		Title:            conv.GetTitle(),
+		Resources:        resources,
+		User:             user,
+		Vars:             vars,
+	}
+}
@@ -0,0 +1,64 @@
+package conversation
+
+import (
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// redactPrompt applies the prompt policy to every message but the leading
+// role system prompt, which is operator-authored. The input is not modified.
+func (srv *conversationService) redactPrompt(messages []LLMMessage, vault *redact.Vault) []LLMMessage {
+	if srv.redaction.Mode(redact.Prompt) == redact.ModeOff {
+		return messages
+	}
+	out := make([]LLMMessage, len(messages))
+	for i, m := range messages {
+		if i > 0 {
+			m.Content = srv.redaction.Redact(redact.Prompt, m.Content, vault)
+		}
+		out[i] = m
+	}
+	return out
+}
+
+// redactTurn applies the transcript policy to a transcript turn. The
+// assembled messages are what the model was sent, so prompt tokens stay.
+func (srv *conversationService) redactTurn(turn TranscriptTurn) TranscriptTurn {
+	if srv.redaction.Mode(redact.Transcripts) == redact.ModeOff {
+		return turn
+	}
+	scrub := func(s string) string { return srv.redaction.Redact(redact.Transcripts, s, nil) }
+	turn.UserMessage = scrub(turn.UserMessage)
+	turn.ConversationSummary = scrub(turn.ConversationSummary)
+	turn.SearchQuery = scrub(turn.SearchQuery)
+	turn.Response = scrub(turn.Response)
+	results := make([]SearchResult, len(turn.SearchResults))
+	for i, r := range turn.SearchResults {
+		r.Snippet = scrub(r.Snippet)
+		results[i] = r
+	}
+	turn.SearchResults = results
+	messages := make([]LLMMessage, len(turn.AssembledMessages))
+	for i, m := range turn.AssembledMessages {
+		m.Content = scrub(m.Content)
+		messages[i] = m
+	}
+	turn.AssembledMessages = messages
+	return turn
+}
+
+// redactTrace applies the transcript policy to a turn trace in place.
+func (srv *conversationService) redactTrace(trace *greysealv1.TurnTrace) {
+	if srv.redaction.Mode(redact.Transcripts) == redact.ModeOff {
+		return
+	}
+	scrub := func(s string) string { return srv.redaction.Redact(redact.Transcripts, s, nil) }
+	trace.SearchQuery = scrub(trace.SearchQuery)
+	trace.Summary = scrub(trace.Summary)
+	for _, r := range trace.Results {
+		r.Snippet = scrub(r.Snippet)
+	}
+	for _, m := range trace.Messages {
+		m.Content = scrub(m.Content)
+	}
+}
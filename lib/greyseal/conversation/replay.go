@@ -0,0 +1,146 @@
+package conversation
+
+import (
+	"context"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+func (srv *conversationService) ReplayTurn(ctx context.Context, messageUUID string, opts ReplayOptions) (*ReplayResult, error) {
+	msg, err := srv.messageRepo.Get(ctx, messageUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, msg.GetOwner()); err != nil {
+		return nil, err
+	}
+	if srv.transcriptWriter == nil {
+		return nil, ErrTurnNotFound
+	}
+	turn, err := srv.transcriptWriter.ReadTurn(ctx, msg.GetConversationUuid(), msg.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	llm := srv.llm
+	if llm == nil {
+		return nil, ErrReplayUnsupported
+	}
+	if opts.Model != "" && opts.Model != turn.Model {
+		selector, ok := llm.(ModelSelector)
+		if !ok {
+			return nil, ErrReplayUnsupported
+		}
+		llm = selector.WithModel(opts.Model)
+	}
+
+	// Overridden prompts and the role's instructions are templates rendered
+	// for the conversation, and a fresh search uses its resource scope.
+	var conv *greysealv1.Conversation
+	if opts.RoleUUID != "" || opts.SystemPrompt != nil || opts.Snippets == SnippetsFresh || turn.RoleVersion > 0 {
+		conv, err = srv.conversationRepo.Get(ctx, msg.GetConversationUuid())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Examples and context formatting come from the override role, or else
+	// from the role version the turn was recorded with.
+	systemPrompt := turn.SystemPrompt
+	var ins roleInstructions
+	switch {
+	case opts.RoleUUID != "" && srv.roleRepo != nil:
+		role, err := srv.roleRepo.Get(ctx, opts.RoleUUID)
+		if err != nil {
+			return nil, err
+		}
+		ins = instructionsFromRole(role)
+		systemPrompt = srv.systemPrompt(ctx, conv, role.GetSystemPrompt())
+	case turn.RoleVersion > 0 && srv.roleRepo != nil:
+		version, err := srv.roleRepo.GetVersion(ctx, turn.RoleUUID, turn.RoleVersion)
+		if err != nil {
+			srv.logger.Warn("failed to load the turn's role version, replaying without its instructions",
+				zap.String("message_uuid", messageUUID),
+				zap.Error(err),
+			)
+			break
+		}
+		ins = instructionsFromVersion(version)
+	}
+	if opts.SystemPrompt != nil {
+		systemPrompt = srv.systemPrompt(ctx, conv, *opts.SystemPrompt)
+	}
+
+	var snippets []SearchResult
+	switch opts.Snippets {
+	case SnippetsOriginal:
+		snippets = turn.SearchResults
+	case SnippetsFresh:
+		scope := srv.retrievalFor(ctx, conv, ins.Retrieval)
+		if opts.RetrievalLimit > 0 {
+			scope.Limit = opts.RetrievalLimit
+		}
+		query := turn.SearchQuery
+		if query == "" {
+			query = turn.UserMessage
+		}
+		snippets, _ = srv.sanitizeContext(conv, srv.retrieve(ctx, conv, query, scope))
+	}
+	if opts.RetrievalLimit > 0 && len(snippets) > int(opts.RetrievalLimit) {
+		snippets = snippets[:opts.RetrievalLimit]
+	}
+
+	messages, snippets, _ := srv.buildPrompt(ctx, conv, ins, promptParts{
+		System:   systemPrompt,
+		Summary:  turn.ConversationSummary,
+		History:  turnHistory(turn),
+		Question: turn.UserMessage,
+	}, snippets)
+	start := time.Now()
+	response, err := llm.Chat(ctx, messages, func(string) error { return nil })
+	if err != nil {
+		srv.logger.Error("replay failed", zap.String("message_uuid", messageUUID), zap.Error(err))
+		return nil, err
+	}
+
+	replay := TurnRun{
+		SystemPrompt: systemPrompt,
+		Model:        opts.Model,
+		Snippets:     snippets,
+		Messages:     messages,
+		Response:     response,
+		Latency:      time.Since(start),
+	}
+	if namer, ok := llm.(ModelNamer); ok {
+		replay.Model = namer.ModelName()
+	}
+	return &ReplayResult{
+		Original: TurnRun{
+			SystemPrompt: turn.SystemPrompt,
+			Model:        turn.Model,
+			Snippets:     turn.SearchResults,
+			Messages:     turn.AssembledMessages,
+			Response:     turn.Response,
+		},
+		Replay: replay,
+	}, nil
+}
+
+// turnHistory recovers the history a turn was sent with: the user and
+// assistant messages of its prompt after the role's examples and before the
+// final question.
+func turnHistory(turn *TranscriptTurn) []LLMMessage {
+	var history []LLMMessage
+	for _, m := range turn.AssembledMessages {
+		if m.Role != "system" {
+			history = append(history, m)
+		}
+	}
+	if len(history) > 0 {
+		history = history[min(turn.ExampleMessages, len(history)-1) : len(history)-1]
+	}
+	return history
+}
@@ -0,0 +1,63 @@
+package conversation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultRestoreWindow is how long a deleted conversation can be restored when
+// the retention policy does not set one.
+const DefaultRestoreWindow = 30 * 24 * time.Hour
+
+// RetentionPolicy controls how long deleted and inactive conversations are kept.
+type RetentionPolicy struct {
+	// RestoreWindow is how long a conversation stays in the trash before it
+	// is purged. Zero means DefaultRestoreWindow.
+	RestoreWindow time.Duration
+	// StaleAfter moves active, unarchived conversations that have not been
+	// updated for this long to the trash. Zero disables it.
+	StaleAfter time.Duration
+}
+
+func (p RetentionPolicy) restoreWindow() time.Duration {
+	if p.RestoreWindow <= 0 {
+		return DefaultRestoreWindow
+	}
+	return p.RestoreWindow
+}
+
+// PurgeResult summarises one Purge run.
+type PurgeResult struct {
+	// Trashed is the number of stale conversations moved to the trash.
+	Trashed int
+	// Purged is the number of conversations permanently deleted.
+	Purged int
+	// Failed is the number of conversations whose cleanup failed; they are
+	// retried on the next run.
+	Failed int
+}
+
+// RunRetention calls Purge every interval until ctx is cancelled.
+func RunRetention(ctx context.Context, svc ConversationService, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := svc.Purge(ctx)
+		if err != nil {
+			logger.Error("conversation retention run failed", zap.Error(err))
+		} else if result != (PurgeResult{}) {
+			logger.Info("conversation retention run completed",
+				zap.Int("trashed", result.Trashed),
+				zap.Int("purged", result.Purged),
+				zap.Int("failed", result.Failed),
+			)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
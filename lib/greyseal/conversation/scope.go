@@ -0,0 +1,103 @@
+package conversation
+
+import (
+	"context"
+	"slices"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+// retrieval is how a turn searches for context.
+type retrieval struct {
+	// ResourceUUIDs restricts the search; empty searches every resource
+	// unless None is set.
+	ResourceUUIDs []string
+	// None is set when a role's scope leaves no resources to search.
+	None     bool
+	Limit    int32
+	MinScore float32
+}
+
+// retrievalFor combines the conversation's resource_uuids with its role's
+// scope and retrieval defaults. Without a role scope the conversation's
+// resources are searched as before. With one, a conversation without
+// resources searches the role's scope; otherwise the scope policy either
+// keeps only the conversation's resources that are in the role's scope
+// (narrow, the default) or adds them to it (widen).
+func (srv *conversationService) retrievalFor(ctx context.Context, conv *greysealv1.Conversation, r *greysealv1.RoleRetrieval) retrieval {
+	out := retrieval{Limit: DefaultRetrievalLimit, MinScore: r.GetMinScore()}
+	if r.GetLimit() > 0 {
+		out.Limit = r.GetLimit()
+	}
+	scope, scoped := srv.roleScope(ctx, conv, r)
+	switch {
+	case !scoped:
+		out.ResourceUUIDs = conv.GetResourceUuids()
+	case len(conv.GetResourceUuids()) == 0:
+		out.ResourceUUIDs = scope
+	case r.GetScopePolicy() == greysealv1.ScopePolicy_SCOPE_POLICY_WIDEN:
+		out.ResourceUUIDs = scope
+		for _, id := range conv.GetResourceUuids() {
+			if !slices.Contains(out.ResourceUUIDs, id) {
+				out.ResourceUUIDs = append(out.ResourceUUIDs, id)
+			}
+		}
+	default:
+		for _, id := range conv.GetResourceUuids() {
+			if slices.Contains(scope, id) {
+				out.ResourceUUIDs = append(out.ResourceUUIDs, id)
+			}
+		}
+	}
+	out.None = scoped && len(out.ResourceUUIDs) == 0
+	return out
+}
+
+// roleScope resolves the role's resource UUIDs, collections and tags to
+// resource UUIDs. scoped is false when the role does not restrict retrieval.
+// Collections and tags that cannot be resolved match nothing, so a failure
+// never widens the scope.
+func (srv *conversationService) roleScope(ctx context.Context, conv *greysealv1.Conversation, r *greysealv1.RoleRetrieval) ([]string, bool) {
+	if len(r.GetResourceUuids()) == 0 && len(r.GetCollections()) == 0 && len(r.GetTags()) == 0 {
+		return nil, false
+	}
+	scope := slices.Clone(r.GetResourceUuids())
+	if len(r.GetCollections()) > 0 || len(r.GetTags()) > 0 {
+		var matched []string
+		var err error
+		if srv.resources != nil {
+			matched, err = srv.resources.MatchUUIDs(ctx, r.GetCollections(), r.GetTags())
+		}
+		if err != nil {
+			srv.logger.Warn("failed to resolve role scope",
+				zap.String("conversation_uuid", conv.GetUuid()),
+				zap.String("role_uuid", conv.GetRoleUuid()),
+				zap.Error(err),
+			)
+		}
+		for _, id := range matched {
+			if !slices.Contains(scope, id) {
+				scope = append(scope, id)
+			}
+		}
+	}
+	return scope, true
+}
+
+// retrieve searches within the turn's scope and drops snippets scoring below
+// its minimum.
+func (srv *conversationService) retrieve(ctx context.Context, conv *greysealv1.Conversation, query string, r retrieval) []SearchResult {
+	if r.None {
+		srv.logger.Info("role scope matches no resources, skipping retrieval",
+			zap.String("conversation_uuid", conv.GetUuid()),
+			zap.String("role_uuid", conv.GetRoleUuid()),
+		)
+		return nil
+	}
+	results := srv.contextSearch(ctx, conv.GetUuid(), query, r.ResourceUUIDs, r.Limit)
+	if r.MinScore > 0 {
+		results = slices.DeleteFunc(results, func(res SearchResult) bool { return res.Score < r.MinScore })
+	}
+	return results
+}
@@ -2,14 +2,19 @@ package conversation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -21,56 +26,162 @@ type LLM interface {
 	Chat(ctx context.Context, messages []LLMMessage, stream func(token string) error) (string, error)
 }
 
+const (
+	// DefaultSystemPrompt establishes the assistant persona for conversations
+	// without a role prompt.
+	DefaultSystemPrompt = "You are a helpful research assistant. When you use information from the provided context, " +
+		"reference it clearly so the user knows which sources informed your answer. " +
+		"Be concise, accurate, and cite sources when relevant."
+	// DefaultRetrievalLimit is how many snippets a chat turn retrieves.
+	DefaultRetrievalLimit = 5
+)
+
+// ModelNamer is implemented by LLMs that can report the model they run. The
+// name is recorded on assistant messages so feedback can be reported by model.
+type ModelNamer interface {
+	ModelName() string
+}
+
+// ModelSelector is implemented by LLMs that can answer with a different model
+// for a single call. ReplayTurn uses it to compare models.
+type ModelSelector interface {
+	WithModel(name string) LLM
+}
+
+// OptionReporter is implemented by LLMs that can report the generation
+// options they send with each request. The options are recorded on turn traces.
+type OptionReporter interface {
+	ChatOptions() map[string]string
+}
+
 // LLMMessage is a single message in the LLM chat format.
 type LLMMessage struct {
-	Role    string // "system", "user", "assistant"
-	Content string
+	Role    string `json:"role"` // "system", "user", "assistant"
+	Content string `json:"content"`
 }
 
 type conversationService struct {
-	conversationRepo base.Repository[*greysealv1.Conversation]
+	conversationRepo ConversationRepository
 	messageRepo      MessageRepository
-	searcher         Searcher         // optional
-	roleRepo         RoleRepository   // optional
-	llm              LLM              // optional
-	cache            ResourceCache    // optional; disables per-conversation snippet caching when nil
-	transcriptWriter TranscriptWriter // optional; nil = no transcript
+	searcher         Searcher           // optional
+	roleRepo         RoleRepository     // optional
+	llm              LLM                // optional
+	cache            ResourceCache      // optional; disables per-conversation snippet caching when nil
+	transcriptWriter TranscriptWriter   // optional; nil = no transcript
+	resources        ResourceRepository // optional; exports carry no citations when nil
+	retention        RetentionPolicy
+	feedback         FeedbackRepository
+	traces           TraceRepository   // optional; nil = no turn traces
+	redaction        *redact.Pipeline  // optional; nil = no redaction
+	promptBudget     int               // estimated tokens; 0 = unlimited
+	sanitizer        *ContextSanitizer // optional; nil = snippets are used as retrieved
+	guardrails       []Guardrail       // run on every turn, before the role's
 	logger           *zap.Logger
 }
 
+// Option configures an optional dependency of the conversation service.
+type Option func(*conversationService)
+
+// WithTranscriptWriter records every turn with w.
+func WithTranscriptWriter(w TranscriptWriter) Option {
+	return func(srv *conversationService) { srv.transcriptWriter = w }
+}
+
+// WithResources resolves citations and resource scopes against resources.
+func WithResources(resources ResourceRepository) Option {
+	return func(srv *conversationService) { srv.resources = resources }
+}
+
+// WithRetention sets how long deleted and stale conversations are kept.
+func WithRetention(retention RetentionPolicy) Option {
+	return func(srv *conversationService) { srv.retention = retention }
+}
+
+// WithTraces stores a trace of every turn.
+func WithTraces(traces TraceRepository) Option {
+	return func(srv *conversationService) { srv.traces = traces }
+}
+
+// WithRedaction redacts messages before they are stored or sent to the LLM.
+func WithRedaction(redaction *redact.Pipeline) Option {
+	return func(srv *conversationService) { srv.redaction = redaction }
+}
+
+// WithPromptBudget caps the estimated prompt size in tokens.
+func WithPromptBudget(tokens int) Option {
+	return func(srv *conversationService) { srv.promptBudget = tokens }
+}
+
+// WithSanitizer cleans retrieved snippets before they enter the prompt.
+func WithSanitizer(sanitizer *ContextSanitizer) Option {
+	return func(srv *conversationService) { srv.sanitizer = sanitizer }
+}
+
+// WithGuardrails runs guardrails on every turn, before the role's.
+func WithGuardrails(guardrails ...Guardrail) Option {
+	return func(srv *conversationService) { srv.guardrails = append(srv.guardrails, guardrails...) }
+}
+
 func NewConversationService(
-	conversationRepo base.Repository[*greysealv1.Conversation],
+	conversationRepo ConversationRepository,
 	messageRepo MessageRepository,
 	searcher Searcher,
 	roleRepo RoleRepository,
 	llm LLM,
 	cache ResourceCache,
 	logger *zap.Logger,
-	transcriptWriter TranscriptWriter,
+	feedback FeedbackRepository,
+	opts ...Option,
 ) ConversationService {
-	return &conversationService{
+	srv := &conversationService{
 		conversationRepo: conversationRepo,
 		messageRepo:      messageRepo,
 		searcher:         searcher,
 		roleRepo:         roleRepo,
 		llm:              llm,
 		cache:            cache,
-		transcriptWriter: transcriptWriter,
+		feedback:         feedback,
 		logger:           logger,
 	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
 }
 
-func (srv *conversationService) List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Conversation], error) {
+func (srv *conversationService) List(ctx context.Context, lis base.ListRequest, filter ListFilter) (base.ListResponse[*greysealv1.Conversation], error) {
 	srv.logger.Info("listing conversations")
-	data, err := srv.conversationRepo.List(ctx, lis.GetCursor(), uint(lis.GetCount()), nil)
+	f := map[string][]any{"status": {filter.Status}}
+	if owner := auth.OwnerScope(ctx); owner != "" {
+		f["owner"] = []any{owner}
+	}
+	if filter.RoleUUID != "" {
+		f["role_uuid"] = []any{filter.RoleUUID}
+	}
+	if filter.ResourceUUID != "" {
+		f["resource_uuid"] = []any{filter.ResourceUUID}
+	}
+	if !filter.UpdatedAfter.IsZero() {
+		f["updated_after"] = []any{filter.UpdatedAfter}
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		f["updated_before"] = []any{filter.UpdatedBefore}
+	}
+
+	limit := pagination.Limit(lis.GetCount())
+	data, err := srv.conversationRepo.List(ctx, lis.GetCursor(), limit+1, f)
 	if err != nil {
 		srv.logger.Error("failed to list conversations", zap.Error(err))
+		return nil, err
 	}
+	data, cursor := pagination.Page(data, limit, func(c *greysealv1.Conversation) (time.Time, string) {
+		return c.GetUpdatedAt().AsTime(), c.GetUuid()
+	})
 	return &base.ListGenericResponse[*greysealv1.Conversation]{
-		Cursor: "",
+		Cursor: cursor,
 		Count:  int32(len(data)),
 		Data:   data,
-	}, err
+	}, nil
 }
 
 func (srv *conversationService) Get(ctx context.Context, get base.GetRequest[*greysealv1.Conversation]) (base.GetResponse[*greysealv1.Conversation], error) {
@@ -78,6 +189,10 @@ func (srv *conversationService) Get(ctx context.Context, get base.GetRequest[*gr
 	data, err := srv.conversationRepo.Get(ctx, get.GetUuid())
 	if err != nil {
 		srv.logger.Error("failed to get conversation", zap.String("uuid", get.GetUuid()), zap.Error(err))
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, data.GetOwner()); err != nil {
+		return nil, err
 	}
 	return &base.GetGenericResponse[*greysealv1.Conversation]{Data: data}, err
 }
@@ -89,6 +204,9 @@ func (srv *conversationService) Create(ctx context.Context, data *greysealv1.Con
 	now := timestamppb.New(time.Now())
 	data.CreatedAt = now
 	data.UpdatedAt = now
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		data.Owner = p.Subject
+	}
 
 	srv.logger.Info("creating conversation", zap.String("title", data.GetTitle()))
 	err := srv.conversationRepo.Create(ctx, data)
@@ -100,75 +218,499 @@ func (srv *conversationService) Create(ctx context.Context, data *greysealv1.Con
 	return data, nil
 }
 
-func (srv *conversationService) Update(ctx context.Context, id string, data *greysealv1.Conversation) (*greysealv1.Conversation, error) {
-	srv.logger.Info("updating conversation", zap.String("uuid", id))
-	data.UpdatedAt = timestamppb.New(time.Now())
-	err := srv.conversationRepo.Update(ctx, id, data)
+func (srv *conversationService) Update(ctx context.Context, id string, data *greysealv1.Conversation, paths ...string) (*greysealv1.Conversation, error) {
+	srv.logger.Info("updating conversation", zap.String("uuid", id), zap.Strings("paths", paths))
+	conv, err := srv.getAuthorized(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		switch path {
+		case "title":
+			conv.Title = data.GetTitle()
+		case "role_uuid":
+			conv.RoleUuid = data.GetRoleUuid()
+		case "role_version":
+			conv.RoleVersion = data.GetRoleVersion()
+		case "resource_uuids":
+			conv.ResourceUuids = data.GetResourceUuids()
+		case "variables":
+			conv.Variables = data.GetVariables()
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidUpdatePath, path)
+		}
+	}
+	conv.UpdatedAt = timestamppb.New(time.Now())
+	if err := srv.conversationRepo.Update(ctx, id, conv); err != nil {
 		srv.logger.Error("failed to update conversation", zap.String("uuid", id), zap.Error(err))
 		return nil, err
 	}
-	return data, nil
+	return conv, nil
 }
 
 func (srv *conversationService) Delete(ctx context.Context, id string) error {
 	srv.logger.Info("deleting conversation", zap.String("uuid", id))
-	err := srv.conversationRepo.Delete(ctx, id)
+	conv, err := srv.getAuthorized(ctx, id)
 	if err != nil {
+		return err
+	}
+	if conv.DeletedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	if err := srv.conversationRepo.SetDeletedAt(ctx, id, &now); err != nil {
 		srv.logger.Error("failed to delete conversation", zap.String("uuid", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (srv *conversationService) Restore(ctx context.Context, id string) (*greysealv1.Conversation, error) {
+	srv.logger.Info("restoring conversation", zap.String("uuid", id))
+	conv, err := srv.getAuthorized(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conv.DeletedAt == nil {
+		return conv, nil
+	}
+	if time.Since(conv.DeletedAt.AsTime()) > srv.retention.restoreWindow() {
+		return nil, ErrRestoreWindowExpired
+	}
+	if err := srv.conversationRepo.SetDeletedAt(ctx, id, nil); err != nil {
+		srv.logger.Error("failed to restore conversation", zap.String("uuid", id), zap.Error(err))
+		return nil, err
 	}
-	return err
+	conv.DeletedAt = nil
+	return conv, nil
+}
+
+func (srv *conversationService) Archive(ctx context.Context, id string) (*greysealv1.Conversation, error) {
+	srv.logger.Info("archiving conversation", zap.String("uuid", id))
+	conv, err := srv.getAuthorized(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conv.DeletedAt != nil {
+		return nil, ErrConversationDeleted
+	}
+	if conv.ArchivedAt != nil {
+		return conv, nil
+	}
+	now := time.Now()
+	if err := srv.conversationRepo.SetArchivedAt(ctx, id, &now); err != nil {
+		srv.logger.Error("failed to archive conversation", zap.String("uuid", id), zap.Error(err))
+		return nil, err
+	}
+	conv.ArchivedAt = timestamppb.New(now)
+	return conv, nil
+}
+
+func (srv *conversationService) Unarchive(ctx context.Context, id string) (*greysealv1.Conversation, error) {
+	srv.logger.Info("unarchiving conversation", zap.String("uuid", id))
+	conv, err := srv.getAuthorized(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conv.DeletedAt != nil {
+		return nil, ErrConversationDeleted
+	}
+	if conv.ArchivedAt == nil {
+		return conv, nil
+	}
+	if err := srv.conversationRepo.SetArchivedAt(ctx, id, nil); err != nil {
+		srv.logger.Error("failed to unarchive conversation", zap.String("uuid", id), zap.Error(err))
+		return nil, err
+	}
+	conv.ArchivedAt = nil
+	return conv, nil
+}
+
+func (srv *conversationService) Purge(ctx context.Context) (PurgeResult, error) {
+	var result PurgeResult
+	now := time.Now()
+	if srv.retention.StaleAfter > 0 {
+		trashed, err := srv.conversationRepo.TrashStale(ctx, now.Add(-srv.retention.StaleAfter), now)
+		if err != nil {
+			srv.logger.Error("failed to trash stale conversations", zap.Error(err))
+			return result, err
+		}
+		result.Trashed = int(trashed)
+	}
+
+	ids, err := srv.conversationRepo.ListTrashed(ctx, now.Add(-srv.retention.restoreWindow()))
+	if err != nil {
+		srv.logger.Error("failed to list trashed conversations", zap.Error(err))
+		return result, err
+	}
+	for _, id := range ids {
+		if err := srv.purge(ctx, id); err != nil {
+			srv.logger.Warn("failed to purge conversation", zap.String("uuid", id), zap.Error(err))
+			result.Failed++
+			continue
+		}
+		result.Purged++
+	}
+	return result, nil
+}
+
+// purge permanently deletes a conversation. Transcripts and cached context go
+// first so a failure leaves the row in the trash to be retried, rather than
+// orphaning blobs nothing refers to any more.
+func (srv *conversationService) purge(ctx context.Context, id string) error {
+	if srv.transcriptWriter != nil {
+		if err := srv.transcriptWriter.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete transcript: %w", err)
+		}
+	}
+	if srv.cache != nil {
+		if err := srv.cache.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete cached context: %w", err)
+		}
+	}
+	return srv.conversationRepo.Delete(ctx, id)
+}
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+func (srv *conversationService) Search(ctx context.Context, query SearchQuery) ([]*greysealv1.ConversationSearchResult, error) {
+	srv.logger.Info("searching conversations", zap.String("role_uuid", query.RoleUUID))
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return nil, ErrQueryRequired
+	}
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultSearchLimit
+	case query.Limit > maxSearchLimit:
+		query.Limit = maxSearchLimit
+	}
+	query.Owner = auth.OwnerScope(ctx)
+
+	results, err := srv.conversationRepo.Search(ctx, query)
+	if err != nil {
+		srv.logger.Error("failed to search conversations", zap.Error(err))
+		return nil, err
+	}
+	return results, nil
+}
+
+func (srv *conversationService) Export(ctx context.Context, id string) (*greysealv1.ConversationExport, error) {
+	srv.logger.Info("exporting conversation", zap.String("uuid", id))
+	conv, err := srv.conversationRepo.Get(ctx, id)
+	if err != nil {
+		srv.logger.Error("failed to get conversation for export", zap.String("uuid", id), zap.Error(err))
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, conv.GetOwner()); err != nil {
+		return nil, err
+	}
+
+	export := &greysealv1.ConversationExport{
+		Version:      ExportVersion,
+		Conversation: conv,
+		ExportedAt:   timestamppb.New(time.Now()),
+	}
+	// The role snapshot and citations are best-effort: a role or resource that
+	// has since been deleted should not make the conversation unexportable.
+	if conv.RoleUuid != "" && srv.roleRepo != nil {
+		if role, err := srv.roleRepo.Get(ctx, conv.RoleUuid); err == nil {
+			export.Role = role
+		}
+	}
+	if srv.resources != nil {
+		seen := map[string]bool{}
+		for _, msg := range conv.Messages {
+			for _, resourceUUID := range msg.ResourceUuids {
+				if seen[resourceUUID] {
+					continue
+				}
+				seen[resourceUUID] = true
+				res, err := srv.resources.Get(ctx, resourceUUID)
+				if err != nil {
+					srv.logger.Warn("cited resource not found", zap.String("resource_uuid", resourceUUID), zap.Error(err))
+					continue
+				}
+				export.Citations = append(export.Citations, res)
+			}
+		}
+	}
+	return export, nil
+}
+
+func (srv *conversationService) Import(ctx context.Context, data *greysealv1.ConversationExport, opts ImportOptions) (*greysealv1.Conversation, map[string]string, error) {
+	if data.GetConversation() == nil || data.GetVersion() > ExportVersion {
+		return nil, nil, ErrInvalidExport
+	}
+	conv := proto.Clone(data.GetConversation()).(*greysealv1.Conversation)
+	messages := conv.Messages
+	conv.Messages = nil
+	srv.logger.Info("importing conversation", zap.String("uuid", conv.GetUuid()), zap.Int("messages", len(messages)))
+
+	ids := []string{conv.Uuid}
+	for _, msg := range messages {
+		ids = append(ids, msg.Uuid)
+	}
+	taken, err := srv.conversationRepo.Taken(ctx, ids)
+	if err != nil {
+		srv.logger.Error("failed to check imported uuids", zap.Error(err))
+		return nil, nil, err
+	}
+	remapped := map[string]string{}
+	assign := func(id string) string {
+		if id != "" && !taken[id] {
+			taken[id] = true
+			return id
+		}
+		newID := uuid.New().String()
+		if id != "" {
+			remapped[id] = newID
+		}
+		return newID
+	}
+
+	now := timestamppb.New(time.Now())
+	conv.Uuid = assign(conv.Uuid)
+	conv.RoleUuid = srv.importRole(ctx, conv.RoleUuid, data.GetRole())
+	conv.WorkspaceUuid = ""
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		conv.Owner = p.Subject
+	}
+	if conv.CreatedAt == nil {
+		conv.CreatedAt = now
+	}
+	if conv.UpdatedAt == nil {
+		conv.UpdatedAt = conv.CreatedAt
+	}
+	conv.Summary = srv.redaction.Redact(redact.Messages, conv.Summary, nil)
+	if err := srv.conversationRepo.Create(ctx, conv); err != nil {
+		srv.logger.Error("failed to create imported conversation", zap.Error(err))
+		return nil, nil, err
+	}
+
+	for _, msg := range messages {
+		msg.Uuid = assign(msg.Uuid)
+		msg.ConversationUuid = conv.Uuid
+		msg.Owner = conv.Owner
+		msg.WorkspaceUuid = conv.WorkspaceUuid
+		if msg.CreatedAt == nil {
+			msg.CreatedAt = conv.CreatedAt
+		}
+		// Imported history is stored like any other message, so it is
+		// redacted the same way.
+		msg.Content = srv.redaction.Redact(redact.Messages, msg.Content, nil)
+		if err := srv.messageRepo.Create(ctx, msg); err != nil {
+			srv.logger.Error("failed to import message", zap.String("conversation_uuid", conv.Uuid), zap.Error(err))
+			// Messages cascade with the conversation, so this removes the partial import.
+			_ = srv.conversationRepo.Delete(ctx, conv.Uuid)
+			return nil, nil, err
+		}
+		// Imported ratings become feedback records of the importing owner so
+		// they show up in reports.
+		if msg.Feedback != 0 {
+			if err := srv.feedback.Create(ctx, &greysealv1.Feedback{
+				Uuid:             uuid.New().String(),
+				MessageUuid:      msg.Uuid,
+				ConversationUuid: conv.Uuid,
+				Rating:           msg.Feedback,
+				Owner:            conv.Owner,
+				WorkspaceUuid:    conv.WorkspaceUuid,
+				CreatedAt:        msg.CreatedAt,
+			}); err != nil {
+				srv.logger.Warn("failed to import message feedback", zap.String("uuid", msg.Uuid), zap.Error(err))
+			}
+		}
+	}
+	if opts.Summarize {
+		if summary := srv.summarizeMessages(ctx, messages); summary != "" {
+			conv.Summary = srv.redaction.Redact(redact.Messages, summary, nil)
+			if err := srv.conversationRepo.Update(ctx, conv.Uuid, conv); err != nil {
+				srv.logger.Warn("failed to save imported conversation summary", zap.String("uuid", conv.Uuid), zap.Error(err))
+			}
+		}
+	}
+	conv.Messages = messages
+
+	srv.logger.Info("conversation imported", zap.String("uuid", conv.Uuid), zap.Int("remapped", len(remapped)))
+	return conv, remapped, nil
+}
+
+// importRole resolves the role an imported conversation should use. A role that
+// already exists in the caller's workspace is kept as-is; otherwise the exported
+// snapshot is recreated under a new UUID. Without either the reference is dropped.
+func (srv *conversationService) importRole(ctx context.Context, roleUUID string, snapshot *greysealv1.Role) string {
+	if roleUUID == "" || srv.roleRepo == nil {
+		return roleUUID
+	}
+	if _, err := srv.roleRepo.Get(ctx, roleUUID); err == nil {
+		return roleUUID
+	}
+	if snapshot == nil {
+		return ""
+	}
+	role := &greysealv1.Role{
+		Uuid:         uuid.New().String(),
+		Name:         snapshot.Name,
+		SystemPrompt: snapshot.SystemPrompt,
+		CreatedAt:    timestamppb.New(time.Now()),
+	}
+	if err := srv.roleRepo.Create(ctx, role); err != nil {
+		srv.logger.Warn("failed to recreate exported role", zap.String("name", snapshot.Name), zap.Error(err))
+		return ""
+	}
+	return role.Uuid
 }
 
 func (srv *conversationService) Chat(ctx context.Context, conversationUUID string, content string, stream func(token string) error) (*greysealv1.Message, error) {
 	srv.logger.Info("chat request", zap.String("conversation_uuid", conversationUUID))
-	// 1. Save user message to DB
+	start := time.Now()
+	// 1. Load conversation to check ownership and get role_uuid and resource_uuids scope
+	conv, err := srv.chatConversation(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Save user message to DB
 	userMsg := &greysealv1.Message{
 		Uuid:             uuid.New().String(),
 		ConversationUuid: conversationUUID,
 		Role:             greysealv1.MessageRole_MESSAGE_ROLE_USER,
-		Content:          content,
+		Content:          srv.redaction.Redact(redact.Messages, content, nil),
 		CreatedAt:        timestamppb.New(time.Now()),
+		Owner:            conv.Owner,
+		WorkspaceUuid:    conv.WorkspaceUuid,
 	}
 	if err := srv.messageRepo.Create(ctx, userMsg); err != nil {
 		srv.logger.Error("failed to save user message", zap.String("conversation_uuid", conversationUUID), zap.Error(err))
 		return nil, fmt.Errorf("failed to save user message: %w", err)
 	}
+	return srv.answer(ctx, conv, content, userMsg.Uuid, start, stream)
+}
+
+// Regenerate replaces the conversation's last answer: the last user message
+// is answered again and, once the new answer is stored, the answers that
+// followed it are deleted. They are kept when the new turn fails or is
+// refused. A question left without an answer is answered as is.
+func (srv *conversationService) Regenerate(ctx context.Context, conversationUUID string, stream func(token string) error) (*greysealv1.Message, error) {
+	srv.logger.Info("regenerate request", zap.String("conversation_uuid", conversationUUID))
+	start := time.Now()
+	conv, err := srv.chatConversation(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := srv.messageRepo.ListByConversation(ctx, conversationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages: %w", err)
+	}
+	q := len(history) - 1
+	for q >= 0 && history[q].GetRole() != greysealv1.MessageRole_MESSAGE_ROLE_USER {
+		q--
+	}
+	if q < 0 {
+		return nil, ErrNothingToRegenerate
+	}
+	question, previous := history[q], history[q+1:]
+	msg, err := srv.answer(ctx, conv, question.GetContent(), question.GetUuid(), start, stream)
+	if err != nil {
+		return msg, err
+	}
+	// A stopped answer still replaces the old one.
+	ctx = context.WithoutCancel(ctx)
+	for _, old := range previous {
+		if err := srv.messageRepo.Delete(ctx, old.GetUuid()); err != nil {
+			srv.logger.Warn("failed to delete regenerated answer", zap.String("uuid", old.GetUuid()), zap.Error(err))
+			continue
+		}
+		if srv.transcriptWriter != nil {
+			if err := srv.transcriptWriter.DeleteTurn(ctx, conversationUUID, old.GetUuid()); err != nil {
+				srv.logger.Warn("failed to delete regenerated transcript turn", zap.String("uuid", old.GetUuid()), zap.Error(err))
+			}
+		}
+	}
+	return msg, nil
+}
 
-	// 2. Load conversation to get role_uuid and resource_uuids scope
+// chatConversation loads a conversation the caller may chat in.
+func (srv *conversationService) chatConversation(ctx context.Context, conversationUUID string) (*greysealv1.Conversation, error) {
 	conv, err := srv.conversationRepo.Get(ctx, conversationUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load conversation: %w", err)
 	}
+	if err := auth.Authorize(ctx, conv.Owner); err != nil {
+		return nil, err
+	}
+	if conv.DeletedAt != nil {
+		return nil, ErrConversationDeleted
+	}
+	return conv, nil
+}
 
-	// Default system prompt establishes the assistant persona.
-	systemPromptText := "You are a helpful research assistant. When you use information from the provided context, " +
-		"reference it clearly so the user knows which sources informed your answer. " +
-		"Be concise, accurate, and cite sources when relevant."
-	llmMessages := []LLMMessage{{Role: "system", Content: systemPromptText}}
+// answer runs a turn for the stored user message questionUUID, whose text
+// is content: it retrieves context, calls the LLM and stores the answer.
+// When ctx is cancelled with ErrGenerationStopped the answer so far is
+// stored with Stopped set and returned without an error.
+func (srv *conversationService) answer(ctx context.Context, conv *greysealv1.Conversation, content, questionUUID string, start time.Time, stream func(token string) error) (*greysealv1.Message, error) {
+	conversationUUID := conv.GetUuid()
+	timings := &greysealv1.TraceTimings{}
 
-	// 3. Load role system prompt if role_uuid is set — overrides the default.
-	if conv.RoleUuid != "" && srv.roleRepo != nil {
-		role, err := srv.roleRepo.Get(ctx, conv.RoleUuid)
-		if err == nil && role.SystemPrompt != "" {
-			systemPromptText = role.SystemPrompt
-			llmMessages = []LLMMessage{{Role: "system", Content: systemPromptText}}
-		}
-	}
+	// 3. Load the role if role_uuid is set and render its system prompt, which
+	// overrides the default. Pinned conversations use their role version.
+	ins := srv.roleInstructions(ctx, conv)
+	systemPromptText := srv.systemPrompt(ctx, conv, ins.SystemPrompt)
 
 	// 4. Load message history and handle overflow summarisation
 	history, err := srv.messageRepo.ListByConversation(ctx, conversationUUID)
 	if err != nil {
 		history = nil // non-fatal; continue without history
 	}
-	// Remove the user message we just persisted (it is always last, sorted ASC).
-	if len(history) > 0 && history[len(history)-1].Uuid == userMsg.Uuid {
-		history = history[:len(history)-1]
+	// Keep only what came before the question (sorted ASC). It is last
+	// unless earlier answers to it are being regenerated.
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Uuid == questionUUID {
+			history = history[:i]
+			break
+		}
+	}
+
+	timings.LoadMs = time.Since(start).Milliseconds()
+
+	// Guardrails check the question before anything is spent on it, the
+	// prompt before the model is called and the answer before it is sent. A
+	// block ends the turn with a refusal.
+	guards := srv.guardrailsFor(conv, ins)
+	gturn := &GuardrailTurn{Conversation: conv, Question: content}
+	var guardEvents []GuardrailEvent
+	guard := func(stage GuardrailStage) error {
+		events, g, d, err := runGuardrails(ctx, guards, stage, gturn)
+		guardEvents = append(guardEvents, events...)
+		if err != nil || g == nil {
+			return err
+		}
+		return srv.refuse(ctx, conv, ins, TranscriptTurn{
+			ConversationUUID: conversationUUID,
+			RoleUUID:         conv.RoleUuid,
+			RoleVersion:      ins.Version,
+			TurnIndex:        len(history) + 1,
+			Timestamp:        time.Now(),
+			UserMessage:      content,
+			SystemPrompt:     systemPromptText,
+			SearchQuery:      gturn.Question,
+			SearchResults:    gturn.Snippets,
+			Guardrails:       guardEvents,
+		}, stage, g, d, stream)
+	}
+	if err := guard(StagePreRetrieval); err != nil {
+		return nil, err
 	}
+	question := gturn.Question
 
 	// If history is deeper than 10 messages, summarise the overflow and persist it.
 	summaryText := conv.Summary
 	if len(history) > 10 {
+		phase := time.Now()
 		overflow := history[:len(history)-10]
 		history = history[len(history)-10:]
 		if generated := srv.summarizeMessages(ctx, overflow); generated != "" {
@@ -177,28 +719,26 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 				Uuid:          conv.Uuid,
 				Title:         conv.Title,
 				RoleUuid:      conv.RoleUuid,
+				RoleVersion:   conv.RoleVersion,
 				ResourceUuids: conv.ResourceUuids,
-				Summary:       generated,
+				Variables:     conv.Variables,
+				Summary:       srv.redaction.Redact(redact.Messages, generated, nil),
 				UpdatedAt:     timestamppb.New(time.Now()),
 			})
 		}
+		timings.SummarizeMs = time.Since(phase).Milliseconds()
 	}
 
-	// Prepend the summary (existing or freshly generated) as a system message.
-	if summaryText != "" {
-		llmMessages = append(llmMessages, LLMMessage{
-			Role:    "system",
-			Content: "Summary of earlier conversation: " + summaryText,
-		})
-	}
-
-	// 5. Retrieve relevant context from shrike.
+	// 5. Retrieve relevant context from shrike within the role's scope and
+	// sanitize it against prompt injection.
 	var usedResourceUUIDs []string
-	contextSnippets := srv.contextSearch(ctx, conversationUUID, content, conv.ResourceUuids)
+	phase := time.Now()
+	scope := srv.retrievalFor(ctx, conv, ins.Retrieval)
+	contextSnippets := srv.retrieve(ctx, conv, question, scope)
+	contextSnippets, contextDecisions := srv.sanitizeContext(conv, contextSnippets)
+	timings.RetrievalMs = time.Since(phase).Milliseconds()
 	if len(contextSnippets) > 0 {
-		var parts []string
-		for i, r := range contextSnippets {
-			parts = append(parts, fmt.Sprintf("%d. [%s]: %s", i+1, r.Title, r.Snippet))
+		for _, r := range contextSnippets {
 			usedResourceUUIDs = append(usedResourceUUIDs, r.EntityUUID)
 		}
 		srv.logger.Info("injecting context into prompt",
@@ -206,39 +746,99 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 			zap.Int("snippet_count", len(contextSnippets)),
 			zap.Strings("entity_uuids", usedResourceUUIDs),
 		)
-		llmMessages = append(llmMessages, LLMMessage{
-			Role:    "system",
-			Content: "Here is relevant context:\n" + strings.Join(parts, "\n"),
-		})
 	} else {
 		srv.logger.Info("no context snippets found, proceeding without RAG context",
 			zap.String("conversation_uuid", conversationUUID),
 		)
 	}
 
-	// 6. Add message history
-	for _, msg := range history {
-		role := "user"
-		if msg.Role == greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT {
-			role = "assistant"
-		}
-		llmMessages = append(llmMessages, LLMMessage{Role: role, Content: msg.Content})
+	// 6-7. Assemble the prompt: system prompt, role examples, summary,
+	// context, history and the current user turn, trimmed to the prompt
+	// budget, then apply the prompt redaction policy. In token mode the vault
+	// maps tokens in the response back to the original values.
+	assembled, contextSnippets, keptHistory := srv.buildPrompt(ctx, conv, ins, promptParts{
+		System:   systemPromptText,
+		Summary:  summaryText,
+		History:  historyMessages(history),
+		Question: question,
+	}, contextSnippets)
+	usedResourceUUIDs = usedResourceUUIDs[:len(contextSnippets)]
+	gturn.Snippets = contextSnippets
+	gturn.Messages = assembled
+	if err := guard(StagePreLLM); err != nil {
+		return nil, err
 	}
+	assembled = gturn.Messages
+	vault := redact.NewVault()
+	llmMessages := srv.redactPrompt(assembled, vault)
 
-	// 7. Append current user turn
-	llmMessages = append(llmMessages, LLMMessage{Role: "user", Content: content})
-
-	// 8. Call LLM (with streaming) or fall back to placeholder
+	// 8. Call LLM (with streaming) or fall back to placeholder. With
+	// guardrails the answer is held back until they have checked it.
 	var responseContent string
+	var partial strings.Builder
+	stopped := false
+	phase = time.Now()
+	firstToken := true
+	deliver := stream
+	if len(guards) > 0 {
+		deliver = func(string) error { return nil }
+	}
+	timedStream := func(token string) error {
+		if firstToken {
+			firstToken = false
+			timings.FirstTokenMs = time.Since(phase).Milliseconds()
+		}
+		partial.WriteString(token)
+		return deliver(token)
+	}
+	llmStream := timedStream
+	restorer := vault.NewRestorer()
+	if vault.Len() > 0 {
+		llmStream = func(token string) error {
+			if restored := restorer.Write(token); restored != "" {
+				return timedStream(restored)
+			}
+			return nil
+		}
+	}
 	if srv.llm != nil {
-		responseContent, err = srv.llm.Chat(ctx, llmMessages, stream)
+		responseContent, err = srv.llm.Chat(ctx, llmMessages, llmStream)
+		if err != nil && (errors.Is(err, ErrGenerationStopped) || errors.Is(context.Cause(ctx), ErrGenerationStopped)) {
+			// Keep the answer so far. The rest of the turn must not be
+			// cancelled with the generation.
+			srv.logger.Info("generation stopped", zap.String("conversation_uuid", conversationUUID))
+			ctx = context.WithoutCancel(ctx)
+			stopped, err = true, nil
+			if rest := restorer.Flush(); rest != "" {
+				_ = timedStream(rest)
+			}
+			responseContent = partial.String()
+		}
 		if err != nil {
 			srv.logger.Error("LLM chat failed", zap.String("conversation_uuid", conversationUUID), zap.Error(err))
 			return nil, fmt.Errorf("LLM chat failed: %w", err)
 		}
+		if rest := restorer.Flush(); rest != "" {
+			if err := timedStream(rest); err != nil {
+				return nil, err
+			}
+		}
+		responseContent = vault.Restore(responseContent)
 	} else {
 		responseContent = "[LLM response not yet implemented]"
-		if err := stream(responseContent); err != nil {
+		if err := timedStream(responseContent); err != nil {
+			return nil, err
+		}
+	}
+	timings.GenerationMs = time.Since(phase).Milliseconds()
+	if len(guards) > 0 {
+		gturn.Answer = responseContent
+		if err := guard(StagePostLLM); err != nil {
+			return nil, err
+		}
+		responseContent = gturn.Answer
+		// A stopped answer is kept even when the caller has gone away.
+		if err := stream(responseContent); err != nil && !stopped {
 			return nil, err
 		}
 	}
@@ -251,10 +851,53 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 		Content:          responseContent,
 		ResourceUuids:    usedResourceUUIDs,
 		CreatedAt:        timestamppb.New(time.Now()),
+		Owner:            conv.Owner,
+		WorkspaceUuid:    conv.WorkspaceUuid,
+		RoleUuid:         conv.RoleUuid,
+		RoleVersion:      ins.Version,
+		Stopped:          stopped,
 	}
-	if err := srv.messageRepo.Create(ctx, assistantMsg); err != nil {
+	if namer, ok := srv.llm.(ModelNamer); ok {
+		assistantMsg.Model = namer.ModelName()
+	}
+	// The caller gets the restored response; the stored copy follows the
+	// messages policy.
+	storedMsg := assistantMsg
+	if srv.redaction.Mode(redact.Messages) != redact.ModeOff {
+		storedMsg = proto.Clone(assistantMsg).(*greysealv1.Message)
+		storedMsg.Content = srv.redaction.Redact(redact.Messages, responseContent, nil)
+	}
+	if err := srv.messageRepo.Create(ctx, storedMsg); err != nil {
 		return nil, fmt.Errorf("failed to save assistant message: %w", err)
 	}
+	timings.TotalMs = time.Since(start).Milliseconds()
+
+	// Record the turn trace (optional; failures are non-fatal).
+	if srv.traces != nil {
+		trace := &greysealv1.TurnTrace{
+			MessageUuid:      assistantMsg.Uuid,
+			ConversationUuid: conversationUUID,
+			RoleUuid:         conv.RoleUuid,
+			Model:            assistantMsg.Model,
+			SearchQuery:      question,
+			Results:          datasetSnippets(contextSnippets),
+			Summary:          summaryText,
+			HistoryDepth:     int32(len(keptHistory)),
+			Messages:         datasetMessages(llmMessages),
+			Options:          srv.chatOptions(scope),
+			Timings:          timings,
+			Owner:            conv.Owner,
+			WorkspaceUuid:    conv.WorkspaceUuid,
+			CreatedAt:        assistantMsg.CreatedAt,
+		}
+		srv.redactTrace(trace)
+		if err := srv.traces.Create(ctx, trace); err != nil {
+			srv.logger.Warn("failed to record turn trace",
+				zap.String("conversation_uuid", conversationUUID),
+				zap.Error(err),
+			)
+		}
+	}
 
 	// Write transcript turn (optional; failures are non-fatal).
 	if srv.transcriptWriter != nil {
@@ -262,19 +905,26 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 		copy(llmMsgs, llmMessages)
 		turn := TranscriptTurn{
 			ConversationUUID:    conversationUUID,
+			MessageUUID:         assistantMsg.Uuid,
+			RoleUUID:            conv.RoleUuid,
+			RoleVersion:         ins.Version,
+			Model:               assistantMsg.Model,
 			TurnIndex:           len(history) + 1,
 			Timestamp:           time.Now(),
 			UserMessage:         content,
 			SystemPrompt:        systemPromptText,
 			ConversationSummary: summaryText,
-			HistoryDepth:        len(history),
-			SearchQuery:         content,
+			HistoryDepth:        len(keptHistory),
+			ExampleMessages:     2 * len(ins.Examples),
+			SearchQuery:         question,
 			SearchResults:       contextSnippets,
+			ContextDecisions:    contextDecisions,
+			Guardrails:          guardEvents,
 			AssembledMessages:   llmMsgs,
 			Response:            responseContent,
 			ResourceUUIDs:       usedResourceUUIDs,
 		}
-		if err := srv.transcriptWriter.WriteTurn(ctx, turn); err != nil {
+		if err := srv.transcriptWriter.WriteTurn(ctx, srv.redactTurn(turn)); err != nil {
 			srv.logger.Warn("failed to write transcript",
 				zap.String("conversation_uuid", conversationUUID),
 				zap.Error(err),
@@ -282,17 +932,22 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 		}
 	}
 
-	// Update conversation updated_at (preserve all existing fields).
-	_ = srv.conversationRepo.Update(ctx, conversationUUID, &greysealv1.Conversation{
-		Uuid:          conversationUUID,
+	srv.touch(ctx, conv)
+	return assistantMsg, nil
+}
+
+// touch updates the conversation's updated_at, preserving all existing fields.
+func (srv *conversationService) touch(ctx context.Context, conv *greysealv1.Conversation) {
+	_ = srv.conversationRepo.Update(ctx, conv.Uuid, &greysealv1.Conversation{
+		Uuid:          conv.Uuid,
 		Title:         conv.Title,
 		RoleUuid:      conv.RoleUuid,
+		RoleVersion:   conv.RoleVersion,
 		ResourceUuids: conv.ResourceUuids,
+		Variables:     conv.Variables,
 		Summary:       conv.Summary,
 		UpdatedAt:     timestamppb.New(time.Now()),
 	})
-
-	return assistantMsg, nil
 }
 
 // contextSearch retrieves relevant snippets for the given query and resource scope.
@@ -300,7 +955,7 @@ func (srv *conversationService) Chat(ctx context.Context, conversationUUID strin
 // TODO: re-enable cache once correctness is confirmed. Note that the cache must be keyed
 // by (conversationUUID + queryHash) rather than conversationUUID alone, otherwise every
 // turn after the first returns stale snippets from the original query.
-func (srv *conversationService) contextSearch(ctx context.Context, conversationUUID, query string, resourceUUIDs []string) []SearchResult {
+func (srv *conversationService) contextSearch(ctx context.Context, conversationUUID, query string, resourceUUIDs []string, limit int32) []SearchResult {
 	srv.logger.Info("context search starting",
 		zap.String("conversation_uuid", conversationUUID),
 		zap.Strings("resource_uuids", resourceUUIDs),
@@ -324,7 +979,7 @@ func (srv *conversationService) contextSearch(ctx context.Context, conversationU
 		return nil
 	}
 
-	results, err := srv.searcher.Search(ctx, query, 5, resourceUUIDs)
+	results, err := srv.searcher.Search(ctx, query, limit, resourceUUIDs)
 	if err != nil {
 		srv.logger.Error("shrike search failed",
 			zap.String("conversation_uuid", conversationUUID),
@@ -352,6 +1007,56 @@ func (srv *conversationService) contextSearch(ctx context.Context, conversationU
 	return results
 }
 
+// promptParts are the pieces of one turn's prompt.
+type promptParts struct {
+	System   string
+	Examples []*greysealv1.RoleExample
+	Summary  string
+	// Context is the formatted snippets, or the no-context instruction.
+	Context  string
+	History  []LLMMessage
+	Question string
+}
+
+// assemblePrompt builds the messages sent to the LLM for one turn: the system
+// prompt, the role's example exchanges, the summary, the context, the history
+// and the question. Chat and ReplayTurn both use it so a replay sees the
+// prompt in the same shape.
+func assemblePrompt(p promptParts) []LLMMessage {
+	messages := []LLMMessage{{Role: "system", Content: p.System}}
+	for _, e := range p.Examples {
+		messages = append(messages,
+			LLMMessage{Role: "user", Content: e.GetUser()},
+			LLMMessage{Role: "assistant", Content: e.GetAssistant()},
+		)
+	}
+	// Prepend the summary (existing or freshly generated) as a system message.
+	if p.Summary != "" {
+		messages = append(messages, LLMMessage{
+			Role:    "system",
+			Content: "Summary of earlier conversation: " + p.Summary,
+		})
+	}
+	if p.Context != "" {
+		messages = append(messages, LLMMessage{Role: "system", Content: p.Context})
+	}
+	messages = append(messages, p.History...)
+	return append(messages, LLMMessage{Role: "user", Content: p.Question})
+}
+
+// historyMessages converts stored messages to the LLM chat format.
+func historyMessages(history []*greysealv1.Message) []LLMMessage {
+	messages := make([]LLMMessage, 0, len(history))
+	for _, msg := range history {
+		role := "user"
+		if msg.Role == greysealv1.MessageRole_MESSAGE_ROLE_ASSISTANT {
+			role = "assistant"
+		}
+		messages = append(messages, LLMMessage{Role: role, Content: msg.Content})
+	}
+	return messages
+}
+
 // summarizeMessages calls the LLM to produce a concise summary of the given messages.
 // Returns an empty string if the LLM is unavailable or returns an error.
 func (srv *conversationService) summarizeMessages(ctx context.Context, messages []*greysealv1.Message) string {
@@ -368,14 +1073,36 @@ func (srv *conversationService) summarizeMessages(ctx context.Context, messages
 		}
 		prompt = append(prompt, LLMMessage{Role: role, Content: msg.Content})
 	}
-	summary, err := srv.llm.Chat(ctx, prompt, func(_ string) error { return nil })
+	vault := redact.NewVault()
+	summary, err := srv.llm.Chat(ctx, srv.redactPrompt(prompt, vault), func(_ string) error { return nil })
 	if err != nil {
 		srv.logger.Warn("failed to summarize conversation history", zap.Error(err))
 		return ""
 	}
-	return summary
+	return vault.Restore(summary)
+}
+
+// getAuthorized loads a conversation and checks that the caller owns it.
+func (srv *conversationService) getAuthorized(ctx context.Context, id string) (*greysealv1.Conversation, error) {
+	conv, err := srv.conversationRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, conv.Owner); err != nil {
+		return nil, err
+	}
+	return conv, nil
 }
 
-func (srv *conversationService) SubmitFeedback(ctx context.Context, messageUUID string, feedback int32) error {
-	return srv.messageRepo.UpdateFeedback(ctx, messageUUID, feedback)
+// authorizeConversation checks that the caller owns the conversation. The lookup
+// is skipped for unrestricted callers so admins and internal callers pay no extra query.
+func (srv *conversationService) authorizeConversation(ctx context.Context, id string) error {
+	if auth.OwnerScope(ctx) == "" {
+		return nil
+	}
+	conv, err := srv.conversationRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return auth.Authorize(ctx, conv.Owner)
 }
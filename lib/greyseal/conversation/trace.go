@@ -0,0 +1,64 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+func (srv *conversationService) GetMessageTrace(ctx context.Context, messageUUID string) (*greysealv1.TurnTrace, error) {
+	srv.logger.Info("getting message trace", zap.String("message_uuid", messageUUID))
+	if srv.traces == nil {
+		return nil, ErrTraceNotFound
+	}
+	trace, err := srv.traces.Get(ctx, messageUUID)
+	if errors.Is(err, ErrTraceNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		srv.logger.Error("failed to get message trace", zap.String("message_uuid", messageUUID), zap.Error(err))
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, trace.GetOwner()); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// chatOptions returns the generation options recorded on a turn trace.
+func (srv *conversationService) chatOptions(scope retrieval) map[string]string {
+	options := map[string]string{"retrieval_limit": strconv.Itoa(int(scope.Limit))}
+	if scope.MinScore > 0 {
+		options["min_score"] = strconv.FormatFloat(float64(scope.MinScore), 'g', -1, 32)
+	}
+	if reporter, ok := srv.llm.(OptionReporter); ok {
+		maps.Copy(options, reporter.ChatOptions())
+	}
+	return options
+}
+
+func datasetSnippets(results []SearchResult) []*greysealv1.DatasetSnippet {
+	snippets := make([]*greysealv1.DatasetSnippet, 0, len(results))
+	for _, r := range results {
+		snippets = append(snippets, &greysealv1.DatasetSnippet{
+			ResourceUuid: r.EntityUUID,
+			Title:        r.Title,
+			Snippet:      r.Snippet,
+			Score:        r.Score,
+		})
+	}
+	return snippets
+}
+
+func datasetMessages(messages []LLMMessage) []*greysealv1.DatasetMessage {
+	out := make([]*greysealv1.DatasetMessage, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, &greysealv1.DatasetMessage{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
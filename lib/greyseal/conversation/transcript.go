@@ -0,0 +1,85 @@
+package conversation
+
+import (
+	"context"
+
+	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListTranscripts pages through the transcript bucket and keeps the
+// conversations the caller can read in their workspace, so a page may be
+// shorter than requested.
+func (srv *conversationService) ListTranscripts(ctx context.Context, lis base.ListRequest) ([]*greysealv1.TranscriptInfo, string, error) {
+	srv.logger.Info("listing transcripts")
+	if srv.transcriptWriter == nil {
+		return nil, "", nil
+	}
+	infos, cursor, err := srv.transcriptWriter.List(ctx, lis.GetCursor(), pagination.Limit(lis.GetCount()))
+	if err != nil {
+		srv.logger.Error("failed to list transcripts", zap.Error(err))
+		return nil, "", err
+	}
+
+	data := make([]*greysealv1.TranscriptInfo, 0, len(infos))
+	for _, info := range infos {
+		conv, err := srv.conversationRepo.Get(ctx, info.ConversationUUID)
+		if err != nil || auth.Authorize(ctx, conv.GetOwner()) != nil {
+			continue
+		}
+		data = append(data, &greysealv1.TranscriptInfo{
+			ConversationUuid: info.ConversationUUID,
+			Title:            conv.GetTitle(),
+			TurnCount:        int32(info.Turns),
+			UpdatedAt:        timestamppb.New(info.UpdatedAt),
+		})
+	}
+	return data, cursor, nil
+}
+
+func (srv *conversationService) GetTranscript(ctx context.Context, conversationUUID string) (*greysealv1.Transcript, error) {
+	srv.logger.Info("getting transcript", zap.String("conversation_uuid", conversationUUID))
+	conv, err := srv.conversationRepo.Get(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, conv.GetOwner()); err != nil {
+		return nil, err
+	}
+	if srv.transcriptWriter == nil {
+		return nil, ErrTranscriptNotFound
+	}
+
+	markdown, err := srv.transcriptWriter.Render(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	turns, err := srv.transcriptWriter.ListTurns(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	transcript := &greysealv1.Transcript{ConversationUuid: conversationUUID, Markdown: string(markdown)}
+	for _, t := range turns {
+		transcript.Turns = append(transcript.Turns, &greysealv1.TranscriptTurn{
+			MessageUuid:         t.MessageUUID,
+			RoleUuid:            t.RoleUUID,
+			Model:               t.Model,
+			TurnIndex:           int32(t.TurnIndex),
+			Timestamp:           timestamppb.New(t.Timestamp),
+			UserMessage:         t.UserMessage,
+			SystemPrompt:        t.SystemPrompt,
+			ConversationSummary: t.ConversationSummary,
+			HistoryDepth:        int32(t.HistoryDepth),
+			SearchQuery:         t.SearchQuery,
+			SearchResults:       datasetSnippets(t.SearchResults),
+			AssembledMessages:   datasetMessages(t.AssembledMessages),
+			Response:            t.Response,
+			ResourceUuids:       t.ResourceUUIDs,
+		})
+	}
+	return transcript, nil
+}
@@ -0,0 +1,128 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Metric names used in comparisons, in report order.
+const (
+	MetricRecall    = "recall@k"
+	MetricMRR       = "mrr"
+	MetricCoverage  = "key_fact_coverage"
+	MetricPrecision = "citation_precision"
+)
+
+var metrics = []string{MetricRecall, MetricMRR, MetricCoverage, MetricPrecision}
+
+// Comparison is the difference between a baseline run and a new one.
+type Comparison struct {
+	Metrics []MetricDelta `json:"metrics"`
+	// Regressions and Improvements list per-case metric changes. Cases only
+	// in one of the runs are listed in Added and Removed.
+	Regressions  []CaseDelta `json:"regressions,omitempty"`
+	Improvements []CaseDelta `json:"improvements,omitempty"`
+	Added        []string    `json:"added,omitempty"`
+	Removed      []string    `json:"removed,omitempty"`
+	// LatencyMeanMS holds the baseline and new mean latency.
+	LatencyMeanMS [2]float64 `json:"latency_mean_ms"`
+}
+
+// MetricDelta is a summary metric in both runs. Before or After is nil when
+// the metric did not apply to that run.
+type MetricDelta struct {
+	Name   string   `json:"name"`
+	Before *float64 `json:"before,omitempty"`
+	After  *float64 `json:"after,omitempty"`
+}
+
+// CaseDelta is a metric that changed for one case.
+type CaseDelta struct {
+	ID     string  `json:"id"`
+	Metric string  `json:"metric"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// Compare diffs a run against a baseline run of the same golden set.
+func Compare(baseline, run *Run) *Comparison {
+	c := &Comparison{LatencyMeanMS: [2]float64{baseline.Summary.LatencyMeanMS, run.Summary.LatencyMeanMS}}
+	before, after := summaryMetrics(baseline.Summary), summaryMetrics(run.Summary)
+	for _, name := range metrics {
+		c.Metrics = append(c.Metrics, MetricDelta{Name: name, Before: before[name], After: after[name]})
+	}
+
+	previous := make(map[string]CaseResult, len(baseline.Cases))
+	for _, r := range baseline.Cases {
+		previous[r.ID] = r
+	}
+	current := make(map[string]bool, len(run.Cases))
+	for _, r := range run.Cases {
+		current[r.ID] = true
+		old, ok := previous[r.ID]
+		if !ok {
+			c.Added = append(c.Added, r.ID)
+			continue
+		}
+		oldMetrics, newMetrics := caseMetrics(old), caseMetrics(r)
+		for _, name := range metrics {
+			o, n := oldMetrics[name], newMetrics[name]
+			if o == nil || n == nil || *o == *n {
+				continue
+			}
+			delta := CaseDelta{ID: r.ID, Metric: name, Before: *o, After: *n}
+			if *n < *o {
+				c.Regressions = append(c.Regressions, delta)
+			} else {
+				c.Improvements = append(c.Improvements, delta)
+			}
+		}
+	}
+	for _, r := range baseline.Cases {
+		if !current[r.ID] {
+			c.Removed = append(c.Removed, r.ID)
+		}
+	}
+	return c
+}
+
+func summaryMetrics(s Summary) map[string]*float64 {
+	return map[string]*float64{
+		MetricRecall:    s.RecallAtK,
+		MetricMRR:       s.MRR,
+		MetricCoverage:  s.KeyFactCoverage,
+		MetricPrecision: s.CitationPrecision,
+	}
+}
+
+func caseMetrics(r CaseResult) map[string]*float64 {
+	return map[string]*float64{
+		MetricRecall:    r.RecallAtK,
+		MetricMRR:       r.ReciprocalRank,
+		MetricCoverage:  r.KeyFactCoverage,
+		MetricPrecision: r.CitationPrecision,
+	}
+}
+
+// LoadRun reads a run saved with Save.
+func LoadRun(path string) (*Run, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	run := &Run{}
+	if err := json.Unmarshal(content, run); err != nil {
+		return nil, fmt.Errorf("failed to read run %s: %w", path, err)
+	}
+	return run, nil
+}
+
+// Save writes the run as indented JSON.
+func (r *Run) Save(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
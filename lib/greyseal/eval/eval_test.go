@@ -0,0 +1,168 @@
+package eval_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/eval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const goldenYAML = `
+system_prompt: Answer from the context only.
+cases:
+  - id: payments-owner
+    question: Who owns the payments service?
+    expected_resources: [res-runbook, res-oncall]
+    key_facts: ["payments team", "#payments-oncall"]
+  - id: rebalance
+    question: How do I tune the consumer rebalance timeout?
+    expected_resources: [res-kafka]
+    reference: Raise max.poll.interval.ms above the longest batch processing time.
+  - id: unscripted
+    question: What is the retention period?
+    expected_resources: [res-retention]
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadGoldenSet(t *testing.T) {
+	set, err := eval.LoadGoldenSet(writeFile(t, "golden.yaml", goldenYAML))
+	require.NoError(t, err)
+	assert.Equal(t, "Answer from the context only.", set.SystemPrompt)
+	require.Len(t, set.Cases, 3)
+	assert.Equal(t, []string{"res-runbook", "res-oncall"}, set.Cases[0].ExpectedResources)
+
+	set, err = eval.LoadGoldenSet(writeFile(t, "golden.jsonl",
+		`{"id":"a","question":"q1","key_facts":["x"]}`+"\n\n"+`{"id":"b","question":"q2"}`+"\n"))
+	require.NoError(t, err)
+	assert.Len(t, set.Cases, 2)
+
+	_, err = eval.LoadGoldenSet(writeFile(t, "dupes.jsonl", `{"id":"a","question":"q1"}`+"\n"+`{"id":"a","question":"q2"}`))
+	assert.ErrorIs(t, err, eval.ErrInvalidGoldenSet)
+}
+
+func TestFixtures_Search(t *testing.T) {
+	f := eval.Fixtures{"q": {{EntityUUID: "a"}, {EntityUUID: "b"}, {EntityUUID: "c"}}}
+
+	results, err := f.Search(context.Background(), " q ", 2, nil)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = f.Search(context.Background(), "q", 5, []string{"c"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "c", results[0].EntityUUID)
+
+	results, err = f.Search(context.Background(), "unknown", 5, nil)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestRecorder_SavesReplayableFixtures(t *testing.T) {
+	live := eval.Fixtures{"q": {{EntityUUID: "a", Title: "A", Snippet: "text", Score: 0.5}}}
+	rec := eval.NewRecorder(live)
+	_, err := rec.Search(context.Background(), "q", 5, nil)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, rec.Fixtures().Save(path))
+	loaded, err := eval.LoadFixtures(path)
+	require.NoError(t, err)
+	assert.Equal(t, live, loaded)
+}
+
+func TestRunner_Offline(t *testing.T) {
+	set, err := eval.LoadGoldenSet(writeFile(t, "golden.yaml", goldenYAML))
+	require.NoError(t, err)
+	fixtures := eval.Fixtures{
+		"Who owns the payments service?": {
+			{EntityUUID: "res-wiki", Title: "Wiki"},
+			{EntityUUID: "res-runbook", Title: "Runbook"},
+			{EntityUUID: "res-runbook", Title: "Runbook"},
+		},
+		"How do I tune the consumer rebalance timeout?": {{EntityUUID: "res-kafka", Title: "Kafka"}},
+	}
+	script, err := eval.LoadScript(writeFile(t, "script.yaml", `
+Who owns the payments service?: "The Payments Team owns it; page #payments-oncall."
+How do I tune the consumer rebalance timeout?: Raise max.poll.interval.ms.
+`))
+	require.NoError(t, err)
+
+	run, err := eval.NewRunner(fixtures, script, 0, zap.NewNop()).Run(context.Background(), set)
+	require.NoError(t, err)
+	assert.Equal(t, eval.DefaultK, run.K)
+	assert.Equal(t, "scripted", run.Model)
+	require.Len(t, run.Cases, 3)
+
+	owner := run.Cases[0]
+	assert.Equal(t, []string{"res-wiki", "res-runbook"}, owner.Retrieved)
+	assert.InDelta(t, 0.5, *owner.RecallAtK, 1e-9)
+	assert.InDelta(t, 0.5, *owner.ReciprocalRank, 1e-9)
+	assert.InDelta(t, 1.0, *owner.KeyFactCoverage, 1e-9)
+	assert.InDelta(t, 0.5, *owner.CitationPrecision, 1e-9)
+
+	rebalance := run.Cases[1]
+	assert.InDelta(t, 1.0, *rebalance.RecallAtK, 1e-9)
+	// "raise" and "max.poll.interval.ms" of seven meaningful reference words.
+	assert.InDelta(t, 2.0/7, *rebalance.KeyFactCoverage, 1e-9)
+
+	unscripted := run.Cases[2]
+	assert.Contains(t, unscripted.Error, eval.ErrNoScript.Error())
+	assert.InDelta(t, 0.0, *unscripted.RecallAtK, 1e-9)
+	assert.Nil(t, unscripted.KeyFactCoverage)
+
+	assert.Equal(t, 3, run.Summary.Cases)
+	assert.Equal(t, 1, run.Summary.Errors)
+	assert.InDelta(t, 0.5, *run.Summary.RecallAtK, 1e-9)
+	assert.InDelta(t, 0.75, *run.Summary.CitationPrecision, 1e-9)
+}
+
+func TestRunner_NoSearcherOrLLM(t *testing.T) {
+	run, err := eval.NewRunner(nil, nil, 3, zap.NewNop()).Run(context.Background(), &eval.GoldenSet{
+		Cases: []eval.Case{{ID: "a", Question: "q", ExpectedResources: []string{"r"}, KeyFacts: []string{"fact"}}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, run.Cases[0].Error)
+	assert.InDelta(t, 0.0, *run.Cases[0].RecallAtK, 1e-9)
+	assert.Equal(t, []string{"fact"}, run.Cases[0].MissingFacts)
+}
+
+func TestCompare(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	baseline := &eval.Run{
+		Summary: eval.Summary{RecallAtK: f(0.5), LatencyMeanMS: 10},
+		Cases: []eval.CaseResult{
+			{ID: "a", RecallAtK: f(1), KeyFactCoverage: f(0.5)},
+			{ID: "b", RecallAtK: f(0)},
+			{ID: "gone"},
+		},
+	}
+	run := &eval.Run{
+		Summary: eval.Summary{RecallAtK: f(0.5), MRR: f(0.2), LatencyMeanMS: 12},
+		Cases: []eval.CaseResult{
+			{ID: "a", RecallAtK: f(0), KeyFactCoverage: f(0.5)},
+			{ID: "b", RecallAtK: f(1)},
+			{ID: "new"},
+		},
+	}
+
+	c := eval.Compare(baseline, run)
+	require.Len(t, c.Metrics, 4)
+	assert.Equal(t, eval.MetricMRR, c.Metrics[1].Name)
+	assert.Nil(t, c.Metrics[1].Before)
+	assert.Equal(t, []eval.CaseDelta{{ID: "a", Metric: eval.MetricRecall, Before: 1, After: 0}}, c.Regressions)
+	assert.Equal(t, []eval.CaseDelta{{ID: "b", Metric: eval.MetricRecall, Before: 0, After: 1}}, c.Improvements)
+	assert.Equal(t, []string{"new"}, c.Added)
+	assert.Equal(t, []string{"gone"}, c.Removed)
+	assert.Equal(t, [2]float64{10, 12}, c.LatencyMeanMS)
+}
@@ -0,0 +1,145 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoScript is returned by ScriptedLLM for questions it has no answer for.
+var ErrNoScript = errors.New("no scripted response")
+
+// Fixtures are recorded search results keyed by query. They replay a search
+// service offline.
+type Fixtures map[string][]conversation.SearchResult
+
+var _ conversation.Searcher = Fixtures(nil)
+
+// Search returns the results recorded for query, restricted to resourceUUIDs
+// when given and cut to limit. Unknown queries return no results.
+func (f Fixtures) Search(_ context.Context, query string, limit int32, resourceUUIDs []string) ([]conversation.SearchResult, error) {
+	var results []conversation.SearchResult
+	for _, r := range f[strings.TrimSpace(query)] {
+		if len(resourceUUIDs) > 0 && !slices.Contains(resourceUUIDs, r.EntityUUID) {
+			continue
+		}
+		if limit > 0 && len(results) == int(limit) {
+			break
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// LoadFixtures reads fixtures written by Save.
+func LoadFixtures(path string) (Fixtures, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := Fixtures{}
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to read fixtures %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Save writes the fixtures as indented JSON.
+func (f Fixtures) Save(path string) error {
+	content, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
+
+// Recorder wraps a live Searcher and keeps every result it returns so they can
+// be saved as fixtures for offline runs.
+type Recorder struct {
+	next conversation.Searcher
+
+	mu       sync.Mutex
+	recorded Fixtures
+}
+
+var _ conversation.Searcher = (*Recorder)(nil)
+
+func NewRecorder(next conversation.Searcher) *Recorder {
+	return &Recorder{next: next, recorded: Fixtures{}}
+}
+
+func (r *Recorder) Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversation.SearchResult, error) {
+	results, err := r.next.Search(ctx, query, limit, resourceUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recorded[strings.TrimSpace(query)] = results
+	return results, nil
+}
+
+// Fixtures returns everything recorded so far.
+func (r *Recorder) Fixtures() Fixtures {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := make(Fixtures, len(r.recorded))
+	for q, results := range r.recorded {
+		f[q] = slices.Clone(results)
+	}
+	return f
+}
+
+// ScriptedLLM answers each question with a fixed response, keyed by the
+// content of the last user message.
+type ScriptedLLM map[string]string
+
+var (
+	_ conversation.LLM        = ScriptedLLM(nil)
+	_ conversation.ModelNamer = ScriptedLLM(nil)
+)
+
+func (s ScriptedLLM) Chat(_ context.Context, messages []conversation.LLMMessage, stream func(token string) error) (string, error) {
+	var question string
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			question = strings.TrimSpace(messages[i].Content)
+			break
+		}
+	}
+	response, ok := s[question]
+	if !ok {
+		return "", fmt.Errorf("%w for %q", ErrNoScript, question)
+	}
+	if err := stream(response); err != nil {
+		return "", err
+	}
+	return response, nil
+}
+
+func (s ScriptedLLM) ModelName() string { return "scripted" }
+
+// LoadScript reads a YAML or JSON object mapping questions to responses.
+func LoadScript(path string) (ScriptedLLM, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]string{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to read script %s: %w", path, err)
+	}
+	script := make(ScriptedLLM, len(raw))
+	for q, response := range raw {
+		script[strings.TrimSpace(q)] = response
+	}
+	return script, nil
+}
@@ -0,0 +1,95 @@
+// Package eval measures answer quality by running a golden set of questions
+// through the conversation service and scoring retrieval and answers against
+// expected resources and key facts. With recorded search fixtures and a
+// scripted LLM it runs entirely offline.
+package eval
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidGoldenSet is returned for golden sets that cannot be evaluated.
+var ErrInvalidGoldenSet = errors.New("invalid golden set")
+
+// GoldenSet is the list of questions an eval run asks.
+type GoldenSet struct {
+	// SystemPrompt replaces the default system prompt for every case that
+	// does not set its own, as a role would.
+	SystemPrompt string `yaml:"system_prompt" json:"system_prompt,omitempty"`
+	Cases        []Case `yaml:"cases" json:"cases"`
+}
+
+// Case is one golden question with what a good answer retrieves and says.
+type Case struct {
+	ID       string `yaml:"id" json:"id"`
+	Question string `yaml:"question" json:"question"`
+	// ExpectedResources are the resources that should be retrieved and cited.
+	ExpectedResources []string `yaml:"expected_resources" json:"expected_resources,omitempty"`
+	// KeyFacts must each appear in the answer (case-insensitive).
+	KeyFacts []string `yaml:"key_facts" json:"key_facts,omitempty"`
+	// Reference is a model answer. It is only scored when there are no key
+	// facts, by how many of its words the answer uses.
+	Reference    string `yaml:"reference" json:"reference,omitempty"`
+	SystemPrompt string `yaml:"system_prompt" json:"system_prompt,omitempty"`
+	// Scope restricts retrieval to these resources, like a conversation's
+	// resource scope.
+	Scope []string `yaml:"scope" json:"scope,omitempty"`
+}
+
+// LoadGoldenSet reads a golden set. Files ending in .jsonl hold one case per
+// line; anything else is parsed as YAML (which includes JSON).
+func LoadGoldenSet(path string) (*GoldenSet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &GoldenSet{}
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var c Case
+			if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidGoldenSet, line, err)
+			}
+			set.Cases = append(set.Cases, c)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else if err := yaml.Unmarshal(content, set); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGoldenSet, err)
+	}
+	return set, set.validate()
+}
+
+// validate requires a unique ID and a question on every case, since runs are
+// compared case by case.
+func (s *GoldenSet) validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("%w: no cases", ErrInvalidGoldenSet)
+	}
+	seen := make(map[string]bool, len(s.Cases))
+	for i, c := range s.Cases {
+		if c.ID == "" || strings.TrimSpace(c.Question) == "" {
+			return fmt.Errorf("%w: case %d needs an id and a question", ErrInvalidGoldenSet, i+1)
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("%w: duplicate case id %q", ErrInvalidGoldenSet, c.ID)
+		}
+		seen[c.ID] = true
+	}
+	return nil
+}
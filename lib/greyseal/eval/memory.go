@@ -0,0 +1,112 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// The in-memory repositories below hold one eval run so the real
+// conversation service can be used without a database. They only implement
+// what Create and Chat call; the embedded interfaces are nil and any other
+// method panics.
+
+type memConversations struct {
+	conversation.ConversationRepository
+
+	mu    sync.Mutex
+	convs map[string]*greysealv1.Conversation
+}
+
+func (m *memConversations) Create(_ context.Context, c *greysealv1.Conversation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.convs[c.Uuid] = c
+	return nil
+}
+
+func (m *memConversations) Update(_ context.Context, id string, c *greysealv1.Conversation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.convs[id] = c
+	return nil
+}
+
+func (m *memConversations) Get(_ context.Context, id string) (*greysealv1.Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.convs[id]
+	if !ok {
+		return nil, fmt.Errorf("conversation %s not found", id)
+	}
+	return c, nil
+}
+
+type memMessages struct {
+	conversation.MessageRepository
+
+	mu       sync.Mutex
+	messages []*greysealv1.Message
+}
+
+func (m *memMessages) Create(_ context.Context, msg *greysealv1.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *memMessages) ListByConversation(_ context.Context, conversationUUID string) ([]*greysealv1.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var messages []*greysealv1.Message
+	for _, msg := range m.messages {
+		if msg.ConversationUuid == conversationUUID {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+type memRoles struct {
+	mu    sync.Mutex
+	roles map[string]*greysealv1.Role
+}
+
+func (m *memRoles) Get(_ context.Context, id string) (*greysealv1.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.roles[id]
+	if !ok {
+		return nil, fmt.Errorf("role %s not found", id)
+	}
+	return r, nil
+}
+
+// GetVersion serves the stored role as its only version; eval cases never
+// pin one.
+func (m *memRoles) GetVersion(ctx context.Context, id string, version int32) (*greysealv1.RoleVersion, error) {
+	r, err := m.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != r.GetVersion() {
+		return nil, fmt.Errorf("role %s version %d not found", id, version)
+	}
+	return &greysealv1.RoleVersion{
+		RoleUuid:     r.GetUuid(),
+		Version:      r.GetVersion(),
+		Name:         r.GetName(),
+		SystemPrompt: r.GetSystemPrompt(),
+	}, nil
+}
+
+func (m *memRoles) Create(_ context.Context, r *greysealv1.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roles[r.Uuid] = r
+	return nil
+}
@@ -0,0 +1,324 @@
+package eval
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+)
+
+// DefaultK is the retrieval cut-off for recall@k and MRR. The conversation
+// service retrieves five snippets per turn.
+const DefaultK = 5
+
+// Run is the result of evaluating a golden set once. Runs are saved as JSON
+// and compared with Compare.
+type Run struct {
+	StartedAt time.Time    `json:"started_at"`
+	K         int          `json:"k"`
+	Model     string       `json:"model,omitempty"`
+	Summary   Summary      `json:"summary"`
+	Cases     []CaseResult `json:"cases"`
+}
+
+// Summary averages each metric over the cases it applies to.
+type Summary struct {
+	Cases             int      `json:"cases"`
+	Errors            int      `json:"errors"`
+	RecallAtK         *float64 `json:"recall_at_k,omitempty"`
+	MRR               *float64 `json:"mrr,omitempty"`
+	KeyFactCoverage   *float64 `json:"key_fact_coverage,omitempty"`
+	CitationPrecision *float64 `json:"citation_precision,omitempty"`
+	LatencyMeanMS     float64  `json:"latency_mean_ms"`
+	LatencyP95MS      float64  `json:"latency_p95_ms"`
+}
+
+// CaseResult scores one case. A metric is nil when the case has nothing to
+// score it against, e.g. no expected resources.
+type CaseResult struct {
+	ID        string   `json:"id"`
+	Question  string   `json:"question"`
+	Answer    string   `json:"answer,omitempty"`
+	Retrieved []string `json:"retrieved,omitempty"`
+	Cited     []string `json:"cited,omitempty"`
+	// RecallAtK is the share of expected resources in the top K retrieved.
+	RecallAtK *float64 `json:"recall_at_k,omitempty"`
+	// ReciprocalRank is 1/rank of the first expected resource in the top K.
+	ReciprocalRank *float64 `json:"reciprocal_rank,omitempty"`
+	// KeyFactCoverage is the share of key facts (or reference words) the
+	// answer contains.
+	KeyFactCoverage *float64 `json:"key_fact_coverage,omitempty"`
+	MissingFacts    []string `json:"missing_facts,omitempty"`
+	// CitationPrecision is the share of cited resources that were expected.
+	CitationPrecision *float64 `json:"citation_precision,omitempty"`
+	LatencyMS         float64  `json:"latency_ms"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// Runner asks each golden question through a conversation service backed by
+// in-memory repositories and the given searcher and LLM.
+type Runner struct {
+	searcher conversation.Searcher // optional; nil retrieves nothing
+	llm      conversation.LLM      // optional; nil answers with a placeholder
+	k        int
+	logger   *zap.Logger
+}
+
+func NewRunner(searcher conversation.Searcher, llm conversation.LLM, k int, logger *zap.Logger) *Runner {
+	if k <= 0 {
+		k = DefaultK
+	}
+	return &Runner{searcher: searcher, llm: llm, k: k, logger: logger}
+}
+
+// Run evaluates every case in order. A case whose chat fails is recorded with
+// its error and counted in Summary.Errors; Run itself only fails if the
+// context is cancelled.
+func (r *Runner) Run(ctx context.Context, set *GoldenSet) (*Run, error) {
+	capture := &captureSearcher{next: r.searcher}
+	var searcher conversation.Searcher
+	if r.searcher != nil {
+		searcher = capture
+	}
+	roles := &memRoles{roles: map[string]*greysealv1.Role{}}
+	svc := conversation.NewConversationService(
+		&memConversations{convs: map[string]*greysealv1.Conversation{}},
+		&memMessages{},
+		searcher,
+		roles,
+		r.llm,
+		nil,
+		r.logger,
+		nil,
+	)
+
+	run := &Run{StartedAt: time.Now().UTC(), K: r.k}
+	if namer, ok := r.llm.(conversation.ModelNamer); ok {
+		run.Model = namer.ModelName()
+	}
+	for _, c := range set.Cases {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		conv := &greysealv1.Conversation{Title: c.ID, ResourceUuids: c.Scope}
+		if prompt := cmp.Or(c.SystemPrompt, set.SystemPrompt); prompt != "" {
+			role := &greysealv1.Role{Uuid: uuid.New().String(), Name: c.ID, SystemPrompt: prompt}
+			_ = roles.Create(ctx, role)
+			conv.RoleUuid = role.Uuid
+		}
+		conv, err := svc.Create(ctx, conv)
+		if err != nil {
+			return nil, err
+		}
+
+		capture.reset()
+		start := time.Now()
+		msg, err := svc.Chat(ctx, conv.GetUuid(), c.Question, func(string) error { return nil })
+		result := CaseResult{
+			ID:        c.ID,
+			Question:  c.Question,
+			LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			Retrieved: capture.retrieved(),
+		}
+		var blocked *conversation.BlockedError
+		if errors.As(err, &blocked) {
+			// A refusal is the answer the user would have seen.
+			msg, err = blocked.Message, nil
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Answer = msg.GetContent()
+			result.Cited = dedupe(msg.GetResourceUuids())
+		}
+		score(&result, c, r.k)
+		run.Cases = append(run.Cases, result)
+	}
+	run.Summary = summarize(run.Cases)
+	return run, nil
+}
+
+// score fills in the metrics of a case result.
+func score(result *CaseResult, c Case, k int) {
+	if len(c.ExpectedResources) > 0 {
+		top := result.Retrieved[:min(k, len(result.Retrieved))]
+		hits := 0
+		for _, id := range c.ExpectedResources {
+			if slices.Contains(top, id) {
+				hits++
+			}
+		}
+		result.RecallAtK = ratio(hits, len(c.ExpectedResources))
+		rr := 0.0
+		for i, id := range top {
+			if slices.Contains(c.ExpectedResources, id) {
+				rr = 1 / float64(i+1)
+				break
+			}
+		}
+		result.ReciprocalRank = &rr
+
+		if len(result.Cited) > 0 {
+			cited := 0
+			for _, id := range result.Cited {
+				if slices.Contains(c.ExpectedResources, id) {
+					cited++
+				}
+			}
+			result.CitationPrecision = ratio(cited, len(result.Cited))
+		}
+	}
+
+	answer := normalize(result.Answer)
+	switch {
+	case len(c.KeyFacts) > 0:
+		found := 0
+		for _, fact := range c.KeyFacts {
+			if strings.Contains(answer, normalize(fact)) {
+				found++
+			} else {
+				result.MissingFacts = append(result.MissingFacts, fact)
+			}
+		}
+		result.KeyFactCoverage = ratio(found, len(c.KeyFacts))
+	case c.Reference != "":
+		words := referenceWords(c.Reference)
+		if len(words) == 0 {
+			break
+		}
+		answerWords := map[string]bool{}
+		for _, w := range strings.Fields(answer) {
+			answerWords[strings.Trim(w, ".")] = true
+		}
+		found := 0
+		for _, w := range words {
+			if answerWords[w] {
+				found++
+			}
+		}
+		result.KeyFactCoverage = ratio(found, len(words))
+	}
+}
+
+// summarize averages the case metrics and latencies.
+func summarize(cases []CaseResult) Summary {
+	s := Summary{Cases: len(cases)}
+	var recall, rr, coverage, precision []float64
+	latencies := make([]float64, 0, len(cases))
+	for _, c := range cases {
+		if c.Error != "" {
+			s.Errors++
+		}
+		for _, m := range []struct {
+			v   *float64
+			all *[]float64
+		}{{c.RecallAtK, &recall}, {c.ReciprocalRank, &rr}, {c.KeyFactCoverage, &coverage}, {c.CitationPrecision, &precision}} {
+			if m.v != nil {
+				*m.all = append(*m.all, *m.v)
+			}
+		}
+		latencies = append(latencies, c.LatencyMS)
+	}
+	s.RecallAtK, s.MRR, s.KeyFactCoverage, s.CitationPrecision = mean(recall), mean(rr), mean(coverage), mean(precision)
+	if m := mean(latencies); m != nil {
+		s.LatencyMeanMS = *m
+		sort.Float64s(latencies)
+		s.LatencyP95MS = latencies[(len(latencies)*95+99)/100-1]
+	}
+	return s
+}
+
+// captureSearcher remembers what the last search returned so retrieval can be
+// scored independently of what the service does with the results.
+type captureSearcher struct {
+	next conversation.Searcher
+
+	mu      sync.Mutex
+	results []conversation.SearchResult
+}
+
+func (c *captureSearcher) Search(ctx context.Context, query string, limit int32, resourceUUIDs []string) ([]conversation.SearchResult, error) {
+	results, err := c.next.Search(ctx, query, limit, resourceUUIDs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = results
+	return results, err
+}
+
+func (c *captureSearcher) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = nil
+}
+
+// retrieved returns the distinct resources of the last search in rank order.
+func (c *captureSearcher) retrieved() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.results))
+	for _, r := range c.results {
+		ids = append(ids, r.EntityUUID)
+	}
+	return dedupe(ids)
+}
+
+func dedupe(ids []string) []string {
+	var out []string
+	for _, id := range ids {
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// normalize lower-cases text and collapses punctuation and whitespace so key
+// facts match regardless of formatting.
+func normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '.' && r != '%'
+	}), " ")
+}
+
+// referenceWords returns the distinct words of a reference answer that carry
+// meaning: numbers and words of four or more letters.
+func referenceWords(reference string) []string {
+	var words []string
+	for _, w := range strings.Fields(normalize(reference)) {
+		w = strings.Trim(w, ".")
+		if len(w) < 4 && strings.IndexFunc(w, unicode.IsNumber) < 0 {
+			continue
+		}
+		if w != "" && !slices.Contains(words, w) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func ratio(n, d int) *float64 {
+	v := float64(n) / float64(d)
+	return &v
+}
+
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	m := sum / float64(len(values))
+	return &m
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	entity "github.com/holmes89/grey-seal/lib/greyseal/model"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+)
+
+// ModelHandler wraps ModelService as a ConnectRPC handler.
+type ModelHandler struct {
+	servicesconnect.UnimplementedModelServiceHandler
+	svc entity.ModelService
+}
+
+// NewModelHandler creates a new ModelHandler.
+func NewModelHandler(svc entity.ModelService) *ModelHandler {
+	return &ModelHandler{svc: svc}
+}
+
+func (h *ModelHandler) ListModels(ctx context.Context, req *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error) {
+	result, err := h.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&services.ListModelsResponse{Data: result}), nil
+}
+
+// PullModel streams pull progress back to the client until the download completes.
+func (h *ModelHandler) PullModel(ctx context.Context, req *connect.Request[services.PullModelRequest], stream *connect.ServerStream[services.PullModelResponse]) error {
+	err := h.svc.Pull(ctx, req.Msg.GetName(), func(p entity.PullProgress) error {
+		return stream.Send(&services.PullModelResponse{
+			Status:    p.Status,
+			Digest:    p.Digest,
+			Total:     p.Total,
+			Completed: p.Completed,
+		})
+	})
+	if errors.Is(err, entity.ErrNameRequired) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return err
+}
+
+func (h *ModelHandler) UnloadModel(ctx context.Context, req *connect.Request[services.UnloadModelRequest]) (*connect.Response[services.UnloadModelResponse], error) {
+	if err := h.svc.Unload(ctx, req.Msg.GetName()); err != nil {
+		if errors.Is(err, entity.ErrNameRequired) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, err
+	}
+	return connect.NewResponse(&services.UnloadModelResponse{}), nil
+}
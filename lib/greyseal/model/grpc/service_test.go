@@ -0,0 +1,53 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/holmes89/grey-seal/lib/greyseal/model"
+	grpchandler "github.com/holmes89/grey-seal/lib/greyseal/model/grpc"
+	"github.com/holmes89/grey-seal/lib/greyseal/model/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModelGRPCHandlerTestSuite struct {
+	suite.Suite
+	svc     *mocks.MockModelService
+	handler *grpchandler.ModelHandler
+}
+
+func (s *ModelGRPCHandlerTestSuite) SetupTest() {
+	s.svc = mocks.NewMockModelService(s.T())
+	s.handler = grpchandler.NewModelHandler(s.svc)
+}
+
+func (s *ModelGRPCHandlerTestSuite) TestListModels() {
+	s.svc.On("List", mock.Anything).Return([]*v1.Model{{Name: "deepseek-r1:latest"}}, nil)
+
+	resp, err := s.handler.ListModels(context.Background(), connect.NewRequest(&services.ListModelsRequest{}))
+	s.Require().NoError(err)
+	s.Len(resp.Msg.GetData(), 1)
+}
+
+func (s *ModelGRPCHandlerTestSuite) TestUnloadModel() {
+	s.svc.On("Unload", mock.Anything, "deepseek-r1").Return(nil)
+
+	_, err := s.handler.UnloadModel(context.Background(), connect.NewRequest(&services.UnloadModelRequest{Name: "deepseek-r1"}))
+	s.Require().NoError(err)
+}
+
+func (s *ModelGRPCHandlerTestSuite) TestUnloadModel_MissingNameIsInvalidArgument() {
+	s.svc.On("Unload", mock.Anything, "").Return(model.ErrNameRequired)
+
+	_, err := s.handler.UnloadModel(context.Background(), connect.NewRequest(&services.UnloadModelRequest{}))
+	s.Require().Error(err)
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestModelGRPCHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ModelGRPCHandlerTestSuite))
+}
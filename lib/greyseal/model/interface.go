@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// ModelService manages the models installed in the LLM backend.
+type ModelService interface {
+	List(ctx context.Context) ([]*greysealv1.Model, error)
+
+	// Pull downloads a model into the backend. The progress callback is invoked
+	// for every status update; returning an error aborts the pull.
+	Pull(ctx context.Context, name string, progress func(PullProgress) error) error
+
+	// Unload evicts a model from backend memory without deleting it.
+	Unload(ctx context.Context, name string) error
+}
+
+// ModelInfo describes a model installed in the backend.
+type ModelInfo struct {
+	Name       string
+	Size       int64
+	Digest     string
+	ModifiedAt time.Time
+	Loaded     bool
+	ExpiresAt  time.Time // zero when the model is not loaded
+}
+
+// PullProgress is a single status update emitted while a model is being pulled.
+type PullProgress struct {
+	Status    string
+	Digest    string
+	Total     int64
+	Completed int64
+}
+
+// Manager is implemented by LLM backends that can list, pull and unload models.
+type Manager interface {
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+	PullModel(ctx context.Context, name string, progress func(PullProgress) error) error
+	UnloadModel(ctx context.Context, name string) error
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"errors"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ ModelService = (*modelService)(nil)
+
+// ErrNameRequired is returned when a model operation is called without a model name.
+var ErrNameRequired = errors.New("model name is required")
+
+type modelService struct {
+	manager Manager
+	logger  *zap.Logger
+}
+
+func NewModelService(manager Manager, logger *zap.Logger) ModelService {
+	return &modelService{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+func (srv *modelService) List(ctx context.Context) ([]*greysealv1.Model, error) {
+	srv.logger.Info("listing models")
+	infos, err := srv.manager.ListModels(ctx)
+	if err != nil {
+		srv.logger.Error("failed to list models", zap.Error(err))
+		return nil, err
+	}
+	models := make([]*greysealv1.Model, 0, len(infos))
+	for _, info := range infos {
+		m := &greysealv1.Model{
+			Name:   info.Name,
+			Size:   info.Size,
+			Digest: info.Digest,
+			Loaded: info.Loaded,
+		}
+		if !info.ModifiedAt.IsZero() {
+			m.ModifiedAt = timestamppb.New(info.ModifiedAt)
+		}
+		if !info.ExpiresAt.IsZero() {
+			m.ExpiresAt = timestamppb.New(info.ExpiresAt)
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+func (srv *modelService) Pull(ctx context.Context, name string, progress func(PullProgress) error) error {
+	if name == "" {
+		return ErrNameRequired
+	}
+	srv.logger.Info("pulling model", zap.String("model", name))
+	if err := srv.manager.PullModel(ctx, name, progress); err != nil {
+		srv.logger.Error("failed to pull model", zap.String("model", name), zap.Error(err))
+		return err
+	}
+	srv.logger.Info("model pulled", zap.String("model", name))
+	return nil
+}
+
+func (srv *modelService) Unload(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrNameRequired
+	}
+	srv.logger.Info("unloading model", zap.String("model", name))
+	err := srv.manager.UnloadModel(ctx, name)
+	if err != nil {
+		srv.logger.Error("failed to unload model", zap.String("model", name), zap.Error(err))
+	}
+	return err
+}
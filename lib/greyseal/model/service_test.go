@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/model"
+	"github.com/holmes89/grey-seal/lib/greyseal/model/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModelServiceTestSuite struct {
+	suite.Suite
+	manager *mocks.MockManager
+	svc     model.ModelService
+}
+
+func (s *ModelServiceTestSuite) SetupTest() {
+	s.manager = mocks.NewMockManager(s.T())
+	s.svc = model.NewModelService(s.manager, zap.NewNop())
+}
+
+func (s *ModelServiceTestSuite) TestList() {
+	modified := time.Date(2026, 4, 16, 12, 0, 0, 0, time.UTC)
+	s.manager.On("ListModels", mock.Anything).Return([]model.ModelInfo{
+		{Name: "deepseek-r1:latest", Size: 42, ModifiedAt: modified, Loaded: true, ExpiresAt: modified.Add(time.Hour)},
+		{Name: "llama3:latest"},
+	}, nil)
+
+	models, err := s.svc.List(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(models, 2)
+	s.Equal("deepseek-r1:latest", models[0].GetName())
+	s.True(models[0].GetLoaded())
+	s.Equal(modified, models[0].GetModifiedAt().AsTime())
+	s.Nil(models[1].GetExpiresAt())
+}
+
+func (s *ModelServiceTestSuite) TestPull_ForwardsProgress() {
+	s.manager.On("PullModel", mock.Anything, "llama3", mock.Anything).
+		Run(func(args mock.Arguments) {
+			progress := args.Get(2).(func(model.PullProgress) error)
+			_ = progress(model.PullProgress{Status: "success"})
+		}).
+		Return(nil)
+
+	var statuses []string
+	err := s.svc.Pull(context.Background(), "llama3", func(p model.PullProgress) error {
+		statuses = append(statuses, p.Status)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"success"}, statuses)
+}
+
+func (s *ModelServiceTestSuite) TestPull_RequiresName() {
+	err := s.svc.Pull(context.Background(), "", nil)
+	s.ErrorIs(err, model.ErrNameRequired)
+}
+
+func (s *ModelServiceTestSuite) TestUnload() {
+	s.manager.On("UnloadModel", mock.Anything, "llama3").Return(errors.New("boom"))
+
+	err := s.svc.Unload(context.Background(), "llama3")
+	s.Require().Error(err)
+}
+
+func TestModelServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ModelServiceTestSuite))
+}
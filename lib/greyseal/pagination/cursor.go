@@ -0,0 +1,78 @@
+// Package pagination implements the keyset cursors shared by every List RPC.
+//
+// A cursor is an opaque token encoding the sort key of the last row on a page:
+// a timestamp and the row's UUID, which breaks ties between equal timestamps.
+// Repositories decode it into a `(ts, uuid) < (?, ?)` predicate; services encode
+// it from the last item they return.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit is used when a request does not set a page size.
+	DefaultLimit = 50
+	// MaxLimit caps the page size a client may request.
+	MaxLimit = 200
+)
+
+// ErrInvalidCursor is returned when a cursor was not produced by Encode.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the decoded position after which the next page starts.
+type Cursor struct {
+	Time time.Time
+	UUID string
+}
+
+// Encode returns the opaque cursor for a row with the given sort key.
+func Encode(t time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+// Decode parses a cursor produced by Encode. The empty cursor is the first page
+// and decodes to a nil Cursor.
+func Decode(cursor string) (*Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Time: t, UUID: id}, nil
+}
+
+// Limit clamps a requested page size to (0, MaxLimit], defaulting to DefaultLimit.
+func Limit(requested int32) uint {
+	switch {
+	case requested <= 0:
+		return DefaultLimit
+	case requested > MaxLimit:
+		return MaxLimit
+	}
+	return uint(requested)
+}
+
+// Page trims items fetched with limit+1 down to limit and returns the cursor
+// for the next page, or "" when items is the last page. key returns the sort
+// key of an item.
+func Page[T any](items []T, limit uint, key func(T) (time.Time, string)) ([]T, string) {
+	if uint(len(items)) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, Encode(key(items[len(items)-1]))
+}
@@ -0,0 +1,54 @@
+package pagination_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	c, err := pagination.Decode(pagination.Encode(ts, "abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Time.Equal(ts) || c.UUID != "abc" {
+		t.Fatalf("round trip mismatch: %+v", c)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	if c, err := pagination.Decode(""); err != nil || c != nil {
+		t.Fatalf("empty cursor should be the first page, got %+v %v", c, err)
+	}
+	for _, bad := range []string{"!!!", "bm90LWEtY3Vyc29y", pagination.Encode(time.Now(), "")} {
+		if _, err := pagination.Decode(bad); err != pagination.ErrInvalidCursor {
+			t.Errorf("Decode(%q) = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	cases := map[int32]uint{0: pagination.DefaultLimit, -5: pagination.DefaultLimit, 10: 10, 5000: pagination.MaxLimit}
+	for in, want := range cases {
+		if got := pagination.Limit(in); got != want {
+			t.Errorf("Limit(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	ts := time.Unix(100, 0)
+	key := func(s string) (time.Time, string) { return ts, s }
+
+	items, next := pagination.Page([]string{"a", "b", "c"}, 2, key)
+	if len(items) != 2 || next != pagination.Encode(ts, "b") {
+		t.Fatalf("expected two items and a cursor after b, got %v %q", items, next)
+	}
+
+	items, next = pagination.Page([]string{"a", "b"}, 2, key)
+	if len(items) != 2 || next != "" {
+		t.Fatalf("expected last page without cursor, got %v %q", items, next)
+	}
+}
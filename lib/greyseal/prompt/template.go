@@ -0,0 +1,119 @@
+// Package prompt renders role system prompts. A prompt is a Go text/template
+// template executed with the details of the conversation it is used in; a
+// prompt without actions renders as written.
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ErrInvalidTemplate is returned for prompts that do not parse or that refer
+// to fields Data does not have.
+var ErrInvalidTemplate = errors.New("invalid prompt template")
+
+// Data is what a prompt template can refer to, e.g.
+//
+//	Today is {{.Date}} ({{.TimeZone}}). You are helping {{default "a colleague" .User.name}}
+//	with "{{.Title}}".{{range .Resources}}
+//	- {{.Name}} ({{.Source}}: {{.Path}}){{end}}
+//	Team: {{.Vars.team}}
+//
+// Missing map keys render as empty strings.
+type Data struct {
+	Now              time.Time // in TimeZone
+	Date             string    // Now as 2006-01-02
+	TimeZone         string
+	ConversationUUID string
+	Title            string
+	Resources        []Resource
+	// User holds the caller's profile fields, such as name and email, and
+	// "subject".
+	User map[string]string
+	// Vars holds the conversation's custom variables.
+	Vars map[string]string
+	// Snippets holds the retrieved context. It is only set for a role's
+	// context template.
+	Snippets []Snippet
+}
+
+// Snippet is a retrieved passage, e.g. in a context template:
+//
+//	Answer from these sources:{{range .Snippets}}
+//	[{{.Index}}] {{.Title}}: {{.Text}}{{end}}
+type Snippet struct {
+	Index        int // 1-based rank
+	Title        string
+	Text         string
+	ResourceUUID string
+	Score        float32
+	Flagged      bool // kept by the context sanitizer despite looking like an injection
+}
+
+// Resource is a resource in the conversation's retrieval scope.
+type Resource struct {
+	UUID   string
+	Name   string
+	Source string // website, pdf or text
+	Path   string // URL or path the resource was ingested from
+}
+
+var funcs = template.FuncMap{
+	// default returns value, or def when value is empty.
+	"default": func(def, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+func parse(text string) (*template.Template, error) {
+	tmpl, err := template.New("system_prompt").Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the prompt template with data.
+func Render(text string, data Data) (string, error) {
+	tmpl, err := parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return out.String(), nil
+}
+
+// Validate parses the template and executes it against empty data with one
+// blank resource and snippet, which catches syntax errors, unknown functions
+// and unknown fields.
+func Validate(text string) error {
+	now := time.Now().UTC()
+	_, err := Render(text, Data{
+		Now:       now,
+		Date:      now.Format(time.DateOnly),
+		TimeZone:  "UTC",
+		Resources: []Resource{{}},
+		Snippets:  []Snippet{{Index: 1}},
+		User:      map[string]string{},
+		Vars:      map[string]string{},
+	})
+	return err
+}
+
+// SourceName returns the lower-case name of a resource source enum value
+// such as SOURCE_WEBSITE.
+func SourceName(enum string) string {
+	return strings.ToLower(strings.TrimPrefix(enum, "SOURCE_"))
+}
@@ -0,0 +1,51 @@
+package prompt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data := prompt.Data{
+		Now:       time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC),
+		Date:      "2026-03-04",
+		TimeZone:  "Europe/London",
+		Title:     "Payments outage",
+		Resources: []prompt.Resource{{Name: "Runbook", Source: "website", Path: "https://wiki/runbook"}, {Name: "Notes", Source: "text"}},
+		User:      map[string]string{"subject": "alice"},
+		Vars:      map[string]string{"team": "payments"},
+		Snippets:  []prompt.Snippet{{Index: 1, Title: "Rota", Text: "weekend cover"}},
+	}
+	for name, tc := range map[string]struct{ in, want string }{
+		"static":    {"Be brief.", "Be brief."},
+		"date":      {"Today is {{.Date}} ({{.TimeZone}}), {{.Now.Format \"15:04\"}}.", "Today is 2026-03-04 (Europe/London), 09:30."},
+		"title":     {"Topic: {{.Title}}", "Topic: Payments outage"},
+		"resources": {"{{range .Resources}}[{{.Name}} {{.Source}}]{{end}}", "[Runbook website][Notes text]"},
+		"default":   {"Hi {{default \"there\" .User.name}} ({{.User.subject}})", "Hi there (alice)"},
+		"vars":      {"Team {{upper .Vars.team}}{{.Vars.missing}}", "Team PAYMENTS"},
+		"snippets":  {"{{range .Snippets}}[{{.Index}}] {{.Title}}: {{.Text}}{{end}}", "[1] Rota: weekend cover"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := prompt.Render(tc.in, data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, prompt.Validate("Hello {{.User.name}} on {{.Date}}{{range .Resources}} {{.Name}}{{end}}"))
+	assert.NoError(t, prompt.Validate("{{range .Snippets}}{{.Index}}. {{.Title}} ({{.ResourceUUID}}): {{.Text}}{{end}}"))
+	for name, text := range map[string]string{
+		"syntax":         "Hello {{.User.name",
+		"unknown func":   "{{shout .Title}}",
+		"unknown field":  "{{.Role}}",
+		"resource field": "{{range .Resources}}{{.URL}}{{end}}",
+	} {
+		assert.ErrorIs(t, prompt.Validate(text), prompt.ErrInvalidTemplate, name)
+	}
+}
@@ -0,0 +1,155 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode is how text bound for one destination is redacted.
+type Mode string
+
+const (
+	// ModeOff leaves text unchanged.
+	ModeOff Mode = "off"
+	// ModeMask replaces each value with its detector's placeholder, e.g. [EMAIL].
+	ModeMask Mode = "mask"
+	// ModeToken replaces each value with a numbered token, e.g. [EMAIL_1],
+	// recorded in a Vault so it can be put back. Only the LLM prompt supports it.
+	ModeToken Mode = "token"
+)
+
+// ErrInvalidPolicy is returned for unknown modes, token mode outside the
+// prompt and custom patterns that do not compile.
+var ErrInvalidPolicy = errors.New("invalid redaction policy")
+
+// ParseMode parses off, mask or token. An empty string is off.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "", ModeOff:
+		return ModeOff, nil
+	case ModeMask, ModeToken:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%w: unknown mode %q", ErrInvalidPolicy, s)
+	}
+}
+
+// Destination is somewhere chat content is sent or stored.
+type Destination int
+
+const (
+	// Prompt is the messages sent to the LLM.
+	Prompt Destination = iota
+	// Messages is the message content persisted in the database.
+	Messages
+	// Transcripts covers transcript objects and turn traces.
+	Transcripts
+	// Logs is log messages and string fields.
+	Logs
+)
+
+// Policy sets the mode of each destination. The zero value redacts nothing.
+type Policy struct {
+	Prompt      Mode
+	Messages    Mode
+	Transcripts Mode
+	Logs        Mode
+}
+
+// Pipeline redacts text according to a policy. A nil *Pipeline redacts
+// nothing.
+type Pipeline struct {
+	policy    Policy
+	detectors []Detector
+}
+
+// NewPipeline applies DefaultDetectors followed by the custom detectors.
+func NewPipeline(policy Policy, custom []Detector) (*Pipeline, error) {
+	for _, m := range []Mode{policy.Messages, policy.Transcripts, policy.Logs} {
+		if m == ModeToken {
+			return nil, fmt.Errorf("%w: token mode is only supported for the prompt", ErrInvalidPolicy)
+		}
+	}
+	detectors := make([]Detector, 0, len(DefaultDetectors)+len(custom))
+	detectors = append(detectors, DefaultDetectors...)
+	return &Pipeline{policy: policy, detectors: append(detectors, custom...)}, nil
+}
+
+// Mode returns the mode for a destination.
+func (p *Pipeline) Mode(d Destination) Mode {
+	if p == nil {
+		return ModeOff
+	}
+	var m Mode
+	switch d {
+	case Prompt:
+		m = p.policy.Prompt
+	case Messages:
+		m = p.policy.Messages
+	case Transcripts:
+		m = p.policy.Transcripts
+	case Logs:
+		m = p.policy.Logs
+	}
+	if m == "" {
+		return ModeOff
+	}
+	return m
+}
+
+// Redact applies the destination's mode to text. Token mode records values
+// in the vault; without a vault it masks.
+func (p *Pipeline) Redact(d Destination, text string, vault *Vault) string {
+	switch p.Mode(d) {
+	case ModeMask:
+		return ScrubWith(text, p.detectors)
+	case ModeToken:
+		if vault == nil {
+			return ScrubWith(text, p.detectors)
+		}
+		return vault.tokenize(text, p.detectors)
+	default:
+		return text
+	}
+}
+
+// LoadPatterns reads custom detectors from a YAML file mapping each name to a
+// regular expression:
+//
+//	EMPLOYEE_ID: 'EMP-\d{6}'
+//	TICKET: '\bSEC-\d+\b'
+//
+// Names are upper-cased for the placeholder. Detectors are applied in file
+// order after DefaultDetectors.
+func LoadPatterns(path string) ([]Detector, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPolicy, path, err)
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+	mapping := node.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: %s: expected a map of name to pattern", ErrInvalidPolicy, path)
+	}
+	detectors := make([]Detector, 0, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		name := strings.ToUpper(mapping.Content[i].Value)
+		pattern, err := regexp.Compile(mapping.Content[i+1].Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: pattern %s: %v", ErrInvalidPolicy, name, err)
+		}
+		detectors = append(detectors, Detector{Name: name, Pattern: pattern})
+	}
+	return detectors, nil
+}
@@ -0,0 +1,99 @@
+package redact_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]redact.Mode{"": redact.ModeOff, "off": redact.ModeOff, "Mask": redact.ModeMask, " token ": redact.ModeToken} {
+		got, err := redact.ParseMode(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := redact.ParseMode("hash")
+	assert.ErrorIs(t, err, redact.ErrInvalidPolicy)
+}
+
+func TestNewPipeline_TokenOnlyForPrompt(t *testing.T) {
+	_, err := redact.NewPipeline(redact.Policy{Messages: redact.ModeToken}, nil)
+	assert.ErrorIs(t, err, redact.ErrInvalidPolicy)
+}
+
+func TestPipeline_Destinations(t *testing.T) {
+	p, err := redact.NewPipeline(redact.Policy{Prompt: redact.ModeToken, Messages: redact.ModeMask}, nil)
+	require.NoError(t, err)
+	text := "mail jane@example.com"
+
+	assert.Equal(t, "mail [EMAIL]", p.Redact(redact.Messages, text, nil))
+	assert.Equal(t, text, p.Redact(redact.Transcripts, text, nil))
+	assert.Equal(t, "mail [EMAIL]", p.Redact(redact.Prompt, text, nil), "token mode without a vault masks")
+
+	var nilPipeline *redact.Pipeline
+	assert.Equal(t, text, nilPipeline.Redact(redact.Messages, text, nil))
+}
+
+func TestVault_RoundTrip(t *testing.T) {
+	p, err := redact.NewPipeline(redact.Policy{Prompt: redact.ModeToken}, nil)
+	require.NoError(t, err)
+	vault := redact.NewVault()
+
+	prompt := p.Redact(redact.Prompt, "jane@example.com and bob@example.com, again jane@example.com", vault)
+	assert.Equal(t, "[EMAIL_1] and [EMAIL_2], again [EMAIL_1]", prompt)
+	assert.Equal(t, 2, vault.Len())
+	assert.Equal(t, "[EMAIL_2] is on the team", p.Redact(redact.Prompt, "bob@example.com is on the team", vault))
+
+	assert.Equal(t, "Reply to bob@example.com, not [EMAIL_9].", vault.Restore("Reply to [EMAIL_2], not [EMAIL_9]."))
+}
+
+func TestRestorer_SplitTokens(t *testing.T) {
+	p, err := redact.NewPipeline(redact.Policy{Prompt: redact.ModeToken}, nil)
+	require.NoError(t, err)
+	vault := redact.NewVault()
+	p.Redact(redact.Prompt, "call (555) 123-4567", vault)
+
+	r := vault.NewRestorer()
+	var out string
+	for _, chunk := range []string{"Dial [PH", "ONE", "_1] now [", "see docs] or [PHONE_"} {
+		out += r.Write(chunk)
+	}
+	out += r.Flush()
+	assert.Equal(t, "Dial (555) 123-4567 now [see docs] or [PHONE_", out)
+}
+
+func TestLoadPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("employee_id: 'EMP-\\d{6}'\nTICKET: '\\bSEC-\\d+\\b'\n"), 0o600))
+
+	custom, err := redact.LoadPatterns(path)
+	require.NoError(t, err)
+	p, err := redact.NewPipeline(redact.Policy{Messages: redact.ModeMask}, custom)
+	require.NoError(t, err)
+	assert.Equal(t, "[EMPLOYEE_ID] filed [TICKET] from [EMAIL]", p.Redact(redact.Messages, "EMP-123456 filed SEC-42 from a@b.io", nil))
+
+	require.NoError(t, os.WriteFile(path, []byte("BAD: '(['\n"), 0o600))
+	_, err = redact.LoadPatterns(path)
+	assert.ErrorIs(t, err, redact.ErrInvalidPolicy)
+}
+
+func TestNewCore(t *testing.T) {
+	p, err := redact.NewPipeline(redact.Policy{Logs: redact.ModeMask}, nil)
+	require.NoError(t, err)
+	obs, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(redact.NewCore(obs, p)).With(zap.String("user", "jane@example.com"))
+
+	logger.Info("question from jane@example.com", zap.Error(errors.New("bad key sk-abcdefghijklmnopqrstuvwx")), zap.Int("n", 1))
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "question from [EMAIL]", entry.Message)
+	assert.Equal(t, map[string]any{"user": "[EMAIL]", "error": "bad key [API_KEY]", "n": int64(1)}, entry.ContextMap())
+}
@@ -0,0 +1,90 @@
+// Package redact finds personal data and secrets in free text and replaces
+// them with typed placeholders such as [EMAIL].
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Detector finds one kind of sensitive value.
+type Detector struct {
+	// Name is used in the placeholder, e.g. EMAIL becomes [EMAIL].
+	Name    string
+	Pattern *regexp.Regexp
+	// Valid optionally rejects pattern matches that are not real values, such
+	// as digit runs that fail the credit card checksum.
+	Valid func(match string) bool
+}
+
+// DefaultDetectors covers emails, common API key formats, credit card numbers
+// and phone numbers. Order matters: earlier detectors claim text first, so
+// secrets and card numbers are matched before the looser phone pattern.
+var DefaultDetectors = []Detector{
+	{
+		Name:    "EMAIL",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	},
+	{
+		Name: "API_KEY",
+		Pattern: regexp.MustCompile(`\b(?:` +
+			`sk-(?:proj-|ant-)?[A-Za-z0-9_-]{20,}` + // OpenAI, Anthropic
+			`|gsk_[A-Za-z0-9_-]{16,}` + // grey-seal
+			`|AKIA[0-9A-Z]{16}` + // AWS access key ID
+			`|gh[pousr]_[A-Za-z0-9]{36,}` + // GitHub
+			`|xox[abprs]-[A-Za-z0-9-]{10,}` + // Slack
+			`|AIza[0-9A-Za-z_-]{35}` + // Google
+			`)`),
+	},
+	{
+		Name:    "CREDIT_CARD",
+		Pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Valid:   luhn,
+	},
+	{
+		Name:    "PHONE",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{3}\)|\b\d{3})[\s.-]?\d{3}[\s.-]?\d{4}\b`),
+	},
+}
+
+// Scrub replaces everything found by DefaultDetectors.
+func Scrub(text string) string {
+	return ScrubWith(text, DefaultDetectors)
+}
+
+// ScrubWith replaces every valid match of each detector, in order, with
+// [NAME].
+func ScrubWith(text string, detectors []Detector) string {
+	for _, d := range detectors {
+		placeholder := "[" + d.Name + "]"
+		text = d.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if d.Valid != nil && !d.Valid(match) {
+				return match
+			}
+			return placeholder
+		})
+	}
+	return text
+}
+
+// luhn reports whether the digits in s pass the Luhn checksum.
+func luhn(s string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
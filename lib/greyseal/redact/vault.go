@@ -0,0 +1,112 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxTokenLen bounds how much a Restorer holds back waiting for a token to
+// close.
+const maxTokenLen = 64
+
+var tokenPattern = regexp.MustCompile(`\[[A-Z][A-Z0-9_]*_\d+\]`)
+
+// Vault maps the tokens written by token mode back to the original values.
+// The same value always gets the same token, so the model can refer to it
+// consistently. A Vault is meant to live for a single turn.
+type Vault struct {
+	mu      sync.Mutex
+	tokens  map[string]string // value -> token
+	values  map[string]string // token -> value
+	counter map[string]int
+}
+
+// NewVault returns an empty vault.
+func NewVault() *Vault {
+	return &Vault{
+		tokens:  map[string]string{},
+		values:  map[string]string{},
+		counter: map[string]int{},
+	}
+}
+
+// Len returns the number of values held.
+func (v *Vault) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.values)
+}
+
+func (v *Vault) tokenize(text string, detectors []Detector) string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, d := range detectors {
+		text = d.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if d.Valid != nil && !d.Valid(match) {
+				return match
+			}
+			if token, ok := v.tokens[match]; ok {
+				return token
+			}
+			v.counter[d.Name]++
+			token := fmt.Sprintf("[%s_%d]", d.Name, v.counter[d.Name])
+			v.tokens[match] = token
+			v.values[token] = match
+			return token
+		})
+	}
+	return text
+}
+
+// Restore replaces every known token in text with its original value.
+// Unknown tokens are left as they are.
+func (v *Vault) Restore(text string) string {
+	if v == nil {
+		return text
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.values) == 0 {
+		return text
+	}
+	return tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		if value, ok := v.values[token]; ok {
+			return value
+		}
+		return token
+	})
+}
+
+// Restorer restores tokens in streamed text, where a token may be split
+// across chunks.
+type Restorer struct {
+	vault   *Vault
+	pending string
+}
+
+// NewRestorer returns a Restorer backed by the vault.
+func (v *Vault) NewRestorer() *Restorer {
+	return &Restorer{vault: v}
+}
+
+// Write adds a chunk and returns the restored text that is safe to emit. Text
+// from an unclosed "[" onwards is held back until the next chunk shows whether
+// it is a token.
+func (r *Restorer) Write(chunk string) string {
+	text := r.pending + chunk
+	r.pending = ""
+	if open := strings.LastIndexByte(text, '['); open >= 0 && !strings.Contains(text[open:], "]") && len(text)-open < maxTokenLen {
+		r.pending = text[open:]
+		text = text[:open]
+	}
+	return r.vault.Restore(text)
+}
+
+// Flush returns whatever is still held back.
+func (r *Restorer) Flush() string {
+	text := r.pending
+	r.pending = ""
+	return r.vault.Restore(text)
+}
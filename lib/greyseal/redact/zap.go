@@ -0,0 +1,58 @@
+package redact
+
+import (
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCore wraps a zap core so that log messages and string, error and
+// Stringer fields are redacted with the pipeline's Logs mode. It returns core
+// unchanged when Logs is off.
+func NewCore(core zapcore.Core, p *Pipeline) zapcore.Core {
+	if p.Mode(Logs) == ModeOff {
+		return core
+	}
+	return &redactingCore{Core: core, pipeline: p}
+}
+
+type redactingCore struct {
+	zapcore.Core
+	pipeline *Pipeline
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.fields(fields)), pipeline: c.pipeline}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.pipeline.Redact(Logs, ent.Message, nil)
+	return c.Core.Write(ent, c.fields(fields))
+}
+
+func (c *redactingCore) fields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = c.pipeline.Redact(Logs, f.String, nil)
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok {
+				f = zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: c.pipeline.Redact(Logs, err.Error(), nil)}
+			}
+		case zapcore.StringerType:
+			if s, ok := f.Interface.(fmt.Stringer); ok {
+				f = zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: c.pipeline.Redact(Logs, s.String(), nil)}
+			}
+		}
+		out[i] = f
+	}
+	return out
+}
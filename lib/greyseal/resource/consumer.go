@@ -0,0 +1,155 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/holmes89/archaea/base"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// syncSubject is the principal synced resources are written as.
+const syncSubject = "kafka-sync"
+
+// SyncHandler applies resource definition events published by other
+// services, such as knowledge pushed from another application.
+//
+// A value is a protobuf Resource. It is matched to an existing resource by
+// uuid, or by its service and entity when the uuid is empty, in the
+// resource's workspace (the default workspace when unset). A missing
+// resource is created and indexed; a changed one is updated, and indexed
+// again if its source or path changed; an unchanged one is left alone, so
+// redelivered events are harmless. A record with a key and no value is a
+// tombstone keyed "<workspace_uuid>:<uuid or service/entity>": the resource
+// in that workspace (the default workspace when empty) whose uuid matches,
+// or else every one whose service and entity match, is deleted.
+type SyncHandler struct {
+	repo    base.Repository[*greysealv1.Resource]
+	indexer Indexer // nil-safe; indexing is skipped when nil
+	logger  *zap.Logger
+}
+
+func NewSyncHandler(repo base.Repository[*greysealv1.Resource], indexer Indexer, logger *zap.Logger) *SyncHandler {
+	return &SyncHandler{repo: repo, indexer: indexer, logger: logger}
+}
+
+// Handle returns ErrInvalidEvent for events that can never be applied.
+func (h *SyncHandler) Handle(ctx context.Context, key, value []byte) error {
+	if len(value) == 0 {
+		return h.delete(ctx, string(key))
+	}
+	in := &greysealv1.Resource{}
+	if err := proto.Unmarshal(value, in); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	var filter map[string][]any
+	switch {
+	case in.GetUuid() != "":
+		filter = map[string][]any{"uuid": {in.GetUuid()}}
+	case in.GetService() != "" && in.GetEntity() != "":
+		filter = map[string][]any{"service": {in.GetService()}, "entity": {in.GetEntity()}}
+	default:
+		return fmt.Errorf("%w: resource needs a uuid or a service and entity", ErrInvalidEvent)
+	}
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: syncSubject, Method: "kafka", WorkspaceUUID: in.GetWorkspaceUuid()})
+	matches, err := h.repo.List(ctx, "", 2, filter)
+	if err != nil {
+		return err
+	}
+	switch len(matches) {
+	case 0:
+		if in.Uuid == "" {
+			in.Uuid = uuid.New().String()
+		}
+		if in.CreatedAt == nil {
+			in.CreatedAt = timestamppb.New(time.Now())
+		}
+		in.IndexedAt = timestamppb.New(time.Time{})
+		if err := h.repo.Create(ctx, in); err != nil {
+			return err
+		}
+		h.logger.Info("resource synced", zap.String("uuid", in.GetUuid()), zap.String("action", "create"))
+		h.index(ctx, in)
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("%w: more than one resource is %s/%s", ErrInvalidEvent, in.GetService(), in.GetEntity())
+	}
+
+	current := matches[0]
+	if sameResource(current, in) {
+		h.logger.Debug("resource unchanged", zap.String("uuid", current.GetUuid()))
+		return nil
+	}
+	reindex := current.GetSource() != in.GetSource() || current.GetPath() != in.GetPath()
+	in.Uuid = current.GetUuid()
+	in.CreatedAt = current.GetCreatedAt()
+	in.IndexedAt = current.GetIndexedAt()
+	if reindex {
+		in.IndexedAt = timestamppb.New(time.Time{})
+	}
+	if err := h.repo.Update(ctx, in.GetUuid(), in); err != nil {
+		return err
+	}
+	h.logger.Info("resource synced", zap.String("uuid", in.GetUuid()), zap.String("action", "update"), zap.Bool("reindex", reindex))
+	if reindex {
+		h.index(ctx, in)
+	}
+	return nil
+}
+
+// index is best-effort, as in Ingest: the resource is saved and can be
+// re-indexed later.
+func (h *SyncHandler) index(ctx context.Context, r *greysealv1.Resource) {
+	if h.indexer == nil {
+		return
+	}
+	if err := h.indexer.Index(ctx, r); err != nil {
+		h.logger.Error("failed to index resource", zap.String("uuid", r.GetUuid()), zap.Error(err))
+	}
+}
+
+func (h *SyncHandler) delete(ctx context.Context, key string) error {
+	ws, key, ok := strings.Cut(key, ":")
+	if !ok || key == "" {
+		return fmt.Errorf("%w: tombstone key must be <workspace_uuid>:<uuid or service/entity>", ErrInvalidEvent)
+	}
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: syncSubject, Method: "kafka", WorkspaceUUID: ws})
+	matches, err := h.repo.List(ctx, "", 0, map[string][]any{"uuid": {key}})
+	if err != nil {
+		return err
+	}
+	if service, entity, ok := strings.Cut(key, "/"); len(matches) == 0 && ok {
+		if matches, err = h.repo.List(ctx, "", 0, map[string][]any{"service": {service}, "entity": {entity}}); err != nil {
+			return err
+		}
+	}
+	for _, r := range matches {
+		if err := h.repo.Delete(ctx, r.GetUuid()); err != nil {
+			return err
+		}
+		h.logger.Info("resource synced", zap.String("uuid", r.GetUuid()), zap.String("action", "delete"))
+	}
+	return nil
+}
+
+// sameResource compares the fields an event can set.
+func sameResource(a, b *greysealv1.Resource) bool {
+	return a.GetName() == b.GetName() &&
+		a.GetService() == b.GetService() &&
+		a.GetEntity() == b.GetEntity() &&
+		a.GetSource() == b.GetSource() &&
+		a.GetPath() == b.GetPath() &&
+		a.GetCollection() == b.GetCollection() &&
+		slices.Equal(a.GetTags(), b.GetTags())
+}
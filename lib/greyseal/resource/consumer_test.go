@@ -0,0 +1,153 @@
+package resource_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/resource"
+	"github.com/holmes89/grey-seal/lib/greyseal/resource/mocks"
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SyncHandlerTestSuite struct {
+	suite.Suite
+	repo    *memResourceRepo
+	indexer *mocks.MockIndexer
+	handler *resource.SyncHandler
+}
+
+func (s *SyncHandlerTestSuite) SetupTest() {
+	s.repo = &memResourceRepo{resources: map[string]*v1.Resource{}}
+	s.indexer = mocks.NewMockIndexer(s.T())
+	s.handler = resource.NewSyncHandler(s.repo, s.indexer, zap.NewNop())
+}
+
+func (s *SyncHandlerTestSuite) apply(key string, r *v1.Resource) error {
+	var value []byte
+	if r != nil {
+		var err error
+		value, err = proto.Marshal(r)
+		s.Require().NoError(err)
+	}
+	return s.handler.Handle(context.Background(), []byte(key), value)
+}
+
+func (s *SyncHandlerTestSuite) TestUpsertByServiceAndEntity() {
+	s.indexer.On("Index", mock.Anything, mock.Anything).Return(nil).Once()
+	doc := &v1.Resource{Name: "Runbook", Service: "magpie", Entity: "doc-1", Source: v1.Source_SOURCE_TEXT, Path: "restart it"}
+	s.Require().NoError(s.apply("", doc))
+	s.Require().Len(s.repo.resources, 1)
+	var created *v1.Resource
+	for _, r := range s.repo.resources {
+		created = r
+	}
+	s.NotEmpty(created.GetUuid())
+
+	// Redelivery and metadata-only changes do not index again.
+	s.Require().NoError(s.apply("", doc))
+	s.Equal(0, s.repo.updates)
+	created.IndexedAt = timestamppb.New(time.Unix(100, 0))
+	s.Require().NoError(s.apply("", &v1.Resource{Name: "Runbook", Service: "magpie", Entity: "doc-1", Source: v1.Source_SOURCE_TEXT, Path: "restart it", Tags: []string{"ops"}}))
+	s.Equal(1, s.repo.updates)
+	s.Equal(int64(100), s.repo.resources[created.GetUuid()].GetIndexedAt().GetSeconds())
+
+	// A new path is indexed again.
+	s.indexer.On("Index", mock.Anything, mock.MatchedBy(func(r *v1.Resource) bool { return r.GetPath() == "restart it twice" })).Return(nil).Once()
+	s.Require().NoError(s.apply("", &v1.Resource{Name: "Runbook", Service: "magpie", Entity: "doc-1", Source: v1.Source_SOURCE_TEXT, Path: "restart it twice"}))
+	s.Len(s.repo.resources, 1)
+	s.True(s.repo.resources[created.GetUuid()].GetIndexedAt().AsTime().IsZero())
+}
+
+func (s *SyncHandlerTestSuite) TestTombstoneByNaturalKey() {
+	s.repo.resources["r1"] = &v1.Resource{Uuid: "r1", Service: "magpie", Entity: "doc/1"}
+	s.Require().NoError(s.apply(":magpie/doc/1", nil))
+	s.Empty(s.repo.resources)
+}
+
+func (s *SyncHandlerTestSuite) TestTombstoneStaysInWorkspace() {
+	s.repo.resources["r1"] = &v1.Resource{Uuid: "r1", Service: "magpie", Entity: "doc/1", WorkspaceUuid: "ws-1"}
+	s.repo.resources["r2"] = &v1.Resource{Uuid: "r2", Service: "magpie", Entity: "doc/1", WorkspaceUuid: "ws-2"}
+	s.Require().NoError(s.apply("ws-2:magpie/doc/1", nil))
+	s.Contains(s.repo.resources, "r1")
+	s.NotContains(s.repo.resources, "r2")
+
+	s.ErrorIs(s.apply("magpie/doc/1", nil), resource.ErrInvalidEvent, "tombstone without a workspace")
+}
+
+func (s *SyncHandlerTestSuite) TestInvalidEvents() {
+	s.ErrorIs(s.handler.Handle(context.Background(), nil, []byte("not a protobuf")), resource.ErrInvalidEvent)
+	s.ErrorIs(s.apply("", &v1.Resource{Name: "No key"}), resource.ErrInvalidEvent)
+	s.ErrorIs(s.apply("", nil), resource.ErrInvalidEvent)
+}
+
+func TestSyncHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SyncHandlerTestSuite))
+}
+
+// memResourceRepo keeps resources in memory and supports the List filters
+// the sync handler uses.
+type memResourceRepo struct {
+	resources map[string]*v1.Resource
+	updates   int
+}
+
+func (r *memResourceRepo) Create(_ context.Context, res *v1.Resource) error {
+	r.resources[res.GetUuid()] = proto.Clone(res).(*v1.Resource)
+	return nil
+}
+
+func (r *memResourceRepo) Update(_ context.Context, id string, res *v1.Resource) error {
+	r.updates++
+	r.resources[id] = proto.Clone(res).(*v1.Resource)
+	return nil
+}
+
+func (r *memResourceRepo) Delete(ctx context.Context, id string) error {
+	if res, ok := r.resources[id]; ok && inWorkspace(ctx, res) {
+		delete(r.resources, id)
+	}
+	return nil
+}
+
+func (r *memResourceRepo) Get(_ context.Context, id string) (*v1.Resource, error) {
+	return r.resources[id], nil
+}
+
+func (r *memResourceRepo) List(ctx context.Context, _ string, _ uint, filter map[string][]any) ([]*v1.Resource, error) {
+	var out []*v1.Resource
+	for _, res := range r.resources {
+		if !inWorkspace(ctx, res) {
+			continue
+		}
+		fields := map[string]string{"uuid": res.GetUuid(), "service": res.GetService(), "entity": res.GetEntity()}
+		match := true
+		for k, v := range filter {
+			match = match && fields[k] == v[0]
+		}
+		if match {
+			out = append(out, res)
+		}
+	}
+	return out, nil
+}
+
+// inWorkspace mirrors the repository's workspace scoping: rows without a
+// workspace are in the default one.
+func inWorkspace(ctx context.Context, res *v1.Resource) bool {
+	ws, ok := auth.WorkspaceFromContext(ctx)
+	if !ok {
+		return true
+	}
+	if res.GetWorkspaceUuid() == "" {
+		return ws == workspace.DefaultUUID
+	}
+	return res.GetWorkspaceUuid() == ws
+}
@@ -2,9 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	"connectrpc.com/connect"
 
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	entity "github.com/holmes89/grey-seal/lib/greyseal/resource"
 	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
 	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
@@ -38,9 +40,16 @@ func (h *ResourceHandler) GetResource(ctx context.Context, req *connect.Request[
 }
 
 func (h *ResourceHandler) ListResources(ctx context.Context, req *connect.Request[services.ListResourcesRequest]) (*connect.Response[services.ListResourcesResponse], error) {
-	result, err := h.svc.List(ctx, req.Msg)
+	filter := entity.ListFilter{
+		Source:     req.Msg.Source,
+		Indexed:    req.Msg.Indexed,
+		Service:    req.Msg.GetService(),
+		Collection: req.Msg.GetCollection(),
+		Tag:        req.Msg.GetTag(),
+	}
+	result, err := h.svc.List(ctx, req.Msg, filter)
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.ListResourcesResponse{
 		Data:   result.GetData(),
@@ -55,3 +64,11 @@ func (h *ResourceHandler) DeleteResource(ctx context.Context, req *connect.Reque
 	}
 	return connect.NewResponse(&services.DeleteResourceResponse{}), nil
 }
+
+// connectError maps domain errors onto Connect status codes.
+func connectError(err error) error {
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return err
+}
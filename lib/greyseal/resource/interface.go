@@ -2,19 +2,34 @@ package resource
 
 import (
 	"context"
+	"errors"
 
 	"github.com/holmes89/archaea/base"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
+// ErrInvalidEvent is returned by SyncHandler for events that can never be
+// applied: undecodable payloads and resources with neither a uuid nor a
+// service and entity to match them by.
+var ErrInvalidEvent = errors.New("invalid resource event")
+
 // ResourceService manages resource metadata persistence and triggers indexing.
 type ResourceService interface {
-	List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Resource], error)
+	List(ctx context.Context, lis base.ListRequest, filter ListFilter) (base.ListResponse[*greysealv1.Resource], error)
 	Get(ctx context.Context, get base.GetRequest[*greysealv1.Resource]) (base.GetResponse[*greysealv1.Resource], error)
 	Ingest(ctx context.Context, data *greysealv1.Resource) (*greysealv1.Resource, error)
 	Delete(ctx context.Context, id string) error
 }
 
+// ListFilter narrows List. Zero values leave a filter unset.
+type ListFilter struct {
+	Source     *greysealv1.Source
+	Indexed    *bool
+	Service    string
+	Collection string
+	Tag        string
+}
+
 // Indexer publishes a resource into the encoding pipeline after it is persisted.
 // For SOURCE_TEXT the content is published directly as a TextExtractedEvent.
 // For SOURCE_WEBSITE and SOURCE_PDF the resource is enqueued for async content fetching.
@@ -9,6 +9,7 @@ import (
 	"github.com/holmes89/archaea/base"
 	mock "github.com/stretchr/testify/mock"
 
+	resource "github.com/holmes89/grey-seal/lib/greyseal/resource"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
@@ -17,8 +18,8 @@ type MockResourceService struct {
 	mock.Mock
 }
 
-func (_m *MockResourceService) List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*v1.Resource], error) {
-	ret := _m.Called(ctx, lis)
+func (_m *MockResourceService) List(ctx context.Context, lis base.ListRequest, filter resource.ListFilter) (base.ListResponse[*v1.Resource], error) {
+	ret := _m.Called(ctx, lis, filter)
 	if ret.Get(0) == nil {
 		return nil, ret.Error(1)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -31,21 +32,39 @@ func NewResourceService(
 	}
 }
 
-func (srv *resourceService) List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Resource], error) {
+func (srv *resourceService) List(ctx context.Context, lis base.ListRequest, filter ListFilter) (base.ListResponse[*greysealv1.Resource], error) {
 	srv.logger.Info("listing resources")
-	count := uint(lis.GetCount())
-	if count == 0 {
-		count = 20
+	f := map[string][]any{}
+	if filter.Source != nil {
+		f["source"] = []any{int32(*filter.Source)}
 	}
-	data, err := srv.resourceRepo.List(ctx, lis.GetCursor(), count, nil)
+	if filter.Indexed != nil {
+		f["indexed"] = []any{*filter.Indexed}
+	}
+	if filter.Service != "" {
+		f["service"] = []any{filter.Service}
+	}
+	if filter.Collection != "" {
+		f["collection"] = []any{filter.Collection}
+	}
+	if filter.Tag != "" {
+		f["tag"] = []any{filter.Tag}
+	}
+
+	limit := pagination.Limit(lis.GetCount())
+	data, err := srv.resourceRepo.List(ctx, lis.GetCursor(), limit+1, f)
 	if err != nil {
 		srv.logger.Error("failed to list resources", zap.Error(err))
+		return nil, err
 	}
+	data, cursor := pagination.Page(data, limit, func(r *greysealv1.Resource) (time.Time, string) {
+		return r.GetCreatedAt().AsTime(), r.GetUuid()
+	})
 	return &base.ListGenericResponse[*greysealv1.Resource]{
-		Cursor: "",
+		Cursor: cursor,
 		Count:  int32(len(data)),
 		Data:   data,
-	}, err
+	}, nil
 }
 
 func (srv *resourceService) Get(ctx context.Context, get base.GetRequest[*greysealv1.Resource]) (base.GetResponse[*greysealv1.Resource], error) {
@@ -1,62 +1,128 @@
-//go:build ignore
-
 package role
+
 import (
 	"context"
-	"log"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/holmes89/archaea/base"
-	entitiesv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
-	servicesv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
-type RoleConsumer struct {
-	consumer    base.Consumer[*entitiesv1.Role]
-	roleservice base.Service[*entitiesv1.Role]
-}
+// syncSubject is recorded as the author of role versions written by sync.
+const syncSubject = "kafka-sync"
 
-func NewRoleConsumer(
-	consumer base.Consumer[*entitiesv1.Role],
-	roleservice base.Service[*entitiesv1.Role],
-) {
-	con := &RoleConsumer{
-		consumer:    consumer,
-		roleservice: roleservice,
-	}
-	go con.run()
+// SyncHandler applies role definition events published by other services.
+//
+// A value is a protobuf Role. It is matched to an existing role by uuid, or
+// by name when the uuid is empty, in the role's workspace (the default
+// workspace when unset). A missing role is created, a changed one is updated
+// to a new version and an unchanged one is left alone, so redelivered events
+// are harmless. A record with a key and no value is a tombstone keyed
+// "<workspace_uuid>:<uuid or name>": every role in that workspace (the
+// default workspace when empty) whose uuid, or else name, matches is deleted.
+type SyncHandler struct {
+	repo   RoleRepository
+	logger *zap.Logger
 }
 
-func ConvertProto(data []byte) (*entitiesv1.Role, error) {
-	var msg entitiesv1.Role
-	log.Println("message", string(data))
-	if err := proto.Unmarshal(data, &msg); err != nil {
-		log.Printf("unable to process message: %s\n", err)
-		return nil, err
-	}
-	return &msg, nil
+func NewSyncHandler(repo RoleRepository, logger *zap.Logger) *SyncHandler {
+	return &SyncHandler{repo: repo, logger: logger}
 }
 
-func (c *RoleConsumer) run() {
-	for i := range c.consumer.Read() {
+// Handle returns ErrInvalidEvent for events that can never be applied.
+func (h *SyncHandler) Handle(ctx context.Context, key, value []byte) error {
+	if len(value) == 0 {
+		return h.delete(ctx, string(key))
+	}
+	in := &greysealv1.Role{}
+	if err := proto.Unmarshal(value, in); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	if in.GetName() == "" {
+		return fmt.Errorf("%w: role has no name", ErrInvalidEvent)
+	}
+	if err := validate(in); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
 
-		role := &entitiesv1.Role{
-			Uuid: uuid.New().String(),
-			Name: i.Name,
-			SystemPrompt: i.SystemPrompt,
-			CreatedAt: i.CreatedAt,
-			
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: syncSubject, Method: "kafka", WorkspaceUUID: in.GetWorkspaceUuid()})
+	filter := map[string][]any{"name": {in.GetName()}}
+	if in.GetUuid() != "" {
+		filter = map[string][]any{"uuid": {in.GetUuid()}}
+	}
+	matches, err := h.repo.List(ctx, "", 2, filter)
+	if err != nil {
+		return err
+	}
+	switch len(matches) {
+	case 0:
+		if in.Uuid == "" {
+			in.Uuid = uuid.New().String()
+		}
+		if in.CreatedAt == nil {
+			in.CreatedAt = timestamppb.New(time.Now())
 		}
+		if err := h.repo.Create(ctx, in); err != nil {
+			return err
+		}
+		h.logger.Info("role synced", zap.String("uuid", in.GetUuid()), zap.String("name", in.GetName()), zap.String("action", "create"))
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("%w: more than one role is named %q", ErrInvalidEvent, in.GetName())
+	}
+
+	current := matches[0]
+	if sameDefinition(current, in) {
+		h.logger.Debug("role unchanged", zap.String("uuid", current.GetUuid()))
+		return nil
+	}
+	if err := h.repo.Update(ctx, current.GetUuid(), in); err != nil {
+		return err
+	}
+	h.logger.Info("role synced", zap.String("uuid", current.GetUuid()), zap.String("name", in.GetName()),
+		zap.String("action", "update"), zap.Int32("version", in.GetVersion()))
+	return nil
+}
 
-		_, err := c.roleservice.Create(context.Background(), &servicesv1.CreateRoleRequest{
-			Data: role,
-		})
-		if err != nil {
-			log.Println(err)
-			continue
+func (h *SyncHandler) delete(ctx context.Context, key string) error {
+	ws, key, ok := strings.Cut(key, ":")
+	if !ok || key == "" {
+		return fmt.Errorf("%w: tombstone key must be <workspace_uuid>:<uuid or name>", ErrInvalidEvent)
+	}
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: syncSubject, Method: "kafka", WorkspaceUUID: ws})
+	matches, err := h.repo.List(ctx, "", 0, map[string][]any{"uuid": {key}})
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		if matches, err = h.repo.List(ctx, "", 0, map[string][]any{"name": {key}}); err != nil {
+			return err
+		}
+	}
+	for _, r := range matches {
+		if err := h.repo.Delete(ctx, r.GetUuid()); err != nil {
+			return err
 		}
-		log.Printf("role %s was imported\n", i.Uuid)
+		h.logger.Info("role synced", zap.String("uuid", r.GetUuid()), zap.String("name", r.GetName()), zap.String("action", "delete"))
 	}
+	return nil
 }
 
+// sameDefinition reports whether two roles have the same manifest definition,
+// so an update would only write an identical version.
+func sameDefinition(a, b *greysealv1.Role) bool {
+	x, err := marshalDefinition(DefinitionOf(a))
+	if err != nil {
+		return false
+	}
+	y, err := marshalDefinition(DefinitionOf(b))
+	return err == nil && x == y
+}
@@ -0,0 +1,105 @@
+package role_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
+	"github.com/holmes89/grey-seal/lib/greyseal/role/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type SyncHandlerTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockRoleRepository
+	handler *role.SyncHandler
+}
+
+func (s *SyncHandlerTestSuite) SetupTest() {
+	s.repo = mocks.NewMockRoleRepository(s.T())
+	s.handler = role.NewSyncHandler(s.repo, zap.NewNop())
+}
+
+func (s *SyncHandlerTestSuite) event(r *v1.Role) []byte {
+	data, err := proto.Marshal(r)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *SyncHandlerTestSuite) TestCreatesByNameInWorkspace() {
+	s.repo.On("List", mock.MatchedBy(func(ctx context.Context) bool {
+		ws, _ := auth.WorkspaceFromContext(ctx)
+		return ws == "ws-1"
+	}), "", uint(2), map[string][]any{"name": {"support"}}).Return(nil, nil)
+	s.repo.On("Create", mock.Anything, mock.MatchedBy(func(r *v1.Role) bool {
+		return r.GetUuid() != "" && r.GetCreatedAt() != nil && r.GetSystemPrompt() == "Be kind."
+	})).Return(nil)
+
+	err := s.handler.Handle(context.Background(), []byte("support"), s.event(&v1.Role{Name: "support", SystemPrompt: "Be kind.", WorkspaceUuid: "ws-1"}))
+	s.Require().NoError(err)
+}
+
+func (s *SyncHandlerTestSuite) TestUpdatesByUUIDOnlyWhenChanged() {
+	current := &v1.Role{Uuid: "r1", Name: "support", SystemPrompt: "Be kind.", Version: 3}
+	s.repo.On("List", mock.Anything, "", uint(2), map[string][]any{"uuid": {"r1"}}).Return([]*v1.Role{current}, nil)
+
+	// A redelivered event writes nothing.
+	s.Require().NoError(s.handler.Handle(context.Background(), nil, s.event(&v1.Role{Uuid: "r1", Name: "support", SystemPrompt: "Be kind."})))
+
+	s.repo.On("Update", mock.Anything, "r1", mock.MatchedBy(func(r *v1.Role) bool {
+		return r.GetSystemPrompt() == "Be brief."
+	})).Return(nil).Once()
+	s.Require().NoError(s.handler.Handle(context.Background(), nil, s.event(&v1.Role{Uuid: "r1", Name: "support", SystemPrompt: "Be brief."})))
+}
+
+func (s *SyncHandlerTestSuite) TestInvalidEvents() {
+	s.repo.On("List", mock.Anything, "", uint(2), map[string][]any{"name": {"twin"}}).
+		Return([]*v1.Role{{Uuid: "r1", Name: "twin"}, {Uuid: "r2", Name: "twin"}}, nil)
+
+	for name, value := range map[string][]byte{
+		"undecodable":    []byte("not a protobuf"),
+		"no name":        s.event(&v1.Role{SystemPrompt: "hi"}),
+		"bad template":   s.event(&v1.Role{Name: "coder", SystemPrompt: "{{"}),
+		"bad retrieval":  s.event(&v1.Role{Name: "coder", Retrieval: &v1.RoleRetrieval{Limit: role.MaxRetrievalLimit + 1}}),
+		"ambiguous name": s.event(&v1.Role{Name: "twin"}),
+	} {
+		err := s.handler.Handle(context.Background(), nil, value)
+		s.ErrorIs(err, role.ErrInvalidEvent, name)
+	}
+	s.ErrorIs(s.handler.Handle(context.Background(), nil, nil), role.ErrInvalidEvent, "tombstone without a key")
+	s.ErrorIs(s.handler.Handle(context.Background(), []byte("support"), nil), role.ErrInvalidEvent, "tombstone without a workspace")
+}
+
+func (s *SyncHandlerTestSuite) TestTombstone() {
+	s.repo.On("List", mock.Anything, "", uint(0), map[string][]any{"uuid": {"r1"}}).Return([]*v1.Role{{Uuid: "r1", Name: "support"}}, nil)
+	s.repo.On("Delete", mock.Anything, "r1").Return(nil)
+	s.Require().NoError(s.handler.Handle(context.Background(), []byte(":r1"), nil))
+
+	// Deleting a role that is already gone is a no-op.
+	s.repo.On("List", mock.Anything, "", uint(0), map[string][]any{"uuid": {"gone"}}).Return(nil, nil)
+	s.repo.On("List", mock.Anything, "", uint(0), map[string][]any{"name": {"gone"}}).Return(nil, nil)
+	s.Require().NoError(s.handler.Handle(context.Background(), []byte(":gone"), nil))
+}
+
+func (s *SyncHandlerTestSuite) TestTombstoneStaysInWorkspace() {
+	inWorkspace := func(want string) any {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			ws, ok := auth.WorkspaceFromContext(ctx)
+			return ok && ws == want
+		})
+	}
+	s.repo.On("List", inWorkspace("ws-2"), "", uint(0), map[string][]any{"uuid": {"support"}}).Return(nil, nil)
+	s.repo.On("List", inWorkspace("ws-2"), "", uint(0), map[string][]any{"name": {"support"}}).Return([]*v1.Role{{Uuid: "r2", Name: "support"}}, nil)
+	s.repo.On("Delete", inWorkspace("ws-2"), "r2").Return(nil)
+
+	s.Require().NoError(s.handler.Handle(context.Background(), []byte("ws-2:support"), nil))
+}
+
+func TestSyncHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SyncHandlerTestSuite))
+}
@@ -0,0 +1,118 @@
+package role
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// unifiedDiff returns a unified diff of two texts, line by line, or an empty
+// string when they are equal. Prompts are short, so a plain LCS table is fine.
+func unifiedDiff(fromLabel, toLabel, a, b string) string {
+	if a == b {
+		return ""
+	}
+	x, y := splitLines(a), splitLines(b)
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, diffLine{' ', x[i]})
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', x[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', y[j]})
+			j++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromLabel, toLabel)
+	for start := 0; start < len(lines); {
+		// Find the next change and the end of its hunk.
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for k := first; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				last = k
+			} else if k-last > 2*diffContext {
+				break
+			}
+		}
+		lo, hi := max(first-diffContext, start), min(last+diffContext+1, len(lines))
+		writeHunk(&out, lines, lo, hi)
+		start = hi
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, lines []diffLine, lo, hi int) {
+	fromLine, toLine := 1, 1
+	for _, l := range lines[:lo] {
+		if l.op != '+' {
+			fromLine++
+		}
+		if l.op != '-' {
+			toLine++
+		}
+	}
+	var fromCount, toCount int
+	for _, l := range lines[lo:hi] {
+		if l.op != '+' {
+			fromCount++
+		}
+		if l.op != '-' {
+			toCount++
+		}
+	}
+	// An empty range is numbered from the line before it, as diff(1) does.
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+	for _, l := range lines[lo:hi] {
+		out.WriteByte(l.op)
+		out.WriteString(l.text)
+		out.WriteByte('\n')
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
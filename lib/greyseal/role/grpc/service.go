@@ -2,10 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"connectrpc.com/connect"
 
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
 	entity "github.com/holmes89/grey-seal/lib/greyseal/role"
 	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
 	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
@@ -23,7 +26,7 @@ func NewRoleHandler(svc entity.RoleService) *RoleHandler {
 func (h *RoleHandler) CreateRole(ctx context.Context, req *connect.Request[services.CreateRoleRequest]) (*connect.Response[services.CreateRoleResponse], error) {
 	result, err := h.svc.Create(ctx, req.Msg)
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.CreateRoleResponse{Data: result.GetData()}), nil
 }
@@ -40,7 +43,7 @@ func (h *RoleHandler) ListRoles(ctx context.Context, req *connect.Request[servic
 	result, err := h.svc.List(ctx, req.Msg)
 	if err != nil {
 		log.Printf("error listing roles: %v", err)
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.ListRolesResponse{
 		Data:   result.GetData(),
@@ -52,7 +55,7 @@ func (h *RoleHandler) ListRoles(ctx context.Context, req *connect.Request[servic
 func (h *RoleHandler) UpdateRole(ctx context.Context, req *connect.Request[services.UpdateRoleRequest]) (*connect.Response[services.UpdateRoleResponse], error) {
 	result, err := h.svc.Update(ctx, req.Msg.GetUuid(), req.Msg.GetData())
 	if err != nil {
-		return nil, err
+		return nil, connectError(err)
 	}
 	return connect.NewResponse(&services.UpdateRoleResponse{Data: result}), nil
 }
@@ -63,3 +66,46 @@ func (h *RoleHandler) DeleteRole(ctx context.Context, req *connect.Request[servi
 	}
 	return connect.NewResponse(&services.DeleteRoleResponse{}), nil
 }
+
+func (h *RoleHandler) ListRoleVersions(ctx context.Context, req *connect.Request[services.ListRoleVersionsRequest]) (*connect.Response[services.ListRoleVersionsResponse], error) {
+	versions, err := h.svc.ListVersions(ctx, req.Msg.GetRoleUuid())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ListRoleVersionsResponse{Data: versions}), nil
+}
+
+func (h *RoleHandler) GetRoleVersion(ctx context.Context, req *connect.Request[services.GetRoleVersionRequest]) (*connect.Response[services.GetRoleVersionResponse], error) {
+	version, err := h.svc.GetVersion(ctx, req.Msg.GetRoleUuid(), req.Msg.GetVersion())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.GetRoleVersionResponse{Data: version}), nil
+}
+
+func (h *RoleHandler) DiffRoleVersions(ctx context.Context, req *connect.Request[services.DiffRoleVersionsRequest]) (*connect.Response[services.DiffRoleVersionsResponse], error) {
+	diff, err := h.svc.Diff(ctx, req.Msg.GetRoleUuid(), req.Msg.GetFromVersion(), req.Msg.GetToVersion())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.DiffRoleVersionsResponse{From: diff.From, To: diff.To, Diff: diff.Diff}), nil
+}
+
+func (h *RoleHandler) RollbackRole(ctx context.Context, req *connect.Request[services.RollbackRoleRequest]) (*connect.Response[services.RollbackRoleResponse], error) {
+	result, err := h.svc.Rollback(ctx, req.Msg.GetRoleUuid(), req.Msg.GetVersion())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.RollbackRoleResponse{Data: result}), nil
+}
+
+// connectError maps domain errors onto Connect status codes.
+func connectError(err error) error {
+	if errors.Is(err, pagination.ErrInvalidCursor) || errors.Is(err, prompt.ErrInvalidTemplate) || errors.Is(err, entity.ErrInvalidRetrieval) || errors.Is(err, entity.ErrInvalidGuardrails) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, entity.ErrVersionNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return err
+}
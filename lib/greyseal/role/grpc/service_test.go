@@ -3,10 +3,13 @@ package grpc_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"connectrpc.com/connect"
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	grpchandler "github.com/holmes89/grey-seal/lib/greyseal/role/grpc"
 	"github.com/holmes89/grey-seal/lib/greyseal/role/mocks"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
@@ -58,6 +61,22 @@ func (s *RoleGRPCHandlerTestSuite) TestCreateRole() {
 	s.Equal("r3", resp.Msg.GetData().GetUuid())
 }
 
+func (s *RoleGRPCHandlerTestSuite) TestCreateRole_InvalidTemplate() {
+	s.svc.On("Create", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: unclosed action", prompt.ErrInvalidTemplate))
+
+	req := connect.NewRequest(&services.CreateRoleRequest{Data: &v1.Role{Name: "Coder", SystemPrompt: "{{"}})
+	_, err := s.handler.CreateRole(context.Background(), req)
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (s *RoleGRPCHandlerTestSuite) TestCreateRole_InvalidRetrieval() {
+	s.svc.On("Create", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: limit must be between 0 and 50", role.ErrInvalidRetrieval))
+
+	req := connect.NewRequest(&services.CreateRoleRequest{Data: &v1.Role{Name: "Coder", Retrieval: &v1.RoleRetrieval{Limit: 99}}})
+	_, err := s.handler.CreateRole(context.Background(), req)
+	s.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
 func (s *RoleGRPCHandlerTestSuite) TestUpdateRole() {
 	updated := &v1.Role{Uuid: "r4", Name: "Updated"}
 	s.svc.On("Update", mock.Anything, "r4", mock.Anything).Return(updated, nil)
@@ -84,6 +103,44 @@ func (s *RoleGRPCHandlerTestSuite) TestDeleteRole_Error() {
 	s.Require().Error(err)
 }
 
+func (s *RoleGRPCHandlerTestSuite) TestListRoleVersions() {
+	versions := []*v1.RoleVersion{{RoleUuid: "r1", Version: 2}, {RoleUuid: "r1", Version: 1}}
+	s.svc.On("ListVersions", mock.Anything, "r1").Return(versions, nil)
+
+	req := connect.NewRequest(&services.ListRoleVersionsRequest{RoleUuid: "r1"})
+	resp, err := s.handler.ListRoleVersions(context.Background(), req)
+	s.Require().NoError(err)
+	s.Len(resp.Msg.GetData(), 2)
+}
+
+func (s *RoleGRPCHandlerTestSuite) TestGetRoleVersion_NotFound() {
+	s.svc.On("GetVersion", mock.Anything, "r1", int32(7)).Return(nil, role.ErrVersionNotFound)
+
+	req := connect.NewRequest(&services.GetRoleVersionRequest{RoleUuid: "r1", Version: 7})
+	_, err := s.handler.GetRoleVersion(context.Background(), req)
+	s.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (s *RoleGRPCHandlerTestSuite) TestDiffRoleVersions_DefaultsToLatest() {
+	diff := &role.VersionDiff{From: &v1.RoleVersion{Version: 1}, To: &v1.RoleVersion{Version: 3}, Diff: "-a\n+b\n"}
+	s.svc.On("Diff", mock.Anything, "r1", int32(1), int32(0)).Return(diff, nil)
+
+	req := connect.NewRequest(&services.DiffRoleVersionsRequest{RoleUuid: "r1", FromVersion: 1})
+	resp, err := s.handler.DiffRoleVersions(context.Background(), req)
+	s.Require().NoError(err)
+	s.Equal(int32(3), resp.Msg.GetTo().GetVersion())
+	s.Equal("-a\n+b\n", resp.Msg.GetDiff())
+}
+
+func (s *RoleGRPCHandlerTestSuite) TestRollbackRole() {
+	s.svc.On("Rollback", mock.Anything, "r1", int32(1)).Return(&v1.Role{Uuid: "r1", Name: "Original"}, nil)
+
+	req := connect.NewRequest(&services.RollbackRoleRequest{RoleUuid: "r1", Version: 1})
+	resp, err := s.handler.RollbackRole(context.Background(), req)
+	s.Require().NoError(err)
+	s.Equal("Original", resp.Msg.GetData().GetName())
+}
+
 func TestRoleGRPCHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(RoleGRPCHandlerTestSuite))
 }
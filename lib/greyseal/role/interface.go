@@ -2,15 +2,51 @@ package role
 
 import (
 	"context"
+	"errors"
 
 	"github.com/holmes89/archaea/base"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
+// ErrVersionNotFound is returned for a role version that does not exist.
+var ErrVersionNotFound = errors.New("role version not found")
+
+// ErrInvalidRetrieval is returned for a retrieval limit outside
+// 0..MaxRetrievalLimit or a negative minimum score.
+var ErrInvalidRetrieval = errors.New("invalid role retrieval settings")
+
+// ErrInvalidGuardrails is returned for a blocked topic that is not a valid
+// regular expression or a negative max_input_chars.
+var ErrInvalidGuardrails = errors.New("invalid role guardrails")
+
+// ErrInvalidEvent is returned by SyncHandler for events that can never be
+// applied: undecodable payloads, roles without a name or failing validation,
+// and names shared by several roles.
+var ErrInvalidEvent = errors.New("invalid role event")
+
+// MaxRetrievalLimit caps how many snippets a role may retrieve per turn.
+const MaxRetrievalLimit = 50
+
 type RoleService interface {
 	List(con context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Role], error)
 	Get(con context.Context, get base.GetRequest[*greysealv1.Role]) (base.GetResponse[*greysealv1.Role], error)
 	Create(con context.Context, cre base.CreateRequest[*greysealv1.Role]) (base.CreateResponse[*greysealv1.Role], error)
+	// Update writes the role's next version.
 	Update(con context.Context, id string, data *greysealv1.Role) (*greysealv1.Role, error)
 	Delete(con context.Context, id string) error
+	ListVersions(ctx context.Context, roleUUID string) ([]*greysealv1.RoleVersion, error)
+	GetVersion(ctx context.Context, roleUUID string, version int32) (*greysealv1.RoleVersion, error)
+	// Diff compares two versions; a to of 0 means the latest version.
+	Diff(ctx context.Context, roleUUID string, from, to int32) (*VersionDiff, error)
+	// Rollback writes a new version with the name and system prompt of an
+	// earlier one and returns the role at that new version.
+	Rollback(ctx context.Context, roleUUID string, version int32) (*greysealv1.Role, error)
+}
+
+// VersionDiff is the difference between two versions of a role.
+type VersionDiff struct {
+	From, To *greysealv1.RoleVersion
+	// Diff is a unified diff of the system prompts, preceded by the name
+	// change if any.
+	Diff string
 }
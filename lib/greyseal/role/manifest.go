@@ -0,0 +1,350 @@
+package role
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidManifest is returned for role manifests that cannot be applied.
+var ErrInvalidManifest = errors.New("invalid role manifest")
+
+// Manifest is a set of role definitions kept outside the server, usually in
+// git. Roles are matched to the server's by name, so names must be unique.
+type Manifest struct {
+	Roles []Definition `yaml:"roles"`
+}
+
+// Definition is a role as written in a manifest. Server-assigned fields
+// (uuid, version, workspace) are left out so a manifest can be applied to
+// any deployment.
+type Definition struct {
+	Name                 string      `yaml:"name"`
+	SystemPrompt         string      `yaml:"system_prompt,omitempty"`
+	Examples             []Example   `yaml:"examples,omitempty"`
+	ContextTemplate      string      `yaml:"context_template,omitempty"`
+	NoContextInstruction string      `yaml:"no_context_instruction,omitempty"`
+	Retrieval            *Retrieval  `yaml:"retrieval,omitempty"`
+	Guardrails           *Guardrails `yaml:"guardrails,omitempty"`
+}
+
+// Example is a few-shot exchange.
+type Example struct {
+	User      string `yaml:"user"`
+	Assistant string `yaml:"assistant"`
+}
+
+// Retrieval is a role's retrieval scope and defaults.
+type Retrieval struct {
+	ResourceUUIDs []string `yaml:"resource_uuids,omitempty"`
+	Collections   []string `yaml:"collections,omitempty"`
+	Tags          []string `yaml:"tags,omitempty"`
+	// ScopePolicy is "narrow" (the default when empty) or "widen".
+	ScopePolicy string  `yaml:"scope_policy,omitempty"`
+	Limit       int32   `yaml:"limit,omitempty"`
+	MinScore    float32 `yaml:"min_score,omitempty"`
+}
+
+// Guardrails are a role's policy checks.
+type Guardrails struct {
+	BlockedTopics  []string `yaml:"blocked_topics,omitempty"`
+	MaxInputChars  int32    `yaml:"max_input_chars,omitempty"`
+	RequireContext bool     `yaml:"require_context,omitempty"`
+	Disclaimer     string   `yaml:"disclaimer,omitempty"`
+	JudgePolicy    string   `yaml:"judge_policy,omitempty"`
+	RefusalMessage string   `yaml:"refusal_message,omitempty"`
+}
+
+var scopePolicies = map[string]greysealv1.ScopePolicy{
+	"":       greysealv1.ScopePolicy_SCOPE_POLICY_UNSPECIFIED,
+	"narrow": greysealv1.ScopePolicy_SCOPE_POLICY_NARROW,
+	"widen":  greysealv1.ScopePolicy_SCOPE_POLICY_WIDEN,
+}
+
+// LoadManifest reads role definitions from a YAML file or from every .yaml
+// and .yml file under a directory, in lexical order. A YAML document holds
+// either a single role or a list under "roles", and a file may hold several
+// documents.
+func LoadManifest(path string) (*Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := strings.ToLower(filepath.Ext(p)); !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	m := &Manifest{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		roles, err := parseManifest(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		m.Roles = append(m.Roles, roles...)
+	}
+	return m, m.validate()
+}
+
+// ParseManifest reads role definitions from YAML content.
+func ParseManifest(content []byte) (*Manifest, error) {
+	roles, err := parseManifest(content)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{Roles: roles}
+	return m, m.validate()
+}
+
+func parseManifest(content []byte) ([]Definition, error) {
+	var roles []Definition
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc struct {
+			Roles      []Definition `yaml:"roles"`
+			Definition `yaml:",inline"`
+		}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return roles, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+		}
+		if len(doc.Roles) > 0 && doc.Name != "" {
+			return nil, fmt.Errorf("%w: a document holds either one role or a roles list", ErrInvalidManifest)
+		}
+		if doc.Name != "" {
+			roles = append(roles, doc.Definition)
+		}
+		roles = append(roles, doc.Roles...)
+	}
+}
+
+// validate requires a unique name on every role and a known scope policy.
+// Templates are validated by the server.
+func (m *Manifest) validate() error {
+	if len(m.Roles) == 0 {
+		return fmt.Errorf("%w: no roles", ErrInvalidManifest)
+	}
+	seen := make(map[string]bool, len(m.Roles))
+	for i, d := range m.Roles {
+		if strings.TrimSpace(d.Name) == "" {
+			return fmt.Errorf("%w: role %d needs a name", ErrInvalidManifest, i+1)
+		}
+		if seen[d.Name] {
+			return fmt.Errorf("%w: duplicate role name %q", ErrInvalidManifest, d.Name)
+		}
+		seen[d.Name] = true
+		if d.Retrieval != nil {
+			if _, ok := scopePolicies[d.Retrieval.ScopePolicy]; !ok {
+				return fmt.Errorf("%w: role %q: scope_policy must be narrow or widen", ErrInvalidManifest, d.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Marshal writes the manifest as YAML; multi-line prompts use block style.
+func (m *Manifest) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Role returns the role the definition describes.
+func (d Definition) Role() *greysealv1.Role {
+	r := &greysealv1.Role{
+		Name:                 d.Name,
+		SystemPrompt:         d.SystemPrompt,
+		ContextTemplate:      d.ContextTemplate,
+		NoContextInstruction: d.NoContextInstruction,
+	}
+	for _, e := range d.Examples {
+		r.Examples = append(r.Examples, &greysealv1.RoleExample{User: e.User, Assistant: e.Assistant})
+	}
+	if rt := d.Retrieval; rt != nil {
+		r.Retrieval = &greysealv1.RoleRetrieval{
+			ResourceUuids: rt.ResourceUUIDs,
+			Collections:   rt.Collections,
+			Tags:          rt.Tags,
+			ScopePolicy:   scopePolicies[rt.ScopePolicy],
+			Limit:         rt.Limit,
+			MinScore:      rt.MinScore,
+		}
+	}
+	if g := d.Guardrails; g != nil {
+		r.Guardrails = &greysealv1.RoleGuardrails{
+			BlockedTopics:  g.BlockedTopics,
+			MaxInputChars:  g.MaxInputChars,
+			RequireContext: g.RequireContext,
+			Disclaimer:     g.Disclaimer,
+			JudgePolicy:    g.JudgePolicy,
+			RefusalMessage: g.RefusalMessage,
+		}
+	}
+	return r
+}
+
+// DefinitionOf returns the manifest definition of a role.
+func DefinitionOf(r *greysealv1.Role) Definition {
+	d := Definition{
+		Name:                 r.GetName(),
+		SystemPrompt:         r.GetSystemPrompt(),
+		ContextTemplate:      r.GetContextTemplate(),
+		NoContextInstruction: r.GetNoContextInstruction(),
+	}
+	for _, e := range r.GetExamples() {
+		d.Examples = append(d.Examples, Example{User: e.GetUser(), Assistant: e.GetAssistant()})
+	}
+	if rt := r.GetRetrieval(); rt != nil {
+		d.Retrieval = &Retrieval{
+			ResourceUUIDs: rt.GetResourceUuids(),
+			Collections:   rt.GetCollections(),
+			Tags:          rt.GetTags(),
+			Limit:         rt.GetLimit(),
+			MinScore:      rt.GetMinScore(),
+		}
+		for name, policy := range scopePolicies {
+			if policy == rt.GetScopePolicy() && policy != greysealv1.ScopePolicy_SCOPE_POLICY_UNSPECIFIED {
+				d.Retrieval.ScopePolicy = name
+			}
+		}
+		if proto.Size(rt) == 0 {
+			d.Retrieval = nil
+		}
+	}
+	if g := r.GetGuardrails(); g != nil && proto.Size(g) > 0 {
+		d.Guardrails = &Guardrails{
+			BlockedTopics:  g.GetBlockedTopics(),
+			MaxInputChars:  g.GetMaxInputChars(),
+			RequireContext: g.GetRequireContext(),
+			Disclaimer:     g.GetDisclaimer(),
+			JudgePolicy:    g.GetJudgePolicy(),
+			RefusalMessage: g.GetRefusalMessage(),
+		}
+	}
+	return d
+}
+
+// Export returns a manifest of the roles, sorted by name.
+func Export(roles []*greysealv1.Role) *Manifest {
+	m := &Manifest{}
+	for _, r := range roles {
+		m.Roles = append(m.Roles, DefinitionOf(r))
+	}
+	slices.SortFunc(m.Roles, func(a, b Definition) int { return strings.Compare(a.Name, b.Name) })
+	return m
+}
+
+// Action is what applying a manifest does to one role.
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// Change is one step of a plan.
+type Change struct {
+	Action Action
+	Name   string
+	// UUID is the server's role; empty for creates.
+	UUID string
+	// Role is the role to create or update to.
+	Role *greysealv1.Role
+	// Diff is a unified diff of the server's definition and the manifest's,
+	// for updates.
+	Diff string
+}
+
+// Plan compares a manifest with the server's roles. Roles in the manifest
+// are created or updated in manifest order; with prune, server roles missing
+// from the manifest are deleted after them. Two server roles with a name the
+// manifest uses cannot be told apart, so they are an error.
+func Plan(m *Manifest, existing []*greysealv1.Role, prune bool) ([]Change, error) {
+	byName := make(map[string]*greysealv1.Role, len(existing))
+	for _, r := range existing {
+		if _, ok := byName[r.GetName()]; ok && m.has(r.GetName()) {
+			return nil, fmt.Errorf("%w: more than one role on the server is named %q", ErrInvalidManifest, r.GetName())
+		}
+		byName[r.GetName()] = r
+	}
+
+	var changes []Change
+	for _, d := range m.Roles {
+		want := d.Role()
+		current, ok := byName[d.Name]
+		if !ok {
+			changes = append(changes, Change{Action: ActionCreate, Name: d.Name, Role: want})
+			continue
+		}
+		from, err := marshalDefinition(DefinitionOf(current))
+		if err != nil {
+			return nil, err
+		}
+		to, err := marshalDefinition(DefinitionOf(want))
+		if err != nil {
+			return nil, err
+		}
+		change := Change{Action: ActionUnchanged, Name: d.Name, UUID: current.GetUuid(), Role: want}
+		if from != to {
+			change.Action = ActionUpdate
+			change.Diff = unifiedDiff(d.Name+" (server)", d.Name+" (manifest)", from, to)
+		}
+		changes = append(changes, change)
+	}
+	if prune {
+		for _, r := range existing {
+			if !m.has(r.GetName()) {
+				changes = append(changes, Change{Action: ActionDelete, Name: r.GetName(), UUID: r.GetUuid()})
+			}
+		}
+	}
+	return changes, nil
+}
+
+func (m *Manifest) has(name string) bool {
+	return slices.ContainsFunc(m.Roles, func(d Definition) bool { return d.Name == name })
+}
+
+// marshalDefinition writes a definition the same way whatever produced it, so
+// equal roles compare equal.
+func marshalDefinition(d Definition) (string, error) {
+	out, err := yaml.Marshal(d)
+	return string(out), err
+}
@@ -0,0 +1,134 @@
+package role_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+type ManifestTestSuite struct {
+	suite.Suite
+}
+
+func (s *ManifestTestSuite) TestLoadManifest_Directory() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`roles:
+  - name: support
+    system_prompt: |
+      Be kind.
+    retrieval:
+      collections: [handbook]
+      scope_policy: widen
+      limit: 3
+    guardrails:
+      blocked_topics: ['\bsalar(y|ies)\b']
+      require_context: true
+  - name: triage
+`), 0o600))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "b.yml"), []byte(`name: coder
+examples:
+  - user: hi
+    assistant: hello
+---
+name: writer
+`), 0o600))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a role"), 0o600))
+
+	m, err := role.LoadManifest(dir)
+	s.Require().NoError(err)
+	s.Require().Len(m.Roles, 4)
+	s.Equal([]string{"support", "triage", "coder", "writer"}, []string{m.Roles[0].Name, m.Roles[1].Name, m.Roles[2].Name, m.Roles[3].Name})
+
+	support := m.Roles[0].Role()
+	s.Equal("Be kind.\n", support.GetSystemPrompt())
+	s.Equal(v1.ScopePolicy_SCOPE_POLICY_WIDEN, support.GetRetrieval().GetScopePolicy())
+	s.Equal(int32(3), support.GetRetrieval().GetLimit())
+	s.Equal([]string{`\bsalar(y|ies)\b`}, support.GetGuardrails().GetBlockedTopics())
+	s.True(support.GetGuardrails().GetRequireContext())
+	s.Equal("hello", m.Roles[2].Role().GetExamples()[0].GetAssistant())
+}
+
+func (s *ManifestTestSuite) TestParseManifest_Invalid() {
+	for name, content := range map[string]string{
+		"empty":          "",
+		"missing name":   "roles:\n  - system_prompt: hi\n",
+		"duplicate name": "roles:\n  - name: a\n  - name: a\n",
+		"unknown policy": "name: a\nretrieval:\n  scope_policy: everything\n",
+		"mixed document": "name: a\nroles:\n  - name: b\n",
+	} {
+		_, err := role.ParseManifest([]byte(content))
+		s.ErrorIs(err, role.ErrInvalidManifest, name)
+	}
+}
+
+func (s *ManifestTestSuite) TestPlan() {
+	m, err := role.ParseManifest([]byte(`roles:
+  - name: new
+    system_prompt: Hello.
+  - name: changed
+    system_prompt: After.
+  - name: same
+    system_prompt: Same.
+    retrieval: {}
+`))
+	s.Require().NoError(err)
+	existing := []*v1.Role{
+		{Uuid: "r1", Name: "changed", SystemPrompt: "Before."},
+		{Uuid: "r2", Name: "same", SystemPrompt: "Same."},
+		{Uuid: "r3", Name: "stale"},
+	}
+
+	changes, err := role.Plan(m, existing, false)
+	s.Require().NoError(err)
+	s.Require().Len(changes, 3)
+	s.Equal(role.ActionCreate, changes[0].Action)
+	s.Equal("Hello.", changes[0].Role.GetSystemPrompt())
+	s.Equal(role.ActionUpdate, changes[1].Action)
+	s.Equal("r1", changes[1].UUID)
+	s.Contains(changes[1].Diff, "-system_prompt: Before.\n+system_prompt: After.\n")
+	s.Equal(role.ActionUnchanged, changes[2].Action)
+
+	changes, err = role.Plan(m, existing, true)
+	s.Require().NoError(err)
+	s.Require().Len(changes, 4)
+	s.Equal(role.Change{Action: role.ActionDelete, Name: "stale", UUID: "r3"}, changes[3])
+}
+
+func (s *ManifestTestSuite) TestPlan_AmbiguousName() {
+	m, err := role.ParseManifest([]byte("name: twin\n"))
+	s.Require().NoError(err)
+
+	_, err = role.Plan(m, []*v1.Role{{Uuid: "r1", Name: "twin"}, {Uuid: "r2", Name: "twin"}}, false)
+	s.ErrorIs(err, role.ErrInvalidManifest)
+}
+
+func (s *ManifestTestSuite) TestExport_RoundTrips() {
+	roles := []*v1.Role{
+		{Uuid: "r2", Name: "writer", SystemPrompt: "Line one.\nLine two.\n", Retrieval: &v1.RoleRetrieval{
+			Tags: []string{"style"}, ScopePolicy: v1.ScopePolicy_SCOPE_POLICY_NARROW, MinScore: 0.5,
+		}},
+		{Uuid: "r1", Name: "coder", Examples: []*v1.RoleExample{{User: "hi", Assistant: "hello"}},
+			Guardrails: &v1.RoleGuardrails{MaxInputChars: 2000, Disclaimer: "Not legal advice."}},
+	}
+
+	out, err := role.Export(roles).Marshal()
+	s.Require().NoError(err)
+	s.Contains(string(out), "system_prompt: |\n")
+	m, err := role.ParseManifest(out)
+	s.Require().NoError(err)
+	s.Equal("coder", m.Roles[0].Name)
+
+	changes, err := role.Plan(m, roles, true)
+	s.Require().NoError(err)
+	for _, c := range changes {
+		s.Equal(role.ActionUnchanged, c.Action, c.Name)
+	}
+}
+
+func TestManifestTestSuite(t *testing.T) {
+	suite.Run(t, new(ManifestTestSuite))
+}
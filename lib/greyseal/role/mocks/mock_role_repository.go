@@ -11,7 +11,7 @@ import (
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
-// MockRoleRepository is a mock type for the base.Repository[*Role] interface.
+// MockRoleRepository is a mock type for the RoleRepository interface.
 type MockRoleRepository struct {
 	mock.Mock
 }
@@ -47,6 +47,22 @@ func (_m *MockRoleRepository) List(ctx context.Context, cursor string, limit uin
 	return ret.Get(0).([]*v1.Role), ret.Error(1)
 }
 
+func (_m *MockRoleRepository) ListVersions(ctx context.Context, roleUUID string) ([]*v1.RoleVersion, error) {
+	ret := _m.Called(ctx, roleUUID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]*v1.RoleVersion), ret.Error(1)
+}
+
+func (_m *MockRoleRepository) GetVersion(ctx context.Context, roleUUID string, version int32) (*v1.RoleVersion, error) {
+	ret := _m.Called(ctx, roleUUID, version)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.RoleVersion), ret.Error(1)
+}
+
 func NewMockRoleRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
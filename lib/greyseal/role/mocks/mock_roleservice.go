@@ -9,6 +9,7 @@ import (
 	"github.com/holmes89/archaea/base"
 	mock "github.com/stretchr/testify/mock"
 
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
@@ -54,6 +55,38 @@ func (_m *MockRoleService) Delete(ctx context.Context, id string) error {
 	return ret.Error(0)
 }
 
+func (_m *MockRoleService) ListVersions(ctx context.Context, roleUUID string) ([]*v1.RoleVersion, error) {
+	ret := _m.Called(ctx, roleUUID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]*v1.RoleVersion), ret.Error(1)
+}
+
+func (_m *MockRoleService) GetVersion(ctx context.Context, roleUUID string, version int32) (*v1.RoleVersion, error) {
+	ret := _m.Called(ctx, roleUUID, version)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.RoleVersion), ret.Error(1)
+}
+
+func (_m *MockRoleService) Diff(ctx context.Context, roleUUID string, from, to int32) (*role.VersionDiff, error) {
+	ret := _m.Called(ctx, roleUUID, from, to)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*role.VersionDiff), ret.Error(1)
+}
+
+func (_m *MockRoleService) Rollback(ctx context.Context, roleUUID string, version int32) (*v1.Role, error) {
+	ret := _m.Called(ctx, roleUUID, version)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*v1.Role), ret.Error(1)
+}
+
 func NewMockRoleService(t interface {
 	mock.TestingT
 	Cleanup(func())
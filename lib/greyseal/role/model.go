@@ -13,6 +13,10 @@ type RoleRepository interface {
 	Delete(context.Context, string) error
 	Get(context.Context, string) (*greysealv1.Role, error)
 	List(context.Context, string, uint, map[string][]any) ([]*greysealv1.Role, error)
+	// ListVersions returns every version of a role, newest first.
+	ListVersions(ctx context.Context, roleUUID string) ([]*greysealv1.RoleVersion, error)
+	// GetVersion returns ErrVersionNotFound for unknown versions.
+	GetVersion(ctx context.Context, roleUUID string, version int32) (*greysealv1.RoleVersion, error)
 }
 
 var _ base.Entity = (*greysealv1.Role)(nil)
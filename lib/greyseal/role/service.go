@@ -2,22 +2,30 @@ package role
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 )
 
 var _ RoleService = (*roleService)(nil)
 
 type roleService struct {
-	roleRepo base.Repository[*greysealv1.Role]
+	roleRepo RoleRepository
 	logger   *zap.Logger
 }
 
 func NewRoleService(
-	roleRepo base.Repository[*greysealv1.Role],
+	roleRepo RoleRepository,
 	logger *zap.Logger,
 ) RoleService {
 	return &roleService{
@@ -28,15 +36,20 @@ func NewRoleService(
 
 func (srv *roleService) List(con context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Role], error) {
 	srv.logger.Info("listing roles")
-	data, err := srv.roleRepo.List(con, lis.GetCursor(), uint(lis.GetCount()), nil)
+	limit := pagination.Limit(lis.GetCount())
+	data, err := srv.roleRepo.List(con, lis.GetCursor(), limit+1, nil)
 	if err != nil {
 		srv.logger.Error("failed to list roles", zap.Error(err))
+		return nil, err
 	}
+	data, cursor := pagination.Page(data, limit, func(r *greysealv1.Role) (time.Time, string) {
+		return r.GetCreatedAt().AsTime(), r.GetUuid()
+	})
 	return &base.ListGenericResponse[*greysealv1.Role]{
-		Cursor: "",
-		Count:  10,
+		Cursor: cursor,
+		Count:  int32(len(data)),
 		Data:   data,
-	}, err
+	}, nil
 }
 
 func (srv *roleService) Get(con context.Context, get base.GetRequest[*greysealv1.Role]) (base.GetResponse[*greysealv1.Role], error) {
@@ -52,7 +65,17 @@ func (srv *roleService) Get(con context.Context, get base.GetRequest[*greysealv1
 
 func (srv *roleService) Create(con context.Context, cre base.CreateRequest[*greysealv1.Role]) (base.CreateResponse[*greysealv1.Role], error) {
 	srv.logger.Info("creating role", zap.String("name", cre.GetData().GetName()))
-	err := srv.roleRepo.Create(con, cre.GetData())
+	if err := validate(cre.GetData()); err != nil {
+		return nil, err
+	}
+	data := cre.GetData()
+	if data.Uuid == "" {
+		data.Uuid = uuid.New().String()
+	}
+	if data.CreatedAt == nil {
+		data.CreatedAt = timestamppb.New(time.Now())
+	}
+	err := srv.roleRepo.Create(con, data)
 	if err != nil {
 		srv.logger.Error("failed to create role", zap.Error(err))
 		return nil, err
@@ -65,6 +88,9 @@ func (srv *roleService) Create(con context.Context, cre base.CreateRequest[*grey
 
 func (srv *roleService) Update(con context.Context, id string, data *greysealv1.Role) (*greysealv1.Role, error) {
 	srv.logger.Info("updating role", zap.String("uuid", id))
+	if err := validate(data); err != nil {
+		return nil, err
+	}
 	err := srv.roleRepo.Update(con, id, data)
 	if err != nil {
 		srv.logger.Error("failed to update role", zap.String("uuid", id), zap.Error(err))
@@ -73,6 +99,32 @@ func (srv *roleService) Update(con context.Context, id string, data *greysealv1.
 	return data, nil
 }
 
+// validate checks the role's templates (the system prompt, the context
+// template and the no-context instruction), its retrieval defaults and its
+// guardrails.
+func validate(r *greysealv1.Role) error {
+	for _, text := range []string{r.GetSystemPrompt(), r.GetContextTemplate(), r.GetNoContextInstruction()} {
+		if err := prompt.Validate(text); err != nil {
+			return err
+		}
+	}
+	if limit := r.GetRetrieval().GetLimit(); limit < 0 || limit > MaxRetrievalLimit {
+		return fmt.Errorf("%w: limit %d", ErrInvalidRetrieval, limit)
+	}
+	if r.GetRetrieval().GetMinScore() < 0 {
+		return fmt.Errorf("%w: negative min_score", ErrInvalidRetrieval)
+	}
+	for _, topic := range r.GetGuardrails().GetBlockedTopics() {
+		if _, err := regexp.Compile("(?i)" + topic); err != nil {
+			return fmt.Errorf("%w: blocked topic %q: %v", ErrInvalidGuardrails, topic, err)
+		}
+	}
+	if r.GetGuardrails().GetMaxInputChars() < 0 {
+		return fmt.Errorf("%w: negative max_input_chars", ErrInvalidGuardrails)
+	}
+	return nil
+}
+
 func (srv *roleService) Delete(con context.Context, id string) error {
 	srv.logger.Info("deleting role", zap.String("uuid", id))
 	err := srv.roleRepo.Delete(con, id)
@@ -81,3 +133,139 @@ func (srv *roleService) Delete(con context.Context, id string) error {
 	}
 	return err
 }
+
+func (srv *roleService) ListVersions(ctx context.Context, roleUUID string) ([]*greysealv1.RoleVersion, error) {
+	srv.logger.Info("listing role versions", zap.String("uuid", roleUUID))
+	versions, err := srv.roleRepo.ListVersions(ctx, roleUUID)
+	if err != nil {
+		srv.logger.Error("failed to list role versions", zap.String("uuid", roleUUID), zap.Error(err))
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (srv *roleService) GetVersion(ctx context.Context, roleUUID string, version int32) (*greysealv1.RoleVersion, error) {
+	return srv.roleRepo.GetVersion(ctx, roleUUID, version)
+}
+
+func (srv *roleService) Diff(ctx context.Context, roleUUID string, from, to int32) (*VersionDiff, error) {
+	if to == 0 {
+		latest, err := srv.roleRepo.Get(ctx, roleUUID)
+		if err != nil {
+			return nil, err
+		}
+		to = latest.GetVersion()
+	}
+	fromVersion, err := srv.roleRepo.GetVersion(ctx, roleUUID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := srv.roleRepo.GetVersion(ctx, roleUUID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff strings.Builder
+	if fromVersion.GetName() != toVersion.GetName() {
+		fmt.Fprintf(&diff, "name: %q -> %q\n", fromVersion.GetName(), toVersion.GetName())
+	}
+	diff.WriteString(unifiedDiff(
+		fmt.Sprintf("v%d", from), fmt.Sprintf("v%d", to),
+		fromVersion.GetSystemPrompt(), toVersion.GetSystemPrompt(),
+	))
+	// The other instructions follow, labelled with the field they come from.
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{"examples", examplesText(fromVersion.GetExamples()), examplesText(toVersion.GetExamples())},
+		{"context_template", fromVersion.GetContextTemplate(), toVersion.GetContextTemplate()},
+		{"no_context_instruction", fromVersion.GetNoContextInstruction(), toVersion.GetNoContextInstruction()},
+		{"retrieval", retrievalText(fromVersion.GetRetrieval()), retrievalText(toVersion.GetRetrieval())},
+		{"guardrails", guardrailsText(fromVersion.GetGuardrails()), guardrailsText(toVersion.GetGuardrails())},
+	} {
+		diff.WriteString(unifiedDiff(
+			fmt.Sprintf("v%d %s", from, field.name), fmt.Sprintf("v%d %s", to, field.name),
+			field.from, field.to,
+		))
+	}
+	return &VersionDiff{From: fromVersion, To: toVersion, Diff: diff.String()}, nil
+}
+
+func (srv *roleService) Rollback(ctx context.Context, roleUUID string, version int32) (*greysealv1.Role, error) {
+	srv.logger.Info("rolling back role", zap.String("uuid", roleUUID), zap.Int32("version", version))
+	old, err := srv.roleRepo.GetVersion(ctx, roleUUID, version)
+	if err != nil {
+		return nil, err
+	}
+	return srv.Update(ctx, roleUUID, &greysealv1.Role{
+		Uuid:                 roleUUID,
+		Name:                 old.GetName(),
+		SystemPrompt:         old.GetSystemPrompt(),
+		Examples:             old.GetExamples(),
+		ContextTemplate:      old.GetContextTemplate(),
+		NoContextInstruction: old.GetNoContextInstruction(),
+		Retrieval:            old.GetRetrieval(),
+		Guardrails:           old.GetGuardrails(),
+	})
+}
+
+// retrievalText writes the set retrieval settings one per line for diffing.
+func retrievalText(r *greysealv1.RoleRetrieval) string {
+	var b strings.Builder
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{"resource_uuids", r.GetResourceUuids()},
+		{"collections", r.GetCollections()},
+		{"tags", r.GetTags()},
+	} {
+		if len(list.values) > 0 {
+			fmt.Fprintf(&b, "%s: %s\n", list.name, strings.Join(list.values, ", "))
+		}
+	}
+	if r.GetScopePolicy() != greysealv1.ScopePolicy_SCOPE_POLICY_UNSPECIFIED {
+		fmt.Fprintf(&b, "scope_policy: %s\n", r.GetScopePolicy())
+	}
+	if r.GetLimit() != 0 {
+		fmt.Fprintf(&b, "limit: %d\n", r.GetLimit())
+	}
+	if r.GetMinScore() != 0 {
+		fmt.Fprintf(&b, "min_score: %g\n", r.GetMinScore())
+	}
+	return b.String()
+}
+
+// examplesText writes examples one line per message for diffing.
+func examplesText(examples []*greysealv1.RoleExample) string {
+	var b strings.Builder
+	for _, e := range examples {
+		fmt.Fprintf(&b, "user: %s\nassistant: %s\n", e.GetUser(), e.GetAssistant())
+	}
+	return b.String()
+}
+
+// guardrailsText writes the set guardrails one per line for diffing.
+func guardrailsText(g *greysealv1.RoleGuardrails) string {
+	var b strings.Builder
+	for _, topic := range g.GetBlockedTopics() {
+		fmt.Fprintf(&b, "blocked_topic: %s\n", topic)
+	}
+	if g.GetMaxInputChars() != 0 {
+		fmt.Fprintf(&b, "max_input_chars: %d\n", g.GetMaxInputChars())
+	}
+	if g.GetRequireContext() {
+		b.WriteString("require_context: true\n")
+	}
+	for _, field := range []struct{ name, value string }{
+		{"disclaimer", g.GetDisclaimer()},
+		{"judge_policy", g.GetJudgePolicy()},
+		{"refusal_message", g.GetRefusalMessage()},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", field.name, field.value)
+		}
+	}
+	return b.String()
+}
@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/prompt"
 	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	"github.com/holmes89/grey-seal/lib/greyseal/role/mocks"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
@@ -27,14 +28,22 @@ func (s *RoleServiceTestSuite) SetupTest() {
 
 func (s *RoleServiceTestSuite) TestList() {
 	roles := []*v1.Role{{Uuid: "r1", Name: "Assistant"}}
-	s.repo.On("List", mock.Anything, "", uint(10), mock.Anything).Return(roles, nil)
+	s.repo.On("List", mock.Anything, "", uint(11), mock.Anything).Return(roles, nil)
 
 	resp, err := s.svc.List(context.Background(), &fakeListReq{count: 10})
 	s.Require().NoError(err)
 	s.Len(resp.GetData(), 1)
+	s.Equal(int32(1), resp.GetCount())
 	s.Equal("r1", resp.GetData()[0].GetUuid())
 }
 
+func (s *RoleServiceTestSuite) TestList_ClampsLimit() {
+	s.repo.On("List", mock.Anything, "", uint(201), mock.Anything).Return([]*v1.Role{}, nil)
+
+	_, err := s.svc.List(context.Background(), &fakeListReq{count: 5000})
+	s.Require().NoError(err)
+}
+
 func (s *RoleServiceTestSuite) TestGet() {
 	r := &v1.Role{Uuid: "r2", Name: "Writer"}
 	s.repo.On("Get", mock.Anything, "r2").Return(r, nil)
@@ -53,6 +62,49 @@ func (s *RoleServiceTestSuite) TestCreate() {
 	s.Equal("r3", resp.GetData().GetUuid())
 }
 
+func (s *RoleServiceTestSuite) TestCreate_AssignsUUID() {
+	s.repo.On("Create", mock.Anything, mock.MatchedBy(func(r *v1.Role) bool {
+		return r.GetUuid() != "" && r.GetCreatedAt() != nil
+	})).Return(nil).Twice()
+
+	first, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: &v1.Role{Name: "Coder"}})
+	s.Require().NoError(err)
+	second, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: &v1.Role{Name: "Writer"}})
+	s.Require().NoError(err)
+	s.NotEqual(first.GetData().GetUuid(), second.GetData().GetUuid())
+}
+
+func (s *RoleServiceTestSuite) TestCreate_InvalidTemplate() {
+	r := &v1.Role{Uuid: "r3", Name: "Coder", SystemPrompt: "Help {{.User.name"}
+
+	_, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: r})
+	s.ErrorIs(err, prompt.ErrInvalidTemplate)
+}
+
+func (s *RoleServiceTestSuite) TestCreate_InvalidContextTemplate() {
+	r := &v1.Role{Uuid: "r3", Name: "Coder", ContextTemplate: "{{range .Snippets}}{{.Body}}{{end}}"}
+
+	_, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: r})
+	s.ErrorIs(err, prompt.ErrInvalidTemplate)
+}
+
+func (s *RoleServiceTestSuite) TestCreate_InvalidRetrieval() {
+	r := &v1.Role{Uuid: "r3", Name: "Coder", Retrieval: &v1.RoleRetrieval{Limit: role.MaxRetrievalLimit + 1}}
+
+	_, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: r})
+	s.ErrorIs(err, role.ErrInvalidRetrieval)
+}
+
+func (s *RoleServiceTestSuite) TestCreate_InvalidGuardrails() {
+	for _, g := range []*v1.RoleGuardrails{
+		{BlockedTopics: []string{"salar(y"}},
+		{MaxInputChars: -1},
+	} {
+		_, err := s.svc.Create(context.Background(), &fakeCreateRoleReq{data: &v1.Role{Uuid: "r3", Name: "Coder", Guardrails: g}})
+		s.ErrorIs(err, role.ErrInvalidGuardrails)
+	}
+}
+
 func (s *RoleServiceTestSuite) TestUpdate() {
 	r := &v1.Role{Uuid: "r4", Name: "Updated"}
 	s.repo.On("Update", mock.Anything, "r4", r).Return(nil)
@@ -62,6 +114,11 @@ func (s *RoleServiceTestSuite) TestUpdate() {
 	s.Equal("Updated", result.GetName())
 }
 
+func (s *RoleServiceTestSuite) TestUpdate_UnknownField() {
+	_, err := s.svc.Update(context.Background(), "r4", &v1.Role{Uuid: "r4", SystemPrompt: "Team {{.Team}}"})
+	s.ErrorIs(err, prompt.ErrInvalidTemplate)
+}
+
 func (s *RoleServiceTestSuite) TestDelete() {
 	s.repo.On("Delete", mock.Anything, "r5").Return(nil)
 
@@ -69,6 +126,92 @@ func (s *RoleServiceTestSuite) TestDelete() {
 	s.Require().NoError(err)
 }
 
+func (s *RoleServiceTestSuite) TestDiff() {
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(1)).Return(&v1.RoleVersion{
+		RoleUuid: "r5", Version: 1, Name: "Helper", SystemPrompt: "Be brief.\nAnswer in English.",
+	}, nil)
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(2)).Return(&v1.RoleVersion{
+		RoleUuid: "r5", Version: 2, Name: "Support", SystemPrompt: "Be brief.\nAnswer in French.",
+	}, nil)
+
+	diff, err := s.svc.Diff(context.Background(), "r5", 1, 2)
+	s.Require().NoError(err)
+	s.Equal(int32(1), diff.From.GetVersion())
+	s.Equal(int32(2), diff.To.GetVersion())
+	s.Equal(`name: "Helper" -> "Support"
+--- v1
++++ v2
+@@ -1,2 +1,2 @@
+ Be brief.
+-Answer in English.
++Answer in French.
+`, diff.Diff)
+}
+
+func (s *RoleServiceTestSuite) TestDiff_Instructions() {
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(1)).Return(&v1.RoleVersion{
+		Version: 1, SystemPrompt: "same",
+		Examples: []*v1.RoleExample{{User: "hi", Assistant: "hello"}},
+	}, nil)
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(2)).Return(&v1.RoleVersion{
+		Version: 2, SystemPrompt: "same",
+		Examples:             []*v1.RoleExample{{User: "hi", Assistant: "hey"}},
+		NoContextInstruction: "Say you don't know.",
+		Guardrails:           &v1.RoleGuardrails{RequireContext: true},
+	}, nil)
+
+	diff, err := s.svc.Diff(context.Background(), "r5", 1, 2)
+	s.Require().NoError(err)
+	s.Equal(`--- v1 examples
++++ v2 examples
+@@ -1,2 +1,2 @@
+ user: hi
+-assistant: hello
++assistant: hey
+--- v1 no_context_instruction
++++ v2 no_context_instruction
+@@ -0,0 +1,1 @@
++Say you don't know.
+--- v1 guardrails
++++ v2 guardrails
+@@ -0,0 +1,1 @@
++require_context: true
+`, diff.Diff)
+}
+
+func (s *RoleServiceTestSuite) TestDiff_ToLatest() {
+	s.repo.On("Get", mock.Anything, "r5").Return(&v1.Role{Uuid: "r5", Version: 3}, nil)
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(1)).Return(&v1.RoleVersion{Version: 1, SystemPrompt: "same"}, nil)
+	s.repo.On("GetVersion", mock.Anything, "r5", int32(3)).Return(&v1.RoleVersion{Version: 3, SystemPrompt: "same"}, nil)
+
+	diff, err := s.svc.Diff(context.Background(), "r5", 1, 0)
+	s.Require().NoError(err)
+	s.Equal(int32(3), diff.To.GetVersion())
+	s.Empty(diff.Diff)
+}
+
+func (s *RoleServiceTestSuite) TestRollback() {
+	s.repo.On("GetVersion", mock.Anything, "r6", int32(1)).Return(&v1.RoleVersion{
+		RoleUuid: "r6", Version: 1, Name: "Original", SystemPrompt: "Be helpful.",
+		Examples: []*v1.RoleExample{{User: "hi", Assistant: "hello"}}, ContextTemplate: "Sources: {{len .Snippets}}",
+	}, nil)
+	s.repo.On("Update", mock.Anything, "r6", mock.MatchedBy(func(r *v1.Role) bool {
+		return r.GetName() == "Original" && r.GetSystemPrompt() == "Be helpful." &&
+			len(r.GetExamples()) == 1 && r.GetContextTemplate() == "Sources: {{len .Snippets}}"
+	})).Return(nil)
+
+	result, err := s.svc.Rollback(context.Background(), "r6", 1)
+	s.Require().NoError(err)
+	s.Equal("Original", result.GetName())
+}
+
+func (s *RoleServiceTestSuite) TestRollback_UnknownVersion() {
+	s.repo.On("GetVersion", mock.Anything, "r6", int32(9)).Return(nil, role.ErrVersionNotFound)
+
+	_, err := s.svc.Rollback(context.Background(), "r6", 9)
+	s.ErrorIs(err, role.ErrVersionNotFound)
+}
+
 func TestRoleServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(RoleServiceTestSuite))
 }
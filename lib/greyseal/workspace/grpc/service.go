@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"connectrpc.com/connect"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	entity "github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	"github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services/servicesconnect"
+)
+
+type WorkspaceHandler struct {
+	servicesconnect.UnimplementedWorkspaceServiceHandler
+	svc entity.WorkspaceService
+}
+
+func NewWorkspaceHandler(svc entity.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{svc: svc}
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(ctx context.Context, req *connect.Request[services.CreateWorkspaceRequest]) (*connect.Response[services.CreateWorkspaceResponse], error) {
+	result, err := h.svc.Create(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.CreateWorkspaceResponse{Data: result.GetData()}), nil
+}
+
+func (h *WorkspaceHandler) GetWorkspace(ctx context.Context, req *connect.Request[services.GetWorkspaceRequest]) (*connect.Response[services.GetWorkspaceResponse], error) {
+	result, err := h.svc.Get(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.GetWorkspaceResponse{Data: result.GetData()}), nil
+}
+
+func (h *WorkspaceHandler) ListWorkspaces(ctx context.Context, req *connect.Request[services.ListWorkspacesRequest]) (*connect.Response[services.ListWorkspacesResponse], error) {
+	result, err := h.svc.List(ctx, req.Msg)
+	if err != nil {
+		log.Printf("error listing workspaces: %v", err)
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.ListWorkspacesResponse{
+		Data:   result.GetData(),
+		Cursor: result.GetCursor(),
+		Count:  result.GetCount(),
+	}), nil
+}
+
+func (h *WorkspaceHandler) UpdateWorkspace(ctx context.Context, req *connect.Request[services.UpdateWorkspaceRequest]) (*connect.Response[services.UpdateWorkspaceResponse], error) {
+	result, err := h.svc.Update(ctx, req.Msg.GetUuid(), req.Msg.GetData())
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.UpdateWorkspaceResponse{Data: result}), nil
+}
+
+func (h *WorkspaceHandler) DeleteWorkspace(ctx context.Context, req *connect.Request[services.DeleteWorkspaceRequest]) (*connect.Response[services.DeleteWorkspaceResponse], error) {
+	if err := h.svc.Delete(ctx, req.Msg.GetUuid()); err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&services.DeleteWorkspaceResponse{}), nil
+}
+
+// connectError maps domain errors onto Connect status codes.
+func connectError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrNameRequired), errors.Is(err, pagination.ErrInvalidCursor):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, entity.ErrDefaultWorkspace), errors.Is(err, entity.ErrWorkspaceNotEmpty):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/holmes89/archaea/base"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+// DefaultUUID is the workspace that pre-existing data, and principals without an
+// explicit workspace, belong to. It is seeded by migration and cannot be deleted.
+const DefaultUUID = "00000000-0000-0000-0000-000000000000"
+
+var (
+	ErrNameRequired      = errors.New("workspace name is required")
+	ErrDefaultWorkspace  = errors.New("the default workspace cannot be deleted")
+	ErrWorkspaceNotEmpty = errors.New("workspace still has roles, resources or conversations")
+)
+
+type WorkspaceService interface {
+	List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Workspace], error)
+	Get(ctx context.Context, get base.GetRequest[*greysealv1.Workspace]) (base.GetResponse[*greysealv1.Workspace], error)
+	Create(ctx context.Context, cre base.CreateRequest[*greysealv1.Workspace]) (base.CreateResponse[*greysealv1.Workspace], error)
+	Update(ctx context.Context, id string, data *greysealv1.Workspace) (*greysealv1.Workspace, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type WorkspaceRepository interface {
+	Create(context.Context, *greysealv1.Workspace) error
+	Update(context.Context, string, *greysealv1.Workspace) error
+	Delete(context.Context, string) error
+	Get(context.Context, string) (*greysealv1.Workspace, error)
+	List(context.Context, string, uint, map[string][]any) ([]*greysealv1.Workspace, error)
+	// InUse reports whether any role, resource or conversation still belongs to the workspace.
+	InUse(ctx context.Context, id string) (bool, error)
+}
+
+var _ base.Entity = (*greysealv1.Workspace)(nil)
+var _ base.Repository[*greysealv1.Workspace] = (WorkspaceRepository)(nil)
+
+// SearchTag returns the shrike tag that marks indexed content as belonging to
+// workspace id. Resources are tagged on ingestion and searches filter on it.
+func SearchTag(id string) string {
+	if id == "" {
+		id = DefaultUUID
+	}
+	return "workspace:" + id
+}
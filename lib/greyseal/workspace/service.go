@@ -0,0 +1,112 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+)
+
+var _ WorkspaceService = (*workspaceService)(nil)
+
+type workspaceService struct {
+	workspaceRepo WorkspaceRepository
+	logger        *zap.Logger
+}
+
+func NewWorkspaceService(
+	workspaceRepo WorkspaceRepository,
+	logger *zap.Logger,
+) WorkspaceService {
+	return &workspaceService{
+		workspaceRepo: workspaceRepo,
+		logger:        logger,
+	}
+}
+
+func (srv *workspaceService) List(ctx context.Context, lis base.ListRequest) (base.ListResponse[*greysealv1.Workspace], error) {
+	srv.logger.Info("listing workspaces")
+	limit := pagination.Limit(lis.GetCount())
+	data, err := srv.workspaceRepo.List(ctx, lis.GetCursor(), limit+1, nil)
+	if err != nil {
+		srv.logger.Error("failed to list workspaces", zap.Error(err))
+		return nil, err
+	}
+	data, cursor := pagination.Page(data, limit, func(w *greysealv1.Workspace) (time.Time, string) {
+		return w.GetCreatedAt().AsTime(), w.GetUuid()
+	})
+	return &base.ListGenericResponse[*greysealv1.Workspace]{
+		Cursor: cursor,
+		Count:  int32(len(data)),
+		Data:   data,
+	}, nil
+}
+
+func (srv *workspaceService) Get(ctx context.Context, get base.GetRequest[*greysealv1.Workspace]) (base.GetResponse[*greysealv1.Workspace], error) {
+	srv.logger.Info("getting workspace", zap.String("uuid", get.GetUuid()))
+	data, err := srv.workspaceRepo.Get(ctx, get.GetUuid())
+	if err != nil {
+		srv.logger.Error("failed to get workspace", zap.String("uuid", get.GetUuid()), zap.Error(err))
+	}
+	return &base.GetGenericResponse[*greysealv1.Workspace]{Data: data}, err
+}
+
+func (srv *workspaceService) Create(ctx context.Context, cre base.CreateRequest[*greysealv1.Workspace]) (base.CreateResponse[*greysealv1.Workspace], error) {
+	data := cre.GetData()
+	if data.GetName() == "" {
+		return nil, ErrNameRequired
+	}
+	if data.Uuid == "" {
+		data.Uuid = uuid.New().String()
+	}
+	data.CreatedAt = timestamppb.New(time.Now())
+
+	srv.logger.Info("creating workspace", zap.String("name", data.GetName()))
+	if err := srv.workspaceRepo.Create(ctx, data); err != nil {
+		srv.logger.Error("failed to create workspace", zap.Error(err))
+		return nil, err
+	}
+	srv.logger.Info("workspace created", zap.String("uuid", data.Uuid))
+	return &base.CreateGenericResponse[*greysealv1.Workspace]{Data: data}, nil
+}
+
+func (srv *workspaceService) Update(ctx context.Context, id string, data *greysealv1.Workspace) (*greysealv1.Workspace, error) {
+	srv.logger.Info("updating workspace", zap.String("uuid", id))
+	if data.GetName() == "" {
+		return nil, ErrNameRequired
+	}
+	if err := srv.workspaceRepo.Update(ctx, id, data); err != nil {
+		srv.logger.Error("failed to update workspace", zap.String("uuid", id), zap.Error(err))
+		return nil, err
+	}
+	data.Uuid = id
+	return data, nil
+}
+
+// Delete removes an empty workspace. Workspaces that still own data are refused
+// rather than cascaded so one team's history is never dropped by accident.
+func (srv *workspaceService) Delete(ctx context.Context, id string) error {
+	srv.logger.Info("deleting workspace", zap.String("uuid", id))
+	if id == DefaultUUID {
+		return ErrDefaultWorkspace
+	}
+	inUse, err := srv.workspaceRepo.InUse(ctx, id)
+	if err != nil {
+		srv.logger.Error("failed to check workspace usage", zap.String("uuid", id), zap.Error(err))
+		return err
+	}
+	if inUse {
+		return ErrWorkspaceNotEmpty
+	}
+	err = srv.workspaceRepo.Delete(ctx, id)
+	if err != nil {
+		srv.logger.Error("failed to delete workspace", zap.String("uuid", id), zap.Error(err))
+	}
+	return err
+}
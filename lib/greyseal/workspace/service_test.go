@@ -0,0 +1,68 @@
+package workspace_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace/mocks"
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspaceServiceTestSuite struct {
+	suite.Suite
+	repo *mocks.MockWorkspaceRepository
+	svc  workspace.WorkspaceService
+}
+
+func (s *WorkspaceServiceTestSuite) SetupTest() {
+	s.repo = mocks.NewMockWorkspaceRepository(s.T())
+	s.svc = workspace.NewWorkspaceService(s.repo, zap.NewNop())
+}
+
+func (s *WorkspaceServiceTestSuite) TestCreate_AssignsUUID() {
+	s.repo.On("Create", mock.Anything, mock.AnythingOfType("*greysealv1.Workspace")).Return(nil)
+
+	resp, err := s.svc.Create(context.Background(), &fakeCreateReq{data: &v1.Workspace{Name: "support"}})
+	s.Require().NoError(err)
+	s.NotEmpty(resp.GetData().GetUuid())
+	s.NotNil(resp.GetData().GetCreatedAt())
+}
+
+func (s *WorkspaceServiceTestSuite) TestCreate_RequiresName() {
+	_, err := s.svc.Create(context.Background(), &fakeCreateReq{data: &v1.Workspace{}})
+	s.ErrorIs(err, workspace.ErrNameRequired)
+}
+
+func (s *WorkspaceServiceTestSuite) TestDelete() {
+	s.repo.On("InUse", mock.Anything, "w1").Return(false, nil)
+	s.repo.On("Delete", mock.Anything, "w1").Return(nil)
+
+	s.Require().NoError(s.svc.Delete(context.Background(), "w1"))
+}
+
+func (s *WorkspaceServiceTestSuite) TestDelete_RefusesDefault() {
+	err := s.svc.Delete(context.Background(), workspace.DefaultUUID)
+	s.ErrorIs(err, workspace.ErrDefaultWorkspace)
+}
+
+func (s *WorkspaceServiceTestSuite) TestDelete_RefusesNonEmpty() {
+	s.repo.On("InUse", mock.Anything, "w2").Return(true, nil)
+
+	err := s.svc.Delete(context.Background(), "w2")
+	s.ErrorIs(err, workspace.ErrWorkspaceNotEmpty)
+	s.repo.AssertNotCalled(s.T(), "Delete", mock.Anything, "w2")
+}
+
+func TestWorkspaceServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkspaceServiceTestSuite))
+}
+
+type fakeCreateReq struct{ data *v1.Workspace }
+
+func (r *fakeCreateReq) GetData() *v1.Workspace { return r.data }
+func (r *fakeCreateReq) GetUuid() string        { return r.data.GetUuid() }
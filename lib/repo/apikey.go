@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/lib/pq"
+)
+
+// APIKeyRepo persists hashed API keys.
+type APIKeyRepo struct {
+	*Conn
+}
+
+var _ auth.APIKeyRepository = (*APIKeyRepo)(nil)
+
+func (r *APIKeyRepo) Create(ctx context.Context, k *auth.APIKey) error {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("api_keys").
+		Columns("uuid", "name", "owner", "key_hash", "scopes", "workspace_uuid", "created_at").
+		Values(
+			k.Uuid,
+			k.Name,
+			k.Owner,
+			k.KeyHash,
+			pq.Array(scopes),
+			workspaceOrDefault(k.WorkspaceUUID),
+			k.CreatedAt).
+		RunWith(r.conn).ExecContext(ctx)
+	return err
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*auth.APIKey, error) {
+	k := &auth.APIKey{}
+	var revokedAt sql.NullTime
+	err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid", "name", "owner", "key_hash", "scopes", "workspace_uuid", "created_at", "revoked_at").
+		From("api_keys").
+		Where(sq.Eq{"key_hash": keyHash}).
+		RunWith(r.conn).
+		QueryRowContext(ctx).
+		Scan(
+			&k.Uuid,
+			&k.Name,
+			&k.Owner,
+			&k.KeyHash,
+			pq.Array(&k.Scopes),
+			&k.WorkspaceUUID,
+			&k.CreatedAt,
+			&revokedAt,
+		)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	return k, nil
+}
+
+// Revoke marks the key as revoked; revoked keys are kept for auditing.
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string) error {
+	query, args, err := sq.Update("api_keys").
+		Set("revoked_at", time.Now()).
+		Where(sq.Eq{"uuid": id}).
+		Where(sq.Eq{"revoked_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.ExecContext(ctx, query, args...)
+	return err
+}
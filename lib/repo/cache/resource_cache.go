@@ -66,6 +66,14 @@ func (c *RedisResourceCache) Merge(ctx context.Context, conversationUUID string,
 	return c.client.Set(ctx, key, data, cacheTTL).Err()
 }
 
+// Delete removes the conversation's cache entry.
+func (c *RedisResourceCache) Delete(ctx context.Context, conversationUUID string) error {
+	if err := c.client.Del(ctx, cacheKey(conversationUUID)).Err(); err != nil {
+		return fmt.Errorf("cache delete: %w", err)
+	}
+	return nil
+}
+
 // List returns all cached resources for the conversation sorted by Score descending.
 func (c *RedisResourceCache) List(ctx context.Context, conversationUUID string) ([]conversation.CachedResource, error) {
 	raw, err := c.client.Get(ctx, cacheKey(conversationUUID)).Bytes()
@@ -2,11 +2,17 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	"github.com/lib/pq"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -24,8 +30,9 @@ func (r *MessageRepo) Create(ctx context.Context, b *greysealv1.Message) error {
 	if resourceUUIDs == nil {
 		resourceUUIDs = []string{}
 	}
+	b.WorkspaceUuid = workspaceForCreate(ctx, b.WorkspaceUuid)
 	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("messages").
-		Columns("uuid", "conversation_uuid", "role", "content", "resource_uuids", "feedback", "created_at").
+		Columns("uuid", "conversation_uuid", "role", "content", "resource_uuids", "feedback", "created_at", "owner", "workspace_uuid", "model", "role_uuid", "role_version", "stopped").
 		Values(
 			b.Uuid,
 			b.ConversationUuid,
@@ -33,7 +40,13 @@ func (r *MessageRepo) Create(ctx context.Context, b *greysealv1.Message) error {
 			b.Content,
 			pq.Array(resourceUUIDs),
 			b.Feedback,
-			b.CreatedAt.AsTime()).
+			b.CreatedAt.AsTime(),
+			b.Owner,
+			b.WorkspaceUuid,
+			b.Model,
+			b.RoleUuid,
+			b.RoleVersion,
+			b.Stopped).
 		RunWith(r.conn).Exec()
 	return err
 }
@@ -49,6 +62,7 @@ func (r *MessageRepo) Update(ctx context.Context, id string, b *greysealv1.Messa
 		Set("resource_uuids", pq.Array(resourceUUIDs)).
 		Set("feedback", b.Feedback).
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -61,6 +75,7 @@ func (r *MessageRepo) Update(ctx context.Context, id string, b *greysealv1.Messa
 func (r *MessageRepo) Delete(ctx context.Context, id string) error {
 	query, args, err := sq.Delete("messages").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -71,39 +86,28 @@ func (r *MessageRepo) Delete(ctx context.Context, id string) error {
 }
 
 func (r *MessageRepo) Get(ctx context.Context, id string) (*greysealv1.Message, error) {
-	message := &greysealv1.Message{}
-	var roleVal int32
-	var createdAtDt time.Time
-	err := sq.StatementBuilder.
+	row := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "conversation_uuid", "role", "content", "resource_uuids", "feedback", "created_at").
+		Select(messageColumns...).
 		From("messages").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		RunWith(r.conn).
-		QueryRow().
-		Scan(
-			&message.Uuid,
-			&message.ConversationUuid,
-			&roleVal,
-			&message.Content,
-			pq.Array(&message.ResourceUuids),
-			&message.Feedback,
-			&createdAtDt,
-		)
+		QueryRow()
+	message, err := scanMessage(row)
 	if err != nil {
 		fmt.Println("error getting message", err)
 		return nil, err
 	}
-	message.Role = greysealv1.MessageRole(roleVal)
-	message.CreatedAt = timestamppb.New(createdAtDt)
 	return message, nil
 }
 
 func (r *MessageRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Message, error) {
 	q := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "conversation_uuid", "role", "content", "resource_uuids", "feedback", "created_at").
+		Select(messageColumns...).
 		From("messages").
+		Where(inWorkspace(ctx)).
 		OrderBy("created_at ASC")
 
 	if convUUIDs, ok := filter["conversation_uuid"]; ok && len(convUUIDs) > 0 {
@@ -119,29 +123,46 @@ func (r *MessageRepo) List(ctx context.Context, cursor string, limit uint, filte
 
 	var messages []*greysealv1.Message
 	for rows.Next() {
-		message := &greysealv1.Message{}
-		var roleVal int32
-		var createdAtDt time.Time
-		err := rows.Scan(
-			&message.Uuid,
-			&message.ConversationUuid,
-			&roleVal,
-			&message.Content,
-			pq.Array(&message.ResourceUuids),
-			&message.Feedback,
-			&createdAtDt,
-		)
+		message, err := scanMessage(rows)
 		if err != nil {
 			fmt.Println("error scanning message", err)
 			return nil, err
 		}
-		message.Role = greysealv1.MessageRole(roleVal)
-		message.CreatedAt = timestamppb.New(createdAtDt)
 		messages = append(messages, message)
 	}
 	return messages, nil
 }
 
+var messageColumns = []string{"uuid", "conversation_uuid", "role", "content", "resource_uuids", "feedback", "created_at", "owner", "workspace_uuid", "model", "role_uuid", "role_version", "stopped"}
+
+// scanMessage reads a row selected with messageColumns.
+func scanMessage(row sq.RowScanner) (*greysealv1.Message, error) {
+	message := &greysealv1.Message{}
+	var roleVal int32
+	var createdAtDt time.Time
+	err := row.Scan(
+		&message.Uuid,
+		&message.ConversationUuid,
+		&roleVal,
+		&message.Content,
+		pq.Array(&message.ResourceUuids),
+		&message.Feedback,
+		&createdAtDt,
+		&message.Owner,
+		&message.WorkspaceUuid,
+		&message.Model,
+		&message.RoleUuid,
+		&message.RoleVersion,
+		&message.Stopped,
+	)
+	if err != nil {
+		return nil, err
+	}
+	message.Role = greysealv1.MessageRole(roleVal)
+	message.CreatedAt = timestamppb.New(createdAtDt)
+	return message, nil
+}
+
 // ListByConversation fetches all messages for a given conversation UUID ordered by created_at.
 func (r *MessageRepo) ListByConversation(ctx context.Context, conversationUUID string) ([]*greysealv1.Message, error) {
 	return r.List(ctx, "", 0, map[string][]any{"conversation_uuid": {conversationUUID}})
@@ -152,6 +173,7 @@ func (r *MessageRepo) UpdateFeedback(ctx context.Context, messageUUID string, fe
 	query, args, err := sq.Update("messages").
 		Set("feedback", feedback).
 		Where(sq.Eq{"uuid": messageUUID}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -175,23 +197,32 @@ func NewConversationRepo(conn *Conn) *ConversationRepo {
 	}
 }
 
-var _ base.Repository[*greysealv1.Conversation] = (*ConversationRepo)(nil)
+var _ conversation.ConversationRepository = (*ConversationRepo)(nil)
 
 func (r *ConversationRepo) Create(ctx context.Context, b *greysealv1.Conversation) error {
 	resourceUUIDs := b.ResourceUuids
 	if resourceUUIDs == nil {
 		resourceUUIDs = []string{}
 	}
-	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("conversations").
-		Columns("uuid", "title", "role_uuid", "resource_uuids", "summary", "created_at", "updated_at").
+	variables, err := encodeVariables(b.Variables)
+	if err != nil {
+		return err
+	}
+	b.WorkspaceUuid = workspaceForCreate(ctx, b.WorkspaceUuid)
+	_, err = sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("conversations").
+		Columns("uuid", "title", "role_uuid", "role_version", "resource_uuids", "summary", "created_at", "updated_at", "owner", "workspace_uuid", "variables").
 		Values(
 			b.Uuid,
 			b.Title,
 			b.RoleUuid,
+			b.RoleVersion,
 			pq.Array(resourceUUIDs),
 			b.Summary,
 			b.CreatedAt.AsTime(),
-			b.UpdatedAt.AsTime()).
+			b.UpdatedAt.AsTime(),
+			b.Owner,
+			b.WorkspaceUuid,
+			variables).
 		RunWith(r.conn).Exec()
 	return err
 }
@@ -201,13 +232,20 @@ func (r *ConversationRepo) Update(ctx context.Context, id string, b *greysealv1.
 	if resourceUUIDs == nil {
 		resourceUUIDs = []string{}
 	}
+	variables, err := encodeVariables(b.Variables)
+	if err != nil {
+		return err
+	}
 	query, args, err := sq.Update("conversations").
 		Set("title", b.Title).
 		Set("role_uuid", b.RoleUuid).
+		Set("role_version", b.RoleVersion).
 		Set("resource_uuids", pq.Array(resourceUUIDs)).
 		Set("summary", b.Summary).
+		Set("variables", variables).
 		Set("updated_at", b.UpdatedAt.AsTime()).
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -220,6 +258,7 @@ func (r *ConversationRepo) Update(ctx context.Context, id string, b *greysealv1.
 func (r *ConversationRepo) Delete(ctx context.Context, id string) error {
 	query, args, err := sq.Delete("conversations").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -230,48 +269,73 @@ func (r *ConversationRepo) Delete(ctx context.Context, id string) error {
 }
 
 func (r *ConversationRepo) Get(ctx context.Context, id string) (*greysealv1.Conversation, error) {
-	conversation := &greysealv1.Conversation{}
-	var createdAtDt time.Time
-	var updatedAtDt time.Time
-	err := sq.StatementBuilder.
+	row := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "title", "role_uuid", "resource_uuids", "summary", "created_at", "updated_at").
+		Select(conversationColumns...).
 		From("conversations").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		RunWith(r.conn).
-		QueryRow().
-		Scan(
-			&conversation.Uuid,
-			&conversation.Title,
-			&conversation.RoleUuid,
-			pq.Array(&conversation.ResourceUuids),
-			&conversation.Summary,
-			&createdAtDt,
-			&updatedAtDt,
-		)
+		QueryRow()
+	conv, err := scanConversation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, conversation.ErrConversationNotFound
+	}
 	if err != nil {
 		fmt.Println("error getting conversation", err)
 		return nil, err
 	}
-	conversation.CreatedAt = timestamppb.New(createdAtDt)
-	conversation.UpdatedAt = timestamppb.New(updatedAtDt)
 
 	msgs, err := r.messages.ListByConversation(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load messages: %w", err)
 	}
-	conversation.Messages = msgs
-	return conversation, nil
+	conv.Messages = msgs
+	return conv, nil
 }
 
 func (r *ConversationRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Conversation, error) {
-	rows, err := sq.StatementBuilder.
+	q := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "title", "role_uuid", "resource_uuids", "summary", "created_at", "updated_at").
+		Select(conversationColumns...).
 		From("conversations").
-		OrderBy("updated_at DESC").
-		RunWith(r.conn).
-		Query()
+		Where(inWorkspace(ctx))
+
+	// Callers that pass no status see conversations in every state.
+	if statuses, ok := filter["status"]; ok && len(statuses) > 0 {
+		switch statuses[0] {
+		case greysealv1.ConversationStatus_CONVERSATION_STATUS_ARCHIVED:
+			q = q.Where(sq.And{sq.NotEq{"archived_at": nil}, sq.Eq{"deleted_at": nil}})
+		case greysealv1.ConversationStatus_CONVERSATION_STATUS_DELETED:
+			q = q.Where(sq.NotEq{"deleted_at": nil})
+		default:
+			q = q.Where(sq.Eq{"archived_at": nil, "deleted_at": nil})
+		}
+	}
+	if owners, ok := filter["owner"]; ok && len(owners) > 0 {
+		q = q.Where(sq.Eq{"owner": owners[0]})
+	}
+	if ids, ok := filter["uuid"]; ok {
+		q = q.Where(sq.Eq{"uuid": ids})
+	}
+	if roles, ok := filter["role_uuid"]; ok && len(roles) > 0 {
+		q = q.Where(sq.Eq{"role_uuid": roles[0]})
+	}
+	if resources, ok := filter["resource_uuid"]; ok && len(resources) > 0 {
+		q = q.Where(sq.Expr("? = ANY(resource_uuids)", resources[0]))
+	}
+	if after, ok := filter["updated_after"]; ok && len(after) > 0 {
+		q = q.Where(sq.GtOrEq{"updated_at": after[0]})
+	}
+	if before, ok := filter["updated_before"]; ok && len(before) > 0 {
+		q = q.Where(sq.Lt{"updated_at": before[0]})
+	}
+
+	q, err := keyset(q, "updated_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.RunWith(r.conn).Query()
 	if err != nil {
 		fmt.Println("error listing conversations", err)
 		return nil, err
@@ -280,25 +344,287 @@ func (r *ConversationRepo) List(ctx context.Context, cursor string, limit uint,
 
 	var conversations []*greysealv1.Conversation
 	for rows.Next() {
-		conversation := &greysealv1.Conversation{}
-		var createdAtDt time.Time
-		var updatedAtDt time.Time
-		err := rows.Scan(
-			&conversation.Uuid,
-			&conversation.Title,
-			&conversation.RoleUuid,
-			pq.Array(&conversation.ResourceUuids),
-			&conversation.Summary,
-			&createdAtDt,
-			&updatedAtDt,
-		)
+		conversation, err := scanConversation(rows)
 		if err != nil {
 			fmt.Println("error scanning conversation", err)
 			return nil, err
 		}
-		conversation.CreatedAt = timestamppb.New(createdAtDt)
-		conversation.UpdatedAt = timestamppb.New(updatedAtDt)
 		conversations = append(conversations, conversation)
 	}
 	return conversations, nil
 }
+
+var conversationColumns = []string{"uuid", "title", "role_uuid", "resource_uuids", "summary", "created_at", "updated_at", "owner", "workspace_uuid", "archived_at", "deleted_at", "variables", "role_version"}
+
+// encodeVariables encodes conversation variables for the JSONB column.
+func encodeVariables(variables map[string]string) ([]byte, error) {
+	if variables == nil {
+		variables = map[string]string{}
+	}
+	return json.Marshal(variables)
+}
+
+// scanConversation reads a row selected with conversationColumns.
+func scanConversation(row sq.RowScanner) (*greysealv1.Conversation, error) {
+	conversation := &greysealv1.Conversation{}
+	var createdAtDt, updatedAtDt time.Time
+	var archivedAt, deletedAt sql.NullTime
+	var variables []byte
+	err := row.Scan(
+		&conversation.Uuid,
+		&conversation.Title,
+		&conversation.RoleUuid,
+		pq.Array(&conversation.ResourceUuids),
+		&conversation.Summary,
+		&createdAtDt,
+		&updatedAtDt,
+		&conversation.Owner,
+		&conversation.WorkspaceUuid,
+		&archivedAt,
+		&deletedAt,
+		&variables,
+		&conversation.RoleVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(variables) > 0 {
+		if err := json.Unmarshal(variables, &conversation.Variables); err != nil {
+			return nil, err
+		}
+	}
+	conversation.CreatedAt = timestamppb.New(createdAtDt)
+	conversation.UpdatedAt = timestamppb.New(updatedAtDt)
+	if archivedAt.Valid {
+		conversation.ArchivedAt = timestamppb.New(archivedAt.Time)
+	}
+	if deletedAt.Valid {
+		conversation.DeletedAt = timestamppb.New(deletedAt.Time)
+	}
+	return conversation, nil
+}
+
+// SetArchivedAt archives the conversation at the given time, or unarchives it when at is nil.
+func (r *ConversationRepo) SetArchivedAt(ctx context.Context, id string, at *time.Time) error {
+	return r.setLifecycle(ctx, id, "archived_at", at)
+}
+
+// SetDeletedAt moves the conversation to the trash at the given time, or restores it when at is nil.
+func (r *ConversationRepo) SetDeletedAt(ctx context.Context, id string, at *time.Time) error {
+	return r.setLifecycle(ctx, id, "deleted_at", at)
+}
+
+func (r *ConversationRepo) setLifecycle(ctx context.Context, id, column string, at *time.Time) error {
+	query, args, err := sq.Update("conversations").
+		Set(column, at).
+		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.ExecContext(ctx, query, args...)
+	return err
+}
+
+// TrashStale moves active conversations not updated since before to the trash
+// at deletedAt. Archived conversations are kept.
+func (r *ConversationRepo) TrashStale(ctx context.Context, before, deletedAt time.Time) (int64, error) {
+	query, args, err := sq.Update("conversations").
+		Set("deleted_at", deletedAt).
+		Where(sq.Eq{"archived_at": nil, "deleted_at": nil}).
+		Where(sq.Lt{"updated_at": before}).
+		Where(inWorkspace(ctx)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ListTrashed returns the UUIDs of conversations deleted before the given time.
+func (r *ConversationRepo) ListTrashed(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid").
+		From("conversations").
+		Where(sq.Lt{"deleted_at": before}).
+		Where(inWorkspace(ctx)).
+		OrderBy("deleted_at").
+		RunWith(r.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+const (
+	// searchExcerpts is the number of highlighted messages returned per conversation.
+	searchExcerpts = 3
+	// markStart and markStop delimit matched terms in ts_headline output.
+	// They are private-use runes, stripped from the content beforehand, so
+	// the excerpt can be HTML-escaped before they become <mark></mark>.
+	markStart = "\ue000"
+	markStop  = "\ue001"
+	// searchHeadline configures ts_headline for message excerpts.
+	searchHeadline = "StartSel=" + markStart + ", StopSel=" + markStop + ", MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=\" … \""
+)
+
+// excerptHighlighter turns a ts_headline excerpt into HTML.
+var excerptHighlighter = strings.NewReplacer(markStart, "<mark>", markStop, "</mark>")
+
+// Search ranks messages against the query with ts_rank, keeps the best few per
+// conversation and orders conversations by their best message. Excerpts are
+// only highlighted for the rows that are returned, and are HTML-escaped so
+// that <mark></mark> is the only markup in them.
+func (r *ConversationRepo) Search(ctx context.Context, query conversation.SearchQuery) ([]*greysealv1.ConversationSearchResult, error) {
+	matches := sq.Select("uuid", "conversation_uuid", "role", "content", "created_at").
+		Column(sq.Expr("ts_rank(search_vector, websearch_to_tsquery('english', ?)) AS rank", query.Query)).
+		From("messages").
+		Where(sq.Expr("search_vector @@ websearch_to_tsquery('english', ?)", query.Query)).
+		Where(inWorkspace(ctx))
+	if query.Owner != "" {
+		matches = matches.Where(sq.Eq{"owner": query.Owner})
+	}
+	if query.RoleUUID != "" {
+		matches = matches.Where(sq.Expr("conversation_uuid IN (SELECT uuid FROM conversations WHERE role_uuid = ?)", query.RoleUUID))
+	}
+	// Trashed conversations are not searchable; archived ones are.
+	matches = matches.Where("conversation_uuid NOT IN (SELECT uuid FROM conversations WHERE deleted_at IS NOT NULL)")
+	if !query.After.IsZero() {
+		matches = matches.Where(sq.GtOrEq{"created_at": query.After})
+	}
+	if !query.Before.IsZero() {
+		matches = matches.Where(sq.Lt{"created_at": query.Before})
+	}
+	if query.Feedback != nil {
+		matches = matches.Where(sq.Eq{"feedback": *query.Feedback})
+	}
+
+	ranked := sq.Select("*").
+		Column("row_number() OVER (PARTITION BY conversation_uuid ORDER BY rank DESC, created_at DESC) AS n").
+		Column("max(rank) OVER (PARTITION BY conversation_uuid) AS conversation_rank").
+		FromSelect(matches, "m")
+	top := sq.Select("*").
+		Column("dense_rank() OVER (ORDER BY conversation_rank DESC, conversation_uuid) AS conversation_position").
+		FromSelect(ranked, "r").
+		Where(sq.LtOrEq{"n": searchExcerpts})
+
+	rows, err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("conversation_uuid", "uuid", "role", "created_at", "rank", "conversation_rank").
+		Column(sq.Expr("ts_headline('english', translate(content, ?, ''), websearch_to_tsquery('english', ?), ?)", markStart+markStop, query.Query, searchHeadline)).
+		FromSelect(top, "t").
+		Where(sq.LtOrEq{"conversation_position": query.Limit}).
+		OrderBy("conversation_rank DESC", "conversation_uuid", "rank DESC").
+		RunWith(r.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("search messages: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var results []*greysealv1.ConversationSearchResult
+	byConversation := map[string]*greysealv1.ConversationSearchResult{}
+	for rows.Next() {
+		excerpt := &greysealv1.MessageExcerpt{}
+		var conversationUUID string
+		var roleVal int32
+		var createdAtDt time.Time
+		var conversationRank float32
+		if err := rows.Scan(
+			&conversationUUID,
+			&excerpt.MessageUuid,
+			&roleVal,
+			&createdAtDt,
+			&excerpt.Rank,
+			&conversationRank,
+			&excerpt.Excerpt,
+		); err != nil {
+			return nil, fmt.Errorf("scan search result: %w", err)
+		}
+		excerpt.Excerpt = excerptHighlighter.Replace(html.EscapeString(excerpt.Excerpt))
+		excerpt.Role = greysealv1.MessageRole(roleVal)
+		excerpt.CreatedAt = timestamppb.New(createdAtDt)
+
+		result, ok := byConversation[conversationUUID]
+		if !ok {
+			result = &greysealv1.ConversationSearchResult{
+				Conversation: &greysealv1.Conversation{Uuid: conversationUUID},
+				Rank:         conversationRank,
+			}
+			byConversation[conversationUUID] = result
+			results = append(results, result)
+		}
+		result.Excerpts = append(result.Excerpts, excerpt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	ids := make([]any, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Conversation.Uuid)
+	}
+	conversations, err := r.List(ctx, "", uint(len(ids)), map[string][]any{"uuid": ids})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conversations {
+		byConversation[c.Uuid].Conversation = c
+	}
+	return results, nil
+}
+
+// Taken reports which of ids already exist as a conversation or message UUID.
+// It deliberately ignores workspaces: UUIDs are primary keys across all tenants.
+func (r *ConversationRepo) Taken(ctx context.Context, ids []string) (map[string]bool, error) {
+	taken := map[string]bool{}
+	if len(ids) == 0 {
+		return taken, nil
+	}
+	for _, table := range []string{"conversations", "messages"} {
+		rows, err := sq.StatementBuilder.
+			PlaceholderFormat(sq.Dollar).
+			Select("uuid").
+			From(table).
+			Where(sq.Eq{"uuid": ids}).
+			RunWith(r.conn).
+			QueryContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close() //nolint:errcheck
+				return nil, err
+			}
+			taken[id] = true
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return taken, nil
+}
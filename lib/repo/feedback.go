@@ -0,0 +1,329 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FeedbackRepo persists feedback records and aggregates them into reports.
+type FeedbackRepo struct {
+	*Conn
+}
+
+var _ conversation.FeedbackRepository = (*FeedbackRepo)(nil)
+
+func (r *FeedbackRepo) Create(ctx context.Context, f *greysealv1.Feedback) error {
+	reasons := make([]int32, 0, len(f.Reasons))
+	for _, reason := range f.Reasons {
+		reasons = append(reasons, int32(reason))
+	}
+	f.WorkspaceUuid = workspaceForCreate(ctx, f.WorkspaceUuid)
+	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("message_feedback").
+		Columns("uuid", "message_uuid", "conversation_uuid", "rating", "reasons", "comment", "owner", "workspace_uuid", "created_at").
+		Values(
+			f.Uuid,
+			f.MessageUuid,
+			f.ConversationUuid,
+			f.Rating,
+			pq.Array(reasons),
+			f.Comment,
+			f.Owner,
+			f.WorkspaceUuid,
+			f.CreatedAt.AsTime()).
+		RunWith(r.conn).ExecContext(ctx)
+	return err
+}
+
+// List returns feedback newest first. Supported filters are message_uuid and owner.
+func (r *FeedbackRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Feedback, error) {
+	q := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid", "message_uuid", "conversation_uuid", "rating", "reasons", "comment", "owner", "workspace_uuid", "created_at").
+		From("message_feedback").
+		Where(inWorkspace(ctx))
+	for _, key := range []string{"message_uuid", "owner"} {
+		if v, ok := filter[key]; ok && len(v) > 0 {
+			q = q.Where(sq.Eq{key: v[0]})
+		}
+	}
+	q, err := keyset(q, "created_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.RunWith(r.conn).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list feedback: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var feedback []*greysealv1.Feedback
+	for rows.Next() {
+		f := &greysealv1.Feedback{}
+		var reasons []int32
+		var createdAtDt time.Time
+		if err := rows.Scan(
+			&f.Uuid,
+			&f.MessageUuid,
+			&f.ConversationUuid,
+			&f.Rating,
+			pq.Array(&reasons),
+			&f.Comment,
+			&f.Owner,
+			&f.WorkspaceUuid,
+			&createdAtDt,
+		); err != nil {
+			return nil, fmt.Errorf("scan feedback: %w", err)
+		}
+		for _, reason := range reasons {
+			f.Reasons = append(f.Reasons, greysealv1.FeedbackReason(reason))
+		}
+		f.CreatedAt = timestamppb.New(createdAtDt)
+		feedback = append(feedback, f)
+	}
+	return feedback, rows.Err()
+}
+
+// Report aggregates each user's latest rating of each message submitted in
+// the query window. Messages are joined for their model and cited resources,
+// and conversations for their current role.
+func (r *FeedbackRepo) Report(ctx context.Context, query conversation.ReportQuery) (*greysealv1.FeedbackReport, error) {
+	submitted := sq.Select("*").
+		From("message_feedback").
+		Where(inWorkspace(ctx)).
+		Where(sq.GtOrEq{"created_at": query.After}).
+		Where(sq.Lt{"created_at": query.Before})
+	if query.Owner != "" {
+		submitted = submitted.Where(sq.Eq{"owner": query.Owner})
+	}
+	latest := sq.Select("f.rating", "f.reasons", "f.created_at", "m.model", "m.resource_uuids", "c.role_uuid").
+		Options("DISTINCT ON (f.message_uuid, f.owner)").
+		FromSelect(submitted, "f").
+		Join("messages m ON m.uuid = f.message_uuid").
+		Join("conversations c ON c.uuid = m.conversation_uuid").
+		OrderBy("f.message_uuid", "f.owner", "f.created_at DESC")
+
+	report := &greysealv1.FeedbackReport{}
+	overall, err := r.stats(ctx, feedbackStats(latest, "''", "''"), false)
+	if err != nil {
+		return nil, err
+	}
+	if len(overall) > 0 {
+		report.Overall = overall[0]
+	}
+
+	byNegative := []string{"6 DESC", "3 DESC", "1"}
+	if report.ByRole, err = r.stats(ctx, feedbackStats(latest, "l.role_uuid", "rl.name").
+		LeftJoin("roles rl ON rl.uuid = l.role_uuid").
+		GroupBy("1", "2").
+		OrderBy(byNegative...), false); err != nil {
+		return nil, err
+	}
+	if report.ByModel, err = r.stats(ctx, feedbackStats(latest, "l.model", "''").
+		GroupBy("1", "2").
+		OrderBy(byNegative...), false); err != nil {
+		return nil, err
+	}
+	byResource := feedbackStats(latest, "res.uuid", "rs.name").
+		JoinClause("CROSS JOIN LATERAL unnest(l.resource_uuids) AS res(uuid)").
+		LeftJoin("resources rs ON rs.uuid = res.uuid").
+		GroupBy("1", "2").
+		OrderBy(byNegative...)
+	if query.ResourceLimit > 0 {
+		byResource = byResource.Limit(uint64(query.ResourceLimit))
+	}
+	if report.ByResource, err = r.stats(ctx, byResource, false); err != nil {
+		return nil, err
+	}
+	if report.ByPeriod, err = r.stats(ctx, feedbackStats(latest, "date_trunc('"+truncUnit(query.Interval)+"', l.created_at, 'UTC')", "''").
+		GroupBy("1", "2").
+		OrderBy("1"), true); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// feedbackStats selects the columns scanned by stats over the latest ratings:
+// key, label, total, positive, neutral, negative, average and one count per
+// reason in conversation.FeedbackReasons.
+func feedbackStats(latest sq.SelectBuilder, key, label string) sq.SelectBuilder {
+	q := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select(
+			key,
+			label,
+			"count(*)",
+			"count(*) FILTER (WHERE l.rating > 0)",
+			"count(*) FILTER (WHERE l.rating = 0)",
+			"count(*) FILTER (WHERE l.rating < 0)",
+			"coalesce(avg(l.rating), 0)::float8",
+		).
+		FromSelect(latest, "l")
+	for _, reason := range conversation.FeedbackReasons {
+		q = q.Column(sq.Expr("count(*) FILTER (WHERE ? = ANY(l.reasons))", int32(reason)))
+	}
+	return q
+}
+
+// stats runs a feedbackStats query. For time series the key is the period
+// start rather than a string.
+func (r *FeedbackRepo) stats(ctx context.Context, q sq.SelectBuilder, period bool) ([]*greysealv1.FeedbackStats, error) {
+	rows, err := q.RunWith(r.conn).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate feedback: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var stats []*greysealv1.FeedbackStats
+	for rows.Next() {
+		s := &greysealv1.FeedbackStats{}
+		var periodStart time.Time
+		var key any = &s.Key
+		if period {
+			key = &periodStart
+		}
+		var label sql.NullString
+		reasons := make([]int32, len(conversation.FeedbackReasons))
+		dest := []any{key, &label, &s.Total, &s.Positive, &s.Neutral, &s.Negative, &s.AverageRating}
+		for i := range reasons {
+			dest = append(dest, &reasons[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan feedback stats: %w", err)
+		}
+		if period {
+			s.PeriodStart = timestamppb.New(periodStart)
+		}
+		s.Label = label.String
+		for i, count := range reasons {
+			if count > 0 {
+				s.Reasons = append(s.Reasons, &greysealv1.FeedbackReasonCount{Reason: conversation.FeedbackReasons[i], Count: count})
+			}
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// truncUnit maps a report interval onto a date_trunc unit.
+func truncUnit(interval greysealv1.FeedbackInterval) string {
+	switch interval {
+	case greysealv1.FeedbackInterval_FEEDBACK_INTERVAL_WEEK:
+		return "week"
+	case greysealv1.FeedbackInterval_FEEDBACK_INTERVAL_MONTH:
+		return "month"
+	default:
+		return "day"
+	}
+}
+
+// ListLabelled returns rated assistant messages newest first, each with its
+// latest rating from any user, the user message it answered and the role of
+// its conversation. Trashed conversations are skipped.
+func (r *FeedbackRepo) ListLabelled(ctx context.Context, cursor string, limit uint, query conversation.DatasetQuery) ([]*conversation.LabelledMessage, error) {
+	latest := sq.Select("message_uuid", "uuid AS feedback_uuid", "rating", "reasons", "comment", "owner AS feedback_owner", "created_at AS feedback_at").
+		Options("DISTINCT ON (message_uuid)").
+		From("message_feedback").
+		Where(inWorkspace(ctx)).
+		OrderBy("message_uuid", "created_at DESC")
+
+	labelled := sq.Select().
+		FromSelect(latest, "lf").
+		Join("messages m ON m.uuid = lf.message_uuid").
+		Join("conversations c ON c.uuid = m.conversation_uuid").
+		Where(sq.Eq{"c.deleted_at": nil})
+	for _, col := range messageColumns {
+		labelled = labelled.Column("m." + col)
+	}
+	labelled = labelled.
+		Column("c.role_uuid AS conversation_role_uuid").
+		Column(sq.Expr(`coalesce((SELECT u.content FROM messages u
+			WHERE u.conversation_uuid = m.conversation_uuid AND u.role = ? AND u.created_at <= m.created_at
+			ORDER BY u.created_at DESC LIMIT 1), '') AS query`, int32(greysealv1.MessageRole_MESSAGE_ROLE_USER))).
+		Columns("lf.feedback_uuid", "lf.rating", "lf.reasons", "lf.comment", "lf.feedback_owner", "lf.feedback_at")
+	if query.Rating != nil {
+		labelled = labelled.Where(sq.Eq{"lf.rating": *query.Rating})
+	}
+	if query.RoleUUID != "" {
+		labelled = labelled.Where(sq.Eq{"c.role_uuid": query.RoleUUID})
+	}
+	if !query.After.IsZero() {
+		labelled = labelled.Where(sq.GtOrEq{"m.created_at": query.After})
+	}
+	if !query.Before.IsZero() {
+		labelled = labelled.Where(sq.Lt{"m.created_at": query.Before})
+	}
+	if query.Owner != "" {
+		labelled = labelled.Where(sq.Eq{"m.owner": query.Owner})
+	}
+
+	columns := append(append([]string{}, messageColumns...),
+		"conversation_role_uuid", "query", "feedback_uuid", "rating", "reasons", "comment", "feedback_owner", "feedback_at")
+	q, err := keyset(sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select(columns...).
+		FromSelect(labelled, "d"), "created_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.RunWith(r.conn).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list labelled messages: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var labelledMessages []*conversation.LabelledMessage
+	for rows.Next() {
+		msg := &greysealv1.Message{}
+		f := &greysealv1.Feedback{}
+		l := &conversation.LabelledMessage{Message: msg, Feedback: f}
+		var roleVal int32
+		var reasons []int32
+		var createdAtDt, feedbackAtDt time.Time
+		if err := rows.Scan(
+			&msg.Uuid,
+			&msg.ConversationUuid,
+			&roleVal,
+			&msg.Content,
+			pq.Array(&msg.ResourceUuids),
+			&msg.Feedback,
+			&createdAtDt,
+			&msg.Owner,
+			&msg.WorkspaceUuid,
+			&msg.Model,
+			&msg.RoleUuid,
+			&msg.RoleVersion,
+			&msg.Stopped,
+			&l.RoleUUID,
+			&l.Query,
+			&f.Uuid,
+			&f.Rating,
+			pq.Array(&reasons),
+			&f.Comment,
+			&f.Owner,
+			&feedbackAtDt,
+		); err != nil {
+			return nil, fmt.Errorf("scan labelled message: %w", err)
+		}
+		msg.Role = greysealv1.MessageRole(roleVal)
+		msg.CreatedAt = timestamppb.New(createdAtDt)
+		f.MessageUuid = msg.Uuid
+		f.ConversationUuid = msg.ConversationUuid
+		f.WorkspaceUuid = msg.WorkspaceUuid
+		f.CreatedAt = timestamppb.New(feedbackAtDt)
+		for _, reason := range reasons {
+			f.Reasons = append(f.Reasons, greysealv1.FeedbackReason(reason))
+		}
+		labelledMessages = append(labelledMessages, l)
+	}
+	return labelledMessages, rows.Err()
+}
@@ -10,6 +10,10 @@ import (
 	"time"
 
 	"github.com/holmes89/archaea/testutil"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	"github.com/holmes89/grey-seal/lib/repo"
 	"github.com/stretchr/testify/suite"
@@ -24,6 +28,11 @@ const (
 	roleUUID1 = "00000000-0000-0000-0000-000000000011"
 	roleUUID2 = "00000000-0000-0000-0000-000000000012"
 	roleUUID3 = "00000000-0000-0000-0000-000000000013"
+	keyUUID1  = "00000000-0000-0000-0000-000000000021"
+	resUUID1  = "00000000-0000-0000-0000-000000000041"
+	resUUID2  = "00000000-0000-0000-0000-000000000042"
+	resUUID3  = "00000000-0000-0000-0000-000000000043"
+	wsUUID1   = "00000000-0000-0000-0000-000000000031"
 )
 
 // integrationDSN holds the full postgres:// URL used by repo.NewDatabase.
@@ -81,17 +90,22 @@ func (s *ConversationRepoTestSuite) TestUpdate() {
 	c := &v1.Conversation{
 		Uuid:      convUUID2,
 		Title:     "Before Update",
+		Variables: map[string]string{"team": "payments"},
 		CreatedAt: timestamppb.New(time.Now()),
 		UpdatedAt: timestamppb.New(time.Now()),
 	}
 	s.Require().NoError(s.conv.Create(context.Background(), c))
 
 	c.Title = "After Update"
+	c.Variables = map[string]string{"team": "search", "region": "eu"}
+	c.RoleVersion = 3
 	s.Require().NoError(s.conv.Update(context.Background(), c.Uuid, c))
 
 	got, err := s.conv.Get(context.Background(), c.Uuid)
 	s.Require().NoError(err)
 	s.Equal("After Update", got.GetTitle())
+	s.Equal(map[string]string{"team": "search", "region": "eu"}, got.GetVariables())
+	s.Equal(int32(3), got.GetRoleVersion())
 }
 
 func (s *ConversationRepoTestSuite) TestDelete() {
@@ -125,6 +139,331 @@ func (s *ConversationRepoTestSuite) TestList() {
 	s.GreaterOrEqual(len(list), 3)
 }
 
+func (s *ConversationRepoTestSuite) TestList_FiltersByOwner() {
+	for i, owner := range []string{"alice", "bob"} {
+		c := &v1.Conversation{
+			Uuid:      [2]string{convUUID1, convUUID2}[i],
+			Title:     owner + "'s chat",
+			Owner:     owner,
+			CreatedAt: timestamppb.New(time.Now()),
+			UpdatedAt: timestamppb.New(time.Now()),
+		}
+		s.Require().NoError(s.conv.Create(context.Background(), c))
+	}
+
+	list, err := s.conv.List(context.Background(), "", 10, map[string][]any{"owner": {"alice"}})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Equal("alice", list[0].GetOwner())
+}
+
+func (s *ConversationRepoTestSuite) TestList_KeysetPagination() {
+	base := time.Now().Truncate(time.Second)
+	for i, id := range []string{convUUID1, convUUID2, convUUID3} {
+		c := &v1.Conversation{
+			Uuid:      id,
+			RoleUuid:  roleUUID1,
+			CreatedAt: timestamppb.New(base),
+			UpdatedAt: timestamppb.New(base.Add(-time.Duration(i) * time.Minute)),
+		}
+		s.Require().NoError(s.conv.Create(context.Background(), c))
+	}
+
+	first, err := s.conv.List(context.Background(), "", 2, nil)
+	s.Require().NoError(err)
+	s.Require().Len(first, 2)
+	s.Equal(convUUID1, first[0].GetUuid())
+
+	last := first[1]
+	second, err := s.conv.List(context.Background(), pagination.Encode(last.GetUpdatedAt().AsTime(), last.GetUuid()), 2, nil)
+	s.Require().NoError(err)
+	s.Require().Len(second, 1)
+	s.Equal(convUUID3, second[0].GetUuid())
+
+	_, err = s.conv.List(context.Background(), "not-a-cursor", 2, nil)
+	s.ErrorIs(err, pagination.ErrInvalidCursor)
+
+	filtered, err := s.conv.List(context.Background(), "", 10, map[string][]any{"updated_after": {base.Add(-90 * time.Second)}})
+	s.Require().NoError(err)
+	s.Len(filtered, 2)
+}
+
+func (s *ConversationRepoTestSuite) TestSearch() {
+	ctx := context.Background()
+	messages := &repo.MessageRepo{Conn: s.db}
+	for i, content := range []string{
+		"How do I tune the Kafka consumer group rebalance timeout? <script>alert(1)</script>",
+		"Recipes for sourdough bread",
+	} {
+		c := &v1.Conversation{
+			Uuid:      [2]string{convUUID1, convUUID2}[i],
+			RoleUuid:  roleUUID1,
+			CreatedAt: timestamppb.New(time.Now()),
+			UpdatedAt: timestamppb.New(time.Now()),
+		}
+		s.Require().NoError(s.conv.Create(ctx, c))
+		s.Require().NoError(messages.Create(ctx, &v1.Message{
+			Uuid:             [2]string{convUUID3, convUUID4}[i],
+			ConversationUuid: c.Uuid,
+			Role:             v1.MessageRole_MESSAGE_ROLE_USER,
+			Content:          content,
+			CreatedAt:        timestamppb.New(time.Now()),
+		}))
+	}
+
+	results, err := s.conv.Search(ctx, conversation.SearchQuery{Query: "kafka rebalancing", Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal(convUUID1, results[0].GetConversation().GetUuid())
+	s.Equal(roleUUID1, results[0].GetConversation().GetRoleUuid())
+	s.Require().Len(results[0].GetExcerpts(), 1)
+	s.Contains(results[0].GetExcerpts()[0].GetExcerpt(), "<mark>Kafka</mark>")
+	s.NotContains(results[0].GetExcerpts()[0].GetExcerpt(), "<script>")
+
+	negative := int32(-1)
+	results, err = s.conv.Search(ctx, conversation.SearchQuery{Query: "kafka", Feedback: &negative, Limit: 10})
+	s.Require().NoError(err)
+	s.Empty(results)
+
+	results, err = s.conv.Search(ctx, conversation.SearchQuery{Query: "kafka", RoleUUID: roleUUID2, Limit: 10})
+	s.Require().NoError(err)
+	s.Empty(results)
+}
+
+func (s *ConversationRepoTestSuite) TestTaken() {
+	ctx := context.Background()
+	c := &v1.Conversation{Uuid: convUUID1, CreatedAt: timestamppb.New(time.Now()), UpdatedAt: timestamppb.New(time.Now())}
+	s.Require().NoError(s.conv.Create(ctx, c))
+	s.Require().NoError((&repo.MessageRepo{Conn: s.db}).Create(ctx, &v1.Message{
+		Uuid:             convUUID2,
+		ConversationUuid: convUUID1,
+		Role:             v1.MessageRole_MESSAGE_ROLE_USER,
+		CreatedAt:        timestamppb.New(time.Now()),
+	}))
+
+	taken, err := s.conv.Taken(ctx, []string{convUUID1, convUUID2, convUUID3})
+	s.Require().NoError(err)
+	s.Equal(map[string]bool{convUUID1: true, convUUID2: true}, taken)
+}
+
+func (s *ConversationRepoTestSuite) TestLifecycle() {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	for i, id := range []string{convUUID1, convUUID2, convUUID3} {
+		s.Require().NoError(s.conv.Create(ctx, &v1.Conversation{
+			Uuid:      id,
+			CreatedAt: timestamppb.New(now),
+			UpdatedAt: timestamppb.New(now.Add(-time.Duration(i) * 24 * time.Hour)),
+		}))
+	}
+	archived := now
+	s.Require().NoError(s.conv.SetArchivedAt(ctx, convUUID2, &archived))
+
+	status := func(st v1.ConversationStatus) []string {
+		list, err := s.conv.List(ctx, "", 10, map[string][]any{"status": {st}})
+		s.Require().NoError(err)
+		var ids []string
+		for _, c := range list {
+			ids = append(ids, c.GetUuid())
+		}
+		return ids
+	}
+	s.Equal([]string{convUUID1, convUUID3}, status(v1.ConversationStatus_CONVERSATION_STATUS_UNSPECIFIED))
+	s.Equal([]string{convUUID2}, status(v1.ConversationStatus_CONVERSATION_STATUS_ARCHIVED))
+
+	got, err := s.conv.Get(ctx, convUUID2)
+	s.Require().NoError(err)
+	s.True(got.GetArchivedAt().AsTime().Equal(archived))
+	s.Nil(got.GetDeletedAt())
+
+	// Conversations idle for more than a day are trashed, except archived ones.
+	trashed, err := s.conv.TrashStale(ctx, now.Add(-12*time.Hour), now)
+	s.Require().NoError(err)
+	s.Equal(int64(1), trashed)
+	s.Equal([]string{convUUID3}, status(v1.ConversationStatus_CONVERSATION_STATUS_DELETED))
+
+	ids, err := s.conv.ListTrashed(ctx, now.Add(time.Second))
+	s.Require().NoError(err)
+	s.Equal([]string{convUUID3}, ids)
+	ids, err = s.conv.ListTrashed(ctx, now.Add(-time.Second))
+	s.Require().NoError(err)
+	s.Empty(ids)
+
+	s.Require().NoError(s.conv.SetDeletedAt(ctx, convUUID3, nil))
+	s.Equal([]string{convUUID1, convUUID3}, status(v1.ConversationStatus_CONVERSATION_STATUS_ACTIVE))
+}
+
+func (s *ConversationRepoTestSuite) TestFeedback() {
+	ctx := context.Background()
+	messages := &repo.MessageRepo{Conn: s.db}
+	feedback := &repo.FeedbackRepo{Conn: s.db}
+	day := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	s.Require().NoError(s.conv.Create(ctx, &v1.Conversation{
+		Uuid:      convUUID1,
+		RoleUuid:  roleUUID1,
+		CreatedAt: timestamppb.New(day),
+		UpdatedAt: timestamppb.New(day),
+	}))
+	for _, m := range []*v1.Message{
+		{Uuid: convUUID3, Model: "llama3.1", ResourceUuids: []string{"res-a", "res-b"}},
+		{Uuid: convUUID4, Model: "mistral", ResourceUuids: []string{"res-a"}},
+	} {
+		m.ConversationUuid = convUUID1
+		m.Role = v1.MessageRole_MESSAGE_ROLE_ASSISTANT
+		m.CreatedAt = timestamppb.New(day)
+		s.Require().NoError(messages.Create(ctx, m))
+	}
+	got, err := messages.Get(ctx, convUUID3)
+	s.Require().NoError(err)
+	s.Equal("llama3.1", got.GetModel())
+
+	// alice changes her mind on the first answer; only her latest rating counts.
+	for i, f := range []*v1.Feedback{
+		{MessageUuid: convUUID3, Owner: "alice", Rating: -1},
+		{MessageUuid: convUUID3, Owner: "alice", Rating: 1},
+		{MessageUuid: convUUID3, Owner: "bob", Rating: -1, Reasons: []v1.FeedbackReason{v1.FeedbackReason_FEEDBACK_REASON_HALLUCINATION}},
+		{MessageUuid: convUUID4, Owner: "alice", Rating: -1, Reasons: []v1.FeedbackReason{v1.FeedbackReason_FEEDBACK_REASON_WRONG_SOURCE}, Comment: "old runbook"},
+	} {
+		f.Uuid = fmt.Sprintf("00000000-0000-0000-0000-00000000004%d", i)
+		f.ConversationUuid = convUUID1
+		f.CreatedAt = timestamppb.New(day.Add(time.Duration(i) * time.Minute))
+		s.Require().NoError(feedback.Create(ctx, f))
+	}
+
+	history, err := feedback.List(ctx, "", 10, map[string][]any{"owner": {"alice"}})
+	s.Require().NoError(err)
+	s.Require().Len(history, 3)
+	s.Equal("old runbook", history[0].GetComment())
+	s.Equal([]v1.FeedbackReason{v1.FeedbackReason_FEEDBACK_REASON_WRONG_SOURCE}, history[0].GetReasons())
+
+	report, err := feedback.Report(ctx, conversation.ReportQuery{
+		After:    day.Truncate(24 * time.Hour),
+		Before:   day.Truncate(24 * time.Hour).Add(24 * time.Hour),
+		Interval: v1.FeedbackInterval_FEEDBACK_INTERVAL_DAY,
+	})
+	s.Require().NoError(err)
+	s.Equal(int32(3), report.GetOverall().GetTotal())
+	s.Equal(int32(1), report.GetOverall().GetPositive())
+	s.Equal(int32(2), report.GetOverall().GetNegative())
+	s.InDelta(-1.0/3, report.GetOverall().GetAverageRating(), 0.001)
+
+	s.Require().Len(report.GetByRole(), 1)
+	s.Equal(roleUUID1, report.GetByRole()[0].GetKey())
+
+	s.Require().Len(report.GetByModel(), 2)
+	s.Equal("llama3.1", report.GetByModel()[0].GetKey())
+	s.Equal(int32(2), report.GetByModel()[0].GetTotal())
+	s.Require().Len(report.GetByModel()[0].GetReasons(), 1)
+	s.Equal(v1.FeedbackReason_FEEDBACK_REASON_HALLUCINATION, report.GetByModel()[0].GetReasons()[0].GetReason())
+
+	s.Require().Len(report.GetByResource(), 2)
+	s.Equal("res-a", report.GetByResource()[0].GetKey())
+	s.Equal(int32(2), report.GetByResource()[0].GetNegative())
+	s.Equal("res-b", report.GetByResource()[1].GetKey())
+
+	s.Require().Len(report.GetByPeriod(), 1)
+	s.True(report.GetByPeriod()[0].GetPeriodStart().AsTime().Equal(day.Truncate(24 * time.Hour)))
+
+	mine, err := feedback.Report(ctx, conversation.ReportQuery{After: day.Add(-time.Hour), Before: day.Add(time.Hour), Owner: "bob"})
+	s.Require().NoError(err)
+	s.Equal(int32(1), mine.GetOverall().GetTotal())
+}
+
+func (s *ConversationRepoTestSuite) TestListLabelled() {
+	ctx := context.Background()
+	messages := &repo.MessageRepo{Conn: s.db}
+	feedback := &repo.FeedbackRepo{Conn: s.db}
+	day := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	for _, c := range []*v1.Conversation{{Uuid: convUUID1, RoleUuid: roleUUID1}, {Uuid: convUUID2}} {
+		c.CreatedAt = timestamppb.New(day)
+		c.UpdatedAt = timestamppb.New(day)
+		s.Require().NoError(s.conv.Create(ctx, c))
+	}
+	trashed := day
+	s.Require().NoError(s.conv.SetDeletedAt(ctx, convUUID2, &trashed))
+
+	for i, m := range []*v1.Message{
+		{ConversationUuid: convUUID1, Role: v1.MessageRole_MESSAGE_ROLE_USER, Content: "who owns payments?"},
+		{ConversationUuid: convUUID1, Role: v1.MessageRole_MESSAGE_ROLE_ASSISTANT, Content: "The payments team.", Model: "llama3.1"},
+		{ConversationUuid: convUUID1, Role: v1.MessageRole_MESSAGE_ROLE_ASSISTANT, Content: "Unrated."},
+		{ConversationUuid: convUUID2, Role: v1.MessageRole_MESSAGE_ROLE_ASSISTANT, Content: "In the trash."},
+	} {
+		m.Uuid = fmt.Sprintf("00000000-0000-0000-0000-00000000005%d", i)
+		m.CreatedAt = timestamppb.New(day.Add(time.Duration(i) * time.Minute))
+		s.Require().NoError(messages.Create(ctx, m))
+	}
+	for i, f := range []*v1.Feedback{
+		{MessageUuid: "00000000-0000-0000-0000-000000000051", ConversationUuid: convUUID1, Owner: "alice", Rating: -1},
+		{MessageUuid: "00000000-0000-0000-0000-000000000051", ConversationUuid: convUUID1, Owner: "bob", Rating: 1, Comment: "spot on"},
+		{MessageUuid: "00000000-0000-0000-0000-000000000053", ConversationUuid: convUUID2, Owner: "alice", Rating: 1},
+	} {
+		f.Uuid = fmt.Sprintf("00000000-0000-0000-0000-00000000006%d", i)
+		f.CreatedAt = timestamppb.New(day.Add(time.Duration(i) * time.Hour))
+		s.Require().NoError(feedback.Create(ctx, f))
+	}
+
+	all, err := feedback.ListLabelled(ctx, "", 10, conversation.DatasetQuery{})
+	s.Require().NoError(err)
+	s.Require().Len(all, 1)
+	s.Equal("00000000-0000-0000-0000-000000000051", all[0].Message.GetUuid())
+	s.Equal("who owns payments?", all[0].Query)
+	s.Equal(roleUUID1, all[0].RoleUUID)
+	s.Equal("llama3.1", all[0].Message.GetModel())
+	s.Equal(int32(1), all[0].Feedback.GetRating())
+	s.Equal("spot on", all[0].Feedback.GetComment())
+
+	negative := int32(-1)
+	none, err := feedback.ListLabelled(ctx, "", 10, conversation.DatasetQuery{Rating: &negative})
+	s.Require().NoError(err)
+	s.Empty(none)
+
+	byRole, err := feedback.ListLabelled(ctx, "", 10, conversation.DatasetQuery{RoleUUID: roleUUID2})
+	s.Require().NoError(err)
+	s.Empty(byRole)
+}
+
+func (s *ConversationRepoTestSuite) TestTraceCreateGet() {
+	ctx := context.Background()
+	messages := &repo.MessageRepo{Conn: s.db}
+	traces := &repo.TraceRepo{Conn: s.db}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	msgUUID := "00000000-0000-0000-0000-000000000071"
+
+	s.Require().NoError(s.conv.Create(ctx, &v1.Conversation{Uuid: convUUID1, CreatedAt: timestamppb.New(now), UpdatedAt: timestamppb.New(now)}))
+	s.Require().NoError(messages.Create(ctx, &v1.Message{
+		Uuid: msgUUID, ConversationUuid: convUUID1, Role: v1.MessageRole_MESSAGE_ROLE_ASSISTANT, CreatedAt: timestamppb.New(now),
+	}))
+	s.Require().NoError(traces.Create(ctx, &v1.TurnTrace{
+		MessageUuid:      msgUUID,
+		ConversationUuid: convUUID1,
+		Model:            "llama3.1",
+		SearchQuery:      "who owns payments?",
+		Results:          []*v1.DatasetSnippet{{ResourceUuid: "res-1", Title: "Runbook", Snippet: "payments team", Score: 0.5}},
+		HistoryDepth:     2,
+		Messages:         []*v1.DatasetMessage{{Role: "system", Content: "be brief"}, {Role: "user", Content: "who owns payments?"}},
+		Options:          map[string]string{"retrieval_limit": "5"},
+		Timings:          &v1.TraceTimings{RetrievalMs: 40, GenerationMs: 900, TotalMs: 1000},
+		Owner:            "alice",
+		CreatedAt:        timestamppb.New(now),
+	}))
+
+	trace, err := traces.Get(ctx, msgUUID)
+	s.Require().NoError(err)
+	s.Equal("llama3.1", trace.GetModel())
+	s.Equal("res-1", trace.GetResults()[0].GetResourceUuid())
+	s.InDelta(0.5, trace.GetResults()[0].GetScore(), 1e-6)
+	s.Len(trace.GetMessages(), 2)
+	s.Equal(map[string]string{"retrieval_limit": "5"}, trace.GetOptions())
+	s.Equal(int64(900), trace.GetTimings().GetGenerationMs())
+	s.Equal(int32(2), trace.GetHistoryDepth())
+	s.True(now.Equal(trace.GetCreatedAt().AsTime()))
+
+	_, err = traces.Get(ctx, "00000000-0000-0000-0000-000000000072")
+	s.ErrorIs(err, conversation.ErrTraceNotFound)
+}
+
 func TestConversationRepoTestSuite(t *testing.T) {
 	suite.Run(t, new(ConversationRepoTestSuite))
 }
@@ -151,10 +490,19 @@ func (s *RoleRepoTestSuite) TearDownTest() {
 
 func (s *RoleRepoTestSuite) TestCreateAndGet() {
 	r := &v1.Role{
-		Uuid:         roleUUID1,
-		Name:         "Test Role",
-		SystemPrompt: "You are helpful.",
-		CreatedAt:    timestamppb.New(time.Now()),
+		Uuid:                 roleUUID1,
+		Name:                 "Test Role",
+		SystemPrompt:         "You are helpful.",
+		Examples:             []*v1.RoleExample{{User: "hi", Assistant: "hello"}},
+		ContextTemplate:      "Sources:{{range .Snippets}} {{.Title}}{{end}}",
+		NoContextInstruction: "Say you don't know.",
+		Retrieval: &v1.RoleRetrieval{
+			Collections: []string{"handbook"},
+			ScopePolicy: v1.ScopePolicy_SCOPE_POLICY_WIDEN,
+			Limit:       3,
+		},
+		Guardrails: &v1.RoleGuardrails{BlockedTopics: []string{`\bsalar(y|ies)\b`}, RequireContext: true},
+		CreatedAt:  timestamppb.New(time.Now()),
 	}
 	s.Require().NoError(s.role.Create(context.Background(), r))
 
@@ -162,6 +510,22 @@ func (s *RoleRepoTestSuite) TestCreateAndGet() {
 	s.Require().NoError(err)
 	s.Equal("Test Role", got.GetName())
 	s.Equal("You are helpful.", got.GetSystemPrompt())
+	s.Require().Len(got.GetExamples(), 1)
+	s.Equal("hello", got.GetExamples()[0].GetAssistant())
+	s.Equal("Sources:{{range .Snippets}} {{.Title}}{{end}}", got.GetContextTemplate())
+	s.Equal("Say you don't know.", got.GetNoContextInstruction())
+	s.Equal([]string{"handbook"}, got.GetRetrieval().GetCollections())
+	s.Equal(v1.ScopePolicy_SCOPE_POLICY_WIDEN, got.GetRetrieval().GetScopePolicy())
+	s.Equal(int32(3), got.GetRetrieval().GetLimit())
+	s.Equal([]string{`\bsalar(y|ies)\b`}, got.GetGuardrails().GetBlockedTopics())
+	s.True(got.GetGuardrails().GetRequireContext())
+
+	first, err := s.role.GetVersion(context.Background(), r.Uuid, 1)
+	s.Require().NoError(err)
+	s.Len(first.GetExamples(), 1)
+	s.Equal("Say you don't know.", first.GetNoContextInstruction())
+	s.Equal(int32(3), first.GetRetrieval().GetLimit())
+	s.True(first.GetGuardrails().GetRequireContext())
 }
 
 func (s *RoleRepoTestSuite) TestUpdate() {
@@ -179,6 +543,20 @@ func (s *RoleRepoTestSuite) TestUpdate() {
 	got, err := s.role.Get(context.Background(), r.Uuid)
 	s.Require().NoError(err)
 	s.Equal("After", got.GetName())
+	s.Equal(int32(2), got.GetVersion())
+
+	versions, err := s.role.ListVersions(context.Background(), r.Uuid)
+	s.Require().NoError(err)
+	s.Require().Len(versions, 2)
+	s.Equal(int32(2), versions[0].GetVersion())
+	s.Equal("Before", versions[1].GetName())
+
+	first, err := s.role.GetVersion(context.Background(), r.Uuid, 1)
+	s.Require().NoError(err)
+	s.Equal("Before", first.GetName())
+
+	_, err = s.role.GetVersion(context.Background(), r.Uuid, 3)
+	s.ErrorIs(err, role.ErrVersionNotFound)
 }
 
 func (s *RoleRepoTestSuite) TestDelete() {
@@ -194,6 +572,146 @@ func (s *RoleRepoTestSuite) TestDelete() {
 	s.Require().Error(err)
 }
 
+func (s *RoleRepoTestSuite) TestWorkspaceIsolation() {
+	_, err := s.db.DB().Exec("INSERT INTO workspaces (uuid, name) VALUES ($1, 'team-b') ON CONFLICT DO NOTHING", wsUUID1)
+	s.Require().NoError(err)
+	teamA := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "alice"})
+	teamB := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "bob", WorkspaceUUID: wsUUID1})
+
+	r := &v1.Role{
+		Uuid:          roleUUID1,
+		Name:          "Team B",
+		WorkspaceUuid: "ignored",
+		CreatedAt:     timestamppb.New(time.Now()),
+	}
+	s.Require().NoError(s.role.Create(teamB, r))
+	s.Equal(wsUUID1, r.GetWorkspaceUuid())
+
+	_, err = s.role.Get(teamA, roleUUID1)
+	s.Require().Error(err)
+	list, err := s.role.List(teamA, "", 10, nil)
+	s.Require().NoError(err)
+	s.Empty(list)
+
+	got, err := s.role.Get(teamB, roleUUID1)
+	s.Require().NoError(err)
+	s.Equal(wsUUID1, got.GetWorkspaceUuid())
+
+	inUse, err := (&repo.WorkspaceRepo{Conn: s.db}).InUse(context.Background(), wsUUID1)
+	s.Require().NoError(err)
+	s.True(inUse)
+}
+
 func TestRoleRepoTestSuite(t *testing.T) {
 	suite.Run(t, new(RoleRepoTestSuite))
 }
+
+// --- Resource repo suite ---
+
+type ResourceRepoTestSuite struct {
+	suite.Suite
+	db        *repo.Conn
+	resources *repo.ResourceRepo
+}
+
+func (s *ResourceRepoTestSuite) SetupTest() {
+	db, err := repo.NewDatabase(integrationDSN)
+	s.Require().NoError(err)
+	s.db = db
+	s.resources = &repo.ResourceRepo{Conn: db}
+}
+
+func (s *ResourceRepoTestSuite) TearDownTest() {
+	_, _ = s.db.DB().Exec("DELETE FROM resources")
+	s.db.Close()
+}
+
+func (s *ResourceRepoTestSuite) TestCollectionsAndTags() {
+	ctx := context.Background()
+	now := timestamppb.New(time.Now())
+	for _, r := range []*v1.Resource{
+		{Uuid: resUUID1, Name: "Handbook", Collection: "handbook", CreatedAt: now, IndexedAt: now},
+		{Uuid: resUUID2, Name: "Runbook", Tags: []string{"ops", "oncall"}, CreatedAt: now, IndexedAt: now},
+		{Uuid: resUUID3, Name: "Notes", CreatedAt: now, IndexedAt: now},
+	} {
+		s.Require().NoError(s.resources.Create(ctx, r))
+	}
+
+	got, err := s.resources.Get(ctx, resUUID2)
+	s.Require().NoError(err)
+	s.Equal([]string{"ops", "oncall"}, got.GetTags())
+
+	list, err := s.resources.List(ctx, "", 10, map[string][]any{"collection": {"handbook"}})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Equal(resUUID1, list[0].GetUuid())
+
+	list, err = s.resources.List(ctx, "", 10, map[string][]any{"tag": {"oncall"}})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Equal(resUUID2, list[0].GetUuid())
+
+	matched, err := s.resources.MatchUUIDs(ctx, []string{"handbook"}, []string{"ops"})
+	s.Require().NoError(err)
+	s.Equal([]string{resUUID1, resUUID2}, matched)
+
+	matched, err = s.resources.MatchUUIDs(ctx, nil, nil)
+	s.Require().NoError(err)
+	s.Empty(matched)
+}
+
+func TestResourceRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceRepoTestSuite))
+}
+
+// --- API key repo suite ---
+
+type APIKeyRepoTestSuite struct {
+	suite.Suite
+	db   *repo.Conn
+	keys *repo.APIKeyRepo
+}
+
+func (s *APIKeyRepoTestSuite) SetupTest() {
+	db, err := repo.NewDatabase(integrationDSN)
+	s.Require().NoError(err)
+	s.db = db
+	s.keys = &repo.APIKeyRepo{Conn: db}
+}
+
+func (s *APIKeyRepoTestSuite) TearDownTest() {
+	_, _ = s.db.DB().Exec("DELETE FROM api_keys")
+	s.db.Close()
+}
+
+func (s *APIKeyRepoTestSuite) TestCreateGetAndRevoke() {
+	_, hash, err := auth.GenerateAPIKey()
+	s.Require().NoError(err)
+	k := &auth.APIKey{
+		Uuid:      keyUUID1,
+		Name:      "ci",
+		Owner:     "alice",
+		KeyHash:   hash,
+		Scopes:    []string{auth.ScopeAdmin},
+		CreatedAt: time.Now(),
+	}
+	s.Require().NoError(s.keys.Create(context.Background(), k))
+
+	got, err := s.keys.GetByHash(context.Background(), hash)
+	s.Require().NoError(err)
+	s.Equal("alice", got.Owner)
+	s.Equal([]string{auth.ScopeAdmin}, got.Scopes)
+	s.Nil(got.RevokedAt)
+
+	s.Require().NoError(s.keys.Revoke(context.Background(), keyUUID1))
+	got, err = s.keys.GetByHash(context.Background(), hash)
+	s.Require().NoError(err)
+	s.NotNil(got.RevokedAt)
+
+	_, err = s.keys.GetByHash(context.Background(), auth.HashAPIKey("gsk_unknown"))
+	s.ErrorIs(err, auth.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyRepoTestSuite))
+}
@@ -0,0 +1,180 @@
+// Package kafkasync runs the consumers that keep roles and resources in step
+// with definition events published to Kafka by other services.
+package kafkasync
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+// Headers added to dead-lettered records, next to the original headers.
+const (
+	HeaderError     = "grey-seal-error"
+	HeaderTopic     = "grey-seal-topic"
+	HeaderPartition = "grey-seal-partition"
+	HeaderOffset    = "grey-seal-offset"
+)
+
+// Handler applies one record. An empty value is a tombstone for the key.
+type Handler interface {
+	Handle(ctx context.Context, key, value []byte) error
+}
+
+// Config describes one sync consumer.
+type Config struct {
+	Brokers []string
+	Topic   string
+	Group   string
+	// DeadLetterTopic receives records the handler rejects as invalid, with
+	// the reason in headers. Empty drops them after logging.
+	DeadLetterTopic string
+	Handler         Handler
+	// Invalid marks handler errors that retrying cannot fix, such as
+	// role.ErrInvalidEvent. Postgres constraint violations and data errors
+	// are treated the same way. Other errors are retried with backoff until
+	// they succeed, so a database outage pauses the consumer instead of
+	// losing events.
+	Invalid error
+	Logger  *zap.Logger
+}
+
+// Consumer reads a topic in a consumer group and applies each record in
+// order. Offsets are committed after a batch is applied, so a restart may
+// apply records again; handlers must be idempotent.
+type Consumer struct {
+	cfg     Config
+	client  *kgo.Client
+	backoff func(attempt int) time.Duration
+}
+
+func NewConsumer(cfg Config) (*Consumer, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.ConsumerGroup(cfg.Group),
+		kgo.ConsumeTopics(cfg.Topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.DisableAutoCommit(),
+		kgo.AllowAutoTopicCreation(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Consumer{cfg: cfg, client: client, backoff: backoff}, nil
+}
+
+// backoff doubles from half a second up to thirty seconds.
+func backoff(attempt int) time.Duration {
+	return min(500*time.Millisecond<<min(attempt, 6), 30*time.Second)
+}
+
+// Run consumes until ctx is cancelled or the consumer is closed.
+func (c *Consumer) Run(ctx context.Context) {
+	c.cfg.Logger.Info("sync consumer started", zap.String("topic", c.cfg.Topic), zap.String("group", c.cfg.Group))
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return
+		}
+		fetches.EachError(func(topic string, partition int32, err error) {
+			c.cfg.Logger.Warn("fetch failed", zap.String("topic", topic), zap.Int32("partition", partition), zap.Error(err))
+		})
+		var records []*kgo.Record
+		fetches.EachRecord(func(r *kgo.Record) { records = append(records, r) })
+		for _, r := range records {
+			if !c.apply(ctx, r) {
+				return
+			}
+		}
+		if len(records) == 0 {
+			continue
+		}
+		if err := c.client.CommitRecords(ctx, records...); err != nil {
+			c.cfg.Logger.Warn("failed to commit offsets", zap.String("topic", c.cfg.Topic), zap.Error(err))
+		}
+	}
+}
+
+// apply handles a record, retrying errors that are not invalid events and
+// dead-lettering invalid ones. It returns false when ctx is cancelled first.
+func (c *Consumer) apply(ctx context.Context, r *kgo.Record) bool {
+	for attempt := 0; ; attempt++ {
+		err := c.cfg.Handler.Handle(ctx, r.Key, r.Value)
+		if err == nil {
+			return true
+		}
+		if (c.cfg.Invalid != nil && errors.Is(err, c.cfg.Invalid)) || permanent(err) {
+			return c.deadLetter(ctx, r, err)
+		}
+		c.cfg.Logger.Warn("failed to apply record, retrying",
+			zap.String("topic", r.Topic), zap.Int32("partition", r.Partition), zap.Int64("offset", r.Offset),
+			zap.Int("attempt", attempt+1), zap.Error(err))
+		if !c.wait(ctx, attempt) {
+			return false
+		}
+	}
+}
+
+// permanent reports database errors that would fail the same way on every
+// retry: data exceptions (class 22) and integrity constraint violations
+// (class 23), such as a duplicate key.
+func permanent(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Class() {
+	case "22", "23":
+		return true
+	}
+	return false
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, r *kgo.Record, reason error) bool {
+	log := c.cfg.Logger.With(zap.String("topic", r.Topic), zap.Int32("partition", r.Partition), zap.Int64("offset", r.Offset), zap.NamedError("reason", reason))
+	if c.cfg.DeadLetterTopic == "" {
+		log.Error("dropping invalid record")
+		return true
+	}
+	dead := &kgo.Record{
+		Topic: c.cfg.DeadLetterTopic,
+		Key:   r.Key,
+		Value: r.Value,
+		Headers: append(append([]kgo.RecordHeader(nil), r.Headers...),
+			kgo.RecordHeader{Key: HeaderError, Value: []byte(reason.Error())},
+			kgo.RecordHeader{Key: HeaderTopic, Value: []byte(r.Topic)},
+			kgo.RecordHeader{Key: HeaderPartition, Value: []byte(strconv.Itoa(int(r.Partition)))},
+			kgo.RecordHeader{Key: HeaderOffset, Value: []byte(strconv.FormatInt(r.Offset, 10))},
+		),
+	}
+	for attempt := 0; ; attempt++ {
+		err := c.client.ProduceSync(ctx, dead).FirstErr()
+		if err == nil {
+			log.Warn("dead-lettered invalid record", zap.String("dead_letter_topic", c.cfg.DeadLetterTopic))
+			return true
+		}
+		log.Warn("failed to dead-letter record, retrying", zap.Int("attempt", attempt+1), zap.Error(err))
+		if !c.wait(ctx, attempt) {
+			return false
+		}
+	}
+}
+
+func (c *Consumer) wait(ctx context.Context, attempt int) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(c.backoff(attempt)):
+		return true
+	}
+}
+
+// Close leaves the consumer group and stops Run.
+func (c *Consumer) Close() {
+	c.client.Close()
+}
@@ -0,0 +1,165 @@
+//go:build integration
+
+package kafkasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
+	"github.com/holmes89/grey-seal/lib/greyseal/role/mocks"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var errInvalid = errors.New("invalid")
+
+// recordingHandler fails the first attempt at each key in failOnce with a
+// retryable error and rejects the value "junk" as invalid.
+type recordingHandler struct {
+	mu       sync.Mutex
+	failOnce map[string]bool
+	applied  []string
+}
+
+func (h *recordingHandler) Handle(_ context.Context, key, value []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.failOnce[string(key)] {
+		delete(h.failOnce, string(key))
+		return errors.New("database unavailable")
+	}
+	if string(value) == "junk" {
+		return fmt.Errorf("%w: cannot decode", errInvalid)
+	}
+	h.applied = append(h.applied, string(key)+"="+string(value))
+	return nil
+}
+
+func (h *recordingHandler) seen() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.applied...)
+}
+
+func startCluster(t *testing.T, topics ...string) (*kfake.Cluster, *kgo.Client) {
+	t.Helper()
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, topics...))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	producer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...))
+	require.NoError(t, err)
+	t.Cleanup(producer.Close)
+	return cluster, producer
+}
+
+func runConsumer(t *testing.T, cfg Config) {
+	t.Helper()
+	c, err := NewConsumer(cfg)
+	require.NoError(t, err)
+	c.backoff = func(int) time.Duration { return time.Millisecond }
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		c.Close()
+	})
+}
+
+func TestConsumer_RetriesAndDeadLetters_Integration(t *testing.T) {
+	cluster, producer := startCluster(t, "defs", "defs.dlq")
+	ctx := context.Background()
+	require.NoError(t, producer.ProduceSync(ctx,
+		&kgo.Record{Topic: "defs", Key: []byte("a"), Value: []byte("one")},
+		&kgo.Record{Topic: "defs", Key: []byte("b"), Value: []byte("junk")},
+		&kgo.Record{Topic: "defs", Key: []byte("a")},
+	).FirstErr())
+
+	handler := &recordingHandler{failOnce: map[string]bool{"a": true}}
+	runConsumer(t, Config{
+		Brokers:         cluster.ListenAddrs(),
+		Topic:           "defs",
+		Group:           "sync-test",
+		DeadLetterTopic: "defs.dlq",
+		Handler:         handler,
+		Invalid:         errInvalid,
+		Logger:          zap.NewNop(),
+	})
+
+	require.Eventually(t, func() bool { return len(handler.seen()) == 2 }, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"a=one", "a="}, handler.seen())
+
+	dlq, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("defs.dlq"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer dlq.Close()
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var dead *kgo.Record
+	for dead == nil {
+		require.NoError(t, waitCtx.Err(), "timed out waiting for the dead letter")
+		dlq.PollFetches(waitCtx).EachRecord(func(r *kgo.Record) { dead = r })
+	}
+	require.Equal(t, "b", string(dead.Key))
+	require.Equal(t, "junk", string(dead.Value))
+	headers := map[string]string{}
+	for _, h := range dead.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	require.Equal(t, "invalid: cannot decode", headers[HeaderError])
+	require.Equal(t, "defs", headers[HeaderTopic])
+	require.Equal(t, "1", headers[HeaderOffset])
+}
+
+func TestConsumer_RoleSync_Integration(t *testing.T) {
+	cluster, producer := startCluster(t, "roles")
+	value, err := proto.Marshal(&greysealv1.Role{Name: "support", SystemPrompt: "Be kind."})
+	require.NoError(t, err)
+	require.NoError(t, producer.ProduceSync(context.Background(),
+		&kgo.Record{Topic: "roles", Key: []byte("support"), Value: value},
+		&kgo.Record{Topic: "roles", Key: []byte(":support")},
+	).FirstErr())
+
+	repo := mocks.NewMockRoleRepository(t)
+	deleted := make(chan struct{})
+	repo.On("List", mock.Anything, "", uint(2), map[string][]any{"name": {"support"}}).Return(nil, nil).Once()
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(r *greysealv1.Role) bool {
+		return r.GetName() == "support" && r.GetUuid() != ""
+	})).Return(nil)
+	repo.On("List", mock.Anything, "", uint(0), map[string][]any{"uuid": {"support"}}).Return(nil, nil)
+	repo.On("List", mock.Anything, "", uint(0), map[string][]any{"name": {"support"}}).
+		Return([]*greysealv1.Role{{Uuid: "r1", Name: "support"}}, nil)
+	repo.On("Delete", mock.Anything, "r1").Return(nil).Run(func(mock.Arguments) { close(deleted) })
+
+	runConsumer(t, Config{
+		Brokers: cluster.ListenAddrs(),
+		Topic:   "roles",
+		Group:   "role-sync-test",
+		Handler: role.NewSyncHandler(repo, zap.NewNop()),
+		Invalid: role.ErrInvalidEvent,
+		Logger:  zap.NewNop(),
+	})
+
+	select {
+	case <-deleted:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the tombstone")
+	}
+}
@@ -0,0 +1,47 @@
+package kafkasync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+// failingHandler returns errs in turn, then nil.
+type failingHandler struct {
+	errs  []error
+	calls int
+}
+
+func (h *failingHandler) Handle(context.Context, []byte, []byte) error {
+	h.calls++
+	if h.calls > len(h.errs) {
+		return nil
+	}
+	return h.errs[h.calls-1]
+}
+
+func newTestConsumer(h Handler) *Consumer {
+	return &Consumer{
+		cfg:     Config{Handler: h, Logger: zap.NewNop()},
+		backoff: func(int) time.Duration { return 0 },
+	}
+}
+
+func TestApply_RetriesTransientErrors(t *testing.T) {
+	h := &failingHandler{errs: []error{errors.New("connection refused"), errors.New("connection refused")}}
+	assert.True(t, newTestConsumer(h).apply(context.Background(), &kgo.Record{}))
+	assert.Equal(t, 3, h.calls)
+}
+
+func TestApply_DropsConstraintViolations(t *testing.T) {
+	h := &failingHandler{errs: []error{fmt.Errorf("create role: %w", &pq.Error{Code: "23505"})}}
+	assert.True(t, newTestConsumer(h).apply(context.Background(), &kgo.Record{}))
+	assert.Equal(t, 1, h.calls)
+}
@@ -12,7 +12,8 @@ import (
 
 // LangchainLLM wraps a golangchain model to implement conversation.LLM.
 type LangchainLLM struct {
-	model llms.Model
+	model     llms.Model
+	modelName string
 }
 
 var _ conversation.LLM = (*LangchainLLM)(nil)
@@ -39,7 +40,12 @@ func New(ollamaHost, modelName string) (*LangchainLLM, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &LangchainLLM{model: m}, nil
+	return &LangchainLLM{model: m, modelName: modelName}, nil
+}
+
+// ModelName returns the Ollama model name, recorded on the messages it generates.
+func (l *LangchainLLM) ModelName() string {
+	return l.modelName
 }
 
 // Chat sends messages to Ollama via golangchain and streams tokens via the
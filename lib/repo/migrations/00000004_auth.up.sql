@@ -0,0 +1,25 @@
+-- +goose Up
+
+ALTER TABLE conversations ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+ALTER TABLE messages ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_conversations_owner ON conversations(owner, updated_at);
+
+-- API keys are stored as a SHA-256 hex digest; the plaintext is shown once on creation.
+CREATE TABLE api_keys (
+    uuid TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    owner TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+
+-- +goose Down
+
+DROP TABLE IF EXISTS api_keys;
+DROP INDEX IF EXISTS idx_conversations_owner;
+ALTER TABLE messages DROP COLUMN IF EXISTS owner;
+ALTER TABLE conversations DROP COLUMN IF EXISTS owner;
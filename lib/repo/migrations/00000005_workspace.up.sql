@@ -0,0 +1,36 @@
+-- +goose Up
+
+CREATE TABLE workspaces (
+    uuid TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Existing data and principals without an explicit workspace belong to the default workspace.
+INSERT INTO workspaces (uuid, name, created_at)
+VALUES ('00000000-0000-0000-0000-000000000000', 'default', NOW());
+
+ALTER TABLE roles ADD COLUMN workspace_uuid TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE resources ADD COLUMN workspace_uuid TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE conversations ADD COLUMN workspace_uuid TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE messages ADD COLUMN workspace_uuid TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE api_keys ADD COLUMN workspace_uuid TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+
+CREATE INDEX idx_roles_workspace_uuid ON roles(workspace_uuid);
+CREATE INDEX idx_resources_workspace_uuid ON resources(workspace_uuid);
+CREATE INDEX idx_conversations_workspace_uuid ON conversations(workspace_uuid, updated_at);
+CREATE INDEX idx_messages_workspace_uuid ON messages(workspace_uuid);
+
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_messages_workspace_uuid;
+DROP INDEX IF EXISTS idx_conversations_workspace_uuid;
+DROP INDEX IF EXISTS idx_resources_workspace_uuid;
+DROP INDEX IF EXISTS idx_roles_workspace_uuid;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS workspace_uuid;
+ALTER TABLE messages DROP COLUMN IF EXISTS workspace_uuid;
+ALTER TABLE conversations DROP COLUMN IF EXISTS workspace_uuid;
+ALTER TABLE resources DROP COLUMN IF EXISTS workspace_uuid;
+ALTER TABLE roles DROP COLUMN IF EXISTS workspace_uuid;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Full-text search over message history. The vector is maintained by a
+-- trigger so every writer (API, importers, backfills) keeps it current.
+ALTER TABLE messages ADD COLUMN search_vector tsvector;
+
+UPDATE messages SET search_vector = to_tsvector('english', content);
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector);
+
+-- +goose StatementBegin
+CREATE FUNCTION messages_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', coalesce(NEW.content, ''));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER messages_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF content ON messages
+    FOR EACH ROW EXECUTE FUNCTION messages_search_vector_update();
+
+
+-- +goose Down
+
+DROP TRIGGER IF EXISTS messages_search_vector_trigger ON messages;
+DROP FUNCTION IF EXISTS messages_search_vector_update();
+DROP INDEX IF EXISTS idx_messages_search_vector;
+ALTER TABLE messages DROP COLUMN IF EXISTS search_vector;
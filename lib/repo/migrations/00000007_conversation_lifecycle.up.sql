@@ -0,0 +1,14 @@
+-- +goose Up
+
+ALTER TABLE conversations ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE conversations ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- The retention job scans the trash by deletion time.
+CREATE INDEX idx_conversations_deleted_at ON conversations(deleted_at) WHERE deleted_at IS NOT NULL;
+
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_conversations_deleted_at;
+ALTER TABLE conversations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE conversations DROP COLUMN IF EXISTS archived_at;
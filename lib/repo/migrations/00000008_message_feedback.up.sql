@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- The model that generated each assistant message, for feedback reports.
+ALTER TABLE messages ADD COLUMN model TEXT NOT NULL DEFAULT '';
+
+-- Every feedback submission is kept. messages.feedback still holds the
+-- latest rating for search filters and exports.
+CREATE TABLE message_feedback (
+    uuid              TEXT PRIMARY KEY,
+    message_uuid      TEXT NOT NULL REFERENCES messages(uuid) ON DELETE CASCADE,
+    conversation_uuid TEXT NOT NULL,
+    rating            INTEGER NOT NULL,
+    reasons           INTEGER[] NOT NULL DEFAULT '{}',
+    comment           TEXT NOT NULL DEFAULT '',
+    owner             TEXT NOT NULL DEFAULT '',
+    workspace_uuid    TEXT NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_message_feedback_message ON message_feedback(message_uuid, owner, created_at);
+CREATE INDEX idx_message_feedback_workspace ON message_feedback(workspace_uuid, created_at);
+CREATE INDEX idx_message_feedback_owner ON message_feedback(owner, created_at);
+
+-- Carry existing ratings over as one record each, attributed to the
+-- conversation owner.
+INSERT INTO message_feedback (uuid, message_uuid, conversation_uuid, rating, owner, workspace_uuid, created_at)
+SELECT gen_random_uuid()::text, uuid, conversation_uuid, feedback, owner, workspace_uuid, created_at
+FROM messages
+WHERE feedback <> 0;
+
+
+-- +goose Down
+
+DROP TABLE IF EXISTS message_feedback;
+ALTER TABLE messages DROP COLUMN IF EXISTS model;
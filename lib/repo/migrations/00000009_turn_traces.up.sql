@@ -0,0 +1,34 @@
+-- +goose Up
+
+-- One trace per assistant message: what went into the prompt and how long
+-- each phase of the turn took. Timings are columns so slow turns can be
+-- found with plain SQL.
+CREATE TABLE turn_traces (
+    message_uuid       TEXT PRIMARY KEY REFERENCES messages(uuid) ON DELETE CASCADE,
+    conversation_uuid  TEXT NOT NULL,
+    role_uuid          TEXT NOT NULL DEFAULT '',
+    model              TEXT NOT NULL DEFAULT '',
+    search_query       TEXT NOT NULL DEFAULT '',
+    results            JSONB NOT NULL DEFAULT '[]',
+    summary            TEXT NOT NULL DEFAULT '',
+    history_depth      INTEGER NOT NULL DEFAULT 0,
+    messages           JSONB NOT NULL DEFAULT '[]',
+    options            JSONB NOT NULL DEFAULT '{}',
+    load_ms            BIGINT NOT NULL DEFAULT 0,
+    summarize_ms       BIGINT NOT NULL DEFAULT 0,
+    retrieval_ms       BIGINT NOT NULL DEFAULT 0,
+    first_token_ms     BIGINT NOT NULL DEFAULT 0,
+    generation_ms      BIGINT NOT NULL DEFAULT 0,
+    total_ms           BIGINT NOT NULL DEFAULT 0,
+    owner              TEXT NOT NULL DEFAULT '',
+    workspace_uuid     TEXT NOT NULL,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_turn_traces_conversation ON turn_traces(conversation_uuid, created_at);
+CREATE INDEX idx_turn_traces_workspace ON turn_traces(workspace_uuid, created_at);
+
+
+-- +goose Down
+
+DROP TABLE IF EXISTS turn_traces;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Custom per-conversation values that templated role prompts read as
+-- {{.Vars.name}}.
+ALTER TABLE conversations ADD COLUMN variables JSONB NOT NULL DEFAULT '{}';
+
+
+-- +goose Down
+
+ALTER TABLE conversations DROP COLUMN IF EXISTS variables;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- Every create, update and rollback of a role writes an immutable version.
+-- roles keeps the latest content and its version number.
+ALTER TABLE roles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE role_versions (
+    role_uuid      TEXT NOT NULL REFERENCES roles(uuid) ON DELETE CASCADE,
+    version        INTEGER NOT NULL,
+    name           TEXT NOT NULL,
+    system_prompt  TEXT NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by     TEXT NOT NULL DEFAULT '',
+    workspace_uuid TEXT NOT NULL,
+    PRIMARY KEY (role_uuid, version)
+);
+
+INSERT INTO role_versions (role_uuid, version, name, system_prompt, created_at, workspace_uuid)
+SELECT uuid, 1, name, system_prompt, created_at, workspace_uuid FROM roles;
+
+-- 0 follows the latest version.
+ALTER TABLE conversations ADD COLUMN role_version INTEGER NOT NULL DEFAULT 0;
+
+-- The role version whose prompt produced an assistant message.
+ALTER TABLE messages ADD COLUMN role_uuid TEXT NOT NULL DEFAULT '';
+ALTER TABLE messages ADD COLUMN role_version INTEGER NOT NULL DEFAULT 0;
+
+
+-- +goose Down
+
+ALTER TABLE messages DROP COLUMN IF EXISTS role_version;
+ALTER TABLE messages DROP COLUMN IF EXISTS role_uuid;
+ALTER TABLE conversations DROP COLUMN IF EXISTS role_version;
+DROP TABLE IF EXISTS role_versions;
+ALTER TABLE roles DROP COLUMN IF EXISTS version;
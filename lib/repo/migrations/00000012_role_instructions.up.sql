@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Few-shot examples ([{"user": ..., "assistant": ...}]), the snippet
+-- formatting template and the no-context instruction, on roles and on each
+-- version.
+ALTER TABLE roles ADD COLUMN examples JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE roles ADD COLUMN context_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE roles ADD COLUMN no_context_instruction TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE role_versions ADD COLUMN examples JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE role_versions ADD COLUMN context_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE role_versions ADD COLUMN no_context_instruction TEXT NOT NULL DEFAULT '';
+
+
+-- +goose Down
+
+ALTER TABLE role_versions DROP COLUMN IF EXISTS no_context_instruction;
+ALTER TABLE role_versions DROP COLUMN IF EXISTS context_template;
+ALTER TABLE role_versions DROP COLUMN IF EXISTS examples;
+ALTER TABLE roles DROP COLUMN IF EXISTS no_context_instruction;
+ALTER TABLE roles DROP COLUMN IF EXISTS context_template;
+ALTER TABLE roles DROP COLUMN IF EXISTS examples;
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Resources can be grouped into collections and tagged so roles can scope
+-- retrieval to them.
+ALTER TABLE resources ADD COLUMN collection TEXT NOT NULL DEFAULT '';
+ALTER TABLE resources ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX idx_resources_collection ON resources(workspace_uuid, collection);
+CREATE INDEX idx_resources_tags ON resources USING GIN (tags);
+
+-- A role's RoleRetrieval: scope, scope policy and retrieval defaults.
+ALTER TABLE roles ADD COLUMN retrieval JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE role_versions ADD COLUMN retrieval JSONB NOT NULL DEFAULT '{}';
+
+
+-- +goose Down
+
+ALTER TABLE role_versions DROP COLUMN IF EXISTS retrieval;
+ALTER TABLE roles DROP COLUMN IF EXISTS retrieval;
+DROP INDEX IF EXISTS idx_resources_tags;
+DROP INDEX IF EXISTS idx_resources_collection;
+ALTER TABLE resources DROP COLUMN IF EXISTS tags;
+ALTER TABLE resources DROP COLUMN IF EXISTS collection;
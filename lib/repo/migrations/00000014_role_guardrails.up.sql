@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- A role's RoleGuardrails: the policy checks run around its chat turns.
+ALTER TABLE roles ADD COLUMN guardrails JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE role_versions ADD COLUMN guardrails JSONB NOT NULL DEFAULT '{}';
+
+
+-- +goose Down
+
+ALTER TABLE role_versions DROP COLUMN IF EXISTS guardrails;
+ALTER TABLE roles DROP COLUMN IF EXISTS guardrails;
@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Set on assistant messages whose generation the user stopped; content holds
+-- the partial answer.
+ALTER TABLE messages ADD COLUMN stopped BOOLEAN NOT NULL DEFAULT false;
+
+
+-- +goose Down
+
+ALTER TABLE messages DROP COLUMN IF EXISTS stopped;
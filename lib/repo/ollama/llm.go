@@ -8,19 +8,32 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
 )
 
+var (
+	_ conversation.ModelNamer     = (*LLM)(nil)
+	_ conversation.ModelSelector  = (*LLM)(nil)
+	_ conversation.OptionReporter = (*LLM)(nil)
+)
+
 // LLM calls the Ollama /api/chat endpoint with streaming support.
 type LLM struct {
-	host   string
-	model  string
-	think  bool
-	client *http.Client
+	host        string
+	model       string
+	think       bool
+	extraModels []string // additional models kept available alongside model
+	keepAlive   string   // Ollama keep_alive duration; empty uses the server default
+	pullMissing bool     // pull configured models that are not installed on startup
+	client      *http.Client
 }
 
 // NewLLM creates an LLM using OLLAMA_HOST, OLLAMA_CHAT_MODEL, and OLLAMA_THINK env vars.
+// OLLAMA_MODELS (comma-separated extra models), OLLAMA_KEEP_ALIVE and
+// OLLAMA_PULL_MODELS configure the startup lifecycle in EnsureModels.
 func NewLLM() *LLM {
 	host := os.Getenv("OLLAMA_HOST")
 	if host == "" {
@@ -30,12 +43,42 @@ func NewLLM() *LLM {
 	if model == "" {
 		model = "deepseek-r1"
 	}
+	var extraModels []string
+	for _, m := range strings.Split(os.Getenv("OLLAMA_MODELS"), ",") {
+		if m = strings.TrimSpace(m); m != "" && m != model {
+			extraModels = append(extraModels, m)
+		}
+	}
 	return &LLM{
-		host:   host,
-		model:  model,
-		think:  os.Getenv("OLLAMA_THINK") == "true",
-		client: &http.Client{},
+		host:        host,
+		model:       model,
+		think:       os.Getenv("OLLAMA_THINK") == "true",
+		extraModels: extraModels,
+		keepAlive:   os.Getenv("OLLAMA_KEEP_ALIVE"),
+		pullMissing: os.Getenv("OLLAMA_PULL_MODELS") == "true",
+		client:      &http.Client{},
+	}
+}
+
+// ModelName returns the chat model, recorded on the messages it generates.
+func (l *LLM) ModelName() string {
+	return l.model
+}
+
+// WithModel returns a copy of the LLM that chats with another model.
+func (l *LLM) WithModel(name string) conversation.LLM {
+	c := *l
+	c.model = name
+	return &c
+}
+
+// ChatOptions returns the options sent with each chat request.
+func (l *LLM) ChatOptions() map[string]string {
+	options := map[string]string{"think": strconv.FormatBool(l.think)}
+	if l.keepAlive != "" {
+		options["keep_alive"] = l.keepAlive
 	}
+	return options
 }
 
 type ollamaMessage struct {
@@ -44,10 +87,11 @@ type ollamaMessage struct {
 }
 
 type chatRequest struct {
-	Model    string          `json:"model"`
-	Messages []ollamaMessage `json:"messages"`
-	Stream   bool            `json:"stream"`
-	Think    bool            `json:"think"`
+	Model     string          `json:"model"`
+	Messages  []ollamaMessage `json:"messages"`
+	Stream    bool            `json:"stream"`
+	Think     bool            `json:"think"`
+	KeepAlive string          `json:"keep_alive,omitempty"`
 }
 
 type chatChunk struct {
@@ -69,10 +113,11 @@ func (l *LLM) Chat(ctx context.Context, messages []conversation.LLMMessage, stre
 	}
 
 	reqBody := chatRequest{
-		Model:    l.model,
-		Messages: ollamaMsgs,
-		Stream:   true,
-		Think:    l.think,
+		Model:     l.model,
+		Messages:  ollamaMsgs,
+		Stream:    true,
+		Think:     l.think,
+		KeepAlive: l.keepAlive,
 	}
 
 	data, err := json.Marshal(reqBody)
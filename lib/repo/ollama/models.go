@@ -0,0 +1,266 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/model"
+)
+
+var _ model.Manager = (*LLM)(nil)
+
+type tagsResponse struct {
+	Models []struct {
+		Name       string    `json:"name"`
+		Size       int64     `json:"size"`
+		Digest     string    `json:"digest"`
+		ModifiedAt time.Time `json:"modified_at"`
+	} `json:"models"`
+}
+
+type psResponse struct {
+	Models []struct {
+		Name      string    `json:"name"`
+		ExpiresAt time.Time `json:"expires_at"`
+	} `json:"models"`
+}
+
+type pullRequest struct {
+	Model  string `json:"model"`
+	Stream bool   `json:"stream"`
+}
+
+type pullChunk struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+	Error     string `json:"error"`
+}
+
+// generateRequest is an empty-prompt /api/generate call, which Ollama treats
+// as a request to load (or, with keep_alive 0, unload) the model.
+type generateRequest struct {
+	Model     string `json:"model"`
+	Stream    bool   `json:"stream"`
+	KeepAlive any    `json:"keep_alive,omitempty"`
+}
+
+// Models returns the configured chat model followed by any extra models from OLLAMA_MODELS.
+func (l *LLM) Models() []string {
+	return append([]string{l.model}, l.extraModels...)
+}
+
+// ListModels returns the models installed in Ollama, marking those currently loaded in memory.
+func (l *LLM) ListModels(ctx context.Context) ([]model.ModelInfo, error) {
+	var tags tagsResponse
+	if err := l.getJSON(ctx, "/api/tags", &tags); err != nil {
+		return nil, err
+	}
+
+	// /api/ps is best-effort: older Ollama releases do not expose it.
+	loaded := map[string]time.Time{}
+	var ps psResponse
+	if err := l.getJSON(ctx, "/api/ps", &ps); err == nil {
+		for _, m := range ps.Models {
+			loaded[m.Name] = m.ExpiresAt
+		}
+	}
+
+	infos := make([]model.ModelInfo, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		expiresAt, isLoaded := loaded[m.Name]
+		infos = append(infos, model.ModelInfo{
+			Name:       m.Name,
+			Size:       m.Size,
+			Digest:     m.Digest,
+			ModifiedAt: m.ModifiedAt,
+			Loaded:     isLoaded,
+			ExpiresAt:  expiresAt,
+		})
+	}
+	return infos, nil
+}
+
+// PullModel downloads a model via /api/pull, invoking progress for every streamed status line.
+func (l *LLM) PullModel(ctx context.Context, name string, progress func(model.PullProgress) error) error {
+	resp, err := l.postJSON(ctx, "/api/pull", pullRequest{Model: name, Stream: true})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var chunk pullChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != "" {
+			return fmt.Errorf("ollama pull %s: %s", name, chunk.Error)
+		}
+		if progress != nil {
+			if err := progress(model.PullProgress{
+				Status:    chunk.Status,
+				Digest:    chunk.Digest,
+				Total:     chunk.Total,
+				Completed: chunk.Completed,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading ollama pull stream: %w", err)
+	}
+	return nil
+}
+
+// UnloadModel asks Ollama to evict the model from memory immediately.
+func (l *LLM) UnloadModel(ctx context.Context, name string) error {
+	return l.generate(ctx, generateRequest{Model: name, KeepAlive: 0})
+}
+
+// WarmModel loads the model into memory so the first chat does not pay the load cost.
+// The model stays resident for OLLAMA_KEEP_ALIVE (Ollama's default when unset).
+func (l *LLM) WarmModel(ctx context.Context, name string) error {
+	req := generateRequest{Model: name}
+	if l.keepAlive != "" {
+		req.KeepAlive = l.keepAlive
+	}
+	return l.generate(ctx, req)
+}
+
+// EnsureModels checks /api/tags for every configured model, pulls missing ones
+// when OLLAMA_PULL_MODELS is true, and warms the models that are available.
+// Failures are logged rather than returned so that a slow or absent Ollama
+// does not prevent the API from starting.
+func (l *LLM) EnsureModels(ctx context.Context, logger *zap.Logger) {
+	infos, err := l.ListModels(ctx)
+	if err != nil {
+		logger.Warn("failed to list ollama models", zap.String("host", l.host), zap.Error(err))
+		return
+	}
+	installed := map[string]bool{}
+	for _, info := range infos {
+		installed[info.Name] = true
+	}
+
+	for _, name := range l.Models() {
+		if !installed[name] && !installed[name+":latest"] {
+			if !l.pullMissing {
+				logger.Warn("configured ollama model is not installed; set OLLAMA_PULL_MODELS=true to pull it on startup",
+					zap.String("model", name),
+				)
+				continue
+			}
+			logger.Info("pulling missing ollama model", zap.String("model", name))
+			if err := l.PullModel(ctx, name, pullLogger(logger, name)); err != nil {
+				logger.Error("failed to pull ollama model", zap.String("model", name), zap.Error(err))
+				continue
+			}
+			logger.Info("ollama model pulled", zap.String("model", name))
+		}
+		if err := l.WarmModel(ctx, name); err != nil {
+			logger.Warn("failed to warm ollama model", zap.String("model", name), zap.Error(err))
+			continue
+		}
+		logger.Info("ollama model warmed", zap.String("model", name), zap.String("keep_alive", l.keepAlive))
+	}
+}
+
+// pullLogger logs every status change and download progress in 10% steps per layer.
+func pullLogger(logger *zap.Logger, name string) func(model.PullProgress) error {
+	lastStatus := ""
+	lastStep := map[string]int64{}
+	return func(p model.PullProgress) error {
+		if p.Total > 0 && p.Digest != "" {
+			step := p.Completed * 10 / p.Total
+			if prev, ok := lastStep[p.Digest]; ok && step <= prev {
+				return nil
+			}
+			lastStep[p.Digest] = step
+			logger.Info("ollama pull progress",
+				zap.String("model", name),
+				zap.String("digest", p.Digest),
+				zap.Int64("completed", p.Completed),
+				zap.Int64("total", p.Total),
+				zap.Int64("percent", step*10),
+			)
+			return nil
+		}
+		if p.Status != lastStatus {
+			lastStatus = p.Status
+			logger.Info("ollama pull status", zap.String("model", name), zap.String("status", p.Status))
+		}
+		return nil
+	}
+}
+
+func (l *LLM) generate(ctx context.Context, body generateRequest) error {
+	resp, err := l.postJSON(ctx, "/api/generate", body)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (l *LLM) getJSON(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.host+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", path, err)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama %s request failed: %w", path, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama %s returned status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode ollama %s response: %w", path, err)
+	}
+	return nil
+}
+
+// postJSON sends body to path and returns the response when the status is 200.
+// The caller must close the response body.
+func (l *LLM) postJSON(ctx context.Context, path string, body any) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s request: %w", path, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.host+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ollama %s request failed: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		_ = resp.Body.Close()
+		if e.Error != "" {
+			return nil, fmt.Errorf("ollama %s returned status %d: %s", path, resp.StatusCode, e.Error)
+		}
+		return nil, fmt.Errorf("ollama %s returned status %d", path, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,169 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/holmes89/grey-seal/lib/greyseal/model"
+)
+
+// fakeOllama is a minimal in-memory Ollama server covering the model lifecycle endpoints.
+type fakeOllama struct {
+	mu        sync.Mutex
+	installed map[string]bool
+	loaded    map[string]bool
+	pulls     []string
+	generates []map[string]any
+	pullError string
+}
+
+func newFakeOllama(t *testing.T, installed ...string) (*fakeOllama, *LLM) {
+	f := &fakeOllama{installed: map[string]bool{}, loaded: map[string]bool{}}
+	for _, name := range installed {
+		f.installed[name] = true
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, &LLM{host: srv.URL, model: "deepseek-r1", client: srv.Client()}
+}
+
+func (f *fakeOllama) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.URL.Path {
+	case "/api/tags":
+		var models []map[string]any
+		for name := range f.installed {
+			models = append(models, map[string]any{"name": name, "size": 1024, "digest": "sha256:" + name})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"models": models})
+	case "/api/ps":
+		var models []map[string]any
+		for name := range f.loaded {
+			models = append(models, map[string]any{"name": name, "expires_at": "2026-04-16T12:00:00Z"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"models": models})
+	case "/api/pull":
+		var req pullRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.pulls = append(f.pulls, req.Model)
+		if f.pullError != "" {
+			fmt.Fprintf(w, `{"status":"pulling manifest"}`+"\n"+`{"error":%q}`+"\n", f.pullError)
+			return
+		}
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+		for _, done := range []int{0, 50, 100} {
+			fmt.Fprintf(w, `{"status":"pulling abc","digest":"sha256:abc","total":100,"completed":%d}`+"\n", done)
+		}
+		fmt.Fprintln(w, `{"status":"success"}`)
+		f.installed[req.Model+":latest"] = true
+	case "/api/generate":
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.generates = append(f.generates, req)
+		name, _ := req["model"].(string)
+		if !f.installed[name] && !f.installed[name+":latest"] {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "model not found"})
+			return
+		}
+		if keepAlive, ok := req["keep_alive"].(float64); ok && keepAlive == 0 {
+			delete(f.loaded, name)
+		} else {
+			f.loaded[name] = true
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"model": name, "done": true})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestListModels_MarksLoadedModels(t *testing.T) {
+	f, llm := newFakeOllama(t, "deepseek-r1:latest", "llama3:latest")
+	f.loaded["llama3:latest"] = true
+
+	infos, err := llm.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	for _, info := range infos {
+		assert.Equal(t, info.Name == "llama3:latest", info.Loaded, info.Name)
+		assert.Equal(t, int64(1024), info.Size)
+	}
+}
+
+func TestPullModel_StreamsProgress(t *testing.T) {
+	f, llm := newFakeOllama(t)
+
+	var updates []model.PullProgress
+	err := llm.PullModel(context.Background(), "llama3", func(p model.PullProgress) error {
+		updates = append(updates, p)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"llama3"}, f.pulls)
+	require.Len(t, updates, 5)
+	assert.Equal(t, "pulling manifest", updates[0].Status)
+	assert.Equal(t, int64(50), updates[2].Completed)
+	assert.Equal(t, "success", updates[4].Status)
+}
+
+func TestPullModel_StreamErrorIsReturned(t *testing.T) {
+	f, llm := newFakeOllama(t)
+	f.pullError = "pull model manifest: file does not exist"
+
+	err := llm.PullModel(context.Background(), "nope", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file does not exist")
+}
+
+func TestUnloadModel_SendsZeroKeepAlive(t *testing.T) {
+	f, llm := newFakeOllama(t, "deepseek-r1")
+	f.loaded["deepseek-r1"] = true
+
+	require.NoError(t, llm.UnloadModel(context.Background(), "deepseek-r1"))
+	require.Len(t, f.generates, 1)
+	assert.Equal(t, float64(0), f.generates[0]["keep_alive"])
+	assert.False(t, f.loaded["deepseek-r1"])
+}
+
+func TestEnsureModels_PullsMissingAndWarms(t *testing.T) {
+	f, llm := newFakeOllama(t)
+	llm.pullMissing = true
+	llm.keepAlive = "30m"
+
+	llm.EnsureModels(context.Background(), zap.NewNop())
+
+	assert.Equal(t, []string{"deepseek-r1"}, f.pulls)
+	require.Len(t, f.generates, 1)
+	assert.Equal(t, "30m", f.generates[0]["keep_alive"])
+	assert.True(t, f.loaded["deepseek-r1"])
+}
+
+func TestEnsureModels_SkipsPullWhenDisabled(t *testing.T) {
+	f, llm := newFakeOllama(t)
+
+	llm.EnsureModels(context.Background(), zap.NewNop())
+
+	assert.Empty(t, f.pulls)
+	assert.Empty(t, f.generates)
+}
+
+func TestEnsureModels_WarmsInstalledModelsWithoutPulling(t *testing.T) {
+	f, llm := newFakeOllama(t, "deepseek-r1:latest", "nomic-embed-text:latest")
+	llm.extraModels = []string{"nomic-embed-text"}
+	llm.pullMissing = true
+
+	llm.EnsureModels(context.Background(), zap.NewNop())
+
+	assert.Empty(t, f.pulls)
+	assert.Len(t, f.generates, 2)
+}
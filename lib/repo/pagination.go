@@ -0,0 +1,23 @@
+package repo
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
+)
+
+// keyset orders q newest first by (column, uuid) and starts it after cursor.
+// A zero limit returns every remaining row.
+func keyset(q sq.SelectBuilder, column, cursor string, limit uint) (sq.SelectBuilder, error) {
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		return q, err
+	}
+	if after != nil {
+		q = q.Where(sq.Expr("("+column+", uuid) < (?, ?)", after.Time, after.UUID))
+	}
+	q = q.OrderBy(column+" DESC", "uuid DESC")
+	if limit > 0 {
+		q = q.Limit(uint64(limit))
+	}
+	return q, nil
+}
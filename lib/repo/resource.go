@@ -8,6 +8,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/holmes89/archaea/base"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"github.com/lib/pq"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,8 +19,13 @@ type ResourceRepo struct {
 var _ base.Repository[*greysealv1.Resource] = (*ResourceRepo)(nil)
 
 func (r *ResourceRepo) Create(ctx context.Context, b *greysealv1.Resource) error {
+	tags := b.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	b.WorkspaceUuid = workspaceForCreate(ctx, b.WorkspaceUuid)
 	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("resources").
-		Columns("uuid", "name", "service", "entity", "source", "path", "created_at", "indexed_at").
+		Columns("uuid", "name", "service", "entity", "source", "path", "created_at", "indexed_at", "workspace_uuid", "collection", "tags").
 		Values(
 			b.Uuid,
 			b.Name,
@@ -28,12 +34,19 @@ func (r *ResourceRepo) Create(ctx context.Context, b *greysealv1.Resource) error
 			int32(b.Source),
 			b.Path,
 			b.CreatedAt.AsTime(),
-			b.IndexedAt.AsTime()).
+			b.IndexedAt.AsTime(),
+			b.WorkspaceUuid,
+			b.Collection,
+			pq.Array(tags)).
 		RunWith(r.conn).Exec()
 	return err
 }
 
 func (r *ResourceRepo) Update(ctx context.Context, id string, b *greysealv1.Resource) error {
+	tags := b.Tags
+	if tags == nil {
+		tags = []string{}
+	}
 	query, args, err := sq.Update("resources").
 		Set("name", b.Name).
 		Set("service", b.Service).
@@ -41,7 +54,10 @@ func (r *ResourceRepo) Update(ctx context.Context, id string, b *greysealv1.Reso
 		Set("source", int32(b.Source)).
 		Set("path", b.Path).
 		Set("indexed_at", b.IndexedAt.AsTime()).
+		Set("collection", b.Collection).
+		Set("tags", pq.Array(tags)).
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -54,6 +70,7 @@ func (r *ResourceRepo) Update(ctx context.Context, id string, b *greysealv1.Reso
 func (r *ResourceRepo) Delete(ctx context.Context, id string) error {
 	query, args, err := sq.Delete("resources").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -64,74 +81,161 @@ func (r *ResourceRepo) Delete(ctx context.Context, id string) error {
 }
 
 func (r *ResourceRepo) Get(ctx context.Context, id string) (*greysealv1.Resource, error) {
-	resource := &greysealv1.Resource{}
-	var sourceVal int32
-	var createdAtDt time.Time
-	var indexedAtDt time.Time
-	err := sq.StatementBuilder.
+	row := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "name", "service", "entity", "source", "path", "created_at", "indexed_at").
+		Select(resourceColumns...).
 		From("resources").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		RunWith(r.conn).
-		QueryRow().
-		Scan(
-			&resource.Uuid,
-			&resource.Name,
-			&resource.Service,
-			&resource.Entity,
-			&sourceVal,
-			&resource.Path,
-			&createdAtDt,
-			&indexedAtDt,
-		)
+		QueryRow()
+	resource, err := scanResource(row)
 	if err != nil {
 		fmt.Println("error getting resource", err)
 		return nil, err
 	}
-	resource.Source = greysealv1.Source(sourceVal)
-	resource.CreatedAt = timestamppb.New(createdAtDt)
-	resource.IndexedAt = timestamppb.New(indexedAtDt)
 	return resource, nil
 }
 
+// MatchUUIDs returns the resources in any of the collections or carrying any
+// of the tags.
+func (r *ResourceRepo) MatchUUIDs(ctx context.Context, collections, tags []string) ([]string, error) {
+	match := sq.Or{}
+	if len(collections) > 0 {
+		match = append(match, sq.Eq{"collection": collections})
+	}
+	if len(tags) > 0 {
+		match = append(match, sq.Expr("tags && ?", pq.Array(tags)))
+	}
+	if len(match) == 0 {
+		return nil, nil
+	}
+	rows, err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid").
+		From("resources").
+		Where(inWorkspace(ctx)).
+		Where(match).
+		OrderBy("uuid").
+		RunWith(r.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	var uuids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		uuids = append(uuids, id)
+	}
+	return uuids, rows.Err()
+}
+
+// Version summarises the resources of the caller's workspace as their count
+// and newest created_at, so it changes when one is created or deleted.
+func (r *ResourceRepo) Version(ctx context.Context) (string, error) {
+	var count int64
+	var newest time.Time
+	err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("count(*)", "coalesce(max(created_at), 'epoch')").
+		From("resources").
+		Where(inWorkspace(ctx)).
+		RunWith(r.conn).
+		QueryRowContext(ctx).
+		Scan(&count, &newest)
+	if err != nil {
+		return "", fmt.Errorf("resource version: %w", err)
+	}
+	return fmt.Sprintf("%d@%d", count, newest.UnixNano()), nil
+}
+
 func (r *ResourceRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Resource, error) {
 	var resources []*greysealv1.Resource
 
-	rows, err := sq.StatementBuilder.
+	q := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "name", "service", "entity", "source", "path", "created_at", "indexed_at").
+		Select(resourceColumns...).
 		From("resources").
-		RunWith(r.conn).
-		Query()
+		Where(inWorkspace(ctx))
+
+	if ids, ok := filter["uuid"]; ok && len(ids) > 0 {
+		q = q.Where(sq.Eq{"uuid": ids[0]})
+	}
+	if sources, ok := filter["source"]; ok && len(sources) > 0 {
+		q = q.Where(sq.Eq{"source": sources[0]})
+	}
+	if services, ok := filter["service"]; ok && len(services) > 0 {
+		q = q.Where(sq.Eq{"service": services[0]})
+	}
+	if entities, ok := filter["entity"]; ok && len(entities) > 0 {
+		q = q.Where(sq.Eq{"entity": entities[0]})
+	}
+	if collections, ok := filter["collection"]; ok && len(collections) > 0 {
+		q = q.Where(sq.Eq{"collection": collections[0]})
+	}
+	if tags, ok := filter["tag"]; ok && len(tags) > 0 {
+		q = q.Where(sq.Expr("? = ANY(tags)", tags[0]))
+	}
+	// Unindexed resources store the zero time rather than NULL.
+	if indexed, ok := filter["indexed"]; ok && len(indexed) > 0 {
+		if indexed[0] == true {
+			q = q.Where(sq.Gt{"indexed_at": time.Time{}})
+		} else {
+			q = q.Where(sq.Or{sq.Eq{"indexed_at": nil}, sq.LtOrEq{"indexed_at": time.Time{}}})
+		}
+	}
+
+	q, err := keyset(q, "created_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.RunWith(r.conn).Query()
 	if err != nil {
 		fmt.Println("error listing resources", err)
 		return nil, err
 	}
 	defer rows.Close() //nolint:errcheck
 	for rows.Next() {
-		resource := &greysealv1.Resource{}
-		var sourceVal int32
-		var createdAtDt time.Time
-		var indexedAtDt time.Time
-		err := rows.Scan(
-			&resource.Uuid,
-			&resource.Name,
-			&resource.Service,
-			&resource.Entity,
-			&sourceVal,
-			&resource.Path,
-			&createdAtDt,
-			&indexedAtDt,
-		)
+		resource, err := scanResource(rows)
 		if err != nil {
 			fmt.Println("error scanning resource", err)
 			return nil, err
 		}
-		resource.Source = greysealv1.Source(sourceVal)
-		resource.CreatedAt = timestamppb.New(createdAtDt)
-		resource.IndexedAt = timestamppb.New(indexedAtDt)
 		resources = append(resources, resource)
 	}
 	return resources, nil
 }
+
+var resourceColumns = []string{"uuid", "name", "service", "entity", "source", "path", "created_at", "indexed_at", "workspace_uuid", "collection", "tags"}
+
+// scanResource reads a row selected with resourceColumns.
+func scanResource(row sq.RowScanner) (*greysealv1.Resource, error) {
+	resource := &greysealv1.Resource{}
+	var sourceVal int32
+	var createdAtDt time.Time
+	var indexedAtDt time.Time
+	err := row.Scan(
+		&resource.Uuid,
+		&resource.Name,
+		&resource.Service,
+		&resource.Entity,
+		&sourceVal,
+		&resource.Path,
+		&createdAtDt,
+		&indexedAtDt,
+		&resource.WorkspaceUuid,
+		&resource.Collection,
+		pq.Array(&resource.Tags),
+	)
+	if err != nil {
+		return nil, err
+	}
+	resource.Source = greysealv1.Source(sourceVal)
+	resource.CreatedAt = timestamppb.New(createdAtDt)
+	resource.IndexedAt = timestamppb.New(indexedAtDt)
+	return resource, nil
+}
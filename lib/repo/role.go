@@ -2,12 +2,19 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/holmes89/archaea/base"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/role"
 	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -16,40 +23,254 @@ type RoleRepo struct {
 }
 
 var _ base.Repository[*greysealv1.Role] = (*RoleRepo)(nil)
+var _ role.RoleRepository = (*RoleRepo)(nil)
 
+// Create inserts the role as version 1 together with its first version row.
 func (r *RoleRepo) Create(ctx context.Context, b *greysealv1.Role) error {
-	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("roles").
-		Columns("uuid", "name", "system_prompt", "created_at").
+	examples, err := encodeExamples(b.Examples)
+	if err != nil {
+		return err
+	}
+	retrieval, err := encodeRetrieval(b.Retrieval)
+	if err != nil {
+		return err
+	}
+	guardrails, err := encodeGuardrails(b.Guardrails)
+	if err != nil {
+		return err
+	}
+	b.WorkspaceUuid = workspaceForCreate(ctx, b.WorkspaceUuid)
+	b.Version = 1
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("roles").
+		Columns("uuid", "name", "system_prompt", "created_at", "workspace_uuid", "version", "examples", "context_template", "no_context_instruction", "retrieval", "guardrails").
 		Values(
 			b.Uuid,
 			b.Name,
 			b.SystemPrompt,
-			b.CreatedAt.AsTime()).
-		RunWith(r.conn).Exec()
+			b.CreatedAt.AsTime(),
+			b.WorkspaceUuid,
+			b.Version,
+			examples,
+			b.ContextTemplate,
+			b.NoContextInstruction,
+			retrieval,
+			guardrails).
+		RunWith(tx).ExecContext(ctx)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	if err := insertRoleVersion(ctx, tx, b, examples, retrieval, guardrails, b.CreatedAt.AsTime()); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
+// Update replaces the role's name, prompt and instructions and records them
+// as the next version. b.Version is set to the new version number.
 func (r *RoleRepo) Update(ctx context.Context, id string, b *greysealv1.Role) error {
-	query, args, err := sq.Update("roles").
+	examples, err := encodeExamples(b.Examples)
+	if err != nil {
+		return err
+	}
+	retrieval, err := encodeRetrieval(b.Retrieval)
+	if err != nil {
+		return err
+	}
+	guardrails, err := encodeGuardrails(b.Guardrails)
+	if err != nil {
+		return err
+	}
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var createdAtDt time.Time
+	err = sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Update("roles").
 		Set("name", b.Name).
 		Set("system_prompt", b.SystemPrompt).
+		Set("examples", examples).
+		Set("context_template", b.ContextTemplate).
+		Set("no_context_instruction", b.NoContextInstruction).
+		Set("retrieval", retrieval).
+		Set("guardrails", guardrails).
+		Set("version", sq.Expr("version + 1")).
 		Where(sq.Eq{"uuid": id}).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+		Where(inWorkspace(ctx)).
+		Suffix("RETURNING version, created_at, workspace_uuid").
+		RunWith(tx).
+		QueryRowContext(ctx).
+		Scan(&b.Version, &createdAtDt, &b.WorkspaceUuid)
 	if err != nil {
 		return err
 	}
-	_, err = r.conn.ExecContext(ctx, query, args...)
+	b.Uuid = id
+	b.CreatedAt = timestamppb.New(createdAtDt)
+	if err := insertRoleVersion(ctx, tx, b, examples, retrieval, guardrails, time.Now()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertRoleVersion(ctx context.Context, tx *sql.Tx, b *greysealv1.Role, examples, retrieval, guardrails []byte, at time.Time) error {
+	var createdBy string
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		createdBy = p.Subject
+	}
+	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("role_versions").
+		Columns("role_uuid", "version", "name", "system_prompt", "created_at", "created_by", "workspace_uuid", "examples", "context_template", "no_context_instruction", "retrieval", "guardrails").
+		Values(b.Uuid, b.Version, b.Name, b.SystemPrompt, at, createdBy, b.WorkspaceUuid, examples, b.ContextTemplate, b.NoContextInstruction, retrieval, guardrails).
+		RunWith(tx).ExecContext(ctx)
 	return err
 }
 
+// ListVersions returns every version of a role, newest first.
+func (r *RoleRepo) ListVersions(ctx context.Context, roleUUID string) ([]*greysealv1.RoleVersion, error) {
+	rows, err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select(roleVersionColumns...).
+		From("role_versions").
+		Where(sq.Eq{"role_uuid": roleUUID}).
+		Where(inWorkspace(ctx)).
+		OrderBy("version DESC").
+		RunWith(r.conn).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	var versions []*greysealv1.RoleVersion
+	for rows.Next() {
+		v, err := scanRoleVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// GetVersion returns role.ErrVersionNotFound if the role has no such version
+// in the caller's workspace.
+func (r *RoleRepo) GetVersion(ctx context.Context, roleUUID string, version int32) (*greysealv1.RoleVersion, error) {
+	row := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select(roleVersionColumns...).
+		From("role_versions").
+		Where(sq.Eq{"role_uuid": roleUUID, "version": version}).
+		Where(inWorkspace(ctx)).
+		RunWith(r.conn).
+		QueryRowContext(ctx)
+	v, err := scanRoleVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, role.ErrVersionNotFound
+	}
+	return v, err
+}
+
+var roleVersionColumns = []string{"role_uuid", "version", "name", "system_prompt", "created_at", "created_by", "workspace_uuid", "examples", "context_template", "no_context_instruction", "retrieval", "guardrails"}
+
+func scanRoleVersion(row sq.RowScanner) (*greysealv1.RoleVersion, error) {
+	v := &greysealv1.RoleVersion{}
+	var createdAtDt time.Time
+	var examples, retrieval, guardrails []byte
+	if err := row.Scan(&v.RoleUuid, &v.Version, &v.Name, &v.SystemPrompt, &createdAtDt, &v.CreatedBy, &v.WorkspaceUuid,
+		&examples, &v.ContextTemplate, &v.NoContextInstruction, &retrieval, &guardrails); err != nil {
+		return nil, err
+	}
+	v.CreatedAt = timestamppb.New(createdAtDt)
+	var err error
+	if v.Examples, err = decodeExamples(examples); err != nil {
+		return nil, err
+	}
+	if v.Retrieval, err = decodeRetrieval(retrieval); err != nil {
+		return nil, err
+	}
+	v.Guardrails, err = decodeGuardrails(guardrails)
+	return v, err
+}
+
+// roleExample is the JSONB form of a RoleExample.
+type roleExample struct {
+	User      string `json:"user"`
+	Assistant string `json:"assistant"`
+}
+
+// encodeExamples encodes few-shot examples for the JSONB column.
+func encodeExamples(examples []*greysealv1.RoleExample) ([]byte, error) {
+	out := make([]roleExample, 0, len(examples))
+	for _, e := range examples {
+		out = append(out, roleExample{User: e.GetUser(), Assistant: e.GetAssistant()})
+	}
+	return json.Marshal(out)
+}
+
+// encodeRetrieval encodes a role's retrieval settings for the JSONB column.
+func encodeRetrieval(retrieval *greysealv1.RoleRetrieval) ([]byte, error) {
+	if retrieval == nil {
+		return []byte("{}"), nil
+	}
+	return protojson.Marshal(retrieval)
+}
+
+// decodeRetrieval returns nil for a role without retrieval settings.
+func decodeRetrieval(data []byte) (*greysealv1.RoleRetrieval, error) {
+	retrieval := &greysealv1.RoleRetrieval{}
+	if err := protojson.Unmarshal(data, retrieval); err != nil {
+		return nil, err
+	}
+	if proto.Size(retrieval) == 0 {
+		return nil, nil
+	}
+	return retrieval, nil
+}
+
+// encodeGuardrails encodes a role's guardrails for the JSONB column.
+func encodeGuardrails(guardrails *greysealv1.RoleGuardrails) ([]byte, error) {
+	if guardrails == nil {
+		return []byte("{}"), nil
+	}
+	return protojson.Marshal(guardrails)
+}
+
+// decodeGuardrails returns nil for a role without guardrails.
+func decodeGuardrails(data []byte) (*greysealv1.RoleGuardrails, error) {
+	guardrails := &greysealv1.RoleGuardrails{}
+	if err := protojson.Unmarshal(data, guardrails); err != nil {
+		return nil, err
+	}
+	if proto.Size(guardrails) == 0 {
+		return nil, nil
+	}
+	return guardrails, nil
+}
+
+func decodeExamples(data []byte) ([]*greysealv1.RoleExample, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var in []roleExample
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, err
+	}
+	var examples []*greysealv1.RoleExample
+	for _, e := range in {
+		examples = append(examples, &greysealv1.RoleExample{User: e.User, Assistant: e.Assistant})
+	}
+	return examples, nil
+}
+
 func (r *RoleRepo) Delete(ctx context.Context, id string) error {
 	query, args, err := sq.Delete("roles").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
@@ -60,58 +281,88 @@ func (r *RoleRepo) Delete(ctx context.Context, id string) error {
 }
 
 func (r *RoleRepo) Get(ctx context.Context, id string) (*greysealv1.Role, error) {
-	role := &greysealv1.Role{}
-	var created_atDt time.Time
-	err := sq.StatementBuilder.
+	row := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "name", "system_prompt", "created_at").
+		Select(roleColumns...).
 		From("roles").
 		Where(sq.Eq{"uuid": id}).
+		Where(inWorkspace(ctx)).
 		RunWith(r.conn).
-		QueryRow().
-		Scan(
-			&role.Uuid,
-			&role.Name,
-			&role.SystemPrompt,
-			&created_atDt,
-		)
+		QueryRow()
+	role, err := scanRole(row)
 	if err != nil {
 		fmt.Println("error getting role", err)
 		return nil, err
 	}
-	role.CreatedAt = timestamppb.New(created_atDt)
 	return role, nil
 }
 
 func (r *RoleRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Role, error) {
 	var roles []*greysealv1.Role
 
-	rows, err := sq.StatementBuilder.
+	q := sq.StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("uuid", "name", "system_prompt", "created_at").
+		Select(roleColumns...).
 		From("roles").
-		RunWith(r.conn).
-		Query()
+		Where(inWorkspace(ctx))
+	if ids, ok := filter["uuid"]; ok && len(ids) > 0 {
+		q = q.Where(sq.Eq{"uuid": ids[0]})
+	}
+	if names, ok := filter["name"]; ok && len(names) > 0 {
+		q = q.Where(sq.Eq{"name": names[0]})
+	}
+
+	q, err := keyset(q, "created_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.RunWith(r.conn).Query()
 	if err != nil {
 		fmt.Println("error listing roles", err)
 		return nil, err
 	}
 	defer rows.Close() //nolint:errcheck
 	for rows.Next() {
-		role := &greysealv1.Role{}
-		var created_atDt time.Time
-		err := rows.Scan(
-			&role.Uuid,
-			&role.Name,
-			&role.SystemPrompt,
-			&created_atDt,
-		)
+		role, err := scanRole(rows)
 		if err != nil {
 			fmt.Println("error getting role", err)
 			return nil, err
 		}
-		role.CreatedAt = timestamppb.New(created_atDt)
 		roles = append(roles, role)
 	}
 	return roles, nil
 }
+
+var roleColumns = []string{"uuid", "name", "system_prompt", "created_at", "workspace_uuid", "version", "examples", "context_template", "no_context_instruction", "retrieval", "guardrails"}
+
+// scanRole reads a row selected with roleColumns.
+func scanRole(row sq.RowScanner) (*greysealv1.Role, error) {
+	role := &greysealv1.Role{}
+	var createdAtDt time.Time
+	var examples, retrieval, guardrails []byte
+	err := row.Scan(
+		&role.Uuid,
+		&role.Name,
+		&role.SystemPrompt,
+		&createdAtDt,
+		&role.WorkspaceUuid,
+		&role.Version,
+		&examples,
+		&role.ContextTemplate,
+		&role.NoContextInstruction,
+		&retrieval,
+		&guardrails,
+	)
+	if err != nil {
+		return nil, err
+	}
+	role.CreatedAt = timestamppb.New(createdAtDt)
+	if role.Examples, err = decodeExamples(examples); err != nil {
+		return nil, err
+	}
+	if role.Retrieval, err = decodeRetrieval(retrieval); err != nil {
+		return nil, err
+	}
+	role.Guardrails, err = decodeGuardrails(guardrails)
+	return role, err
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TraceRepo persists turn traces. Results and messages are stored as JSONB
+// in the same shape as transcript turns.
+type TraceRepo struct {
+	*Conn
+}
+
+var _ conversation.TraceRepository = (*TraceRepo)(nil)
+
+var traceColumns = []string{
+	"message_uuid", "conversation_uuid", "role_uuid", "model", "search_query", "results", "summary",
+	"history_depth", "messages", "options", "load_ms", "summarize_ms", "retrieval_ms", "first_token_ms",
+	"generation_ms", "total_ms", "owner", "workspace_uuid", "created_at",
+}
+
+func (r *TraceRepo) Create(ctx context.Context, t *greysealv1.TurnTrace) error {
+	results := make([]conversation.SearchResult, 0, len(t.Results))
+	for _, s := range t.Results {
+		results = append(results, conversation.SearchResult{
+			EntityUUID: s.ResourceUuid,
+			Title:      s.Title,
+			Snippet:    s.Snippet,
+			Score:      s.Score,
+		})
+	}
+	messages := make([]conversation.LLMMessage, 0, len(t.Messages))
+	for _, m := range t.Messages {
+		messages = append(messages, conversation.LLMMessage{Role: m.Role, Content: m.Content})
+	}
+	options := t.Options
+	if options == nil {
+		options = map[string]string{}
+	}
+	var encoded [3][]byte
+	for i, v := range []any{results, messages, options} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		encoded[i] = b
+	}
+
+	timings := t.GetTimings()
+	t.WorkspaceUuid = workspaceForCreate(ctx, t.WorkspaceUuid)
+	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("turn_traces").
+		Columns(traceColumns...).
+		Values(
+			t.MessageUuid,
+			t.ConversationUuid,
+			t.RoleUuid,
+			t.Model,
+			t.SearchQuery,
+			encoded[0],
+			t.Summary,
+			t.HistoryDepth,
+			encoded[1],
+			encoded[2],
+			timings.GetLoadMs(),
+			timings.GetSummarizeMs(),
+			timings.GetRetrievalMs(),
+			timings.GetFirstTokenMs(),
+			timings.GetGenerationMs(),
+			timings.GetTotalMs(),
+			t.Owner,
+			t.WorkspaceUuid,
+			t.CreatedAt.AsTime()).
+		RunWith(r.conn).ExecContext(ctx)
+	return err
+}
+
+// Get returns conversation.ErrTraceNotFound if the message has no trace in
+// the caller's workspace.
+func (r *TraceRepo) Get(ctx context.Context, messageUUID string) (*greysealv1.TurnTrace, error) {
+	t := &greysealv1.TurnTrace{Timings: &greysealv1.TraceTimings{}}
+	var results, messages, options []byte
+	var createdAtDt time.Time
+	err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select(traceColumns...).
+		From("turn_traces").
+		Where(sq.Eq{"message_uuid": messageUUID}).
+		Where(inWorkspace(ctx)).
+		RunWith(r.conn).
+		QueryRowContext(ctx).
+		Scan(
+			&t.MessageUuid,
+			&t.ConversationUuid,
+			&t.RoleUuid,
+			&t.Model,
+			&t.SearchQuery,
+			&results,
+			&t.Summary,
+			&t.HistoryDepth,
+			&messages,
+			&options,
+			&t.Timings.LoadMs,
+			&t.Timings.SummarizeMs,
+			&t.Timings.RetrievalMs,
+			&t.Timings.FirstTokenMs,
+			&t.Timings.GenerationMs,
+			&t.Timings.TotalMs,
+			&t.Owner,
+			&t.WorkspaceUuid,
+			&createdAtDt,
+		)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, conversation.ErrTraceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get turn trace: %w", err)
+	}
+
+	var searchResults []conversation.SearchResult
+	var llmMessages []conversation.LLMMessage
+	for _, field := range []struct {
+		data []byte
+		v    any
+	}{{results, &searchResults}, {messages, &llmMessages}, {options, &t.Options}} {
+		if err := json.Unmarshal(field.data, field.v); err != nil {
+			return nil, fmt.Errorf("failed to decode turn trace %s: %w", messageUUID, err)
+		}
+	}
+	for _, s := range searchResults {
+		t.Results = append(t.Results, &greysealv1.DatasetSnippet{
+			ResourceUuid: s.EntityUUID,
+			Title:        s.Title,
+			Snippet:      s.Snippet,
+			Score:        s.Score,
+		})
+	}
+	for _, m := range llmMessages {
+		t.Messages = append(t.Messages, &greysealv1.DatasetMessage{Role: m.Role, Content: m.Content})
+	}
+	t.CreatedAt = timestamppb.New(createdAtDt)
+	return t, nil
+}
@@ -2,22 +2,36 @@ package transcript
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	"gocloud.dev/blob"
 	_ "gocloud.dev/blob/fileblob"
+	"gocloud.dev/gcerrors"
 )
 
-// Writer appends markdown transcript turns to per-conversation files in a
-// gocloud.dev/blob bucket. Supports file:// buckets locally and can be
+// Writer stores each transcript turn as its own JSON object under
+// {conversationUUID}/{messageUUID}.json in a gocloud.dev/blob bucket, so
+// writes never read or rewrite earlier turns. The combined Markdown
+// transcript is rendered on demand and kept as {conversationUUID}/transcript.md
+// until a turn is written or rewritten. Supports file:// buckets locally and can be
 // switched to s3:// or gcs:// by changing the bucket URL.
 type Writer struct {
 	bucket *blob.Bucket
+	// MarkdownTurns also writes each turn rendered as
+	// {conversationUUID}/{messageUUID}.md.
+	MarkdownTurns bool
 }
 
 // NewWriter opens (or creates) the given directory as a local blob bucket.
@@ -51,36 +65,271 @@ func (w *Writer) Close() error {
 	return w.bucket.Close()
 }
 
-// WriteTurn appends a markdown turn section to {conversationUUID}.md.
+// WriteTurn writes the turn as JSON and, with MarkdownTurns, as Markdown.
 // Implements conversation.TranscriptWriter.
 func (w *Writer) WriteTurn(ctx context.Context, turn conversation.TranscriptTurn) error {
-	key := turn.ConversationUUID + ".md"
+	if turn.MessageUUID == "" {
+		return errors.New("transcript turn has no message UUID")
+	}
+	data, err := json.Marshal(turn)
+	if err != nil {
+		return err
+	}
+	if err := w.bucket.WriteAll(ctx, turnKey(turn.ConversationUUID, turn.MessageUUID), data, &blob.WriterOptions{ContentType: "application/json"}); err != nil {
+		return fmt.Errorf("write turn %s: %w", turn.MessageUUID, err)
+	}
+	// The compacted transcript no longer covers every turn.
+	if err := w.bucket.Delete(ctx, compactedKey(turn.ConversationUUID)); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("delete transcript %s: %w", turn.ConversationUUID, err)
+	}
+	if !w.MarkdownTurns {
+		return nil
+	}
+	var sb strings.Builder
+	renderTurn(&sb, turn)
+	key := turn.ConversationUUID + "/" + turn.MessageUUID + ".md"
+	if err := w.bucket.WriteAll(ctx, key, []byte(sb.String()), &blob.WriterOptions{ContentType: markdownType}); err != nil {
+		return fmt.Errorf("write turn %s: %w", key, err)
+	}
+	return nil
+}
+
+// ReadTurn reads the JSON turn written for an assistant message.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) ReadTurn(ctx context.Context, conversationUUID, messageUUID string) (*conversation.TranscriptTurn, error) {
+	data, err := w.bucket.ReadAll(ctx, turnKey(conversationUUID, messageUUID))
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, conversation.ErrTurnNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read turn %s: %w", messageUUID, err)
+	}
+	turn := &conversation.TranscriptTurn{}
+	if err := json.Unmarshal(data, turn); err != nil {
+		return nil, fmt.Errorf("decode turn %s: %w", messageUUID, err)
+	}
+	return turn, nil
+}
 
-	var existing []byte
-	rc, err := w.bucket.NewReader(ctx, key, nil)
-	if err == nil {
-		existing, _ = io.ReadAll(rc)
-		_ = rc.Close()
+// ListTurns reads a conversation's turns, ordered by time and turn index.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) ListTurns(ctx context.Context, conversationUUID string) ([]*conversation.TranscriptTurn, error) {
+	objects, err := w.turnObjects(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
 	}
+	return w.readTurns(ctx, objects)
+}
 
+// readTurns reads turn objects, ordered by time and turn index.
+func (w *Writer) readTurns(ctx context.Context, objects []*blob.ListObject) ([]*conversation.TranscriptTurn, error) {
+	turns := make([]*conversation.TranscriptTurn, 0, len(objects))
+	for _, obj := range objects {
+		data, err := w.bucket.ReadAll(ctx, obj.Key)
+		if err != nil {
+			return nil, fmt.Errorf("read turn %s: %w", obj.Key, err)
+		}
+		turn := &conversation.TranscriptTurn{}
+		if err := json.Unmarshal(data, turn); err != nil {
+			return nil, fmt.Errorf("decode turn %s: %w", obj.Key, err)
+		}
+		turns = append(turns, turn)
+	}
+	sort.SliceStable(turns, func(i, j int) bool {
+		if !turns[i].Timestamp.Equal(turns[j].Timestamp) {
+			return turns[i].Timestamp.Before(turns[j].Timestamp)
+		}
+		return turns[i].TurnIndex < turns[j].TurnIndex
+	})
+	return turns, nil
+}
+
+// Render returns the combined Markdown transcript. The copy saved by the last
+// Compact is reused while the conversation has the same turns, going by their
+// count and newest modification time.
+// Conversations recorded before turns were stored separately only have the
+// legacy {conversationUUID}.md, which is returned as-is.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) Render(ctx context.Context, conversationUUID string) ([]byte, error) {
+	objects, err := w.turnObjects(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		data, err := w.bucket.ReadAll(ctx, conversationUUID+".md")
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, conversation.ErrTranscriptNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read transcript %s: %w", conversationUUID, err)
+		}
+		return data, nil
+	}
+
+	key := compactedKey(conversationUUID)
+	attrs, err := w.bucket.Attributes(ctx, key)
+	if err == nil && attrs.Metadata[turnsMetadata] == turnsVersion(objects) {
+		data, err := w.bucket.ReadAll(ctx, key)
+		if err == nil {
+			return data, nil
+		}
+	}
+	return w.Compact(ctx, conversationUUID)
+}
+
+// Compact renders every turn of a conversation into one Markdown document,
+// saves it as {conversationUUID}/transcript.md and returns it.
+func (w *Writer) Compact(ctx context.Context, conversationUUID string) ([]byte, error) {
+	objects, err := w.turnObjects(ctx, conversationUUID)
+	if err != nil {
+		return nil, err
+	}
+	turns, err := w.readTurns(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+	if len(turns) == 0 {
+		return nil, conversation.ErrTranscriptNotFound
+	}
 	var sb strings.Builder
-	renderTurn(&sb, turn)
+	for _, turn := range turns {
+		renderTurn(&sb, *turn)
+	}
+	data := []byte(sb.String())
+	if err := w.bucket.WriteAll(ctx, compactedKey(conversationUUID), data, &blob.WriterOptions{
+		ContentType: markdownType,
+		Metadata:    map[string]string{turnsMetadata: turnsVersion(objects)},
+	}); err != nil {
+		return nil, fmt.Errorf("write transcript %s: %w", conversationUUID, err)
+	}
+	return data, nil
+}
 
-	wc, err := w.bucket.NewWriter(ctx, key, &blob.WriterOptions{ContentType: "text/markdown; charset=utf-8"})
+// List pages through the conversation prefixes of the bucket in key order.
+// The cursor is the bucket's page token.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) List(ctx context.Context, cursor string, limit uint) ([]conversation.TranscriptInfo, string, error) {
+	token := blob.FirstPageToken
+	if cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", pagination.ErrInvalidCursor
+		}
+		token = decoded
+	}
+	objects, next, err := w.bucket.ListPage(ctx, token, int(limit), &blob.ListOptions{Delimiter: "/"})
 	if err != nil {
-		return fmt.Errorf("open writer for %s: %w", key, err)
+		return nil, "", fmt.Errorf("list transcripts: %w", err)
 	}
-	if len(existing) > 0 {
-		if _, err := wc.Write(existing); err != nil {
-			_ = wc.Close()
-			return err
+
+	var infos []conversation.TranscriptInfo
+	for _, obj := range objects {
+		if !obj.IsDir {
+			continue
+		}
+		conversationUUID := strings.TrimSuffix(obj.Key, "/")
+		turns, err := w.turnObjects(ctx, conversationUUID)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(turns) == 0 {
+			continue
 		}
+		info := conversation.TranscriptInfo{ConversationUUID: conversationUUID, Turns: len(turns)}
+		for _, t := range turns {
+			if t.ModTime.After(info.UpdatedAt) {
+				info.UpdatedAt = t.ModTime
+			}
+		}
+		infos = append(infos, info)
 	}
-	if _, err := wc.Write([]byte(sb.String())); err != nil {
-		_ = wc.Close()
-		return err
+	if len(next) == 0 {
+		return infos, "", nil
+	}
+	return infos, base64.RawURLEncoding.EncodeToString(next), nil
+}
+
+// Delete removes everything under the conversation's prefix and the legacy
+// {conversationUUID}.md. A missing transcript is not an error.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) Delete(ctx context.Context, conversationUUID string) error {
+	err := w.bucket.Delete(ctx, conversationUUID+".md")
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("delete transcript %s: %w", conversationUUID, err)
+	}
+	iter := w.bucket.List(&blob.ListOptions{Prefix: conversationUUID + "/"})
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("list turns %s: %w", conversationUUID, err)
+		}
+		if err := w.bucket.Delete(ctx, obj.Key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return fmt.Errorf("delete turn %s: %w", obj.Key, err)
+		}
+	}
+}
+
+// DeleteTurn removes the JSON and Markdown objects of a turn and the
+// compacted transcript that included it.
+// Implements conversation.TranscriptWriter.
+func (w *Writer) DeleteTurn(ctx context.Context, conversationUUID, messageUUID string) error {
+	for _, key := range []string{
+		turnKey(conversationUUID, messageUUID),
+		conversationUUID + "/" + messageUUID + ".md",
+		compactedKey(conversationUUID),
+	} {
+		if err := w.bucket.Delete(ctx, key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return fmt.Errorf("delete turn %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+const (
+	markdownType = "text/markdown; charset=utf-8"
+	// turnsMetadata records the turnsVersion a compacted transcript covers.
+	turnsMetadata = "turns"
+)
+
+// turnsVersion identifies a set of turn objects by their count and newest
+// modification time, so a rewritten turn invalidates the compacted copy.
+func turnsVersion(objects []*blob.ListObject) string {
+	var newest time.Time
+	for _, obj := range objects {
+		if obj.ModTime.After(newest) {
+			newest = obj.ModTime
+		}
+	}
+	return strconv.Itoa(len(objects)) + "@" + strconv.FormatInt(newest.UnixNano(), 10)
+}
+
+func turnKey(conversationUUID, messageUUID string) string {
+	return conversationUUID + "/" + messageUUID + ".json"
+}
+
+func compactedKey(conversationUUID string) string {
+	return conversationUUID + "/transcript.md"
+}
+
+// turnObjects lists the JSON turn objects of a conversation.
+func (w *Writer) turnObjects(ctx context.Context, conversationUUID string) ([]*blob.ListObject, error) {
+	var objects []*blob.ListObject
+	iter := w.bucket.List(&blob.ListOptions{Prefix: conversationUUID + "/"})
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list turns %s: %w", conversationUUID, err)
+		}
+		if strings.HasSuffix(obj.Key, ".json") {
+			objects = append(objects, obj)
+		}
 	}
-	return wc.Close()
 }
 
 func renderTurn(sb *strings.Builder, t conversation.TranscriptTurn) {
@@ -102,6 +351,24 @@ func renderTurn(sb *strings.Builder, t conversation.TranscriptTurn) {
 		}
 		sb.WriteString("\n")
 	}
+	if flagged := sanitized(t.ContextDecisions); len(flagged) > 0 {
+		sb.WriteString("**Context sanitizer**:\n\n")
+		sb.WriteString("| Entity | Title | Action | Risk | Findings |\n")
+		sb.WriteString("|--------|-------|--------|------|----------|\n")
+		for _, d := range flagged {
+			fmt.Fprintf(sb, "| %s | %s | %s | %.2f | %s |\n", d.EntityUUID, d.Title, d.Action, d.Risk, strings.Join(d.Findings, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	if len(t.Guardrails) > 0 {
+		sb.WriteString("**Guardrails**:\n\n")
+		sb.WriteString("| Guardrail | Stage | Action | Reason | Detail |\n")
+		sb.WriteString("|-----------|-------|--------|--------|--------|\n")
+		for _, e := range t.Guardrails {
+			fmt.Fprintf(sb, "| %s | %s | %s | %s | %s |\n", e.Guardrail, e.Stage, e.Action, e.Reason, e.Detail)
+		}
+		sb.WriteString("\n")
+	}
 	if len(t.AssembledMessages) > 0 {
 		sb.WriteString("**Assembled prompt**:\n\n")
 		for _, m := range t.AssembledMessages {
@@ -114,3 +381,14 @@ func renderTurn(sb *strings.Builder, t conversation.TranscriptTurn) {
 	}
 	sb.WriteString("---\n\n")
 }
+
+// sanitized returns the decisions where the sanitizer found something.
+func sanitized(decisions []conversation.ContextDecision) []conversation.ContextDecision {
+	var out []conversation.ContextDecision
+	for _, d := range decisions {
+		if len(d.Findings) > 0 {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -2,6 +2,8 @@ package transcript
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"time"
 
 	"github.com/holmes89/grey-seal/lib/greyseal/conversation"
+	"github.com/holmes89/grey-seal/lib/greyseal/pagination"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,6 +19,7 @@ import (
 func newTurn(convUUID string, idx int, user, response string) conversation.TranscriptTurn {
 	return conversation.TranscriptTurn{
 		ConversationUUID: convUUID,
+		MessageUUID:      fmt.Sprintf("msg-%d", idx),
 		TurnIndex:        idx,
 		Timestamp:        time.Date(2026, 4, 16, 12, 0, 0, 0, time.UTC),
 		UserMessage:      user,
@@ -23,7 +27,14 @@ func newTurn(convUUID string, idx int, user, response string) conversation.Trans
 	}
 }
 
-func TestWriter_CreatesFileOnFirstWrite(t *testing.T) {
+func render(t *testing.T, w *Writer, convUUID string) string {
+	t.Helper()
+	content, err := w.Render(context.Background(), convUUID)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestWriter_WritesOneObjectPerTurn(t *testing.T) {
 	dir := t.TempDir()
 	w, err := NewWriter(dir)
 	require.NoError(t, err)
@@ -32,64 +43,123 @@ func TestWriter_CreatesFileOnFirstWrite(t *testing.T) {
 	err = w.WriteTurn(context.Background(), newTurn("conv-1", 1, "hello", "world"))
 	require.NoError(t, err)
 
-	content, err := os.ReadFile(filepath.Join(dir, "conv-1.md"))
+	_, err = os.Stat(filepath.Join(dir, "conv-1", "msg-1.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "conv-1", "msg-1.md"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "conv-1.md"))
+	assert.True(t, os.IsNotExist(err))
+
+	content := render(t, w, "conv-1")
+	assert.Contains(t, content, "## Turn 1")
+	assert.Contains(t, content, "hello")
+	assert.Contains(t, content, "world")
+}
+
+func TestWriter_RequiresMessageUUID(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	require.NoError(t, err)
+	defer w.Close()
+
+	turn := newTurn("conv-1", 1, "hello", "world")
+	turn.MessageUUID = ""
+	assert.Error(t, w.WriteTurn(context.Background(), turn))
+}
+
+func TestWriter_MarkdownTurns(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+	defer w.Close()
+	w.MarkdownTurns = true
+
+	require.NoError(t, w.WriteTurn(context.Background(), newTurn("conv-1", 1, "hello", "world")))
+
+	content, err := os.ReadFile(filepath.Join(dir, "conv-1", "msg-1.md"))
 	require.NoError(t, err)
 	assert.Contains(t, string(content), "## Turn 1")
-	assert.Contains(t, string(content), "hello")
-	assert.Contains(t, string(content), "world")
 }
 
-func TestWriter_TwoCallsAppendTwoSections(t *testing.T) {
+func TestWriter_RenderCombinesTurnsInOrder(t *testing.T) {
 	dir := t.TempDir()
 	w, err := NewWriter(dir)
 	require.NoError(t, err)
 	defer w.Close()
 
 	ctx := context.Background()
+	second := newTurn("conv-2", 2, "second", "answer two")
+	second.Timestamp = second.Timestamp.Add(time.Minute)
+	require.NoError(t, w.WriteTurn(ctx, second))
 	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-2", 1, "first", "answer one")))
-	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-2", 2, "second", "answer two")))
 
-	content, err := os.ReadFile(filepath.Join(dir, "conv-2.md"))
+	content := render(t, w, "conv-2")
+	assert.Equal(t, 2, strings.Count(content, "## Turn"))
+	assert.Less(t, strings.Index(content, "first"), strings.Index(content, "second"))
+
+	// The compacted copy is kept and refreshed once another turn arrives.
+	compacted, err := os.ReadFile(filepath.Join(dir, "conv-2", "transcript.md"))
 	require.NoError(t, err)
-	assert.Equal(t, 2, strings.Count(string(content), "## Turn"))
-	assert.Contains(t, string(content), "first")
-	assert.Contains(t, string(content), "second")
+	assert.Equal(t, content, string(compacted))
+
+	third := newTurn("conv-2", 3, "third", "answer three")
+	third.Timestamp = third.Timestamp.Add(2 * time.Minute)
+	require.NoError(t, w.WriteTurn(ctx, third))
+	assert.Equal(t, 3, strings.Count(render(t, w, "conv-2"), "## Turn"))
+
+	turns, err := w.ListTurns(ctx, "conv-2")
+	require.NoError(t, err)
+	require.Len(t, turns, 3)
+	assert.Equal(t, []string{"first", "second", "third"}, []string{turns[0].UserMessage, turns[1].UserMessage, turns[2].UserMessage})
 }
 
-func TestWriter_DifferentUUIDsCreateSeparateFiles(t *testing.T) {
-	dir := t.TempDir()
-	w, err := NewWriter(dir)
+func TestWriter_RenderAfterRewrite(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
 	require.NoError(t, err)
 	defer w.Close()
 
 	ctx := context.Background()
-	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-a", 1, "q1", "r1")))
-	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-b", 1, "q2", "r2")))
+	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-r", 1, "question", "first answer")))
+	assert.Contains(t, render(t, w, "conv-r"), "first answer")
+
+	// Rewriting a turn keeps the count but refreshes the compacted copy.
+	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-r", 1, "question", "second answer")))
+	assert.Contains(t, render(t, w, "conv-r"), "second answer")
 
-	_, errA := os.Stat(filepath.Join(dir, "conv-a.md"))
-	_, errB := os.Stat(filepath.Join(dir, "conv-b.md"))
-	assert.NoError(t, errA)
-	assert.NoError(t, errB)
+	// So does a turn object changed behind the writer's back.
+	data, err := json.Marshal(newTurn("conv-r", 1, "question", "third answer"))
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, w.bucket.WriteAll(ctx, turnKey("conv-r", "msg-1"), data, nil))
+	assert.Contains(t, render(t, w, "conv-r"), "third answer")
 }
 
-func TestWriter_EmptySummaryOmitsSection(t *testing.T) {
+func TestWriter_RenderLegacyTranscript(t *testing.T) {
 	dir := t.TempDir()
 	w, err := NewWriter(dir)
 	require.NoError(t, err)
 	defer w.Close()
 
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "conv-old.md"), []byte("## Turn 1 — legacy\n"), 0o600))
+	assert.Equal(t, "## Turn 1 — legacy\n", render(t, w, "conv-old"))
+
+	_, err = w.Render(context.Background(), "conv-none")
+	assert.ErrorIs(t, err, conversation.ErrTranscriptNotFound)
+}
+
+func TestWriter_EmptySummaryOmitsSection(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	require.NoError(t, err)
+	defer w.Close()
+
 	turn := newTurn("conv-3", 1, "hi", "bye")
 	turn.ConversationSummary = ""
 	require.NoError(t, w.WriteTurn(context.Background(), turn))
 
-	content, err := os.ReadFile(filepath.Join(dir, "conv-3.md"))
-	require.NoError(t, err)
-	assert.NotContains(t, string(content), "**Conversation summary**")
+	assert.NotContains(t, render(t, w, "conv-3"), "**Conversation summary**")
 }
 
 func TestWriter_EmptySearchResultsOmitsTable(t *testing.T) {
-	dir := t.TempDir()
-	w, err := NewWriter(dir)
+	w, err := NewWriter(t.TempDir())
 	require.NoError(t, err)
 	defer w.Close()
 
@@ -97,14 +167,45 @@ func TestWriter_EmptySearchResultsOmitsTable(t *testing.T) {
 	turn.SearchResults = nil
 	require.NoError(t, w.WriteTurn(context.Background(), turn))
 
-	content, err := os.ReadFile(filepath.Join(dir, "conv-4.md"))
+	assert.NotContains(t, render(t, w, "conv-4"), "**Shrike search**")
+}
+
+func TestWriter_RendersSanitizerFindings(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
 	require.NoError(t, err)
-	assert.NotContains(t, string(content), "**Shrike search**")
+	defer w.Close()
+
+	turn := newTurn("conv-6", 1, "hi", "bye")
+	turn.ContextDecisions = []conversation.ContextDecision{
+		{EntityUUID: "res-1", Title: "Clean", Action: conversation.ContextKept},
+		{EntityUUID: "res-2", Title: "Evil", Action: conversation.ContextDropped, Risk: 0.6, Findings: []string{"instruction_override"}},
+	}
+	require.NoError(t, w.WriteTurn(context.Background(), turn))
+
+	content := render(t, w, "conv-6")
+	assert.Contains(t, content, "**Context sanitizer**")
+	assert.Contains(t, content, "| res-2 | Evil | drop | 0.60 | instruction_override |")
+	assert.NotContains(t, content, "res-1")
+}
+
+func TestWriter_RendersGuardrailEvents(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	require.NoError(t, err)
+	defer w.Close()
+
+	turn := newTurn("conv-7", 1, "any lockpicking tips?", "I can't help with that topic.")
+	turn.Guardrails = []conversation.GuardrailEvent{
+		{Guardrail: "keyword", Stage: conversation.StagePreRetrieval, Action: conversation.GuardrailBlock, Reason: "GUARDRAIL_REASON_BLOCKED_TOPIC", Detail: "lock ?pick"},
+	}
+	require.NoError(t, w.WriteTurn(context.Background(), turn))
+
+	content := render(t, w, "conv-7")
+	assert.Contains(t, content, "**Guardrails**")
+	assert.Contains(t, content, "| keyword | pre_retrieval | block | GUARDRAIL_REASON_BLOCKED_TOPIC | lock ?pick |")
 }
 
 func TestWriter_EmptyResourceUUIDsOmitsCitedLine(t *testing.T) {
-	dir := t.TempDir()
-	w, err := NewWriter(dir)
+	w, err := NewWriter(t.TempDir())
 	require.NoError(t, err)
 	defer w.Close()
 
@@ -112,7 +213,103 @@ func TestWriter_EmptyResourceUUIDsOmitsCitedLine(t *testing.T) {
 	turn.ResourceUUIDs = nil
 	require.NoError(t, w.WriteTurn(context.Background(), turn))
 
-	content, err := os.ReadFile(filepath.Join(dir, "conv-5.md"))
+	assert.NotContains(t, render(t, w, "conv-5"), "**Resources cited**")
+}
+
+func TestWriter_List(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	require.NoError(t, err)
+	defer w.Close()
+
+	ctx := context.Background()
+	for _, conv := range []string{"conv-a", "conv-b", "conv-c"} {
+		require.NoError(t, w.WriteTurn(ctx, newTurn(conv, 1, "q", "r")))
+	}
+	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-b", 2, "q", "r")))
+
+	page, cursor, err := w.List(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "conv-a", page[0].ConversationUUID)
+	assert.Equal(t, 2, page[1].Turns)
+	assert.False(t, page[1].UpdatedAt.IsZero())
+	require.NotEmpty(t, cursor)
+
+	page, cursor, err = w.List(ctx, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "conv-c", page[0].ConversationUUID)
+	assert.Empty(t, cursor)
+
+	_, _, err = w.List(ctx, "not base64!", 2)
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
+
+func TestWriter_Delete(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
 	require.NoError(t, err)
-	assert.NotContains(t, string(content), "**Resources cited**")
+	defer w.Close()
+
+	ctx := context.Background()
+	turn := newTurn("conv-del", 1, "hello", "world")
+	turn.MessageUUID = "msg-1"
+	require.NoError(t, w.WriteTurn(ctx, turn))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "conv-del.md"), []byte("legacy"), 0o600))
+	_, err = w.Compact(ctx, "conv-del")
+	require.NoError(t, err)
+	require.NoError(t, w.Delete(ctx, "conv-del"))
+
+	_, err = os.Stat(filepath.Join(dir, "conv-del.md"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "conv-del", "transcript.md"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = w.ReadTurn(ctx, "conv-del", "msg-1")
+	assert.ErrorIs(t, err, conversation.ErrTurnNotFound)
+
+	// Deleting again is a no-op.
+	require.NoError(t, w.Delete(ctx, "conv-del"))
+}
+
+func TestWriter_DeleteTurn(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	require.NoError(t, err)
+	defer w.Close()
+	w.MarkdownTurns = true
+
+	ctx := context.Background()
+	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-dt", 1, "question", "first answer")))
+	require.NoError(t, w.WriteTurn(ctx, newTurn("conv-dt", 2, "question", "second answer")))
+	assert.Contains(t, render(t, w, "conv-dt"), "first answer")
+
+	require.NoError(t, w.DeleteTurn(ctx, "conv-dt", "msg-1"))
+	_, err = w.ReadTurn(ctx, "conv-dt", "msg-1")
+	assert.ErrorIs(t, err, conversation.ErrTurnNotFound)
+	content := render(t, w, "conv-dt")
+	assert.NotContains(t, content, "first answer")
+	assert.Contains(t, content, "second answer")
+
+	// Deleting again is a no-op.
+	require.NoError(t, w.DeleteTurn(ctx, "conv-dt", "msg-1"))
+}
+
+func TestWriter_ReadTurn(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+	defer w.Close()
+
+	ctx := context.Background()
+	turn := newTurn("conv-json", 1, "what is kafka?", "a log")
+	turn.MessageUUID = "msg-1"
+	turn.SearchResults = []conversation.SearchResult{{EntityUUID: "res-1", Title: "Kafka", Snippet: "distributed log", Score: 0.9}}
+	turn.AssembledMessages = []conversation.LLMMessage{{Role: "user", Content: "what is kafka?"}}
+	require.NoError(t, w.WriteTurn(ctx, turn))
+
+	got, err := w.ReadTurn(ctx, "conv-json", "msg-1")
+	require.NoError(t, err)
+	assert.Equal(t, turn, *got)
+
+	_, err = w.ReadTurn(ctx, "conv-json", "msg-2")
+	assert.ErrorIs(t, err, conversation.ErrTurnNotFound)
 }
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/holmes89/grey-seal/lib/greyseal/auth"
+	"github.com/holmes89/grey-seal/lib/greyseal/workspace"
+	greysealv1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// inWorkspace restricts a query to the caller's workspace. Every repository
+// query on tenant data goes through it. Callers without a principal (auth
+// disabled, the worker) are not scoped.
+func inWorkspace(ctx context.Context) sq.Sqlizer {
+	if ws, ok := auth.WorkspaceFromContext(ctx); ok {
+		return sq.Eq{"workspace_uuid": ws}
+	}
+	return sq.And{}
+}
+
+// workspaceForCreate returns the workspace a new row is written to. The
+// caller's workspace always wins so clients cannot write into another tenant.
+func workspaceForCreate(ctx context.Context, requested string) string {
+	if ws, ok := auth.WorkspaceFromContext(ctx); ok {
+		return ws
+	}
+	if requested == "" {
+		return workspace.DefaultUUID
+	}
+	return requested
+}
+
+// WorkspaceRepo persists workspaces. Workspaces are global; access is limited
+// to admins by the auth interceptor rather than by query scoping.
+type WorkspaceRepo struct {
+	*Conn
+}
+
+var _ workspace.WorkspaceRepository = (*WorkspaceRepo)(nil)
+
+func (r *WorkspaceRepo) Create(ctx context.Context, b *greysealv1.Workspace) error {
+	_, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Insert("workspaces").
+		Columns("uuid", "name", "created_at").
+		Values(
+			b.Uuid,
+			b.Name,
+			b.CreatedAt.AsTime()).
+		RunWith(r.conn).Exec()
+	return err
+}
+
+func (r *WorkspaceRepo) Update(ctx context.Context, id string, b *greysealv1.Workspace) error {
+	query, args, err := sq.Update("workspaces").
+		Set("name", b.Name).
+		Where(sq.Eq{"uuid": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *WorkspaceRepo) Delete(ctx context.Context, id string) error {
+	query, args, err := sq.Delete("workspaces").
+		Where(sq.Eq{"uuid": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *WorkspaceRepo) Get(ctx context.Context, id string) (*greysealv1.Workspace, error) {
+	ws := &greysealv1.Workspace{}
+	var createdAtDt time.Time
+	err := sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid", "name", "created_at").
+		From("workspaces").
+		Where(sq.Eq{"uuid": id}).
+		RunWith(r.conn).
+		QueryRow().
+		Scan(
+			&ws.Uuid,
+			&ws.Name,
+			&createdAtDt,
+		)
+	if err != nil {
+		return nil, fmt.Errorf("get workspace: %w", err)
+	}
+	ws.CreatedAt = timestamppb.New(createdAtDt)
+	return ws, nil
+}
+
+func (r *WorkspaceRepo) List(ctx context.Context, cursor string, limit uint, filter map[string][]any) ([]*greysealv1.Workspace, error) {
+	q, err := keyset(sq.StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("uuid", "name", "created_at").
+		From("workspaces"), "created_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.RunWith(r.conn).Query()
+	if err != nil {
+		return nil, fmt.Errorf("list workspaces: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var workspaces []*greysealv1.Workspace
+	for rows.Next() {
+		ws := &greysealv1.Workspace{}
+		var createdAtDt time.Time
+		if err := rows.Scan(&ws.Uuid, &ws.Name, &createdAtDt); err != nil {
+			return nil, fmt.Errorf("scan workspace: %w", err)
+		}
+		ws.CreatedAt = timestamppb.New(createdAtDt)
+		workspaces = append(workspaces, ws)
+	}
+	return workspaces, nil
+}
+
+// InUse reports whether any role, resource or conversation still references the workspace.
+func (r *WorkspaceRepo) InUse(ctx context.Context, id string) (bool, error) {
+	for _, table := range []string{"roles", "resources", "conversations"} {
+		var exists bool
+		err := sq.StatementBuilder.
+			PlaceholderFormat(sq.Dollar).
+			Select("1").
+			Prefix("SELECT EXISTS (").
+			From(table).
+			Where(sq.Eq{"workspace_uuid": id}).
+			Suffix(")").
+			RunWith(r.conn).
+			QueryRow().
+			Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func workspaceOrDefault(id string) string {
+	if id == "" {
+		return workspace.DefaultUUID
+	}
+	return id
+}
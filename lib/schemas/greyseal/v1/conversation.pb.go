@@ -71,6 +71,124 @@ func (MessageRole) EnumDescriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{0}
 }
 
+// ConversationStatus selects conversations by lifecycle state.
+type ConversationStatus int32
+
+const (
+	// UNSPECIFIED is treated as ACTIVE.
+	ConversationStatus_CONVERSATION_STATUS_UNSPECIFIED ConversationStatus = 0
+	ConversationStatus_CONVERSATION_STATUS_ACTIVE      ConversationStatus = 1
+	ConversationStatus_CONVERSATION_STATUS_ARCHIVED    ConversationStatus = 2
+	ConversationStatus_CONVERSATION_STATUS_DELETED     ConversationStatus = 3
+)
+
+// Enum value maps for ConversationStatus.
+var (
+	ConversationStatus_name = map[int32]string{
+		0: "CONVERSATION_STATUS_UNSPECIFIED",
+		1: "CONVERSATION_STATUS_ACTIVE",
+		2: "CONVERSATION_STATUS_ARCHIVED",
+		3: "CONVERSATION_STATUS_DELETED",
+	}
+	ConversationStatus_value = map[string]int32{
+		"CONVERSATION_STATUS_UNSPECIFIED": 0,
+		"CONVERSATION_STATUS_ACTIVE":      1,
+		"CONVERSATION_STATUS_ARCHIVED":    2,
+		"CONVERSATION_STATUS_DELETED":     3,
+	}
+)
+
+func (x ConversationStatus) Enum() *ConversationStatus {
+	p := new(ConversationStatus)
+	*p = x
+	return p
+}
+
+func (x ConversationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConversationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_conversation_proto_enumTypes[1].Descriptor()
+}
+
+func (ConversationStatus) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_conversation_proto_enumTypes[1]
+}
+
+func (x ConversationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConversationStatus.Descriptor instead.
+func (ConversationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{1}
+}
+
+// GuardrailReason is why a guardrail refused a chat turn.
+type GuardrailReason int32
+
+const (
+	GuardrailReason_GUARDRAIL_REASON_UNSPECIFIED GuardrailReason = 0
+	// GUARDRAIL_REASON_BLOCKED_TOPIC: the question or answer matched a
+	// blocked topic.
+	GuardrailReason_GUARDRAIL_REASON_BLOCKED_TOPIC GuardrailReason = 1
+	// GUARDRAIL_REASON_INPUT_TOO_LONG: the question was over the role's
+	// max_input_chars.
+	GuardrailReason_GUARDRAIL_REASON_INPUT_TOO_LONG GuardrailReason = 2
+	// GUARDRAIL_REASON_NO_CONTEXT: the role requires retrieved context and
+	// none was found.
+	GuardrailReason_GUARDRAIL_REASON_NO_CONTEXT GuardrailReason = 3
+	// GUARDRAIL_REASON_POLICY: the LLM judge found the question or answer
+	// breaks the role's policy.
+	GuardrailReason_GUARDRAIL_REASON_POLICY GuardrailReason = 4
+)
+
+// Enum value maps for GuardrailReason.
+var (
+	GuardrailReason_name = map[int32]string{
+		0: "GUARDRAIL_REASON_UNSPECIFIED",
+		1: "GUARDRAIL_REASON_BLOCKED_TOPIC",
+		2: "GUARDRAIL_REASON_INPUT_TOO_LONG",
+		3: "GUARDRAIL_REASON_NO_CONTEXT",
+		4: "GUARDRAIL_REASON_POLICY",
+	}
+	GuardrailReason_value = map[string]int32{
+		"GUARDRAIL_REASON_UNSPECIFIED":    0,
+		"GUARDRAIL_REASON_BLOCKED_TOPIC":  1,
+		"GUARDRAIL_REASON_INPUT_TOO_LONG": 2,
+		"GUARDRAIL_REASON_NO_CONTEXT":     3,
+		"GUARDRAIL_REASON_POLICY":         4,
+	}
+)
+
+func (x GuardrailReason) Enum() *GuardrailReason {
+	p := new(GuardrailReason)
+	*p = x
+	return p
+}
+
+func (x GuardrailReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GuardrailReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_conversation_proto_enumTypes[2].Descriptor()
+}
+
+func (GuardrailReason) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_conversation_proto_enumTypes[2]
+}
+
+func (x GuardrailReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GuardrailReason.Descriptor instead.
+func (GuardrailReason) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{2}
+}
+
 // Message is a single turn in a conversation.
 type Message struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -81,9 +199,23 @@ type Message struct {
 	// resource_uuids holds references to indexed resources used to generate
 	// this response (populated for ASSISTANT messages).
 	ResourceUuids []string `protobuf:"bytes,5,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
-	// feedback allows simple quality tracking: -1 negative, 0 neutral, 1 positive.
-	Feedback      int32                  `protobuf:"varint,6,opt,name=feedback,proto3" json:"feedback,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// feedback is the latest rating submitted for this message: -1 negative,
+	// 0 neutral, 1 positive. Full records are returned by ListFeedback.
+	Feedback  int32                  `protobuf:"varint,6,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// owner is the subject of the principal that owns the parent conversation.
+	Owner string `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	// workspace_uuid is copied from the parent conversation.
+	WorkspaceUuid string `protobuf:"bytes,9,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	// model is the LLM model that generated an ASSISTANT message, if known.
+	Model string `protobuf:"bytes,10,opt,name=model,proto3" json:"model,omitempty"`
+	// role_uuid and role_version identify the role version whose system prompt
+	// produced an ASSISTANT message; empty and 0 when no role was used.
+	RoleUuid    string `protobuf:"bytes,11,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	RoleVersion int32  `protobuf:"varint,12,opt,name=role_version,json=roleVersion,proto3" json:"role_version,omitempty"`
+	// stopped is set on an ASSISTANT message whose generation was stopped by
+	// the user; content holds the partial answer.
+	Stopped       bool `protobuf:"varint,13,opt,name=stopped,proto3" json:"stopped,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -167,6 +299,48 @@ func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Message) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Message) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *Message) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Message) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *Message) GetRoleVersion() int32 {
+	if x != nil {
+		return x.RoleVersion
+	}
+	return 0
+}
+
+func (x *Message) GetStopped() bool {
+	if x != nil {
+		return x.Stopped
+	}
+	return false
+}
+
 // Conversation is a chat session that persists and can be resumed.
 type Conversation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -181,10 +355,28 @@ type Conversation struct {
 	ResourceUuids []string `protobuf:"bytes,4,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
 	// summary holds a rolling compressed summary of older messages to manage
 	// context window length.
-	Summary       string                 `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
-	Messages      []*Message             `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Summary   string                 `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	Messages  []*Message             `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// owner is the subject of the authenticated principal that created the
+	// conversation. It is set by the server and cannot be changed by clients.
+	Owner string `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	// workspace_uuid is the tenant this record belongs to. It is set by the
+	// server from the caller's workspace.
+	WorkspaceUuid string `protobuf:"bytes,10,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	// archived_at is set while the conversation is archived. Archived
+	// conversations are hidden from the default list and exempt from retention.
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// deleted_at is set when the conversation is deleted. It can be restored
+	// until the restore window passes, after which it is purged.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// variables are custom values the role's system prompt template can read
+	// as {{.Vars.name}}.
+	Variables map[string]string `protobuf:"bytes,13,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// role_version pins the conversation to a version of its role. 0 follows
+	// the latest version.
+	RoleVersion   int32 `protobuf:"varint,14,opt,name=role_version,json=roleVersion,proto3" json:"role_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -275,11 +467,268 @@ func (x *Conversation) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Conversation) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Conversation) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *Conversation) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Conversation) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Conversation) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *Conversation) GetRoleVersion() int32 {
+	if x != nil {
+		return x.RoleVersion
+	}
+	return 0
+}
+
+// MessageExcerpt is a highlighted fragment of a message matched by a search.
+type MessageExcerpt struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageUuid string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	Role        MessageRole            `protobuf:"varint,2,opt,name=role,proto3,enum=schemas.greyseal.v1.MessageRole" json:"role,omitempty"`
+	// excerpt is an HTML fragment of the message content: the content is
+	// escaped and matched terms are wrapped in <mark></mark>.
+	Excerpt       string                 `protobuf:"bytes,3,opt,name=excerpt,proto3" json:"excerpt,omitempty"`
+	Rank          float32                `protobuf:"fixed32,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageExcerpt) Reset() {
+	*x = MessageExcerpt{}
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageExcerpt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageExcerpt) ProtoMessage() {}
+
+func (x *MessageExcerpt) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageExcerpt.ProtoReflect.Descriptor instead.
+func (*MessageExcerpt) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MessageExcerpt) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *MessageExcerpt) GetRole() MessageRole {
+	if x != nil {
+		return x.Role
+	}
+	return MessageRole_MESSAGE_ROLE_UNSPECIFIED
+}
+
+func (x *MessageExcerpt) GetExcerpt() string {
+	if x != nil {
+		return x.Excerpt
+	}
+	return ""
+}
+
+func (x *MessageExcerpt) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *MessageExcerpt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ConversationSearchResult is a conversation matched by a full-text search.
+type ConversationSearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// conversation is returned without its messages.
+	Conversation *Conversation `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	// excerpts holds the best matching messages, most relevant first.
+	Excerpts []*MessageExcerpt `protobuf:"bytes,2,rep,name=excerpts,proto3" json:"excerpts,omitempty"`
+	// rank is the relevance of the best matching message.
+	Rank          float32 `protobuf:"fixed32,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationSearchResult) Reset() {
+	*x = ConversationSearchResult{}
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationSearchResult) ProtoMessage() {}
+
+func (x *ConversationSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationSearchResult.ProtoReflect.Descriptor instead.
+func (*ConversationSearchResult) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConversationSearchResult) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ConversationSearchResult) GetExcerpts() []*MessageExcerpt {
+	if x != nil {
+		return x.Excerpts
+	}
+	return nil
+}
+
+func (x *ConversationSearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// GuardrailBlock describes a refused chat turn.
+type GuardrailBlock struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason GuardrailReason        `protobuf:"varint,1,opt,name=reason,proto3,enum=schemas.greyseal.v1.GuardrailReason" json:"reason,omitempty"`
+	// message is the user-facing explanation, also sent as the turn's answer.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// guardrail names the guardrail that refused the turn.
+	Guardrail string `protobuf:"bytes,3,opt,name=guardrail,proto3" json:"guardrail,omitempty"`
+	// stage is when the turn was refused: pre_retrieval, pre_llm or post_llm.
+	Stage         string `protobuf:"bytes,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuardrailBlock) Reset() {
+	*x = GuardrailBlock{}
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuardrailBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuardrailBlock) ProtoMessage() {}
+
+func (x *GuardrailBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_conversation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuardrailBlock.ProtoReflect.Descriptor instead.
+func (*GuardrailBlock) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_conversation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GuardrailBlock) GetReason() GuardrailReason {
+	if x != nil {
+		return x.Reason
+	}
+	return GuardrailReason_GUARDRAIL_REASON_UNSPECIFIED
+}
+
+func (x *GuardrailBlock) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GuardrailBlock) GetGuardrail() string {
+	if x != nil {
+		return x.Guardrail
+	}
+	return ""
+}
+
+func (x *GuardrailBlock) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
 var File_schemas_greyseal_v1_conversation_proto protoreflect.FileDescriptor
 
 const file_schemas_greyseal_v1_conversation_proto_rawDesc = "" +
 	"\n" +
-	"&schemas/greyseal/v1/conversation.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x02\n" +
+	"&schemas/greyseal/v1/conversation.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x03\n" +
 	"\aMessage\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12+\n" +
 	"\x11conversation_uuid\x18\x02 \x01(\tR\x10conversationUuid\x124\n" +
@@ -288,7 +737,14 @@ const file_schemas_greyseal_v1_conversation_proto_rawDesc = "" +
 	"\x0eresource_uuids\x18\x05 \x03(\tR\rresourceUuids\x12\x1a\n" +
 	"\bfeedback\x18\x06 \x01(\x05R\bfeedback\x129\n" +
 	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc6\x02\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05owner\x18\b \x01(\tR\x05owner\x12%\n" +
+	"\x0eworkspace_uuid\x18\t \x01(\tR\rworkspaceUuid\x12\x14\n" +
+	"\x05model\x18\n" +
+	" \x01(\tR\x05model\x12\x1b\n" +
+	"\trole_uuid\x18\v \x01(\tR\broleUuid\x12!\n" +
+	"\frole_version\x18\f \x01(\x05R\vroleVersion\x12\x18\n" +
+	"\astopped\x18\r \x01(\bR\astopped\"\xac\x05\n" +
 	"\fConversation\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1b\n" +
@@ -299,11 +755,50 @@ const file_schemas_greyseal_v1_conversation_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*^\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05owner\x18\t \x01(\tR\x05owner\x12%\n" +
+	"\x0eworkspace_uuid\x18\n" +
+	" \x01(\tR\rworkspaceUuid\x12;\n" +
+	"\varchived_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12N\n" +
+	"\tvariables\x18\r \x03(\v20.schemas.greyseal.v1.Conversation.VariablesEntryR\tvariables\x12!\n" +
+	"\frole_version\x18\x0e \x01(\x05R\vroleVersion\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd2\x01\n" +
+	"\x0eMessageExcerpt\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x124\n" +
+	"\x04role\x18\x02 \x01(\x0e2 .schemas.greyseal.v1.MessageRoleR\x04role\x12\x18\n" +
+	"\aexcerpt\x18\x03 \x01(\tR\aexcerpt\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x02R\x04rank\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb6\x01\n" +
+	"\x18ConversationSearchResult\x12E\n" +
+	"\fconversation\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\fconversation\x12?\n" +
+	"\bexcerpts\x18\x02 \x03(\v2#.schemas.greyseal.v1.MessageExcerptR\bexcerpts\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x02R\x04rank\"\x9c\x01\n" +
+	"\x0eGuardrailBlock\x12<\n" +
+	"\x06reason\x18\x01 \x01(\x0e2$.schemas.greyseal.v1.GuardrailReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\tguardrail\x18\x03 \x01(\tR\tguardrail\x12\x14\n" +
+	"\x05stage\x18\x04 \x01(\tR\x05stage*^\n" +
 	"\vMessageRole\x12\x1c\n" +
 	"\x18MESSAGE_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11MESSAGE_ROLE_USER\x10\x01\x12\x1a\n" +
-	"\x16MESSAGE_ROLE_ASSISTANT\x10\x02B\xdc\x01\n" +
+	"\x16MESSAGE_ROLE_ASSISTANT\x10\x02*\x9c\x01\n" +
+	"\x12ConversationStatus\x12#\n" +
+	"\x1fCONVERSATION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aCONVERSATION_STATUS_ACTIVE\x10\x01\x12 \n" +
+	"\x1cCONVERSATION_STATUS_ARCHIVED\x10\x02\x12\x1f\n" +
+	"\x1bCONVERSATION_STATUS_DELETED\x10\x03*\xba\x01\n" +
+	"\x0fGuardrailReason\x12 \n" +
+	"\x1cGUARDRAIL_REASON_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eGUARDRAIL_REASON_BLOCKED_TOPIC\x10\x01\x12#\n" +
+	"\x1fGUARDRAIL_REASON_INPUT_TOO_LONG\x10\x02\x12\x1f\n" +
+	"\x1bGUARDRAIL_REASON_NO_CONTEXT\x10\x03\x12\x1b\n" +
+	"\x17GUARDRAIL_REASON_POLICY\x10\x04B\xdc\x01\n" +
 	"\x17com.schemas.greyseal.v1B\x11ConversationProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
 
 var (
@@ -318,25 +813,39 @@ func file_schemas_greyseal_v1_conversation_proto_rawDescGZIP() []byte {
 	return file_schemas_greyseal_v1_conversation_proto_rawDescData
 }
 
-var file_schemas_greyseal_v1_conversation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_schemas_greyseal_v1_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_schemas_greyseal_v1_conversation_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_schemas_greyseal_v1_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_schemas_greyseal_v1_conversation_proto_goTypes = []any{
-	(MessageRole)(0),              // 0: schemas.greyseal.v1.MessageRole
-	(*Message)(nil),               // 1: schemas.greyseal.v1.Message
-	(*Conversation)(nil),          // 2: schemas.greyseal.v1.Conversation
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(MessageRole)(0),                 // 0: schemas.greyseal.v1.MessageRole
+	(ConversationStatus)(0),          // 1: schemas.greyseal.v1.ConversationStatus
+	(GuardrailReason)(0),             // 2: schemas.greyseal.v1.GuardrailReason
+	(*Message)(nil),                  // 3: schemas.greyseal.v1.Message
+	(*Conversation)(nil),             // 4: schemas.greyseal.v1.Conversation
+	(*MessageExcerpt)(nil),           // 5: schemas.greyseal.v1.MessageExcerpt
+	(*ConversationSearchResult)(nil), // 6: schemas.greyseal.v1.ConversationSearchResult
+	(*GuardrailBlock)(nil),           // 7: schemas.greyseal.v1.GuardrailBlock
+	nil,                              // 8: schemas.greyseal.v1.Conversation.VariablesEntry
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
 }
 var file_schemas_greyseal_v1_conversation_proto_depIdxs = []int32{
-	0, // 0: schemas.greyseal.v1.Message.role:type_name -> schemas.greyseal.v1.MessageRole
-	3, // 1: schemas.greyseal.v1.Message.created_at:type_name -> google.protobuf.Timestamp
-	1, // 2: schemas.greyseal.v1.Conversation.messages:type_name -> schemas.greyseal.v1.Message
-	3, // 3: schemas.greyseal.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
-	3, // 4: schemas.greyseal.v1.Conversation.updated_at:type_name -> google.protobuf.Timestamp
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: schemas.greyseal.v1.Message.role:type_name -> schemas.greyseal.v1.MessageRole
+	9,  // 1: schemas.greyseal.v1.Message.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: schemas.greyseal.v1.Conversation.messages:type_name -> schemas.greyseal.v1.Message
+	9,  // 3: schemas.greyseal.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: schemas.greyseal.v1.Conversation.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: schemas.greyseal.v1.Conversation.archived_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: schemas.greyseal.v1.Conversation.deleted_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: schemas.greyseal.v1.Conversation.variables:type_name -> schemas.greyseal.v1.Conversation.VariablesEntry
+	0,  // 8: schemas.greyseal.v1.MessageExcerpt.role:type_name -> schemas.greyseal.v1.MessageRole
+	9,  // 9: schemas.greyseal.v1.MessageExcerpt.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 10: schemas.greyseal.v1.ConversationSearchResult.conversation:type_name -> schemas.greyseal.v1.Conversation
+	5,  // 11: schemas.greyseal.v1.ConversationSearchResult.excerpts:type_name -> schemas.greyseal.v1.MessageExcerpt
+	2,  // 12: schemas.greyseal.v1.GuardrailBlock.reason:type_name -> schemas.greyseal.v1.GuardrailReason
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_schemas_greyseal_v1_conversation_proto_init() }
@@ -349,8 +858,8 @@ func file_schemas_greyseal_v1_conversation_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_conversation_proto_rawDesc), len(file_schemas_greyseal_v1_conversation_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,390 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/dataset.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DatasetSnippet is one search result retrieved for a turn.
+type DatasetSnippet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResourceUuid  string                 `protobuf:"bytes,1,opt,name=resource_uuid,json=resourceUuid,proto3" json:"resource_uuid,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Score         float32                `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatasetSnippet) Reset() {
+	*x = DatasetSnippet{}
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatasetSnippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetSnippet) ProtoMessage() {}
+
+func (x *DatasetSnippet) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetSnippet.ProtoReflect.Descriptor instead.
+func (*DatasetSnippet) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_dataset_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DatasetSnippet) GetResourceUuid() string {
+	if x != nil {
+		return x.ResourceUuid
+	}
+	return ""
+}
+
+func (x *DatasetSnippet) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DatasetSnippet) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *DatasetSnippet) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// DatasetMessage is one message of the prompt sent to the LLM.
+type DatasetMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// role is "system", "user" or "assistant".
+	Role          string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatasetMessage) Reset() {
+	*x = DatasetMessage{}
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatasetMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetMessage) ProtoMessage() {}
+
+func (x *DatasetMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetMessage.ProtoReflect.Descriptor instead.
+func (*DatasetMessage) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_dataset_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DatasetMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *DatasetMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// DatasetRecord is a rated assistant turn with the context that produced it,
+// for evaluation suites and preference or fine-tuning datasets.
+type DatasetRecord struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MessageUuid      string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	ConversationUuid string                 `protobuf:"bytes,2,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	RoleUuid         string                 `protobuf:"bytes,3,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	Model            string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	// query is the user message the assistant answered.
+	Query string `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	// snippets and messages are empty when the turn's transcript was not
+	// recorded; has_context tells the two cases apart.
+	Snippets      []*DatasetSnippet `protobuf:"bytes,6,rep,name=snippets,proto3" json:"snippets,omitempty"`
+	Messages      []*DatasetMessage `protobuf:"bytes,7,rep,name=messages,proto3" json:"messages,omitempty"`
+	HasContext    bool              `protobuf:"varint,8,opt,name=has_context,json=hasContext,proto3" json:"has_context,omitempty"`
+	Response      string            `protobuf:"bytes,9,opt,name=response,proto3" json:"response,omitempty"`
+	ResourceUuids []string          `protobuf:"bytes,10,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	// rating, reasons and comment come from the latest feedback on the message.
+	Rating        int32                  `protobuf:"varint,11,opt,name=rating,proto3" json:"rating,omitempty"`
+	Reasons       []FeedbackReason       `protobuf:"varint,12,rep,packed,name=reasons,proto3,enum=schemas.greyseal.v1.FeedbackReason" json:"reasons,omitempty"`
+	Comment       string                 `protobuf:"bytes,13,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatasetRecord) Reset() {
+	*x = DatasetRecord{}
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatasetRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetRecord) ProtoMessage() {}
+
+func (x *DatasetRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_dataset_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetRecord.ProtoReflect.Descriptor instead.
+func (*DatasetRecord) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_dataset_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DatasetRecord) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetSnippets() []*DatasetSnippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+func (x *DatasetRecord) GetMessages() []*DatasetMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *DatasetRecord) GetHasContext() bool {
+	if x != nil {
+		return x.HasContext
+	}
+	return false
+}
+
+func (x *DatasetRecord) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetResourceUuids() []string {
+	if x != nil {
+		return x.ResourceUuids
+	}
+	return nil
+}
+
+func (x *DatasetRecord) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *DatasetRecord) GetReasons() []FeedbackReason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *DatasetRecord) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *DatasetRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_dataset_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_dataset_proto_rawDesc = "" +
+	"\n" +
+	"!schemas/greyseal/v1/dataset.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\"schemas/greyseal/v1/feedback.proto\"{\n" +
+	"\x0eDatasetSnippet\x12#\n" +
+	"\rresource_uuid\x18\x01 \x01(\tR\fresourceUuid\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x02R\x05score\">\n" +
+	"\x0eDatasetMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xba\x04\n" +
+	"\rDatasetRecord\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x12+\n" +
+	"\x11conversation_uuid\x18\x02 \x01(\tR\x10conversationUuid\x12\x1b\n" +
+	"\trole_uuid\x18\x03 \x01(\tR\broleUuid\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12?\n" +
+	"\bsnippets\x18\x06 \x03(\v2#.schemas.greyseal.v1.DatasetSnippetR\bsnippets\x12?\n" +
+	"\bmessages\x18\a \x03(\v2#.schemas.greyseal.v1.DatasetMessageR\bmessages\x12\x1f\n" +
+	"\vhas_context\x18\b \x01(\bR\n" +
+	"hasContext\x12\x1a\n" +
+	"\bresponse\x18\t \x01(\tR\bresponse\x12%\n" +
+	"\x0eresource_uuids\x18\n" +
+	" \x03(\tR\rresourceUuids\x12\x16\n" +
+	"\x06rating\x18\v \x01(\x05R\x06rating\x12=\n" +
+	"\areasons\x18\f \x03(\x0e2#.schemas.greyseal.v1.FeedbackReasonR\areasons\x12\x18\n" +
+	"\acomment\x18\r \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\xd7\x01\n" +
+	"\x17com.schemas.greyseal.v1B\fDatasetProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_dataset_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_dataset_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_dataset_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_dataset_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_dataset_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_dataset_proto_rawDesc), len(file_schemas_greyseal_v1_dataset_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_dataset_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_dataset_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_schemas_greyseal_v1_dataset_proto_goTypes = []any{
+	(*DatasetSnippet)(nil),        // 0: schemas.greyseal.v1.DatasetSnippet
+	(*DatasetMessage)(nil),        // 1: schemas.greyseal.v1.DatasetMessage
+	(*DatasetRecord)(nil),         // 2: schemas.greyseal.v1.DatasetRecord
+	(FeedbackReason)(0),           // 3: schemas.greyseal.v1.FeedbackReason
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_schemas_greyseal_v1_dataset_proto_depIdxs = []int32{
+	0, // 0: schemas.greyseal.v1.DatasetRecord.snippets:type_name -> schemas.greyseal.v1.DatasetSnippet
+	1, // 1: schemas.greyseal.v1.DatasetRecord.messages:type_name -> schemas.greyseal.v1.DatasetMessage
+	3, // 2: schemas.greyseal.v1.DatasetRecord.reasons:type_name -> schemas.greyseal.v1.FeedbackReason
+	4, // 3: schemas.greyseal.v1.DatasetRecord.created_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_dataset_proto_init() }
+func file_schemas_greyseal_v1_dataset_proto_init() {
+	if File_schemas_greyseal_v1_dataset_proto != nil {
+		return
+	}
+	file_schemas_greyseal_v1_feedback_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_dataset_proto_rawDesc), len(file_schemas_greyseal_v1_dataset_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_dataset_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_dataset_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_dataset_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_dataset_proto = out.File
+	file_schemas_greyseal_v1_dataset_proto_goTypes = nil
+	file_schemas_greyseal_v1_dataset_proto_depIdxs = nil
+}
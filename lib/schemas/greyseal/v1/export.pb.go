@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/export.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConversationExport is a self-contained copy of a conversation that can be
+// archived or imported into another instance.
+type ConversationExport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// version is the export format version.
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// conversation includes its messages with timestamps, feedback and the
+	// resource_uuids each assistant reply cited.
+	Conversation *Conversation `protobuf:"bytes,2,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	// role is a snapshot of the conversation's role at export time, if any.
+	Role *Role `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// citations describes every resource referenced by the messages, so
+	// sources stay readable where the resources do not exist.
+	Citations     []*Resource            `protobuf:"bytes,4,rep,name=citations,proto3" json:"citations,omitempty"`
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationExport) Reset() {
+	*x = ConversationExport{}
+	mi := &file_schemas_greyseal_v1_export_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationExport) ProtoMessage() {}
+
+func (x *ConversationExport) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_export_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationExport.ProtoReflect.Descriptor instead.
+func (*ConversationExport) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConversationExport) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConversationExport) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ConversationExport) GetRole() *Role {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+func (x *ConversationExport) GetCitations() []*Resource {
+	if x != nil {
+		return x.Citations
+	}
+	return nil
+}
+
+func (x *ConversationExport) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_export_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_export_proto_rawDesc = "" +
+	"\n" +
+	" schemas/greyseal/v1/export.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&schemas/greyseal/v1/conversation.proto\x1a\"schemas/greyseal/v1/resource.proto\x1a\x1eschemas/greyseal/v1/role.proto\"\x9e\x02\n" +
+	"\x12ConversationExport\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12E\n" +
+	"\fconversation\x18\x02 \x01(\v2!.schemas.greyseal.v1.ConversationR\fconversation\x12-\n" +
+	"\x04role\x18\x03 \x01(\v2\x19.schemas.greyseal.v1.RoleR\x04role\x12;\n" +
+	"\tcitations\x18\x04 \x03(\v2\x1d.schemas.greyseal.v1.ResourceR\tcitations\x12;\n" +
+	"\vexported_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAtB\xd6\x01\n" +
+	"\x17com.schemas.greyseal.v1B\vExportProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_export_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_export_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_export_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_export_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_export_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_export_proto_rawDesc), len(file_schemas_greyseal_v1_export_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_export_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_export_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_schemas_greyseal_v1_export_proto_goTypes = []any{
+	(*ConversationExport)(nil),    // 0: schemas.greyseal.v1.ConversationExport
+	(*Conversation)(nil),          // 1: schemas.greyseal.v1.Conversation
+	(*Role)(nil),                  // 2: schemas.greyseal.v1.Role
+	(*Resource)(nil),              // 3: schemas.greyseal.v1.Resource
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_schemas_greyseal_v1_export_proto_depIdxs = []int32{
+	1, // 0: schemas.greyseal.v1.ConversationExport.conversation:type_name -> schemas.greyseal.v1.Conversation
+	2, // 1: schemas.greyseal.v1.ConversationExport.role:type_name -> schemas.greyseal.v1.Role
+	3, // 2: schemas.greyseal.v1.ConversationExport.citations:type_name -> schemas.greyseal.v1.Resource
+	4, // 3: schemas.greyseal.v1.ConversationExport.exported_at:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_export_proto_init() }
+func file_schemas_greyseal_v1_export_proto_init() {
+	if File_schemas_greyseal_v1_export_proto != nil {
+		return
+	}
+	file_schemas_greyseal_v1_conversation_proto_init()
+	file_schemas_greyseal_v1_resource_proto_init()
+	file_schemas_greyseal_v1_role_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_export_proto_rawDesc), len(file_schemas_greyseal_v1_export_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_export_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_export_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_export_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_export_proto = out.File
+	file_schemas_greyseal_v1_export_proto_goTypes = nil
+	file_schemas_greyseal_v1_export_proto_depIdxs = nil
+}
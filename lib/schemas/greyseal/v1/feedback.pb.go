@@ -0,0 +1,641 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/feedback.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FeedbackReason categorises what was wrong (or right) with an answer.
+type FeedbackReason int32
+
+const (
+	FeedbackReason_FEEDBACK_REASON_UNSPECIFIED FeedbackReason = 0
+	// HALLUCINATION: the answer states things not supported by any source.
+	FeedbackReason_FEEDBACK_REASON_HALLUCINATION FeedbackReason = 1
+	// WRONG_SOURCE: the answer cites or relies on the wrong documents.
+	FeedbackReason_FEEDBACK_REASON_WRONG_SOURCE FeedbackReason = 2
+	// INCOMPLETE: the answer misses part of the question.
+	FeedbackReason_FEEDBACK_REASON_INCOMPLETE FeedbackReason = 3
+	// TONE: the answer's tone or style is inappropriate.
+	FeedbackReason_FEEDBACK_REASON_TONE FeedbackReason = 4
+)
+
+// Enum value maps for FeedbackReason.
+var (
+	FeedbackReason_name = map[int32]string{
+		0: "FEEDBACK_REASON_UNSPECIFIED",
+		1: "FEEDBACK_REASON_HALLUCINATION",
+		2: "FEEDBACK_REASON_WRONG_SOURCE",
+		3: "FEEDBACK_REASON_INCOMPLETE",
+		4: "FEEDBACK_REASON_TONE",
+	}
+	FeedbackReason_value = map[string]int32{
+		"FEEDBACK_REASON_UNSPECIFIED":   0,
+		"FEEDBACK_REASON_HALLUCINATION": 1,
+		"FEEDBACK_REASON_WRONG_SOURCE":  2,
+		"FEEDBACK_REASON_INCOMPLETE":    3,
+		"FEEDBACK_REASON_TONE":          4,
+	}
+)
+
+func (x FeedbackReason) Enum() *FeedbackReason {
+	p := new(FeedbackReason)
+	*p = x
+	return p
+}
+
+func (x FeedbackReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeedbackReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_feedback_proto_enumTypes[0].Descriptor()
+}
+
+func (FeedbackReason) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_feedback_proto_enumTypes[0]
+}
+
+func (x FeedbackReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedbackReason.Descriptor instead.
+func (FeedbackReason) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{0}
+}
+
+// FeedbackInterval is the bucket size of a report's time series.
+type FeedbackInterval int32
+
+const (
+	// UNSPECIFIED is treated as DAY.
+	FeedbackInterval_FEEDBACK_INTERVAL_UNSPECIFIED FeedbackInterval = 0
+	FeedbackInterval_FEEDBACK_INTERVAL_DAY         FeedbackInterval = 1
+	FeedbackInterval_FEEDBACK_INTERVAL_WEEK        FeedbackInterval = 2
+	FeedbackInterval_FEEDBACK_INTERVAL_MONTH       FeedbackInterval = 3
+)
+
+// Enum value maps for FeedbackInterval.
+var (
+	FeedbackInterval_name = map[int32]string{
+		0: "FEEDBACK_INTERVAL_UNSPECIFIED",
+		1: "FEEDBACK_INTERVAL_DAY",
+		2: "FEEDBACK_INTERVAL_WEEK",
+		3: "FEEDBACK_INTERVAL_MONTH",
+	}
+	FeedbackInterval_value = map[string]int32{
+		"FEEDBACK_INTERVAL_UNSPECIFIED": 0,
+		"FEEDBACK_INTERVAL_DAY":         1,
+		"FEEDBACK_INTERVAL_WEEK":        2,
+		"FEEDBACK_INTERVAL_MONTH":       3,
+	}
+)
+
+func (x FeedbackInterval) Enum() *FeedbackInterval {
+	p := new(FeedbackInterval)
+	*p = x
+	return p
+}
+
+func (x FeedbackInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeedbackInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_feedback_proto_enumTypes[1].Descriptor()
+}
+
+func (FeedbackInterval) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_feedback_proto_enumTypes[1]
+}
+
+func (x FeedbackInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedbackInterval.Descriptor instead.
+func (FeedbackInterval) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{1}
+}
+
+// Feedback is one rating of an assistant message by one user. Every
+// submission is kept, so a user's history on a message can be reviewed; the
+// message's own feedback field holds the latest rating.
+type Feedback struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Uuid             string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	MessageUuid      string                 `protobuf:"bytes,2,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	ConversationUuid string                 `protobuf:"bytes,3,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	// rating: -1 negative, 0 neutral, 1 positive.
+	Rating  int32            `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Reasons []FeedbackReason `protobuf:"varint,5,rep,packed,name=reasons,proto3,enum=schemas.greyseal.v1.FeedbackReason" json:"reasons,omitempty"`
+	Comment string           `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	// owner is the subject of the principal that submitted the feedback.
+	Owner         string                 `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	WorkspaceUuid string                 `protobuf:"bytes,8,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feedback) Reset() {
+	*x = Feedback{}
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feedback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feedback) ProtoMessage() {}
+
+func (x *Feedback) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feedback.ProtoReflect.Descriptor instead.
+func (*Feedback) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Feedback) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Feedback) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *Feedback) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *Feedback) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Feedback) GetReasons() []FeedbackReason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *Feedback) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Feedback) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Feedback) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *Feedback) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type FeedbackReasonCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        FeedbackReason         `protobuf:"varint,1,opt,name=reason,proto3,enum=schemas.greyseal.v1.FeedbackReason" json:"reason,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackReasonCount) Reset() {
+	*x = FeedbackReasonCount{}
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackReasonCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackReasonCount) ProtoMessage() {}
+
+func (x *FeedbackReasonCount) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackReasonCount.ProtoReflect.Descriptor instead.
+func (*FeedbackReasonCount) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FeedbackReasonCount) GetReason() FeedbackReason {
+	if x != nil {
+		return x.Reason
+	}
+	return FeedbackReason_FEEDBACK_REASON_UNSPECIFIED
+}
+
+func (x *FeedbackReasonCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// FeedbackStats aggregates the ratings in one group of a report. Only each
+// user's latest rating of a message is counted.
+type FeedbackStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key identifies the group: a role UUID, model name or resource UUID. It is
+	// empty for messages without a role or model.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// label is the role or resource name, if it still exists.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// period_start is set for time series groups.
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Positive      int32                  `protobuf:"varint,5,opt,name=positive,proto3" json:"positive,omitempty"`
+	Neutral       int32                  `protobuf:"varint,6,opt,name=neutral,proto3" json:"neutral,omitempty"`
+	Negative      int32                  `protobuf:"varint,7,opt,name=negative,proto3" json:"negative,omitempty"`
+	AverageRating float64                `protobuf:"fixed64,8,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	Reasons       []*FeedbackReasonCount `protobuf:"bytes,9,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackStats) Reset() {
+	*x = FeedbackStats{}
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackStats) ProtoMessage() {}
+
+func (x *FeedbackStats) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackStats.ProtoReflect.Descriptor instead.
+func (*FeedbackStats) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FeedbackStats) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FeedbackStats) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FeedbackStats) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *FeedbackStats) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FeedbackStats) GetPositive() int32 {
+	if x != nil {
+		return x.Positive
+	}
+	return 0
+}
+
+func (x *FeedbackStats) GetNeutral() int32 {
+	if x != nil {
+		return x.Neutral
+	}
+	return 0
+}
+
+func (x *FeedbackStats) GetNegative() int32 {
+	if x != nil {
+		return x.Negative
+	}
+	return 0
+}
+
+func (x *FeedbackStats) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *FeedbackStats) GetReasons() []*FeedbackReasonCount {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+// FeedbackReport aggregates feedback submitted within [after, before).
+type FeedbackReport struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	After    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	Before   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	Interval FeedbackInterval       `protobuf:"varint,3,opt,name=interval,proto3,enum=schemas.greyseal.v1.FeedbackInterval" json:"interval,omitempty"`
+	Overall  *FeedbackStats         `protobuf:"bytes,4,opt,name=overall,proto3" json:"overall,omitempty"`
+	// by_role, by_model and by_resource are ordered by negative ratings, most
+	// first. A message counts towards every resource it cites.
+	ByRole     []*FeedbackStats `protobuf:"bytes,5,rep,name=by_role,json=byRole,proto3" json:"by_role,omitempty"`
+	ByModel    []*FeedbackStats `protobuf:"bytes,6,rep,name=by_model,json=byModel,proto3" json:"by_model,omitempty"`
+	ByResource []*FeedbackStats `protobuf:"bytes,7,rep,name=by_resource,json=byResource,proto3" json:"by_resource,omitempty"`
+	// by_period is ordered by period_start and omits periods without feedback.
+	ByPeriod      []*FeedbackStats `protobuf:"bytes,8,rep,name=by_period,json=byPeriod,proto3" json:"by_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackReport) Reset() {
+	*x = FeedbackReport{}
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackReport) ProtoMessage() {}
+
+func (x *FeedbackReport) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_feedback_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackReport.ProtoReflect.Descriptor instead.
+func (*FeedbackReport) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_feedback_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FeedbackReport) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetInterval() FeedbackInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return FeedbackInterval_FEEDBACK_INTERVAL_UNSPECIFIED
+}
+
+func (x *FeedbackReport) GetOverall() *FeedbackStats {
+	if x != nil {
+		return x.Overall
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetByRole() []*FeedbackStats {
+	if x != nil {
+		return x.ByRole
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetByModel() []*FeedbackStats {
+	if x != nil {
+		return x.ByModel
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetByResource() []*FeedbackStats {
+	if x != nil {
+		return x.ByResource
+	}
+	return nil
+}
+
+func (x *FeedbackReport) GetByPeriod() []*FeedbackStats {
+	if x != nil {
+		return x.ByPeriod
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_feedback_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_feedback_proto_rawDesc = "" +
+	"\n" +
+	"\"schemas/greyseal/v1/feedback.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x02\n" +
+	"\bFeedback\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fmessage_uuid\x18\x02 \x01(\tR\vmessageUuid\x12+\n" +
+	"\x11conversation_uuid\x18\x03 \x01(\tR\x10conversationUuid\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12=\n" +
+	"\areasons\x18\x05 \x03(\x0e2#.schemas.greyseal.v1.FeedbackReasonR\areasons\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\x12\x14\n" +
+	"\x05owner\x18\a \x01(\tR\x05owner\x12%\n" +
+	"\x0eworkspace_uuid\x18\b \x01(\tR\rworkspaceUuid\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"h\n" +
+	"\x13FeedbackReasonCount\x12;\n" +
+	"\x06reason\x18\x01 \x01(\x0e2#.schemas.greyseal.v1.FeedbackReasonR\x06reason\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xc9\x02\n" +
+	"\rFeedbackStats\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12=\n" +
+	"\fperiod_start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bpositive\x18\x05 \x01(\x05R\bpositive\x12\x18\n" +
+	"\aneutral\x18\x06 \x01(\x05R\aneutral\x12\x1a\n" +
+	"\bnegative\x18\a \x01(\x05R\bnegative\x12%\n" +
+	"\x0eaverage_rating\x18\b \x01(\x01R\raverageRating\x12B\n" +
+	"\areasons\x18\t \x03(\v2(.schemas.greyseal.v1.FeedbackReasonCountR\areasons\"\xf9\x03\n" +
+	"\x0eFeedbackReport\x120\n" +
+	"\x05after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\x12A\n" +
+	"\binterval\x18\x03 \x01(\x0e2%.schemas.greyseal.v1.FeedbackIntervalR\binterval\x12<\n" +
+	"\aoverall\x18\x04 \x01(\v2\".schemas.greyseal.v1.FeedbackStatsR\aoverall\x12;\n" +
+	"\aby_role\x18\x05 \x03(\v2\".schemas.greyseal.v1.FeedbackStatsR\x06byRole\x12=\n" +
+	"\bby_model\x18\x06 \x03(\v2\".schemas.greyseal.v1.FeedbackStatsR\abyModel\x12C\n" +
+	"\vby_resource\x18\a \x03(\v2\".schemas.greyseal.v1.FeedbackStatsR\n" +
+	"byResource\x12?\n" +
+	"\tby_period\x18\b \x03(\v2\".schemas.greyseal.v1.FeedbackStatsR\bbyPeriod*\xb0\x01\n" +
+	"\x0eFeedbackReason\x12\x1f\n" +
+	"\x1bFEEDBACK_REASON_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dFEEDBACK_REASON_HALLUCINATION\x10\x01\x12 \n" +
+	"\x1cFEEDBACK_REASON_WRONG_SOURCE\x10\x02\x12\x1e\n" +
+	"\x1aFEEDBACK_REASON_INCOMPLETE\x10\x03\x12\x18\n" +
+	"\x14FEEDBACK_REASON_TONE\x10\x04*\x89\x01\n" +
+	"\x10FeedbackInterval\x12!\n" +
+	"\x1dFEEDBACK_INTERVAL_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15FEEDBACK_INTERVAL_DAY\x10\x01\x12\x1a\n" +
+	"\x16FEEDBACK_INTERVAL_WEEK\x10\x02\x12\x1b\n" +
+	"\x17FEEDBACK_INTERVAL_MONTH\x10\x03B\xd8\x01\n" +
+	"\x17com.schemas.greyseal.v1B\rFeedbackProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_feedback_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_feedback_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_feedback_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_feedback_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_feedback_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_feedback_proto_rawDesc), len(file_schemas_greyseal_v1_feedback_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_feedback_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_feedback_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_schemas_greyseal_v1_feedback_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_schemas_greyseal_v1_feedback_proto_goTypes = []any{
+	(FeedbackReason)(0),           // 0: schemas.greyseal.v1.FeedbackReason
+	(FeedbackInterval)(0),         // 1: schemas.greyseal.v1.FeedbackInterval
+	(*Feedback)(nil),              // 2: schemas.greyseal.v1.Feedback
+	(*FeedbackReasonCount)(nil),   // 3: schemas.greyseal.v1.FeedbackReasonCount
+	(*FeedbackStats)(nil),         // 4: schemas.greyseal.v1.FeedbackStats
+	(*FeedbackReport)(nil),        // 5: schemas.greyseal.v1.FeedbackReport
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_schemas_greyseal_v1_feedback_proto_depIdxs = []int32{
+	0,  // 0: schemas.greyseal.v1.Feedback.reasons:type_name -> schemas.greyseal.v1.FeedbackReason
+	6,  // 1: schemas.greyseal.v1.Feedback.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: schemas.greyseal.v1.FeedbackReasonCount.reason:type_name -> schemas.greyseal.v1.FeedbackReason
+	6,  // 3: schemas.greyseal.v1.FeedbackStats.period_start:type_name -> google.protobuf.Timestamp
+	3,  // 4: schemas.greyseal.v1.FeedbackStats.reasons:type_name -> schemas.greyseal.v1.FeedbackReasonCount
+	6,  // 5: schemas.greyseal.v1.FeedbackReport.after:type_name -> google.protobuf.Timestamp
+	6,  // 6: schemas.greyseal.v1.FeedbackReport.before:type_name -> google.protobuf.Timestamp
+	1,  // 7: schemas.greyseal.v1.FeedbackReport.interval:type_name -> schemas.greyseal.v1.FeedbackInterval
+	4,  // 8: schemas.greyseal.v1.FeedbackReport.overall:type_name -> schemas.greyseal.v1.FeedbackStats
+	4,  // 9: schemas.greyseal.v1.FeedbackReport.by_role:type_name -> schemas.greyseal.v1.FeedbackStats
+	4,  // 10: schemas.greyseal.v1.FeedbackReport.by_model:type_name -> schemas.greyseal.v1.FeedbackStats
+	4,  // 11: schemas.greyseal.v1.FeedbackReport.by_resource:type_name -> schemas.greyseal.v1.FeedbackStats
+	4,  // 12: schemas.greyseal.v1.FeedbackReport.by_period:type_name -> schemas.greyseal.v1.FeedbackStats
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_feedback_proto_init() }
+func file_schemas_greyseal_v1_feedback_proto_init() {
+	if File_schemas_greyseal_v1_feedback_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_feedback_proto_rawDesc), len(file_schemas_greyseal_v1_feedback_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_feedback_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_feedback_proto_depIdxs,
+		EnumInfos:         file_schemas_greyseal_v1_feedback_proto_enumTypes,
+		MessageInfos:      file_schemas_greyseal_v1_feedback_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_feedback_proto = out.File
+	file_schemas_greyseal_v1_feedback_proto_goTypes = nil
+	file_schemas_greyseal_v1_feedback_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/model.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Model is an LLM model installed in the inference backend (Ollama).
+type Model struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// size is the on-disk size of the model in bytes.
+	Size   int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// loaded is true when the model is currently held in backend memory.
+	Loaded     bool                   `protobuf:"varint,4,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	ModifiedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	// expires_at is when a loaded model will be evicted; unset when not loaded.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Model) Reset() {
+	*x = Model{}
+	mi := &file_schemas_greyseal_v1_model_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Model) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Model) ProtoMessage() {}
+
+func (x *Model) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_model_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Model.ProtoReflect.Descriptor instead.
+func (*Model) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_model_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Model) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Model) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Model) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Model) GetLoaded() bool {
+	if x != nil {
+		return x.Loaded
+	}
+	return false
+}
+
+func (x *Model) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+func (x *Model) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_model_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_model_proto_rawDesc = "" +
+	"\n" +
+	"\x1fschemas/greyseal/v1/model.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x01\n" +
+	"\x05Model\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06digest\x18\x03 \x01(\tR\x06digest\x12\x16\n" +
+	"\x06loaded\x18\x04 \x01(\bR\x06loaded\x12;\n" +
+	"\vmodified_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"modifiedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAtB\xd5\x01\n" +
+	"\x17com.schemas.greyseal.v1B\n" +
+	"ModelProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_model_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_model_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_model_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_model_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_model_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_model_proto_rawDesc), len(file_schemas_greyseal_v1_model_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_model_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_model_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_schemas_greyseal_v1_model_proto_goTypes = []any{
+	(*Model)(nil),                 // 0: schemas.greyseal.v1.Model
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_schemas_greyseal_v1_model_proto_depIdxs = []int32{
+	1, // 0: schemas.greyseal.v1.Model.modified_at:type_name -> google.protobuf.Timestamp
+	1, // 1: schemas.greyseal.v1.Model.expires_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_model_proto_init() }
+func file_schemas_greyseal_v1_model_proto_init() {
+	if File_schemas_greyseal_v1_model_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_model_proto_rawDesc), len(file_schemas_greyseal_v1_model_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_model_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_model_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_model_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_model_proto = out.File
+	file_schemas_greyseal_v1_model_proto_goTypes = nil
+	file_schemas_greyseal_v1_model_proto_depIdxs = nil
+}
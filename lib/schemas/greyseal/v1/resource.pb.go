@@ -76,15 +76,21 @@ func (Source) EnumDescriptor() ([]byte, []int) {
 
 // Resource represents an ingested and indexed document used as conversation context.
 type Resource struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
-	Entity        string                 `protobuf:"bytes,4,opt,name=entity,proto3" json:"entity,omitempty"`
-	Source        Source                 `protobuf:"varint,5,opt,name=source,proto3,enum=schemas.greyseal.v1.Source" json:"source,omitempty"`
-	Path          string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	IndexedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Uuid      string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Service   string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Entity    string                 `protobuf:"bytes,4,opt,name=entity,proto3" json:"entity,omitempty"`
+	Source    Source                 `protobuf:"varint,5,opt,name=source,proto3,enum=schemas.greyseal.v1.Source" json:"source,omitempty"`
+	Path      string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IndexedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	// workspace_uuid is the tenant this record belongs to. It is set by the
+	// server from the caller's workspace.
+	WorkspaceUuid string `protobuf:"bytes,9,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	// collection groups resources into a named knowledge base, e.g. "hr".
+	Collection    string   `protobuf:"bytes,10,opt,name=collection,proto3" json:"collection,omitempty"`
+	Tags          []string `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -175,11 +181,32 @@ func (x *Resource) GetIndexedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Resource) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *Resource) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *Resource) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 var File_schemas_greyseal_v1_resource_proto protoreflect.FileDescriptor
 
 const file_schemas_greyseal_v1_resource_proto_rawDesc = "" +
 	"\n" +
-	"\"schemas/greyseal/v1/resource.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x02\n" +
+	"\"schemas/greyseal/v1/resource.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x02\n" +
 	"\bResource\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -190,7 +217,13 @@ const file_schemas_greyseal_v1_resource_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"indexed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt*U\n" +
+	"indexed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x12%\n" +
+	"\x0eworkspace_uuid\x18\t \x01(\tR\rworkspaceUuid\x12\x1e\n" +
+	"\n" +
+	"collection\x18\n" +
+	" \x01(\tR\n" +
+	"collection\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags*U\n" +
 	"\x06Source\x12\x16\n" +
 	"\x12SOURCE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eSOURCE_WEBSITE\x10\x01\x12\x0e\n" +
@@ -22,15 +22,91 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ScopePolicy says how a conversation's resource_uuids combine with its
+// role's scope.
+type ScopePolicy int32
+
+const (
+	// SCOPE_POLICY_UNSPECIFIED narrows.
+	ScopePolicy_SCOPE_POLICY_UNSPECIFIED ScopePolicy = 0
+	// SCOPE_POLICY_NARROW searches only the conversation's resources that are
+	// also in the role's scope.
+	ScopePolicy_SCOPE_POLICY_NARROW ScopePolicy = 1
+	// SCOPE_POLICY_WIDEN searches the role's scope and the conversation's
+	// resources.
+	ScopePolicy_SCOPE_POLICY_WIDEN ScopePolicy = 2
+)
+
+// Enum value maps for ScopePolicy.
+var (
+	ScopePolicy_name = map[int32]string{
+		0: "SCOPE_POLICY_UNSPECIFIED",
+		1: "SCOPE_POLICY_NARROW",
+		2: "SCOPE_POLICY_WIDEN",
+	}
+	ScopePolicy_value = map[string]int32{
+		"SCOPE_POLICY_UNSPECIFIED": 0,
+		"SCOPE_POLICY_NARROW":      1,
+		"SCOPE_POLICY_WIDEN":       2,
+	}
+)
+
+func (x ScopePolicy) Enum() *ScopePolicy {
+	p := new(ScopePolicy)
+	*p = x
+	return p
+}
+
+func (x ScopePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScopePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_role_proto_enumTypes[0].Descriptor()
+}
+
+func (ScopePolicy) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_role_proto_enumTypes[0]
+}
+
+func (x ScopePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScopePolicy.Descriptor instead.
+func (ScopePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_role_proto_rawDescGZIP(), []int{0}
+}
+
 // Role is a reusable named system prompt that can be assigned to a conversation
 // to shape how the chatbot responds. Leaving role_uuid blank on a conversation
 // means no system prompt is applied.
 type Role struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	SystemPrompt  string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Uuid         string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SystemPrompt string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// workspace_uuid is the tenant this record belongs to. It is set by the
+	// server from the caller's workspace.
+	WorkspaceUuid string `protobuf:"bytes,5,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	// version is the latest version number. It is set by the server: 1 on
+	// create, incremented by every update.
+	Version int32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// examples are few-shot exchanges sent, in order, after the system prompt
+	// and before the conversation.
+	Examples []*RoleExample `protobuf:"bytes,7,rep,name=examples,proto3" json:"examples,omitempty"`
+	// context_template formats retrieved snippets as a prompt template that can
+	// also range over .Snippets. Empty uses the built-in "Here is relevant
+	// context:" list.
+	ContextTemplate string `protobuf:"bytes,8,opt,name=context_template,json=contextTemplate,proto3" json:"context_template,omitempty"`
+	// no_context_instruction is sent as a system message, rendered like the
+	// system prompt, when retrieval finds nothing.
+	NoContextInstruction string `protobuf:"bytes,9,opt,name=no_context_instruction,json=noContextInstruction,proto3" json:"no_context_instruction,omitempty"`
+	// retrieval is the role's knowledge base and retrieval defaults.
+	Retrieval *RoleRetrieval `protobuf:"bytes,10,opt,name=retrieval,proto3" json:"retrieval,omitempty"`
+	// guardrails are the policy checks applied to turns that use the role.
+	Guardrails    *RoleGuardrails `protobuf:"bytes,11,opt,name=guardrails,proto3" json:"guardrails,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -93,17 +169,489 @@ func (x *Role) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Role) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *Role) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Role) GetExamples() []*RoleExample {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *Role) GetContextTemplate() string {
+	if x != nil {
+		return x.ContextTemplate
+	}
+	return ""
+}
+
+func (x *Role) GetNoContextInstruction() string {
+	if x != nil {
+		return x.NoContextInstruction
+	}
+	return ""
+}
+
+func (x *Role) GetRetrieval() *RoleRetrieval {
+	if x != nil {
+		return x.Retrieval
+	}
+	return nil
+}
+
+func (x *Role) GetGuardrails() *RoleGuardrails {
+	if x != nil {
+		return x.Guardrails
+	}
+	return nil
+}
+
+// RoleRetrieval is the resources a role searches and how. A resource is in
+// scope if it is listed, in one of the collections or carries one of the
+// tags; with all three empty the role does not restrict retrieval.
+type RoleRetrieval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResourceUuids []string               `protobuf:"bytes,1,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	Collections   []string               `protobuf:"bytes,2,rep,name=collections,proto3" json:"collections,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	ScopePolicy   ScopePolicy            `protobuf:"varint,4,opt,name=scope_policy,json=scopePolicy,proto3,enum=schemas.greyseal.v1.ScopePolicy" json:"scope_policy,omitempty"`
+	// limit is how many snippets a turn retrieves; 0 uses the default of 5.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// min_score drops snippets scoring below it; 0 keeps every snippet.
+	MinScore      float32 `protobuf:"fixed32,6,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleRetrieval) Reset() {
+	*x = RoleRetrieval{}
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleRetrieval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleRetrieval) ProtoMessage() {}
+
+func (x *RoleRetrieval) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleRetrieval.ProtoReflect.Descriptor instead.
+func (*RoleRetrieval) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_role_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoleRetrieval) GetResourceUuids() []string {
+	if x != nil {
+		return x.ResourceUuids
+	}
+	return nil
+}
+
+func (x *RoleRetrieval) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *RoleRetrieval) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RoleRetrieval) GetScopePolicy() ScopePolicy {
+	if x != nil {
+		return x.ScopePolicy
+	}
+	return ScopePolicy_SCOPE_POLICY_UNSPECIFIED
+}
+
+func (x *RoleRetrieval) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RoleRetrieval) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+// RoleGuardrails are policy checks run around each chat turn. A turn that
+// fails one is refused with a GuardrailBlock instead of answered.
+type RoleGuardrails struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// blocked_topics are case-insensitive regular expressions; a question or
+	// answer matching one is refused.
+	BlockedTopics []string `protobuf:"bytes,1,rep,name=blocked_topics,json=blockedTopics,proto3" json:"blocked_topics,omitempty"`
+	// max_input_chars refuses longer questions; 0 is unlimited.
+	MaxInputChars int32 `protobuf:"varint,2,opt,name=max_input_chars,json=maxInputChars,proto3" json:"max_input_chars,omitempty"`
+	// require_context refuses to answer when retrieval finds nothing.
+	RequireContext bool `protobuf:"varint,3,opt,name=require_context,json=requireContext,proto3" json:"require_context,omitempty"`
+	// disclaimer is appended to answers that do not already contain it.
+	Disclaimer string `protobuf:"bytes,4,opt,name=disclaimer,proto3" json:"disclaimer,omitempty"`
+	// judge_policy, when set, has the chat model judge each question and
+	// answer against this policy and refuse those that break it.
+	JudgePolicy string `protobuf:"bytes,5,opt,name=judge_policy,json=judgePolicy,proto3" json:"judge_policy,omitempty"`
+	// refusal_message is shown when a turn is refused. Empty uses a message
+	// for the reason.
+	RefusalMessage string `protobuf:"bytes,6,opt,name=refusal_message,json=refusalMessage,proto3" json:"refusal_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RoleGuardrails) Reset() {
+	*x = RoleGuardrails{}
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleGuardrails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleGuardrails) ProtoMessage() {}
+
+func (x *RoleGuardrails) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleGuardrails.ProtoReflect.Descriptor instead.
+func (*RoleGuardrails) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_role_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RoleGuardrails) GetBlockedTopics() []string {
+	if x != nil {
+		return x.BlockedTopics
+	}
+	return nil
+}
+
+func (x *RoleGuardrails) GetMaxInputChars() int32 {
+	if x != nil {
+		return x.MaxInputChars
+	}
+	return 0
+}
+
+func (x *RoleGuardrails) GetRequireContext() bool {
+	if x != nil {
+		return x.RequireContext
+	}
+	return false
+}
+
+func (x *RoleGuardrails) GetDisclaimer() string {
+	if x != nil {
+		return x.Disclaimer
+	}
+	return ""
+}
+
+func (x *RoleGuardrails) GetJudgePolicy() string {
+	if x != nil {
+		return x.JudgePolicy
+	}
+	return ""
+}
+
+func (x *RoleGuardrails) GetRefusalMessage() string {
+	if x != nil {
+		return x.RefusalMessage
+	}
+	return ""
+}
+
+// RoleExample is one example exchange shown to the model.
+type RoleExample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Assistant     string                 `protobuf:"bytes,2,opt,name=assistant,proto3" json:"assistant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleExample) Reset() {
+	*x = RoleExample{}
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleExample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleExample) ProtoMessage() {}
+
+func (x *RoleExample) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleExample.ProtoReflect.Descriptor instead.
+func (*RoleExample) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_role_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RoleExample) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *RoleExample) GetAssistant() string {
+	if x != nil {
+		return x.Assistant
+	}
+	return ""
+}
+
+// RoleVersion is an immutable snapshot of a role, written on every create,
+// update and rollback.
+type RoleVersion struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RoleUuid     string                 `protobuf:"bytes,1,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	Version      int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	SystemPrompt string                 `protobuf:"bytes,4,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// created_by is the subject of the principal that wrote this version.
+	CreatedBy            string          `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	WorkspaceUuid        string          `protobuf:"bytes,7,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	Examples             []*RoleExample  `protobuf:"bytes,8,rep,name=examples,proto3" json:"examples,omitempty"`
+	ContextTemplate      string          `protobuf:"bytes,9,opt,name=context_template,json=contextTemplate,proto3" json:"context_template,omitempty"`
+	NoContextInstruction string          `protobuf:"bytes,10,opt,name=no_context_instruction,json=noContextInstruction,proto3" json:"no_context_instruction,omitempty"`
+	Retrieval            *RoleRetrieval  `protobuf:"bytes,11,opt,name=retrieval,proto3" json:"retrieval,omitempty"`
+	Guardrails           *RoleGuardrails `protobuf:"bytes,12,opt,name=guardrails,proto3" json:"guardrails,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RoleVersion) Reset() {
+	*x = RoleVersion{}
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleVersion) ProtoMessage() {}
+
+func (x *RoleVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_role_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleVersion.ProtoReflect.Descriptor instead.
+func (*RoleVersion) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_role_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RoleVersion) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RoleVersion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RoleVersion) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetExamples() []*RoleExample {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *RoleVersion) GetContextTemplate() string {
+	if x != nil {
+		return x.ContextTemplate
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetNoContextInstruction() string {
+	if x != nil {
+		return x.NoContextInstruction
+	}
+	return ""
+}
+
+func (x *RoleVersion) GetRetrieval() *RoleRetrieval {
+	if x != nil {
+		return x.Retrieval
+	}
+	return nil
+}
+
+func (x *RoleVersion) GetGuardrails() *RoleGuardrails {
+	if x != nil {
+		return x.Guardrails
+	}
+	return nil
+}
+
 var File_schemas_greyseal_v1_role_proto protoreflect.FileDescriptor
 
 const file_schemas_greyseal_v1_role_proto_rawDesc = "" +
 	"\n" +
-	"\x1eschemas/greyseal/v1/role.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x01\n" +
+	"\x1eschemas/greyseal/v1/role.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf5\x03\n" +
 	"\x04Role\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
 	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x129\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\xd4\x01\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12%\n" +
+	"\x0eworkspace_uuid\x18\x05 \x01(\tR\rworkspaceUuid\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\x12<\n" +
+	"\bexamples\x18\a \x03(\v2 .schemas.greyseal.v1.RoleExampleR\bexamples\x12)\n" +
+	"\x10context_template\x18\b \x01(\tR\x0fcontextTemplate\x124\n" +
+	"\x16no_context_instruction\x18\t \x01(\tR\x14noContextInstruction\x12@\n" +
+	"\tretrieval\x18\n" +
+	" \x01(\v2\".schemas.greyseal.v1.RoleRetrievalR\tretrieval\x12C\n" +
+	"\n" +
+	"guardrails\x18\v \x01(\v2#.schemas.greyseal.v1.RoleGuardrailsR\n" +
+	"guardrails\"\xe4\x01\n" +
+	"\rRoleRetrieval\x12%\n" +
+	"\x0eresource_uuids\x18\x01 \x03(\tR\rresourceUuids\x12 \n" +
+	"\vcollections\x18\x02 \x03(\tR\vcollections\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12C\n" +
+	"\fscope_policy\x18\x04 \x01(\x0e2 .schemas.greyseal.v1.ScopePolicyR\vscopePolicy\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tmin_score\x18\x06 \x01(\x02R\bminScore\"\xf4\x01\n" +
+	"\x0eRoleGuardrails\x12%\n" +
+	"\x0eblocked_topics\x18\x01 \x03(\tR\rblockedTopics\x12&\n" +
+	"\x0fmax_input_chars\x18\x02 \x01(\x05R\rmaxInputChars\x12'\n" +
+	"\x0frequire_context\x18\x03 \x01(\bR\x0erequireContext\x12\x1e\n" +
+	"\n" +
+	"disclaimer\x18\x04 \x01(\tR\n" +
+	"disclaimer\x12!\n" +
+	"\fjudge_policy\x18\x05 \x01(\tR\vjudgePolicy\x12'\n" +
+	"\x0frefusal_message\x18\x06 \x01(\tR\x0erefusalMessage\"?\n" +
+	"\vRoleExample\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12\x1c\n" +
+	"\tassistant\x18\x02 \x01(\tR\tassistant\"\xa4\x04\n" +
+	"\vRoleVersion\x12\x1b\n" +
+	"\trole_uuid\x18\x01 \x01(\tR\broleUuid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rsystem_prompt\x18\x04 \x01(\tR\fsystemPrompt\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12%\n" +
+	"\x0eworkspace_uuid\x18\a \x01(\tR\rworkspaceUuid\x12<\n" +
+	"\bexamples\x18\b \x03(\v2 .schemas.greyseal.v1.RoleExampleR\bexamples\x12)\n" +
+	"\x10context_template\x18\t \x01(\tR\x0fcontextTemplate\x124\n" +
+	"\x16no_context_instruction\x18\n" +
+	" \x01(\tR\x14noContextInstruction\x12@\n" +
+	"\tretrieval\x18\v \x01(\v2\".schemas.greyseal.v1.RoleRetrievalR\tretrieval\x12C\n" +
+	"\n" +
+	"guardrails\x18\f \x01(\v2#.schemas.greyseal.v1.RoleGuardrailsR\n" +
+	"guardrails*\\\n" +
+	"\vScopePolicy\x12\x1c\n" +
+	"\x18SCOPE_POLICY_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SCOPE_POLICY_NARROW\x10\x01\x12\x16\n" +
+	"\x12SCOPE_POLICY_WIDEN\x10\x02B\xd4\x01\n" +
 	"\x17com.schemas.greyseal.v1B\tRoleProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
 
 var (
@@ -118,18 +666,32 @@ func file_schemas_greyseal_v1_role_proto_rawDescGZIP() []byte {
 	return file_schemas_greyseal_v1_role_proto_rawDescData
 }
 
-var file_schemas_greyseal_v1_role_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_schemas_greyseal_v1_role_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_schemas_greyseal_v1_role_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_schemas_greyseal_v1_role_proto_goTypes = []any{
-	(*Role)(nil),                  // 0: schemas.greyseal.v1.Role
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(ScopePolicy)(0),              // 0: schemas.greyseal.v1.ScopePolicy
+	(*Role)(nil),                  // 1: schemas.greyseal.v1.Role
+	(*RoleRetrieval)(nil),         // 2: schemas.greyseal.v1.RoleRetrieval
+	(*RoleGuardrails)(nil),        // 3: schemas.greyseal.v1.RoleGuardrails
+	(*RoleExample)(nil),           // 4: schemas.greyseal.v1.RoleExample
+	(*RoleVersion)(nil),           // 5: schemas.greyseal.v1.RoleVersion
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_schemas_greyseal_v1_role_proto_depIdxs = []int32{
-	1, // 0: schemas.greyseal.v1.Role.created_at:type_name -> google.protobuf.Timestamp
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6, // 0: schemas.greyseal.v1.Role.created_at:type_name -> google.protobuf.Timestamp
+	4, // 1: schemas.greyseal.v1.Role.examples:type_name -> schemas.greyseal.v1.RoleExample
+	2, // 2: schemas.greyseal.v1.Role.retrieval:type_name -> schemas.greyseal.v1.RoleRetrieval
+	3, // 3: schemas.greyseal.v1.Role.guardrails:type_name -> schemas.greyseal.v1.RoleGuardrails
+	0, // 4: schemas.greyseal.v1.RoleRetrieval.scope_policy:type_name -> schemas.greyseal.v1.ScopePolicy
+	6, // 5: schemas.greyseal.v1.RoleVersion.created_at:type_name -> google.protobuf.Timestamp
+	4, // 6: schemas.greyseal.v1.RoleVersion.examples:type_name -> schemas.greyseal.v1.RoleExample
+	2, // 7: schemas.greyseal.v1.RoleVersion.retrieval:type_name -> schemas.greyseal.v1.RoleRetrieval
+	3, // 8: schemas.greyseal.v1.RoleVersion.guardrails:type_name -> schemas.greyseal.v1.RoleGuardrails
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_schemas_greyseal_v1_role_proto_init() }
@@ -142,13 +704,14 @@ func file_schemas_greyseal_v1_role_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_role_proto_rawDesc), len(file_schemas_greyseal_v1_role_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_schemas_greyseal_v1_role_proto_goTypes,
 		DependencyIndexes: file_schemas_greyseal_v1_role_proto_depIdxs,
+		EnumInfos:         file_schemas_greyseal_v1_role_proto_enumTypes,
 		MessageInfos:      file_schemas_greyseal_v1_role_proto_msgTypes,
 	}.Build()
 	File_schemas_greyseal_v1_role_proto = out.File
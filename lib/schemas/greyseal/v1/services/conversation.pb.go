@@ -10,6 +10,8 @@ import (
 	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +24,241 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ExportFormat int32
+
+const (
+	// EXPORT_FORMAT_UNSPECIFIED renders JSON.
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_JSON        ExportFormat = 1
+	// EXPORT_FORMAT_MARKDOWN is a human-readable transcript; it cannot be imported.
+	ExportFormat_EXPORT_FORMAT_MARKDOWN ExportFormat = 2
+	// EXPORT_FORMAT_JSONL writes a header line followed by one line per message.
+	ExportFormat_EXPORT_FORMAT_JSONL ExportFormat = 3
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_JSON",
+		2: "EXPORT_FORMAT_MARKDOWN",
+		3: "EXPORT_FORMAT_JSONL",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_JSON":        1,
+		"EXPORT_FORMAT_MARKDOWN":    2,
+		"EXPORT_FORMAT_JSONL":       3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_services_conversation_proto_enumTypes[0].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_services_conversation_proto_enumTypes[0]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{0}
+}
+
+// ChatSessionAction is what a ChatSessionRequest asks the server to do.
+type ChatSessionAction int32
+
+const (
+	ChatSessionAction_CHAT_SESSION_ACTION_UNSPECIFIED ChatSessionAction = 0
+	// CHAT_SESSION_ACTION_SEND sends content as a user message and answers it.
+	ChatSessionAction_CHAT_SESSION_ACTION_SEND ChatSessionAction = 1
+	// CHAT_SESSION_ACTION_STOP stops the answer being generated and keeps what
+	// was generated so far. It is ignored when no answer is running.
+	ChatSessionAction_CHAT_SESSION_ACTION_STOP ChatSessionAction = 2
+	// CHAT_SESSION_ACTION_REGENERATE replaces the last answer with a new one.
+	ChatSessionAction_CHAT_SESSION_ACTION_REGENERATE ChatSessionAction = 3
+	// CHAT_SESSION_ACTION_CONFIGURE changes the conversation's role or resource
+	// scope. It is rejected while an answer is running.
+	ChatSessionAction_CHAT_SESSION_ACTION_CONFIGURE ChatSessionAction = 4
+)
+
+// Enum value maps for ChatSessionAction.
+var (
+	ChatSessionAction_name = map[int32]string{
+		0: "CHAT_SESSION_ACTION_UNSPECIFIED",
+		1: "CHAT_SESSION_ACTION_SEND",
+		2: "CHAT_SESSION_ACTION_STOP",
+		3: "CHAT_SESSION_ACTION_REGENERATE",
+		4: "CHAT_SESSION_ACTION_CONFIGURE",
+	}
+	ChatSessionAction_value = map[string]int32{
+		"CHAT_SESSION_ACTION_UNSPECIFIED": 0,
+		"CHAT_SESSION_ACTION_SEND":        1,
+		"CHAT_SESSION_ACTION_STOP":        2,
+		"CHAT_SESSION_ACTION_REGENERATE":  3,
+		"CHAT_SESSION_ACTION_CONFIGURE":   4,
+	}
+)
+
+func (x ChatSessionAction) Enum() *ChatSessionAction {
+	p := new(ChatSessionAction)
+	*p = x
+	return p
+}
+
+func (x ChatSessionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatSessionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_services_conversation_proto_enumTypes[1].Descriptor()
+}
+
+func (ChatSessionAction) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_services_conversation_proto_enumTypes[1]
+}
+
+func (x ChatSessionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatSessionAction.Descriptor instead.
+func (ChatSessionAction) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{1}
+}
+
+// ChatSessionEvent says which fields of a ChatSessionResponse are set.
+type ChatSessionEvent int32
+
+const (
+	ChatSessionEvent_CHAT_SESSION_EVENT_UNSPECIFIED ChatSessionEvent = 0
+	// CHAT_SESSION_EVENT_READY carries the conversation when the session opens
+	// and after CONFIGURE.
+	ChatSessionEvent_CHAT_SESSION_EVENT_READY ChatSessionEvent = 1
+	// CHAT_SESSION_EVENT_TOKEN carries one token of the running answer.
+	ChatSessionEvent_CHAT_SESSION_EVENT_TOKEN ChatSessionEvent = 2
+	// CHAT_SESSION_EVENT_DONE carries the stored answer, and blocked when a
+	// guardrail refused the turn. message.stopped is set after a STOP.
+	ChatSessionEvent_CHAT_SESSION_EVENT_DONE ChatSessionEvent = 3
+	// CHAT_SESSION_EVENT_ERROR reports a failed request; the session stays open.
+	ChatSessionEvent_CHAT_SESSION_EVENT_ERROR ChatSessionEvent = 4
+)
+
+// Enum value maps for ChatSessionEvent.
+var (
+	ChatSessionEvent_name = map[int32]string{
+		0: "CHAT_SESSION_EVENT_UNSPECIFIED",
+		1: "CHAT_SESSION_EVENT_READY",
+		2: "CHAT_SESSION_EVENT_TOKEN",
+		3: "CHAT_SESSION_EVENT_DONE",
+		4: "CHAT_SESSION_EVENT_ERROR",
+	}
+	ChatSessionEvent_value = map[string]int32{
+		"CHAT_SESSION_EVENT_UNSPECIFIED": 0,
+		"CHAT_SESSION_EVENT_READY":       1,
+		"CHAT_SESSION_EVENT_TOKEN":       2,
+		"CHAT_SESSION_EVENT_DONE":        3,
+		"CHAT_SESSION_EVENT_ERROR":       4,
+	}
+)
+
+func (x ChatSessionEvent) Enum() *ChatSessionEvent {
+	p := new(ChatSessionEvent)
+	*p = x
+	return p
+}
+
+func (x ChatSessionEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatSessionEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_services_conversation_proto_enumTypes[2].Descriptor()
+}
+
+func (ChatSessionEvent) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_services_conversation_proto_enumTypes[2]
+}
+
+func (x ChatSessionEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatSessionEvent.Descriptor instead.
+func (ChatSessionEvent) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{2}
+}
+
+// SnippetSource chooses the context a replayed turn is given.
+type SnippetSource int32
+
+const (
+	// SNIPPET_SOURCE_UNSPECIFIED reuses the recorded snippets.
+	SnippetSource_SNIPPET_SOURCE_UNSPECIFIED SnippetSource = 0
+	SnippetSource_SNIPPET_SOURCE_ORIGINAL    SnippetSource = 1
+	// SNIPPET_SOURCE_FRESH searches again with the recorded query.
+	SnippetSource_SNIPPET_SOURCE_FRESH SnippetSource = 2
+	// SNIPPET_SOURCE_NONE answers without retrieved context.
+	SnippetSource_SNIPPET_SOURCE_NONE SnippetSource = 3
+)
+
+// Enum value maps for SnippetSource.
+var (
+	SnippetSource_name = map[int32]string{
+		0: "SNIPPET_SOURCE_UNSPECIFIED",
+		1: "SNIPPET_SOURCE_ORIGINAL",
+		2: "SNIPPET_SOURCE_FRESH",
+		3: "SNIPPET_SOURCE_NONE",
+	}
+	SnippetSource_value = map[string]int32{
+		"SNIPPET_SOURCE_UNSPECIFIED": 0,
+		"SNIPPET_SOURCE_ORIGINAL":    1,
+		"SNIPPET_SOURCE_FRESH":       2,
+		"SNIPPET_SOURCE_NONE":        3,
+	}
+)
+
+func (x SnippetSource) Enum() *SnippetSource {
+	p := new(SnippetSource)
+	*p = x
+	return p
+}
+
+func (x SnippetSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SnippetSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemas_greyseal_v1_services_conversation_proto_enumTypes[3].Descriptor()
+}
+
+func (SnippetSource) Type() protoreflect.EnumType {
+	return &file_schemas_greyseal_v1_services_conversation_proto_enumTypes[3]
+}
+
+func (x SnippetSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SnippetSource.Descriptor instead.
+func (SnippetSource) EnumDescriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{3}
+}
+
 type CreateConversationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// title is optional; left blank it can be auto-generated after the first exchange.
@@ -30,6 +267,10 @@ type CreateConversationRequest struct {
 	RoleUuid string `protobuf:"bytes,2,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
 	// resource_uuids optionally scopes retrieval to specific resources.
 	ResourceUuids []string `protobuf:"bytes,3,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	// role_version pins the role to one version; 0 follows the latest.
+	RoleVersion int32 `protobuf:"varint,4,opt,name=role_version,json=roleVersion,proto3" json:"role_version,omitempty"`
+	// variables are custom values the role's system prompt template can read.
+	Variables     map[string]string `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -85,6 +326,20 @@ func (x *CreateConversationRequest) GetResourceUuids() []string {
 	return nil
 }
 
+func (x *CreateConversationRequest) GetRoleVersion() int32 {
+	if x != nil {
+		return x.RoleVersion
+	}
+	return 0
+}
+
+func (x *CreateConversationRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
 type CreateConversationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          *v1.Conversation       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -218,9 +473,20 @@ func (x *GetConversationResponse) GetData() *v1.Conversation {
 }
 
 type ListConversationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         *int32                 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
-	Cursor        *string                `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200).
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// role_uuid restricts results to conversations using this role.
+	RoleUuid *string `protobuf:"bytes,3,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
+	// resource_uuid restricts results to conversations scoped to this resource.
+	ResourceUuid *string `protobuf:"bytes,4,opt,name=resource_uuid,json=resourceUuid,proto3,oneof" json:"resource_uuid,omitempty"`
+	// updated_after and updated_before bound updated_at.
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	// status selects active (default), archived or deleted conversations.
+	Status        v1.ConversationStatus `protobuf:"varint,7,opt,name=status,proto3,enum=schemas.greyseal.v1.ConversationStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -269,12 +535,49 @@ func (x *ListConversationsRequest) GetCursor() string {
 	return ""
 }
 
+func (x *ListConversationsRequest) GetRoleUuid() string {
+	if x != nil && x.RoleUuid != nil {
+		return *x.RoleUuid
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetResourceUuid() string {
+	if x != nil && x.ResourceUuid != nil {
+		return *x.ResourceUuid
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListConversationsRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListConversationsRequest) GetStatus() v1.ConversationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return v1.ConversationStatus(0)
+}
+
 type ListConversationsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Returns conversations without their messages for efficiency.
-	Data          []*v1.Conversation `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
-	Cursor        string             `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Count         int32              `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Returns conversations without their messages for efficiency, most
+	// recently updated first.
+	Data []*v1.Conversation `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -337,6 +640,15 @@ type UpdateConversationRequest struct {
 	Title         *string  `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	RoleUuid      *string  `protobuf:"bytes,3,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
 	ResourceUuids []string `protobuf:"bytes,4,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	// role_version pins the role to one version; 0 follows the latest.
+	RoleVersion int32             `protobuf:"varint,5,opt,name=role_version,json=roleVersion,proto3" json:"role_version,omitempty"`
+	Variables   map[string]string `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// update_mask names the fields to overwrite: title, role_uuid,
+	// role_version, resource_uuids and variables. Without it, title and
+	// role_uuid are overwritten when set, role_version along with role_uuid,
+	// and resource_uuids and variables when not empty. Fields that are not
+	// overwritten, and the running summary, are kept.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -399,6 +711,27 @@ func (x *UpdateConversationRequest) GetResourceUuids() []string {
 	return nil
 }
 
+func (x *UpdateConversationRequest) GetRoleVersion() int32 {
+	if x != nil {
+		return x.RoleVersion
+	}
+	return 0
+}
+
+func (x *UpdateConversationRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *UpdateConversationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateConversationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          *v1.Conversation       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -523,28 +856,27 @@ func (*DeleteConversationResponse) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{9}
 }
 
-type ChatRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	ConversationUuid string                 `protobuf:"bytes,1,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
-	Content          string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type RestoreConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatRequest) Reset() {
-	*x = ChatRequest{}
+func (x *RestoreConversationRequest) Reset() {
+	*x = RestoreConversationRequest{}
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatRequest) String() string {
+func (x *RestoreConversationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatRequest) ProtoMessage() {}
+func (*RestoreConversationRequest) ProtoMessage() {}
 
-func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreConversationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -556,51 +888,39 @@ func (x *ChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
-func (*ChatRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreConversationRequest.ProtoReflect.Descriptor instead.
+func (*RestoreConversationRequest) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ChatRequest) GetConversationUuid() string {
-	if x != nil {
-		return x.ConversationUuid
-	}
-	return ""
-}
-
-func (x *ChatRequest) GetContent() string {
+func (x *RestoreConversationRequest) GetUuid() string {
 	if x != nil {
-		return x.Content
+		return x.Uuid
 	}
 	return ""
 }
 
-// ChatResponse is streamed; each message carries one token or chunk of content.
-// The final message in the stream includes the fully-populated Message with
-// resource references and uuid set.
-type ChatResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	// final_message is populated only on the last streamed response.
-	FinalMessage  *v1.Message `protobuf:"bytes,2,opt,name=final_message,json=finalMessage,proto3" json:"final_message,omitempty"`
+type RestoreConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Conversation       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatResponse) Reset() {
-	*x = ChatResponse{}
+func (x *RestoreConversationResponse) Reset() {
+	*x = RestoreConversationResponse{}
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatResponse) String() string {
+func (x *RestoreConversationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse) ProtoMessage() {}
+func (*RestoreConversationResponse) ProtoMessage() {}
 
-func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+func (x *RestoreConversationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -612,48 +932,39 @@ func (x *ChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
-func (*ChatResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreConversationResponse.ProtoReflect.Descriptor instead.
+func (*RestoreConversationResponse) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ChatResponse) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
-}
-
-func (x *ChatResponse) GetFinalMessage() *v1.Message {
+func (x *RestoreConversationResponse) GetData() *v1.Conversation {
 	if x != nil {
-		return x.FinalMessage
+		return x.Data
 	}
 	return nil
 }
 
-type SubmitFeedbackRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	MessageUuid string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
-	// feedback: -1 negative, 0 neutral, 1 positive.
-	Feedback      int32 `protobuf:"varint,2,opt,name=feedback,proto3" json:"feedback,omitempty"`
+type ArchiveConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitFeedbackRequest) Reset() {
-	*x = SubmitFeedbackRequest{}
+func (x *ArchiveConversationRequest) Reset() {
+	*x = ArchiveConversationRequest{}
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitFeedbackRequest) String() string {
+func (x *ArchiveConversationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitFeedbackRequest) ProtoMessage() {}
+func (*ArchiveConversationRequest) ProtoMessage() {}
 
-func (x *SubmitFeedbackRequest) ProtoReflect() protoreflect.Message {
+func (x *ArchiveConversationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -665,45 +976,39 @@ func (x *SubmitFeedbackRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitFeedbackRequest.ProtoReflect.Descriptor instead.
-func (*SubmitFeedbackRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArchiveConversationRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveConversationRequest) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SubmitFeedbackRequest) GetMessageUuid() string {
+func (x *ArchiveConversationRequest) GetUuid() string {
 	if x != nil {
-		return x.MessageUuid
+		return x.Uuid
 	}
 	return ""
 }
 
-func (x *SubmitFeedbackRequest) GetFeedback() int32 {
-	if x != nil {
-		return x.Feedback
-	}
-	return 0
-}
-
-type SubmitFeedbackResponse struct {
+type ArchiveConversationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Conversation       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitFeedbackResponse) Reset() {
-	*x = SubmitFeedbackResponse{}
+func (x *ArchiveConversationResponse) Reset() {
+	*x = ArchiveConversationResponse{}
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitFeedbackResponse) String() string {
+func (x *ArchiveConversationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitFeedbackResponse) ProtoMessage() {}
+func (*ArchiveConversationResponse) ProtoMessage() {}
 
-func (x *SubmitFeedbackResponse) ProtoReflect() protoreflect.Message {
+func (x *ArchiveConversationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -715,66 +1020,2066 @@ func (x *SubmitFeedbackResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitFeedbackResponse.ProtoReflect.Descriptor instead.
-func (*SubmitFeedbackResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArchiveConversationResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveConversationResponse) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{13}
 }
 
-var File_schemas_greyseal_v1_services_conversation_proto protoreflect.FileDescriptor
+func (x *ArchiveConversationResponse) GetData() *v1.Conversation {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
 
-const file_schemas_greyseal_v1_services_conversation_proto_rawDesc = "" +
-	"\n" +
-	"/schemas/greyseal/v1/services/conversation.proto\x12\x1cschemas.greyseal.services.v1\x1a&schemas/greyseal/v1/conversation.proto\"u\n" +
-	"\x19CreateConversationRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1b\n" +
-	"\trole_uuid\x18\x02 \x01(\tR\broleUuid\x12%\n" +
-	"\x0eresource_uuids\x18\x03 \x03(\tR\rresourceUuids\"S\n" +
-	"\x1aCreateConversationResponse\x125\n" +
-	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\",\n" +
-	"\x16GetConversationRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"P\n" +
-	"\x17GetConversationResponse\x125\n" +
-	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"g\n" +
-	"\x18ListConversationsRequest\x12\x19\n" +
-	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
-	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
-	"\x06_countB\t\n" +
-	"\a_cursor\"\x80\x01\n" +
-	"\x19ListConversationsResponse\x125\n" +
-	"\x04data\x18\x01 \x03(\v2!.schemas.greyseal.v1.ConversationR\x04data\x12\x16\n" +
-	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\"\xab\x01\n" +
-	"\x19UpdateConversationRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12 \n" +
-	"\trole_uuid\x18\x03 \x01(\tH\x01R\broleUuid\x88\x01\x01\x12%\n" +
-	"\x0eresource_uuids\x18\x04 \x03(\tR\rresourceUuidsB\b\n" +
-	"\x06_titleB\f\n" +
-	"\n" +
-	"_role_uuid\"S\n" +
-	"\x1aUpdateConversationResponse\x125\n" +
+type UnarchiveConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnarchiveConversationRequest) Reset() {
+	*x = UnarchiveConversationRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnarchiveConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnarchiveConversationRequest) ProtoMessage() {}
+
+func (x *UnarchiveConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnarchiveConversationRequest.ProtoReflect.Descriptor instead.
+func (*UnarchiveConversationRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnarchiveConversationRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type UnarchiveConversationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Conversation       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnarchiveConversationResponse) Reset() {
+	*x = UnarchiveConversationResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnarchiveConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnarchiveConversationResponse) ProtoMessage() {}
+
+func (x *UnarchiveConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnarchiveConversationResponse.ProtoReflect.Descriptor instead.
+func (*UnarchiveConversationResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UnarchiveConversationResponse) GetData() *v1.Conversation {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SearchConversationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query accepts web search syntax: quoted phrases, OR, and -excluded terms.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// role_uuid restricts results to conversations using this role.
+	RoleUuid *string `protobuf:"bytes,2,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
+	// after and before bound the creation time of matching messages.
+	After  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	Before *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	// feedback restricts matches to messages with this feedback value (-1, 0, 1).
+	Feedback *int32 `protobuf:"varint,5,opt,name=feedback,proto3,oneof" json:"feedback,omitempty"`
+	// count is the maximum number of conversations returned (default 20, max 100).
+	Count         *int32 `protobuf:"varint,6,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConversationsRequest) Reset() {
+	*x = SearchConversationsRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConversationsRequest) ProtoMessage() {}
+
+func (x *SearchConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConversationsRequest.ProtoReflect.Descriptor instead.
+func (*SearchConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchConversationsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchConversationsRequest) GetRoleUuid() string {
+	if x != nil && x.RoleUuid != nil {
+		return *x.RoleUuid
+	}
+	return ""
+}
+
+func (x *SearchConversationsRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *SearchConversationsRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *SearchConversationsRequest) GetFeedback() int32 {
+	if x != nil && x.Feedback != nil {
+		return *x.Feedback
+	}
+	return 0
+}
+
+func (x *SearchConversationsRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+type SearchConversationsResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Data          []*v1.ConversationSearchResult `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConversationsResponse) Reset() {
+	*x = SearchConversationsResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConversationsResponse) ProtoMessage() {}
+
+func (x *SearchConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConversationsResponse.ProtoReflect.Descriptor instead.
+func (*SearchConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchConversationsResponse) GetData() []*v1.ConversationSearchResult {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportConversationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=schemas.greyseal.services.v1.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportConversationRequest) Reset() {
+	*x = ExportConversationRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationRequest) ProtoMessage() {}
+
+func (x *ExportConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationRequest.ProtoReflect.Descriptor instead.
+func (*ExportConversationRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportConversationRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ExportConversationRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportConversationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  *v1.ConversationExport `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// content is data rendered in the requested format.
+	Content       []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ContentType   string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportConversationResponse) Reset() {
+	*x = ExportConversationResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportConversationResponse) ProtoMessage() {}
+
+func (x *ExportConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportConversationResponse.ProtoReflect.Descriptor instead.
+func (*ExportConversationResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportConversationResponse) GetData() *v1.ConversationExport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportConversationResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ExportConversationResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type ImportConversationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  *v1.ConversationExport `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// summarize replaces the exported summary with one generated by the LLM
+	// from the imported messages.
+	Summarize     bool `protobuf:"varint,2,opt,name=summarize,proto3" json:"summarize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportConversationRequest) Reset() {
+	*x = ImportConversationRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportConversationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportConversationRequest) ProtoMessage() {}
+
+func (x *ImportConversationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportConversationRequest.ProtoReflect.Descriptor instead.
+func (*ImportConversationRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportConversationRequest) GetData() *v1.ConversationExport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportConversationRequest) GetSummarize() bool {
+	if x != nil {
+		return x.Summarize
+	}
+	return false
+}
+
+type ImportConversationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// data is the imported conversation with its final UUIDs.
+	Data *v1.Conversation `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// remapped maps exported UUIDs that collided to the UUIDs they were given.
+	Remapped      map[string]string `protobuf:"bytes,2,rep,name=remapped,proto3" json:"remapped,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportConversationResponse) Reset() {
+	*x = ImportConversationResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportConversationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportConversationResponse) ProtoMessage() {}
+
+func (x *ImportConversationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportConversationResponse.ProtoReflect.Descriptor instead.
+func (*ImportConversationResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportConversationResponse) GetData() *v1.Conversation {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportConversationResponse) GetRemapped() map[string]string {
+	if x != nil {
+		return x.Remapped
+	}
+	return nil
+}
+
+type ChatRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationUuid string                 `protobuf:"bytes,1,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	Content          string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChatRequest) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// ChatResponse is streamed; each message carries one token or chunk of content.
+// The final message in the stream includes the fully-populated Message with
+// resource references and uuid set.
+type ChatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// final_message is populated only on the last streamed response.
+	FinalMessage *v1.Message `protobuf:"bytes,2,opt,name=final_message,json=finalMessage,proto3" json:"final_message,omitempty"`
+	// blocked is set on the last response when a guardrail refused the turn.
+	// final_message then holds the stored refusal.
+	Blocked       *v1.GuardrailBlock `protobuf:"bytes,3,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ChatResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ChatResponse) GetFinalMessage() *v1.Message {
+	if x != nil {
+		return x.FinalMessage
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetBlocked() *v1.GuardrailBlock {
+	if x != nil {
+		return x.Blocked
+	}
+	return nil
+}
+
+type ChatSessionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Action ChatSessionAction      `protobuf:"varint,1,opt,name=action,proto3,enum=schemas.greyseal.services.v1.ChatSessionAction" json:"action,omitempty"`
+	// conversation_uuid is required on the first request and must not change.
+	ConversationUuid string `protobuf:"bytes,2,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	// content is the user message for SEND.
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// role_uuid, role_version and resource_scope are read by CONFIGURE.
+	// An empty role_uuid clears the role; unset fields are left unchanged.
+	RoleUuid      *string        `protobuf:"bytes,4,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
+	RoleVersion   int32          `protobuf:"varint,5,opt,name=role_version,json=roleVersion,proto3" json:"role_version,omitempty"`
+	ResourceScope *ResourceScope `protobuf:"bytes,6,opt,name=resource_scope,json=resourceScope,proto3" json:"resource_scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSessionRequest) Reset() {
+	*x = ChatSessionRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSessionRequest) ProtoMessage() {}
+
+func (x *ChatSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSessionRequest.ProtoReflect.Descriptor instead.
+func (*ChatSessionRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChatSessionRequest) GetAction() ChatSessionAction {
+	if x != nil {
+		return x.Action
+	}
+	return ChatSessionAction_CHAT_SESSION_ACTION_UNSPECIFIED
+}
+
+func (x *ChatSessionRequest) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *ChatSessionRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatSessionRequest) GetRoleUuid() string {
+	if x != nil && x.RoleUuid != nil {
+		return *x.RoleUuid
+	}
+	return ""
+}
+
+func (x *ChatSessionRequest) GetRoleVersion() int32 {
+	if x != nil {
+		return x.RoleVersion
+	}
+	return 0
+}
+
+func (x *ChatSessionRequest) GetResourceScope() *ResourceScope {
+	if x != nil {
+		return x.ResourceScope
+	}
+	return nil
+}
+
+// ResourceScope replaces a conversation's resource scope. Empty
+// resource_uuids searches every resource.
+type ResourceScope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResourceUuids []string               `protobuf:"bytes,1,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceScope) Reset() {
+	*x = ResourceScope{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceScope) ProtoMessage() {}
+
+func (x *ResourceScope) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceScope.ProtoReflect.Descriptor instead.
+func (*ResourceScope) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResourceScope) GetResourceUuids() []string {
+	if x != nil {
+		return x.ResourceUuids
+	}
+	return nil
+}
+
+type ChatSessionResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Event        ChatSessionEvent       `protobuf:"varint,1,opt,name=event,proto3,enum=schemas.greyseal.services.v1.ChatSessionEvent" json:"event,omitempty"`
+	Token        string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Message      *v1.Message            `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Blocked      *v1.GuardrailBlock     `protobuf:"bytes,4,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Conversation *v1.Conversation       `protobuf:"bytes,5,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	// error and code describe an ERROR; code is a Connect error code such as
+	// "failed_precondition".
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Code          string `protobuf:"bytes,7,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSessionResponse) Reset() {
+	*x = ChatSessionResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSessionResponse) ProtoMessage() {}
+
+func (x *ChatSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSessionResponse.ProtoReflect.Descriptor instead.
+func (*ChatSessionResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChatSessionResponse) GetEvent() ChatSessionEvent {
+	if x != nil {
+		return x.Event
+	}
+	return ChatSessionEvent_CHAT_SESSION_EVENT_UNSPECIFIED
+}
+
+func (x *ChatSessionResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ChatSessionResponse) GetMessage() *v1.Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ChatSessionResponse) GetBlocked() *v1.GuardrailBlock {
+	if x != nil {
+		return x.Blocked
+	}
+	return nil
+}
+
+func (x *ChatSessionResponse) GetConversation() *v1.Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ChatSessionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChatSessionResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type SubmitFeedbackRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageUuid string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	// feedback: -1 negative, 0 neutral, 1 positive.
+	Feedback int32 `protobuf:"varint,2,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	// reasons optionally categorises the rating.
+	Reasons []v1.FeedbackReason `protobuf:"varint,3,rep,packed,name=reasons,proto3,enum=schemas.greyseal.v1.FeedbackReason" json:"reasons,omitempty"`
+	// comment is optional free text (at most 2000 characters).
+	Comment       string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFeedbackRequest) Reset() {
+	*x = SubmitFeedbackRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFeedbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFeedbackRequest) ProtoMessage() {}
+
+func (x *SubmitFeedbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFeedbackRequest.ProtoReflect.Descriptor instead.
+func (*SubmitFeedbackRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SubmitFeedbackRequest) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *SubmitFeedbackRequest) GetFeedback() int32 {
+	if x != nil {
+		return x.Feedback
+	}
+	return 0
+}
+
+func (x *SubmitFeedbackRequest) GetReasons() []v1.FeedbackReason {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *SubmitFeedbackRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type SubmitFeedbackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Feedback           `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFeedbackResponse) Reset() {
+	*x = SubmitFeedbackResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFeedbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFeedbackResponse) ProtoMessage() {}
+
+func (x *SubmitFeedbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFeedbackResponse.ProtoReflect.Descriptor instead.
+func (*SubmitFeedbackResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SubmitFeedbackResponse) GetData() *v1.Feedback {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListFeedbackRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200).
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// message_uuid restricts results to one message.
+	MessageUuid *string `protobuf:"bytes,3,opt,name=message_uuid,json=messageUuid,proto3,oneof" json:"message_uuid,omitempty"`
+	// owner restricts results to one user; only honoured for admins.
+	Owner         *string `protobuf:"bytes,4,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedbackRequest) Reset() {
+	*x = ListFeedbackRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedbackRequest) ProtoMessage() {}
+
+func (x *ListFeedbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedbackRequest.ProtoReflect.Descriptor instead.
+func (*ListFeedbackRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListFeedbackRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ListFeedbackRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListFeedbackRequest) GetMessageUuid() string {
+	if x != nil && x.MessageUuid != nil {
+		return *x.MessageUuid
+	}
+	return ""
+}
+
+func (x *ListFeedbackRequest) GetOwner() string {
+	if x != nil && x.Owner != nil {
+		return *x.Owner
+	}
+	return ""
+}
+
+type ListFeedbackResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []*v1.Feedback         `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedbackResponse) Reset() {
+	*x = ListFeedbackResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedbackResponse) ProtoMessage() {}
+
+func (x *ListFeedbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedbackResponse.ProtoReflect.Descriptor instead.
+func (*ListFeedbackResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListFeedbackResponse) GetData() []*v1.Feedback {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListFeedbackResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListFeedbackResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetFeedbackReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// after and before bound when feedback was submitted. They default to the
+	// 30 days up to now.
+	After  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	Before *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// interval is the bucket size of by_period (default DAY).
+	Interval      v1.FeedbackInterval `protobuf:"varint,3,opt,name=interval,proto3,enum=schemas.greyseal.v1.FeedbackInterval" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedbackReportRequest) Reset() {
+	*x = GetFeedbackReportRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedbackReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedbackReportRequest) ProtoMessage() {}
+
+func (x *GetFeedbackReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedbackReportRequest.ProtoReflect.Descriptor instead.
+func (*GetFeedbackReportRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetFeedbackReportRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *GetFeedbackReportRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *GetFeedbackReportRequest) GetInterval() v1.FeedbackInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return v1.FeedbackInterval(0)
+}
+
+type GetFeedbackReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.FeedbackReport     `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedbackReportResponse) Reset() {
+	*x = GetFeedbackReportResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedbackReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedbackReportResponse) ProtoMessage() {}
+
+func (x *GetFeedbackReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedbackReportResponse.ProtoReflect.Descriptor instead.
+func (*GetFeedbackReportResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetFeedbackReportResponse) GetData() *v1.FeedbackReport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportDatasetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rating restricts records to this latest rating (-1, 0, 1).
+	Rating *int32 `protobuf:"varint,1,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	// role_uuid restricts records to conversations using this role.
+	RoleUuid *string `protobuf:"bytes,2,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
+	// after and before bound when the assistant message was created.
+	After  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	Before *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`
+	// scrub_pii replaces emails, phone numbers, card numbers and API keys in
+	// all text fields with placeholders such as [EMAIL].
+	ScrubPii bool `protobuf:"varint,5,opt,name=scrub_pii,json=scrubPii,proto3" json:"scrub_pii,omitempty"`
+	// count caps the number of records; unset exports everything.
+	Count         *int32 `protobuf:"varint,6,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDatasetRequest) Reset() {
+	*x = ExportDatasetRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDatasetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDatasetRequest) ProtoMessage() {}
+
+func (x *ExportDatasetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDatasetRequest.ProtoReflect.Descriptor instead.
+func (*ExportDatasetRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportDatasetRequest) GetRating() int32 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *ExportDatasetRequest) GetRoleUuid() string {
+	if x != nil && x.RoleUuid != nil {
+		return *x.RoleUuid
+	}
+	return ""
+}
+
+func (x *ExportDatasetRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *ExportDatasetRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *ExportDatasetRequest) GetScrubPii() bool {
+	if x != nil {
+		return x.ScrubPii
+	}
+	return false
+}
+
+func (x *ExportDatasetRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+// ExportDatasetResponse carries one record per streamed message.
+type ExportDatasetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *v1.DatasetRecord      `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDatasetResponse) Reset() {
+	*x = ExportDatasetResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDatasetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDatasetResponse) ProtoMessage() {}
+
+func (x *ExportDatasetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDatasetResponse.ProtoReflect.Descriptor instead.
+func (*ExportDatasetResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportDatasetResponse) GetRecord() *v1.DatasetRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type ReplayTurnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message_uuid is the assistant message whose turn is replayed.
+	MessageUuid string `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	// system_prompt replaces the recorded system prompt.
+	SystemPrompt *string `protobuf:"bytes,2,opt,name=system_prompt,json=systemPrompt,proto3,oneof" json:"system_prompt,omitempty"`
+	// role_uuid uses this role's system prompt; system_prompt takes precedence.
+	RoleUuid *string `protobuf:"bytes,3,opt,name=role_uuid,json=roleUuid,proto3,oneof" json:"role_uuid,omitempty"`
+	// model runs the replay on another model, if the LLM supports it.
+	Model    *string       `protobuf:"bytes,4,opt,name=model,proto3,oneof" json:"model,omitempty"`
+	Snippets SnippetSource `protobuf:"varint,5,opt,name=snippets,proto3,enum=schemas.greyseal.services.v1.SnippetSource" json:"snippets,omitempty"`
+	// retrieval_limit caps the number of snippets.
+	RetrievalLimit *int32 `protobuf:"varint,6,opt,name=retrieval_limit,json=retrievalLimit,proto3,oneof" json:"retrieval_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReplayTurnRequest) Reset() {
+	*x = ReplayTurnRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTurnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTurnRequest) ProtoMessage() {}
+
+func (x *ReplayTurnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTurnRequest.ProtoReflect.Descriptor instead.
+func (*ReplayTurnRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReplayTurnRequest) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *ReplayTurnRequest) GetSystemPrompt() string {
+	if x != nil && x.SystemPrompt != nil {
+		return *x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *ReplayTurnRequest) GetRoleUuid() string {
+	if x != nil && x.RoleUuid != nil {
+		return *x.RoleUuid
+	}
+	return ""
+}
+
+func (x *ReplayTurnRequest) GetModel() string {
+	if x != nil && x.Model != nil {
+		return *x.Model
+	}
+	return ""
+}
+
+func (x *ReplayTurnRequest) GetSnippets() SnippetSource {
+	if x != nil {
+		return x.Snippets
+	}
+	return SnippetSource_SNIPPET_SOURCE_UNSPECIFIED
+}
+
+func (x *ReplayTurnRequest) GetRetrievalLimit() int32 {
+	if x != nil && x.RetrievalLimit != nil {
+		return *x.RetrievalLimit
+	}
+	return 0
+}
+
+// TurnRun is one execution of a turn: its inputs and the response.
+type TurnRun struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SystemPrompt string                 `protobuf:"bytes,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Model        string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Snippets     []*v1.DatasetSnippet   `protobuf:"bytes,3,rep,name=snippets,proto3" json:"snippets,omitempty"`
+	Messages     []*v1.DatasetMessage   `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	Response     string                 `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`
+	// latency_ms is only set for the replay.
+	LatencyMs     int64 `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnRun) Reset() {
+	*x = TurnRun{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnRun) ProtoMessage() {}
+
+func (x *TurnRun) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnRun.ProtoReflect.Descriptor instead.
+func (*TurnRun) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TurnRun) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *TurnRun) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TurnRun) GetSnippets() []*v1.DatasetSnippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+func (x *TurnRun) GetMessages() []*v1.DatasetMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *TurnRun) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *TurnRun) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+type ReplayTurnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Original      *TurnRun               `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	Replay        *TurnRun               `protobuf:"bytes,2,opt,name=replay,proto3" json:"replay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayTurnResponse) Reset() {
+	*x = ReplayTurnResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTurnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTurnResponse) ProtoMessage() {}
+
+func (x *ReplayTurnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTurnResponse.ProtoReflect.Descriptor instead.
+func (*ReplayTurnResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReplayTurnResponse) GetOriginal() *TurnRun {
+	if x != nil {
+		return x.Original
+	}
+	return nil
+}
+
+func (x *ReplayTurnResponse) GetReplay() *TurnRun {
+	if x != nil {
+		return x.Replay
+	}
+	return nil
+}
+
+type GetMessageTraceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message_uuid is the assistant message to trace.
+	MessageUuid   string `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageTraceRequest) Reset() {
+	*x = GetMessageTraceRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageTraceRequest) ProtoMessage() {}
+
+func (x *GetMessageTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageTraceRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetMessageTraceRequest) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+type GetMessageTraceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trace         *v1.TurnTrace          `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageTraceResponse) Reset() {
+	*x = GetMessageTraceResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageTraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageTraceResponse) ProtoMessage() {}
+
+func (x *GetMessageTraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageTraceResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageTraceResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetMessageTraceResponse) GetTrace() *v1.TurnTrace {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+type ListTranscriptsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200). Pages may hold fewer
+	// transcripts than count when some belong to other users.
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor        *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTranscriptsRequest) Reset() {
+	*x = ListTranscriptsRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTranscriptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranscriptsRequest) ProtoMessage() {}
+
+func (x *ListTranscriptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranscriptsRequest.ProtoReflect.Descriptor instead.
+func (*ListTranscriptsRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTranscriptsRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ListTranscriptsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListTranscriptsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []*v1.TranscriptInfo   `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTranscriptsResponse) Reset() {
+	*x = ListTranscriptsResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTranscriptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranscriptsResponse) ProtoMessage() {}
+
+func (x *ListTranscriptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranscriptsResponse.ProtoReflect.Descriptor instead.
+func (*ListTranscriptsResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListTranscriptsResponse) GetData() []*v1.TranscriptInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListTranscriptsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTranscriptsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetTranscriptRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationUuid string                 `protobuf:"bytes,1,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTranscriptRequest) Reset() {
+	*x = GetTranscriptRequest{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranscriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranscriptRequest) ProtoMessage() {}
+
+func (x *GetTranscriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranscriptRequest.ProtoReflect.Descriptor instead.
+func (*GetTranscriptRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetTranscriptRequest) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+type GetTranscriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transcript    *v1.Transcript         `protobuf:"bytes,1,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTranscriptResponse) Reset() {
+	*x = GetTranscriptResponse{}
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranscriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranscriptResponse) ProtoMessage() {}
+
+func (x *GetTranscriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_conversation_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranscriptResponse.ProtoReflect.Descriptor instead.
+func (*GetTranscriptResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetTranscriptResponse) GetTranscript() *v1.Transcript {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_services_conversation_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_services_conversation_proto_rawDesc = "" +
+	"\n" +
+	"/schemas/greyseal/v1/services/conversation.proto\x12\x1cschemas.greyseal.services.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&schemas/greyseal/v1/conversation.proto\x1a!schemas/greyseal/v1/dataset.proto\x1a schemas/greyseal/v1/export.proto\x1a\"schemas/greyseal/v1/feedback.proto\x1a\x1fschemas/greyseal/v1/trace.proto\x1a$schemas/greyseal/v1/transcript.proto\"\xbc\x02\n" +
+	"\x19CreateConversationRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1b\n" +
+	"\trole_uuid\x18\x02 \x01(\tR\broleUuid\x12%\n" +
+	"\x0eresource_uuids\x18\x03 \x03(\tR\rresourceUuids\x12!\n" +
+	"\frole_version\x18\x04 \x01(\x05R\vroleVersion\x12d\n" +
+	"\tvariables\x18\x05 \x03(\v2F.schemas.greyseal.services.v1.CreateConversationRequest.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"S\n" +
+	"\x1aCreateConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\",\n" +
+	"\x16GetConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"P\n" +
+	"\x17GetConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"\x98\x03\n" +
+	"\x18ListConversationsRequest\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01\x12 \n" +
+	"\trole_uuid\x18\x03 \x01(\tH\x02R\broleUuid\x88\x01\x01\x12(\n" +
+	"\rresource_uuid\x18\x04 \x01(\tH\x03R\fresourceUuid\x88\x01\x01\x12?\n" +
+	"\rupdated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12?\n" +
+	"\x06status\x18\a \x01(\x0e2'.schemas.greyseal.v1.ConversationStatusR\x06statusB\b\n" +
+	"\x06_countB\t\n" +
+	"\a_cursorB\f\n" +
+	"\n" +
+	"_role_uuidB\x10\n" +
+	"\x0e_resource_uuid\"\x80\x01\n" +
+	"\x19ListConversationsResponse\x125\n" +
+	"\x04data\x18\x01 \x03(\v2!.schemas.greyseal.v1.ConversationR\x04data\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xaf\x03\n" +
+	"\x19UpdateConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12 \n" +
+	"\trole_uuid\x18\x03 \x01(\tH\x01R\broleUuid\x88\x01\x01\x12%\n" +
+	"\x0eresource_uuids\x18\x04 \x03(\tR\rresourceUuids\x12!\n" +
+	"\frole_version\x18\x05 \x01(\x05R\vroleVersion\x12d\n" +
+	"\tvariables\x18\x06 \x03(\v2F.schemas.greyseal.services.v1.UpdateConversationRequest.VariablesEntryR\tvariables\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_titleB\f\n" +
+	"\n" +
+	"_role_uuid\"S\n" +
+	"\x1aUpdateConversationResponse\x125\n" +
 	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"/\n" +
 	"\x19DeleteConversationRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x1c\n" +
-	"\x1aDeleteConversationResponse\"T\n" +
+	"\x1aDeleteConversationResponse\"0\n" +
+	"\x1aRestoreConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"T\n" +
+	"\x1bRestoreConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"0\n" +
+	"\x1aArchiveConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"T\n" +
+	"\x1bArchiveConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"2\n" +
+	"\x1cUnarchiveConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"V\n" +
+	"\x1dUnarchiveConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\"\x9b\x02\n" +
+	"\x1aSearchConversationsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12 \n" +
+	"\trole_uuid\x18\x02 \x01(\tH\x00R\broleUuid\x88\x01\x01\x120\n" +
+	"\x05after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\x12\x1f\n" +
+	"\bfeedback\x18\x05 \x01(\x05H\x01R\bfeedback\x88\x01\x01\x12\x19\n" +
+	"\x05count\x18\x06 \x01(\x05H\x02R\x05count\x88\x01\x01B\f\n" +
+	"\n" +
+	"_role_uuidB\v\n" +
+	"\t_feedbackB\b\n" +
+	"\x06_count\"`\n" +
+	"\x1bSearchConversationsResponse\x12A\n" +
+	"\x04data\x18\x01 \x03(\v2-.schemas.greyseal.v1.ConversationSearchResultR\x04data\"s\n" +
+	"\x19ExportConversationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12B\n" +
+	"\x06format\x18\x02 \x01(\x0e2*.schemas.greyseal.services.v1.ExportFormatR\x06format\"\x96\x01\n" +
+	"\x1aExportConversationResponse\x12;\n" +
+	"\x04data\x18\x01 \x01(\v2'.schemas.greyseal.v1.ConversationExportR\x04data\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"v\n" +
+	"\x19ImportConversationRequest\x12;\n" +
+	"\x04data\x18\x01 \x01(\v2'.schemas.greyseal.v1.ConversationExportR\x04data\x12\x1c\n" +
+	"\tsummarize\x18\x02 \x01(\bR\tsummarize\"\xf4\x01\n" +
+	"\x1aImportConversationResponse\x125\n" +
+	"\x04data\x18\x01 \x01(\v2!.schemas.greyseal.v1.ConversationR\x04data\x12b\n" +
+	"\bremapped\x18\x02 \x03(\v2F.schemas.greyseal.services.v1.ImportConversationResponse.RemappedEntryR\bremapped\x1a;\n" +
+	"\rRemappedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"T\n" +
 	"\vChatRequest\x12+\n" +
 	"\x11conversation_uuid\x18\x01 \x01(\tR\x10conversationUuid\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"g\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xa6\x01\n" +
 	"\fChatResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12A\n" +
-	"\rfinal_message\x18\x02 \x01(\v2\x1c.schemas.greyseal.v1.MessageR\ffinalMessage\"V\n" +
+	"\rfinal_message\x18\x02 \x01(\v2\x1c.schemas.greyseal.v1.MessageR\ffinalMessage\x12=\n" +
+	"\ablocked\x18\x03 \x01(\v2#.schemas.greyseal.v1.GuardrailBlockR\ablocked\"\xcb\x02\n" +
+	"\x12ChatSessionRequest\x12G\n" +
+	"\x06action\x18\x01 \x01(\x0e2/.schemas.greyseal.services.v1.ChatSessionActionR\x06action\x12+\n" +
+	"\x11conversation_uuid\x18\x02 \x01(\tR\x10conversationUuid\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12 \n" +
+	"\trole_uuid\x18\x04 \x01(\tH\x00R\broleUuid\x88\x01\x01\x12!\n" +
+	"\frole_version\x18\x05 \x01(\x05R\vroleVersion\x12R\n" +
+	"\x0eresource_scope\x18\x06 \x01(\v2+.schemas.greyseal.services.v1.ResourceScopeR\rresourceScopeB\f\n" +
+	"\n" +
+	"_role_uuid\"6\n" +
+	"\rResourceScope\x12%\n" +
+	"\x0eresource_uuids\x18\x01 \x03(\tR\rresourceUuids\"\xd9\x02\n" +
+	"\x13ChatSessionResponse\x12D\n" +
+	"\x05event\x18\x01 \x01(\x0e2..schemas.greyseal.services.v1.ChatSessionEventR\x05event\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x126\n" +
+	"\amessage\x18\x03 \x01(\v2\x1c.schemas.greyseal.v1.MessageR\amessage\x12=\n" +
+	"\ablocked\x18\x04 \x01(\v2#.schemas.greyseal.v1.GuardrailBlockR\ablocked\x12E\n" +
+	"\fconversation\x18\x05 \x01(\v2!.schemas.greyseal.v1.ConversationR\fconversation\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x12\n" +
+	"\x04code\x18\a \x01(\tR\x04code\"\xaf\x01\n" +
 	"\x15SubmitFeedbackRequest\x12!\n" +
 	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x12\x1a\n" +
-	"\bfeedback\x18\x02 \x01(\x05R\bfeedback\"\x18\n" +
-	"\x16SubmitFeedbackResponse2\xa7\a\n" +
+	"\bfeedback\x18\x02 \x01(\x05R\bfeedback\x12=\n" +
+	"\areasons\x18\x03 \x03(\x0e2#.schemas.greyseal.v1.FeedbackReasonR\areasons\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"K\n" +
+	"\x16SubmitFeedbackResponse\x121\n" +
+	"\x04data\x18\x01 \x01(\v2\x1d.schemas.greyseal.v1.FeedbackR\x04data\"\xc0\x01\n" +
+	"\x13ListFeedbackRequest\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01\x12&\n" +
+	"\fmessage_uuid\x18\x03 \x01(\tH\x02R\vmessageUuid\x88\x01\x01\x12\x19\n" +
+	"\x05owner\x18\x04 \x01(\tH\x03R\x05owner\x88\x01\x01B\b\n" +
+	"\x06_countB\t\n" +
+	"\a_cursorB\x0f\n" +
+	"\r_message_uuidB\b\n" +
+	"\x06_owner\"w\n" +
+	"\x14ListFeedbackResponse\x121\n" +
+	"\x04data\x18\x01 \x03(\v2\x1d.schemas.greyseal.v1.FeedbackR\x04data\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xc3\x01\n" +
+	"\x18GetFeedbackReportRequest\x120\n" +
+	"\x05after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\x12A\n" +
+	"\binterval\x18\x03 \x01(\x0e2%.schemas.greyseal.v1.FeedbackIntervalR\binterval\"T\n" +
+	"\x19GetFeedbackReportResponse\x127\n" +
+	"\x04data\x18\x01 \x01(\v2#.schemas.greyseal.v1.FeedbackReportR\x04data\"\x96\x02\n" +
+	"\x14ExportDatasetRequest\x12\x1b\n" +
+	"\x06rating\x18\x01 \x01(\x05H\x00R\x06rating\x88\x01\x01\x12 \n" +
+	"\trole_uuid\x18\x02 \x01(\tH\x01R\broleUuid\x88\x01\x01\x120\n" +
+	"\x05after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06before\x12\x1b\n" +
+	"\tscrub_pii\x18\x05 \x01(\bR\bscrubPii\x12\x19\n" +
+	"\x05count\x18\x06 \x01(\x05H\x02R\x05count\x88\x01\x01B\t\n" +
+	"\a_ratingB\f\n" +
+	"\n" +
+	"_role_uuidB\b\n" +
+	"\x06_count\"S\n" +
+	"\x15ExportDatasetResponse\x12:\n" +
+	"\x06record\x18\x01 \x01(\v2\".schemas.greyseal.v1.DatasetRecordR\x06record\"\xd2\x02\n" +
+	"\x11ReplayTurnRequest\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x12(\n" +
+	"\rsystem_prompt\x18\x02 \x01(\tH\x00R\fsystemPrompt\x88\x01\x01\x12 \n" +
+	"\trole_uuid\x18\x03 \x01(\tH\x01R\broleUuid\x88\x01\x01\x12\x19\n" +
+	"\x05model\x18\x04 \x01(\tH\x02R\x05model\x88\x01\x01\x12G\n" +
+	"\bsnippets\x18\x05 \x01(\x0e2+.schemas.greyseal.services.v1.SnippetSourceR\bsnippets\x12,\n" +
+	"\x0fretrieval_limit\x18\x06 \x01(\x05H\x03R\x0eretrievalLimit\x88\x01\x01B\x10\n" +
+	"\x0e_system_promptB\f\n" +
+	"\n" +
+	"_role_uuidB\b\n" +
+	"\x06_modelB\x12\n" +
+	"\x10_retrieval_limit\"\x81\x02\n" +
+	"\aTurnRun\x12#\n" +
+	"\rsystem_prompt\x18\x01 \x01(\tR\fsystemPrompt\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12?\n" +
+	"\bsnippets\x18\x03 \x03(\v2#.schemas.greyseal.v1.DatasetSnippetR\bsnippets\x12?\n" +
+	"\bmessages\x18\x04 \x03(\v2#.schemas.greyseal.v1.DatasetMessageR\bmessages\x12\x1a\n" +
+	"\bresponse\x18\x05 \x01(\tR\bresponse\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\"\x96\x01\n" +
+	"\x12ReplayTurnResponse\x12A\n" +
+	"\boriginal\x18\x01 \x01(\v2%.schemas.greyseal.services.v1.TurnRunR\boriginal\x12=\n" +
+	"\x06replay\x18\x02 \x01(\v2%.schemas.greyseal.services.v1.TurnRunR\x06replay\";\n" +
+	"\x16GetMessageTraceRequest\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\"O\n" +
+	"\x17GetMessageTraceResponse\x124\n" +
+	"\x05trace\x18\x01 \x01(\v2\x1e.schemas.greyseal.v1.TurnTraceR\x05trace\"e\n" +
+	"\x16ListTranscriptsRequest\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_countB\t\n" +
+	"\a_cursor\"\x80\x01\n" +
+	"\x17ListTranscriptsResponse\x127\n" +
+	"\x04data\x18\x01 \x03(\v2#.schemas.greyseal.v1.TranscriptInfoR\x04data\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"C\n" +
+	"\x14GetTranscriptRequest\x12+\n" +
+	"\x11conversation_uuid\x18\x01 \x01(\tR\x10conversationUuid\"X\n" +
+	"\x15GetTranscriptResponse\x12?\n" +
+	"\n" +
+	"transcript\x18\x01 \x01(\v2\x1f.schemas.greyseal.v1.TranscriptR\n" +
+	"transcript*z\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12EXPORT_FORMAT_JSON\x10\x01\x12\x1a\n" +
+	"\x16EXPORT_FORMAT_MARKDOWN\x10\x02\x12\x17\n" +
+	"\x13EXPORT_FORMAT_JSONL\x10\x03*\xbb\x01\n" +
+	"\x11ChatSessionAction\x12#\n" +
+	"\x1fCHAT_SESSION_ACTION_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CHAT_SESSION_ACTION_SEND\x10\x01\x12\x1c\n" +
+	"\x18CHAT_SESSION_ACTION_STOP\x10\x02\x12\"\n" +
+	"\x1eCHAT_SESSION_ACTION_REGENERATE\x10\x03\x12!\n" +
+	"\x1dCHAT_SESSION_ACTION_CONFIGURE\x10\x04*\xad\x01\n" +
+	"\x10ChatSessionEvent\x12\"\n" +
+	"\x1eCHAT_SESSION_EVENT_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CHAT_SESSION_EVENT_READY\x10\x01\x12\x1c\n" +
+	"\x18CHAT_SESSION_EVENT_TOKEN\x10\x02\x12\x1b\n" +
+	"\x17CHAT_SESSION_EVENT_DONE\x10\x03\x12\x1c\n" +
+	"\x18CHAT_SESSION_EVENT_ERROR\x10\x04*\x7f\n" +
+	"\rSnippetSource\x12\x1e\n" +
+	"\x1aSNIPPET_SOURCE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SNIPPET_SOURCE_ORIGINAL\x10\x01\x12\x18\n" +
+	"\x14SNIPPET_SOURCE_FRESH\x10\x02\x12\x17\n" +
+	"\x13SNIPPET_SOURCE_NONE\x10\x032\xf0\x15\n" +
 	"\x13ConversationService\x12\x89\x01\n" +
 	"\x12CreateConversation\x127.schemas.greyseal.services.v1.CreateConversationRequest\x1a8.schemas.greyseal.services.v1.CreateConversationResponse\"\x00\x12\x80\x01\n" +
 	"\x0fGetConversation\x124.schemas.greyseal.services.v1.GetConversationRequest\x1a5.schemas.greyseal.services.v1.GetConversationResponse\"\x00\x12\x86\x01\n" +
 	"\x11ListConversations\x126.schemas.greyseal.services.v1.ListConversationsRequest\x1a7.schemas.greyseal.services.v1.ListConversationsResponse\"\x00\x12\x89\x01\n" +
 	"\x12UpdateConversation\x127.schemas.greyseal.services.v1.UpdateConversationRequest\x1a8.schemas.greyseal.services.v1.UpdateConversationResponse\"\x00\x12\x89\x01\n" +
-	"\x12DeleteConversation\x127.schemas.greyseal.services.v1.DeleteConversationRequest\x1a8.schemas.greyseal.services.v1.DeleteConversationResponse\"\x00\x12a\n" +
-	"\x04Chat\x12).schemas.greyseal.services.v1.ChatRequest\x1a*.schemas.greyseal.services.v1.ChatResponse\"\x000\x01\x12}\n" +
-	"\x0eSubmitFeedback\x123.schemas.greyseal.services.v1.SubmitFeedbackRequest\x1a4.schemas.greyseal.services.v1.SubmitFeedbackResponse\"\x00B\x93\x02\n" +
+	"\x12DeleteConversation\x127.schemas.greyseal.services.v1.DeleteConversationRequest\x1a8.schemas.greyseal.services.v1.DeleteConversationResponse\"\x00\x12\x8c\x01\n" +
+	"\x13RestoreConversation\x128.schemas.greyseal.services.v1.RestoreConversationRequest\x1a9.schemas.greyseal.services.v1.RestoreConversationResponse\"\x00\x12\x8c\x01\n" +
+	"\x13ArchiveConversation\x128.schemas.greyseal.services.v1.ArchiveConversationRequest\x1a9.schemas.greyseal.services.v1.ArchiveConversationResponse\"\x00\x12\x92\x01\n" +
+	"\x15UnarchiveConversation\x12:.schemas.greyseal.services.v1.UnarchiveConversationRequest\x1a;.schemas.greyseal.services.v1.UnarchiveConversationResponse\"\x00\x12\x8c\x01\n" +
+	"\x13SearchConversations\x128.schemas.greyseal.services.v1.SearchConversationsRequest\x1a9.schemas.greyseal.services.v1.SearchConversationsResponse\"\x00\x12\x89\x01\n" +
+	"\x12ExportConversation\x127.schemas.greyseal.services.v1.ExportConversationRequest\x1a8.schemas.greyseal.services.v1.ExportConversationResponse\"\x00\x12\x89\x01\n" +
+	"\x12ImportConversation\x127.schemas.greyseal.services.v1.ImportConversationRequest\x1a8.schemas.greyseal.services.v1.ImportConversationResponse\"\x00\x12a\n" +
+	"\x04Chat\x12).schemas.greyseal.services.v1.ChatRequest\x1a*.schemas.greyseal.services.v1.ChatResponse\"\x000\x01\x12x\n" +
+	"\vChatSession\x120.schemas.greyseal.services.v1.ChatSessionRequest\x1a1.schemas.greyseal.services.v1.ChatSessionResponse\"\x00(\x010\x01\x12}\n" +
+	"\x0eSubmitFeedback\x123.schemas.greyseal.services.v1.SubmitFeedbackRequest\x1a4.schemas.greyseal.services.v1.SubmitFeedbackResponse\"\x00\x12w\n" +
+	"\fListFeedback\x121.schemas.greyseal.services.v1.ListFeedbackRequest\x1a2.schemas.greyseal.services.v1.ListFeedbackResponse\"\x00\x12\x86\x01\n" +
+	"\x11GetFeedbackReport\x126.schemas.greyseal.services.v1.GetFeedbackReportRequest\x1a7.schemas.greyseal.services.v1.GetFeedbackReportResponse\"\x00\x12|\n" +
+	"\rExportDataset\x122.schemas.greyseal.services.v1.ExportDatasetRequest\x1a3.schemas.greyseal.services.v1.ExportDatasetResponse\"\x000\x01\x12q\n" +
+	"\n" +
+	"ReplayTurn\x12/.schemas.greyseal.services.v1.ReplayTurnRequest\x1a0.schemas.greyseal.services.v1.ReplayTurnResponse\"\x00\x12\x80\x01\n" +
+	"\x0fGetMessageTrace\x124.schemas.greyseal.services.v1.GetMessageTraceRequest\x1a5.schemas.greyseal.services.v1.GetMessageTraceResponse\"\x00\x12\x80\x01\n" +
+	"\x0fListTranscripts\x124.schemas.greyseal.services.v1.ListTranscriptsRequest\x1a5.schemas.greyseal.services.v1.ListTranscriptsResponse\"\x00\x12z\n" +
+	"\rGetTranscript\x122.schemas.greyseal.services.v1.GetTranscriptRequest\x1a3.schemas.greyseal.services.v1.GetTranscriptResponse\"\x00B\x93\x02\n" +
 	" com.schemas.greyseal.services.v1B\x11ConversationProtoP\x01ZIgithub.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services;servicesv1\xa2\x02\x03SGS\xaa\x02\x1cSchemas.Greyseal.Services.V1\xca\x02\x1cSchemas\\Greyseal\\Services\\V1\xe2\x02(Schemas\\Greyseal\\Services\\V1\\GPBMetadata\xea\x02\x1fSchemas::Greyseal::Services::V1b\x06proto3"
 
 var (
@@ -789,50 +3094,174 @@ func file_schemas_greyseal_v1_services_conversation_proto_rawDescGZIP() []byte {
 	return file_schemas_greyseal_v1_services_conversation_proto_rawDescData
 }
 
-var file_schemas_greyseal_v1_services_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_schemas_greyseal_v1_services_conversation_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_schemas_greyseal_v1_services_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_schemas_greyseal_v1_services_conversation_proto_goTypes = []any{
-	(*CreateConversationRequest)(nil),  // 0: schemas.greyseal.services.v1.CreateConversationRequest
-	(*CreateConversationResponse)(nil), // 1: schemas.greyseal.services.v1.CreateConversationResponse
-	(*GetConversationRequest)(nil),     // 2: schemas.greyseal.services.v1.GetConversationRequest
-	(*GetConversationResponse)(nil),    // 3: schemas.greyseal.services.v1.GetConversationResponse
-	(*ListConversationsRequest)(nil),   // 4: schemas.greyseal.services.v1.ListConversationsRequest
-	(*ListConversationsResponse)(nil),  // 5: schemas.greyseal.services.v1.ListConversationsResponse
-	(*UpdateConversationRequest)(nil),  // 6: schemas.greyseal.services.v1.UpdateConversationRequest
-	(*UpdateConversationResponse)(nil), // 7: schemas.greyseal.services.v1.UpdateConversationResponse
-	(*DeleteConversationRequest)(nil),  // 8: schemas.greyseal.services.v1.DeleteConversationRequest
-	(*DeleteConversationResponse)(nil), // 9: schemas.greyseal.services.v1.DeleteConversationResponse
-	(*ChatRequest)(nil),                // 10: schemas.greyseal.services.v1.ChatRequest
-	(*ChatResponse)(nil),               // 11: schemas.greyseal.services.v1.ChatResponse
-	(*SubmitFeedbackRequest)(nil),      // 12: schemas.greyseal.services.v1.SubmitFeedbackRequest
-	(*SubmitFeedbackResponse)(nil),     // 13: schemas.greyseal.services.v1.SubmitFeedbackResponse
-	(*v1.Conversation)(nil),            // 14: schemas.greyseal.v1.Conversation
-	(*v1.Message)(nil),                 // 15: schemas.greyseal.v1.Message
+	(ExportFormat)(0),                     // 0: schemas.greyseal.services.v1.ExportFormat
+	(ChatSessionAction)(0),                // 1: schemas.greyseal.services.v1.ChatSessionAction
+	(ChatSessionEvent)(0),                 // 2: schemas.greyseal.services.v1.ChatSessionEvent
+	(SnippetSource)(0),                    // 3: schemas.greyseal.services.v1.SnippetSource
+	(*CreateConversationRequest)(nil),     // 4: schemas.greyseal.services.v1.CreateConversationRequest
+	(*CreateConversationResponse)(nil),    // 5: schemas.greyseal.services.v1.CreateConversationResponse
+	(*GetConversationRequest)(nil),        // 6: schemas.greyseal.services.v1.GetConversationRequest
+	(*GetConversationResponse)(nil),       // 7: schemas.greyseal.services.v1.GetConversationResponse
+	(*ListConversationsRequest)(nil),      // 8: schemas.greyseal.services.v1.ListConversationsRequest
+	(*ListConversationsResponse)(nil),     // 9: schemas.greyseal.services.v1.ListConversationsResponse
+	(*UpdateConversationRequest)(nil),     // 10: schemas.greyseal.services.v1.UpdateConversationRequest
+	(*UpdateConversationResponse)(nil),    // 11: schemas.greyseal.services.v1.UpdateConversationResponse
+	(*DeleteConversationRequest)(nil),     // 12: schemas.greyseal.services.v1.DeleteConversationRequest
+	(*DeleteConversationResponse)(nil),    // 13: schemas.greyseal.services.v1.DeleteConversationResponse
+	(*RestoreConversationRequest)(nil),    // 14: schemas.greyseal.services.v1.RestoreConversationRequest
+	(*RestoreConversationResponse)(nil),   // 15: schemas.greyseal.services.v1.RestoreConversationResponse
+	(*ArchiveConversationRequest)(nil),    // 16: schemas.greyseal.services.v1.ArchiveConversationRequest
+	(*ArchiveConversationResponse)(nil),   // 17: schemas.greyseal.services.v1.ArchiveConversationResponse
+	(*UnarchiveConversationRequest)(nil),  // 18: schemas.greyseal.services.v1.UnarchiveConversationRequest
+	(*UnarchiveConversationResponse)(nil), // 19: schemas.greyseal.services.v1.UnarchiveConversationResponse
+	(*SearchConversationsRequest)(nil),    // 20: schemas.greyseal.services.v1.SearchConversationsRequest
+	(*SearchConversationsResponse)(nil),   // 21: schemas.greyseal.services.v1.SearchConversationsResponse
+	(*ExportConversationRequest)(nil),     // 22: schemas.greyseal.services.v1.ExportConversationRequest
+	(*ExportConversationResponse)(nil),    // 23: schemas.greyseal.services.v1.ExportConversationResponse
+	(*ImportConversationRequest)(nil),     // 24: schemas.greyseal.services.v1.ImportConversationRequest
+	(*ImportConversationResponse)(nil),    // 25: schemas.greyseal.services.v1.ImportConversationResponse
+	(*ChatRequest)(nil),                   // 26: schemas.greyseal.services.v1.ChatRequest
+	(*ChatResponse)(nil),                  // 27: schemas.greyseal.services.v1.ChatResponse
+	(*ChatSessionRequest)(nil),            // 28: schemas.greyseal.services.v1.ChatSessionRequest
+	(*ResourceScope)(nil),                 // 29: schemas.greyseal.services.v1.ResourceScope
+	(*ChatSessionResponse)(nil),           // 30: schemas.greyseal.services.v1.ChatSessionResponse
+	(*SubmitFeedbackRequest)(nil),         // 31: schemas.greyseal.services.v1.SubmitFeedbackRequest
+	(*SubmitFeedbackResponse)(nil),        // 32: schemas.greyseal.services.v1.SubmitFeedbackResponse
+	(*ListFeedbackRequest)(nil),           // 33: schemas.greyseal.services.v1.ListFeedbackRequest
+	(*ListFeedbackResponse)(nil),          // 34: schemas.greyseal.services.v1.ListFeedbackResponse
+	(*GetFeedbackReportRequest)(nil),      // 35: schemas.greyseal.services.v1.GetFeedbackReportRequest
+	(*GetFeedbackReportResponse)(nil),     // 36: schemas.greyseal.services.v1.GetFeedbackReportResponse
+	(*ExportDatasetRequest)(nil),          // 37: schemas.greyseal.services.v1.ExportDatasetRequest
+	(*ExportDatasetResponse)(nil),         // 38: schemas.greyseal.services.v1.ExportDatasetResponse
+	(*ReplayTurnRequest)(nil),             // 39: schemas.greyseal.services.v1.ReplayTurnRequest
+	(*TurnRun)(nil),                       // 40: schemas.greyseal.services.v1.TurnRun
+	(*ReplayTurnResponse)(nil),            // 41: schemas.greyseal.services.v1.ReplayTurnResponse
+	(*GetMessageTraceRequest)(nil),        // 42: schemas.greyseal.services.v1.GetMessageTraceRequest
+	(*GetMessageTraceResponse)(nil),       // 43: schemas.greyseal.services.v1.GetMessageTraceResponse
+	(*ListTranscriptsRequest)(nil),        // 44: schemas.greyseal.services.v1.ListTranscriptsRequest
+	(*ListTranscriptsResponse)(nil),       // 45: schemas.greyseal.services.v1.ListTranscriptsResponse
+	(*GetTranscriptRequest)(nil),          // 46: schemas.greyseal.services.v1.GetTranscriptRequest
+	(*GetTranscriptResponse)(nil),         // 47: schemas.greyseal.services.v1.GetTranscriptResponse
+	nil,                                   // 48: schemas.greyseal.services.v1.CreateConversationRequest.VariablesEntry
+	nil,                                   // 49: schemas.greyseal.services.v1.UpdateConversationRequest.VariablesEntry
+	nil,                                   // 50: schemas.greyseal.services.v1.ImportConversationResponse.RemappedEntry
+	(*v1.Conversation)(nil),               // 51: schemas.greyseal.v1.Conversation
+	(*timestamppb.Timestamp)(nil),         // 52: google.protobuf.Timestamp
+	(v1.ConversationStatus)(0),            // 53: schemas.greyseal.v1.ConversationStatus
+	(*fieldmaskpb.FieldMask)(nil),         // 54: google.protobuf.FieldMask
+	(*v1.ConversationSearchResult)(nil),   // 55: schemas.greyseal.v1.ConversationSearchResult
+	(*v1.ConversationExport)(nil),         // 56: schemas.greyseal.v1.ConversationExport
+	(*v1.Message)(nil),                    // 57: schemas.greyseal.v1.Message
+	(*v1.GuardrailBlock)(nil),             // 58: schemas.greyseal.v1.GuardrailBlock
+	(v1.FeedbackReason)(0),                // 59: schemas.greyseal.v1.FeedbackReason
+	(*v1.Feedback)(nil),                   // 60: schemas.greyseal.v1.Feedback
+	(v1.FeedbackInterval)(0),              // 61: schemas.greyseal.v1.FeedbackInterval
+	(*v1.FeedbackReport)(nil),             // 62: schemas.greyseal.v1.FeedbackReport
+	(*v1.DatasetRecord)(nil),              // 63: schemas.greyseal.v1.DatasetRecord
+	(*v1.DatasetSnippet)(nil),             // 64: schemas.greyseal.v1.DatasetSnippet
+	(*v1.DatasetMessage)(nil),             // 65: schemas.greyseal.v1.DatasetMessage
+	(*v1.TurnTrace)(nil),                  // 66: schemas.greyseal.v1.TurnTrace
+	(*v1.TranscriptInfo)(nil),             // 67: schemas.greyseal.v1.TranscriptInfo
+	(*v1.Transcript)(nil),                 // 68: schemas.greyseal.v1.Transcript
 }
 var file_schemas_greyseal_v1_services_conversation_proto_depIdxs = []int32{
-	14, // 0: schemas.greyseal.services.v1.CreateConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
-	14, // 1: schemas.greyseal.services.v1.GetConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
-	14, // 2: schemas.greyseal.services.v1.ListConversationsResponse.data:type_name -> schemas.greyseal.v1.Conversation
-	14, // 3: schemas.greyseal.services.v1.UpdateConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
-	15, // 4: schemas.greyseal.services.v1.ChatResponse.final_message:type_name -> schemas.greyseal.v1.Message
-	0,  // 5: schemas.greyseal.services.v1.ConversationService.CreateConversation:input_type -> schemas.greyseal.services.v1.CreateConversationRequest
-	2,  // 6: schemas.greyseal.services.v1.ConversationService.GetConversation:input_type -> schemas.greyseal.services.v1.GetConversationRequest
-	4,  // 7: schemas.greyseal.services.v1.ConversationService.ListConversations:input_type -> schemas.greyseal.services.v1.ListConversationsRequest
-	6,  // 8: schemas.greyseal.services.v1.ConversationService.UpdateConversation:input_type -> schemas.greyseal.services.v1.UpdateConversationRequest
-	8,  // 9: schemas.greyseal.services.v1.ConversationService.DeleteConversation:input_type -> schemas.greyseal.services.v1.DeleteConversationRequest
-	10, // 10: schemas.greyseal.services.v1.ConversationService.Chat:input_type -> schemas.greyseal.services.v1.ChatRequest
-	12, // 11: schemas.greyseal.services.v1.ConversationService.SubmitFeedback:input_type -> schemas.greyseal.services.v1.SubmitFeedbackRequest
-	1,  // 12: schemas.greyseal.services.v1.ConversationService.CreateConversation:output_type -> schemas.greyseal.services.v1.CreateConversationResponse
-	3,  // 13: schemas.greyseal.services.v1.ConversationService.GetConversation:output_type -> schemas.greyseal.services.v1.GetConversationResponse
-	5,  // 14: schemas.greyseal.services.v1.ConversationService.ListConversations:output_type -> schemas.greyseal.services.v1.ListConversationsResponse
-	7,  // 15: schemas.greyseal.services.v1.ConversationService.UpdateConversation:output_type -> schemas.greyseal.services.v1.UpdateConversationResponse
-	9,  // 16: schemas.greyseal.services.v1.ConversationService.DeleteConversation:output_type -> schemas.greyseal.services.v1.DeleteConversationResponse
-	11, // 17: schemas.greyseal.services.v1.ConversationService.Chat:output_type -> schemas.greyseal.services.v1.ChatResponse
-	13, // 18: schemas.greyseal.services.v1.ConversationService.SubmitFeedback:output_type -> schemas.greyseal.services.v1.SubmitFeedbackResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	48, // 0: schemas.greyseal.services.v1.CreateConversationRequest.variables:type_name -> schemas.greyseal.services.v1.CreateConversationRequest.VariablesEntry
+	51, // 1: schemas.greyseal.services.v1.CreateConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	51, // 2: schemas.greyseal.services.v1.GetConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	52, // 3: schemas.greyseal.services.v1.ListConversationsRequest.updated_after:type_name -> google.protobuf.Timestamp
+	52, // 4: schemas.greyseal.services.v1.ListConversationsRequest.updated_before:type_name -> google.protobuf.Timestamp
+	53, // 5: schemas.greyseal.services.v1.ListConversationsRequest.status:type_name -> schemas.greyseal.v1.ConversationStatus
+	51, // 6: schemas.greyseal.services.v1.ListConversationsResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	49, // 7: schemas.greyseal.services.v1.UpdateConversationRequest.variables:type_name -> schemas.greyseal.services.v1.UpdateConversationRequest.VariablesEntry
+	54, // 8: schemas.greyseal.services.v1.UpdateConversationRequest.update_mask:type_name -> google.protobuf.FieldMask
+	51, // 9: schemas.greyseal.services.v1.UpdateConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	51, // 10: schemas.greyseal.services.v1.RestoreConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	51, // 11: schemas.greyseal.services.v1.ArchiveConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	51, // 12: schemas.greyseal.services.v1.UnarchiveConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	52, // 13: schemas.greyseal.services.v1.SearchConversationsRequest.after:type_name -> google.protobuf.Timestamp
+	52, // 14: schemas.greyseal.services.v1.SearchConversationsRequest.before:type_name -> google.protobuf.Timestamp
+	55, // 15: schemas.greyseal.services.v1.SearchConversationsResponse.data:type_name -> schemas.greyseal.v1.ConversationSearchResult
+	0,  // 16: schemas.greyseal.services.v1.ExportConversationRequest.format:type_name -> schemas.greyseal.services.v1.ExportFormat
+	56, // 17: schemas.greyseal.services.v1.ExportConversationResponse.data:type_name -> schemas.greyseal.v1.ConversationExport
+	56, // 18: schemas.greyseal.services.v1.ImportConversationRequest.data:type_name -> schemas.greyseal.v1.ConversationExport
+	51, // 19: schemas.greyseal.services.v1.ImportConversationResponse.data:type_name -> schemas.greyseal.v1.Conversation
+	50, // 20: schemas.greyseal.services.v1.ImportConversationResponse.remapped:type_name -> schemas.greyseal.services.v1.ImportConversationResponse.RemappedEntry
+	57, // 21: schemas.greyseal.services.v1.ChatResponse.final_message:type_name -> schemas.greyseal.v1.Message
+	58, // 22: schemas.greyseal.services.v1.ChatResponse.blocked:type_name -> schemas.greyseal.v1.GuardrailBlock
+	1,  // 23: schemas.greyseal.services.v1.ChatSessionRequest.action:type_name -> schemas.greyseal.services.v1.ChatSessionAction
+	29, // 24: schemas.greyseal.services.v1.ChatSessionRequest.resource_scope:type_name -> schemas.greyseal.services.v1.ResourceScope
+	2,  // 25: schemas.greyseal.services.v1.ChatSessionResponse.event:type_name -> schemas.greyseal.services.v1.ChatSessionEvent
+	57, // 26: schemas.greyseal.services.v1.ChatSessionResponse.message:type_name -> schemas.greyseal.v1.Message
+	58, // 27: schemas.greyseal.services.v1.ChatSessionResponse.blocked:type_name -> schemas.greyseal.v1.GuardrailBlock
+	51, // 28: schemas.greyseal.services.v1.ChatSessionResponse.conversation:type_name -> schemas.greyseal.v1.Conversation
+	59, // 29: schemas.greyseal.services.v1.SubmitFeedbackRequest.reasons:type_name -> schemas.greyseal.v1.FeedbackReason
+	60, // 30: schemas.greyseal.services.v1.SubmitFeedbackResponse.data:type_name -> schemas.greyseal.v1.Feedback
+	60, // 31: schemas.greyseal.services.v1.ListFeedbackResponse.data:type_name -> schemas.greyseal.v1.Feedback
+	52, // 32: schemas.greyseal.services.v1.GetFeedbackReportRequest.after:type_name -> google.protobuf.Timestamp
+	52, // 33: schemas.greyseal.services.v1.GetFeedbackReportRequest.before:type_name -> google.protobuf.Timestamp
+	61, // 34: schemas.greyseal.services.v1.GetFeedbackReportRequest.interval:type_name -> schemas.greyseal.v1.FeedbackInterval
+	62, // 35: schemas.greyseal.services.v1.GetFeedbackReportResponse.data:type_name -> schemas.greyseal.v1.FeedbackReport
+	52, // 36: schemas.greyseal.services.v1.ExportDatasetRequest.after:type_name -> google.protobuf.Timestamp
+	52, // 37: schemas.greyseal.services.v1.ExportDatasetRequest.before:type_name -> google.protobuf.Timestamp
+	63, // 38: schemas.greyseal.services.v1.ExportDatasetResponse.record:type_name -> schemas.greyseal.v1.DatasetRecord
+	3,  // 39: schemas.greyseal.services.v1.ReplayTurnRequest.snippets:type_name -> schemas.greyseal.services.v1.SnippetSource
+	64, // 40: schemas.greyseal.services.v1.TurnRun.snippets:type_name -> schemas.greyseal.v1.DatasetSnippet
+	65, // 41: schemas.greyseal.services.v1.TurnRun.messages:type_name -> schemas.greyseal.v1.DatasetMessage
+	40, // 42: schemas.greyseal.services.v1.ReplayTurnResponse.original:type_name -> schemas.greyseal.services.v1.TurnRun
+	40, // 43: schemas.greyseal.services.v1.ReplayTurnResponse.replay:type_name -> schemas.greyseal.services.v1.TurnRun
+	66, // 44: schemas.greyseal.services.v1.GetMessageTraceResponse.trace:type_name -> schemas.greyseal.v1.TurnTrace
+	67, // 45: schemas.greyseal.services.v1.ListTranscriptsResponse.data:type_name -> schemas.greyseal.v1.TranscriptInfo
+	68, // 46: schemas.greyseal.services.v1.GetTranscriptResponse.transcript:type_name -> schemas.greyseal.v1.Transcript
+	4,  // 47: schemas.greyseal.services.v1.ConversationService.CreateConversation:input_type -> schemas.greyseal.services.v1.CreateConversationRequest
+	6,  // 48: schemas.greyseal.services.v1.ConversationService.GetConversation:input_type -> schemas.greyseal.services.v1.GetConversationRequest
+	8,  // 49: schemas.greyseal.services.v1.ConversationService.ListConversations:input_type -> schemas.greyseal.services.v1.ListConversationsRequest
+	10, // 50: schemas.greyseal.services.v1.ConversationService.UpdateConversation:input_type -> schemas.greyseal.services.v1.UpdateConversationRequest
+	12, // 51: schemas.greyseal.services.v1.ConversationService.DeleteConversation:input_type -> schemas.greyseal.services.v1.DeleteConversationRequest
+	14, // 52: schemas.greyseal.services.v1.ConversationService.RestoreConversation:input_type -> schemas.greyseal.services.v1.RestoreConversationRequest
+	16, // 53: schemas.greyseal.services.v1.ConversationService.ArchiveConversation:input_type -> schemas.greyseal.services.v1.ArchiveConversationRequest
+	18, // 54: schemas.greyseal.services.v1.ConversationService.UnarchiveConversation:input_type -> schemas.greyseal.services.v1.UnarchiveConversationRequest
+	20, // 55: schemas.greyseal.services.v1.ConversationService.SearchConversations:input_type -> schemas.greyseal.services.v1.SearchConversationsRequest
+	22, // 56: schemas.greyseal.services.v1.ConversationService.ExportConversation:input_type -> schemas.greyseal.services.v1.ExportConversationRequest
+	24, // 57: schemas.greyseal.services.v1.ConversationService.ImportConversation:input_type -> schemas.greyseal.services.v1.ImportConversationRequest
+	26, // 58: schemas.greyseal.services.v1.ConversationService.Chat:input_type -> schemas.greyseal.services.v1.ChatRequest
+	28, // 59: schemas.greyseal.services.v1.ConversationService.ChatSession:input_type -> schemas.greyseal.services.v1.ChatSessionRequest
+	31, // 60: schemas.greyseal.services.v1.ConversationService.SubmitFeedback:input_type -> schemas.greyseal.services.v1.SubmitFeedbackRequest
+	33, // 61: schemas.greyseal.services.v1.ConversationService.ListFeedback:input_type -> schemas.greyseal.services.v1.ListFeedbackRequest
+	35, // 62: schemas.greyseal.services.v1.ConversationService.GetFeedbackReport:input_type -> schemas.greyseal.services.v1.GetFeedbackReportRequest
+	37, // 63: schemas.greyseal.services.v1.ConversationService.ExportDataset:input_type -> schemas.greyseal.services.v1.ExportDatasetRequest
+	39, // 64: schemas.greyseal.services.v1.ConversationService.ReplayTurn:input_type -> schemas.greyseal.services.v1.ReplayTurnRequest
+	42, // 65: schemas.greyseal.services.v1.ConversationService.GetMessageTrace:input_type -> schemas.greyseal.services.v1.GetMessageTraceRequest
+	44, // 66: schemas.greyseal.services.v1.ConversationService.ListTranscripts:input_type -> schemas.greyseal.services.v1.ListTranscriptsRequest
+	46, // 67: schemas.greyseal.services.v1.ConversationService.GetTranscript:input_type -> schemas.greyseal.services.v1.GetTranscriptRequest
+	5,  // 68: schemas.greyseal.services.v1.ConversationService.CreateConversation:output_type -> schemas.greyseal.services.v1.CreateConversationResponse
+	7,  // 69: schemas.greyseal.services.v1.ConversationService.GetConversation:output_type -> schemas.greyseal.services.v1.GetConversationResponse
+	9,  // 70: schemas.greyseal.services.v1.ConversationService.ListConversations:output_type -> schemas.greyseal.services.v1.ListConversationsResponse
+	11, // 71: schemas.greyseal.services.v1.ConversationService.UpdateConversation:output_type -> schemas.greyseal.services.v1.UpdateConversationResponse
+	13, // 72: schemas.greyseal.services.v1.ConversationService.DeleteConversation:output_type -> schemas.greyseal.services.v1.DeleteConversationResponse
+	15, // 73: schemas.greyseal.services.v1.ConversationService.RestoreConversation:output_type -> schemas.greyseal.services.v1.RestoreConversationResponse
+	17, // 74: schemas.greyseal.services.v1.ConversationService.ArchiveConversation:output_type -> schemas.greyseal.services.v1.ArchiveConversationResponse
+	19, // 75: schemas.greyseal.services.v1.ConversationService.UnarchiveConversation:output_type -> schemas.greyseal.services.v1.UnarchiveConversationResponse
+	21, // 76: schemas.greyseal.services.v1.ConversationService.SearchConversations:output_type -> schemas.greyseal.services.v1.SearchConversationsResponse
+	23, // 77: schemas.greyseal.services.v1.ConversationService.ExportConversation:output_type -> schemas.greyseal.services.v1.ExportConversationResponse
+	25, // 78: schemas.greyseal.services.v1.ConversationService.ImportConversation:output_type -> schemas.greyseal.services.v1.ImportConversationResponse
+	27, // 79: schemas.greyseal.services.v1.ConversationService.Chat:output_type -> schemas.greyseal.services.v1.ChatResponse
+	30, // 80: schemas.greyseal.services.v1.ConversationService.ChatSession:output_type -> schemas.greyseal.services.v1.ChatSessionResponse
+	32, // 81: schemas.greyseal.services.v1.ConversationService.SubmitFeedback:output_type -> schemas.greyseal.services.v1.SubmitFeedbackResponse
+	34, // 82: schemas.greyseal.services.v1.ConversationService.ListFeedback:output_type -> schemas.greyseal.services.v1.ListFeedbackResponse
+	36, // 83: schemas.greyseal.services.v1.ConversationService.GetFeedbackReport:output_type -> schemas.greyseal.services.v1.GetFeedbackReportResponse
+	38, // 84: schemas.greyseal.services.v1.ConversationService.ExportDataset:output_type -> schemas.greyseal.services.v1.ExportDatasetResponse
+	41, // 85: schemas.greyseal.services.v1.ConversationService.ReplayTurn:output_type -> schemas.greyseal.services.v1.ReplayTurnResponse
+	43, // 86: schemas.greyseal.services.v1.ConversationService.GetMessageTrace:output_type -> schemas.greyseal.services.v1.GetMessageTraceResponse
+	45, // 87: schemas.greyseal.services.v1.ConversationService.ListTranscripts:output_type -> schemas.greyseal.services.v1.ListTranscriptsResponse
+	47, // 88: schemas.greyseal.services.v1.ConversationService.GetTranscript:output_type -> schemas.greyseal.services.v1.GetTranscriptResponse
+	68, // [68:89] is the sub-list for method output_type
+	47, // [47:68] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_schemas_greyseal_v1_services_conversation_proto_init() }
@@ -842,18 +3271,25 @@ func file_schemas_greyseal_v1_services_conversation_proto_init() {
 	}
 	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[4].OneofWrappers = []any{}
 	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[6].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[16].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[24].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[29].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[33].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[35].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_conversation_proto_msgTypes[40].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_conversation_proto_rawDesc), len(file_schemas_greyseal_v1_services_conversation_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      4,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_schemas_greyseal_v1_services_conversation_proto_goTypes,
 		DependencyIndexes: file_schemas_greyseal_v1_services_conversation_proto_depIdxs,
+		EnumInfos:         file_schemas_greyseal_v1_services_conversation_proto_enumTypes,
 		MessageInfos:      file_schemas_greyseal_v1_services_conversation_proto_msgTypes,
 	}.Build()
 	File_schemas_greyseal_v1_services_conversation_proto = out.File
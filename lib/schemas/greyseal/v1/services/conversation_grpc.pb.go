@@ -19,13 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ConversationService_CreateConversation_FullMethodName = "/schemas.greyseal.services.v1.ConversationService/CreateConversation"
-	ConversationService_GetConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/GetConversation"
-	ConversationService_ListConversations_FullMethodName  = "/schemas.greyseal.services.v1.ConversationService/ListConversations"
-	ConversationService_UpdateConversation_FullMethodName = "/schemas.greyseal.services.v1.ConversationService/UpdateConversation"
-	ConversationService_DeleteConversation_FullMethodName = "/schemas.greyseal.services.v1.ConversationService/DeleteConversation"
-	ConversationService_Chat_FullMethodName               = "/schemas.greyseal.services.v1.ConversationService/Chat"
-	ConversationService_SubmitFeedback_FullMethodName     = "/schemas.greyseal.services.v1.ConversationService/SubmitFeedback"
+	ConversationService_CreateConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/CreateConversation"
+	ConversationService_GetConversation_FullMethodName       = "/schemas.greyseal.services.v1.ConversationService/GetConversation"
+	ConversationService_ListConversations_FullMethodName     = "/schemas.greyseal.services.v1.ConversationService/ListConversations"
+	ConversationService_UpdateConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/UpdateConversation"
+	ConversationService_DeleteConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/DeleteConversation"
+	ConversationService_RestoreConversation_FullMethodName   = "/schemas.greyseal.services.v1.ConversationService/RestoreConversation"
+	ConversationService_ArchiveConversation_FullMethodName   = "/schemas.greyseal.services.v1.ConversationService/ArchiveConversation"
+	ConversationService_UnarchiveConversation_FullMethodName = "/schemas.greyseal.services.v1.ConversationService/UnarchiveConversation"
+	ConversationService_SearchConversations_FullMethodName   = "/schemas.greyseal.services.v1.ConversationService/SearchConversations"
+	ConversationService_ExportConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/ExportConversation"
+	ConversationService_ImportConversation_FullMethodName    = "/schemas.greyseal.services.v1.ConversationService/ImportConversation"
+	ConversationService_Chat_FullMethodName                  = "/schemas.greyseal.services.v1.ConversationService/Chat"
+	ConversationService_ChatSession_FullMethodName           = "/schemas.greyseal.services.v1.ConversationService/ChatSession"
+	ConversationService_SubmitFeedback_FullMethodName        = "/schemas.greyseal.services.v1.ConversationService/SubmitFeedback"
+	ConversationService_ListFeedback_FullMethodName          = "/schemas.greyseal.services.v1.ConversationService/ListFeedback"
+	ConversationService_GetFeedbackReport_FullMethodName     = "/schemas.greyseal.services.v1.ConversationService/GetFeedbackReport"
+	ConversationService_ExportDataset_FullMethodName         = "/schemas.greyseal.services.v1.ConversationService/ExportDataset"
+	ConversationService_ReplayTurn_FullMethodName            = "/schemas.greyseal.services.v1.ConversationService/ReplayTurn"
+	ConversationService_GetMessageTrace_FullMethodName       = "/schemas.greyseal.services.v1.ConversationService/GetMessageTrace"
+	ConversationService_ListTranscripts_FullMethodName       = "/schemas.greyseal.services.v1.ConversationService/ListTranscripts"
+	ConversationService_GetTranscript_FullMethodName         = "/schemas.greyseal.services.v1.ConversationService/GetTranscript"
 )
 
 // ConversationServiceClient is the client API for ConversationService service.
@@ -36,11 +50,53 @@ type ConversationServiceClient interface {
 	GetConversation(ctx context.Context, in *GetConversationRequest, opts ...grpc.CallOption) (*GetConversationResponse, error)
 	ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error)
 	UpdateConversation(ctx context.Context, in *UpdateConversationRequest, opts ...grpc.CallOption) (*UpdateConversationResponse, error)
+	// DeleteConversation moves a conversation to the trash. It can be restored
+	// with RestoreConversation until the restore window passes.
 	DeleteConversation(ctx context.Context, in *DeleteConversationRequest, opts ...grpc.CallOption) (*DeleteConversationResponse, error)
+	// RestoreConversation undoes DeleteConversation within the restore window.
+	RestoreConversation(ctx context.Context, in *RestoreConversationRequest, opts ...grpc.CallOption) (*RestoreConversationResponse, error)
+	// ArchiveConversation hides a conversation from the default list and
+	// exempts it from retention. UnarchiveConversation reverses it.
+	ArchiveConversation(ctx context.Context, in *ArchiveConversationRequest, opts ...grpc.CallOption) (*ArchiveConversationResponse, error)
+	UnarchiveConversation(ctx context.Context, in *UnarchiveConversationRequest, opts ...grpc.CallOption) (*UnarchiveConversationResponse, error)
+	// SearchConversations finds conversations by full-text search over their
+	// messages, ranked by relevance.
+	SearchConversations(ctx context.Context, in *SearchConversationsRequest, opts ...grpc.CallOption) (*SearchConversationsResponse, error)
+	// ExportConversation returns a portable copy of a conversation, rendered in
+	// the requested format.
+	ExportConversation(ctx context.Context, in *ExportConversationRequest, opts ...grpc.CallOption) (*ExportConversationResponse, error)
+	// ImportConversation recreates an exported conversation, preserving
+	// timestamps and feedback. UUIDs already in use are replaced.
+	ImportConversation(ctx context.Context, in *ImportConversationRequest, opts ...grpc.CallOption) (*ImportConversationResponse, error)
 	// Chat sends a user message and streams back the assistant response token by token.
 	Chat(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
+	// ChatSession keeps one conversation open over a long-lived stream. The
+	// client sends messages, stops or regenerates answers and switches role or
+	// scope between turns; the server streams tokens and turn results back.
+	ChatSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChatSessionRequest, ChatSessionResponse], error)
 	// SubmitFeedback records user feedback on an assistant message.
 	SubmitFeedback(ctx context.Context, in *SubmitFeedbackRequest, opts ...grpc.CallOption) (*SubmitFeedbackResponse, error)
+	// ListFeedback returns feedback records, newest first. Callers see their
+	// own feedback; admins see everyone's.
+	ListFeedback(ctx context.Context, in *ListFeedbackRequest, opts ...grpc.CallOption) (*ListFeedbackResponse, error)
+	// GetFeedbackReport aggregates ratings by role, model, cited resource and
+	// time period.
+	GetFeedbackReport(ctx context.Context, in *GetFeedbackReportRequest, opts ...grpc.CallOption) (*GetFeedbackReportResponse, error)
+	// ExportDataset streams rated assistant turns with their prompt context,
+	// newest first.
+	ExportDataset(ctx context.Context, in *ExportDatasetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDatasetResponse], error)
+	// ReplayTurn re-runs a recorded turn with a different prompt, model or
+	// retrieval setting and returns both responses. Nothing is saved.
+	ReplayTurn(ctx context.Context, in *ReplayTurnRequest, opts ...grpc.CallOption) (*ReplayTurnResponse, error)
+	// GetMessageTrace returns what went into the prompt that produced an
+	// assistant message.
+	GetMessageTrace(ctx context.Context, in *GetMessageTraceRequest, opts ...grpc.CallOption) (*GetMessageTraceResponse, error)
+	// ListTranscripts lists the caller's conversations that have recorded
+	// transcripts.
+	ListTranscripts(ctx context.Context, in *ListTranscriptsRequest, opts ...grpc.CallOption) (*ListTranscriptsResponse, error)
+	// GetTranscript returns a conversation's recorded turns and their combined
+	// Markdown rendering.
+	GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error)
 }
 
 type conversationServiceClient struct {
@@ -101,6 +157,66 @@ func (c *conversationServiceClient) DeleteConversation(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *conversationServiceClient) RestoreConversation(ctx context.Context, in *RestoreConversationRequest, opts ...grpc.CallOption) (*RestoreConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationService_RestoreConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) ArchiveConversation(ctx context.Context, in *ArchiveConversationRequest, opts ...grpc.CallOption) (*ArchiveConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ArchiveConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) UnarchiveConversation(ctx context.Context, in *UnarchiveConversationRequest, opts ...grpc.CallOption) (*UnarchiveConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnarchiveConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationService_UnarchiveConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) SearchConversations(ctx context.Context, in *SearchConversationsRequest, opts ...grpc.CallOption) (*SearchConversationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchConversationsResponse)
+	err := c.cc.Invoke(ctx, ConversationService_SearchConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) ExportConversation(ctx context.Context, in *ExportConversationRequest, opts ...grpc.CallOption) (*ExportConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ExportConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) ImportConversation(ctx context.Context, in *ImportConversationRequest, opts ...grpc.CallOption) (*ImportConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportConversationResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ImportConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *conversationServiceClient) Chat(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &ConversationService_ServiceDesc.Streams[0], ConversationService_Chat_FullMethodName, cOpts...)
@@ -120,6 +236,19 @@ func (c *conversationServiceClient) Chat(ctx context.Context, in *ChatRequest, o
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ConversationService_ChatClient = grpc.ServerStreamingClient[ChatResponse]
 
+func (c *conversationServiceClient) ChatSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChatSessionRequest, ChatSessionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConversationService_ServiceDesc.Streams[1], ConversationService_ChatSession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChatSessionRequest, ChatSessionResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversationService_ChatSessionClient = grpc.BidiStreamingClient[ChatSessionRequest, ChatSessionResponse]
+
 func (c *conversationServiceClient) SubmitFeedback(ctx context.Context, in *SubmitFeedbackRequest, opts ...grpc.CallOption) (*SubmitFeedbackResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubmitFeedbackResponse)
@@ -130,6 +259,85 @@ func (c *conversationServiceClient) SubmitFeedback(ctx context.Context, in *Subm
 	return out, nil
 }
 
+func (c *conversationServiceClient) ListFeedback(ctx context.Context, in *ListFeedbackRequest, opts ...grpc.CallOption) (*ListFeedbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeedbackResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ListFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) GetFeedbackReport(ctx context.Context, in *GetFeedbackReportRequest, opts ...grpc.CallOption) (*GetFeedbackReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeedbackReportResponse)
+	err := c.cc.Invoke(ctx, ConversationService_GetFeedbackReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) ExportDataset(ctx context.Context, in *ExportDatasetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDatasetResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConversationService_ServiceDesc.Streams[2], ConversationService_ExportDataset_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportDatasetRequest, ExportDatasetResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversationService_ExportDatasetClient = grpc.ServerStreamingClient[ExportDatasetResponse]
+
+func (c *conversationServiceClient) ReplayTurn(ctx context.Context, in *ReplayTurnRequest, opts ...grpc.CallOption) (*ReplayTurnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayTurnResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ReplayTurn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) GetMessageTrace(ctx context.Context, in *GetMessageTraceRequest, opts ...grpc.CallOption) (*GetMessageTraceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMessageTraceResponse)
+	err := c.cc.Invoke(ctx, ConversationService_GetMessageTrace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) ListTranscripts(ctx context.Context, in *ListTranscriptsRequest, opts ...grpc.CallOption) (*ListTranscriptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTranscriptsResponse)
+	err := c.cc.Invoke(ctx, ConversationService_ListTranscripts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTranscriptResponse)
+	err := c.cc.Invoke(ctx, ConversationService_GetTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConversationServiceServer is the server API for ConversationService service.
 // All implementations must embed UnimplementedConversationServiceServer
 // for forward compatibility.
@@ -138,11 +346,53 @@ type ConversationServiceServer interface {
 	GetConversation(context.Context, *GetConversationRequest) (*GetConversationResponse, error)
 	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
 	UpdateConversation(context.Context, *UpdateConversationRequest) (*UpdateConversationResponse, error)
+	// DeleteConversation moves a conversation to the trash. It can be restored
+	// with RestoreConversation until the restore window passes.
 	DeleteConversation(context.Context, *DeleteConversationRequest) (*DeleteConversationResponse, error)
+	// RestoreConversation undoes DeleteConversation within the restore window.
+	RestoreConversation(context.Context, *RestoreConversationRequest) (*RestoreConversationResponse, error)
+	// ArchiveConversation hides a conversation from the default list and
+	// exempts it from retention. UnarchiveConversation reverses it.
+	ArchiveConversation(context.Context, *ArchiveConversationRequest) (*ArchiveConversationResponse, error)
+	UnarchiveConversation(context.Context, *UnarchiveConversationRequest) (*UnarchiveConversationResponse, error)
+	// SearchConversations finds conversations by full-text search over their
+	// messages, ranked by relevance.
+	SearchConversations(context.Context, *SearchConversationsRequest) (*SearchConversationsResponse, error)
+	// ExportConversation returns a portable copy of a conversation, rendered in
+	// the requested format.
+	ExportConversation(context.Context, *ExportConversationRequest) (*ExportConversationResponse, error)
+	// ImportConversation recreates an exported conversation, preserving
+	// timestamps and feedback. UUIDs already in use are replaced.
+	ImportConversation(context.Context, *ImportConversationRequest) (*ImportConversationResponse, error)
 	// Chat sends a user message and streams back the assistant response token by token.
 	Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error
+	// ChatSession keeps one conversation open over a long-lived stream. The
+	// client sends messages, stops or regenerates answers and switches role or
+	// scope between turns; the server streams tokens and turn results back.
+	ChatSession(grpc.BidiStreamingServer[ChatSessionRequest, ChatSessionResponse]) error
 	// SubmitFeedback records user feedback on an assistant message.
 	SubmitFeedback(context.Context, *SubmitFeedbackRequest) (*SubmitFeedbackResponse, error)
+	// ListFeedback returns feedback records, newest first. Callers see their
+	// own feedback; admins see everyone's.
+	ListFeedback(context.Context, *ListFeedbackRequest) (*ListFeedbackResponse, error)
+	// GetFeedbackReport aggregates ratings by role, model, cited resource and
+	// time period.
+	GetFeedbackReport(context.Context, *GetFeedbackReportRequest) (*GetFeedbackReportResponse, error)
+	// ExportDataset streams rated assistant turns with their prompt context,
+	// newest first.
+	ExportDataset(*ExportDatasetRequest, grpc.ServerStreamingServer[ExportDatasetResponse]) error
+	// ReplayTurn re-runs a recorded turn with a different prompt, model or
+	// retrieval setting and returns both responses. Nothing is saved.
+	ReplayTurn(context.Context, *ReplayTurnRequest) (*ReplayTurnResponse, error)
+	// GetMessageTrace returns what went into the prompt that produced an
+	// assistant message.
+	GetMessageTrace(context.Context, *GetMessageTraceRequest) (*GetMessageTraceResponse, error)
+	// ListTranscripts lists the caller's conversations that have recorded
+	// transcripts.
+	ListTranscripts(context.Context, *ListTranscriptsRequest) (*ListTranscriptsResponse, error)
+	// GetTranscript returns a conversation's recorded turns and their combined
+	// Markdown rendering.
+	GetTranscript(context.Context, *GetTranscriptRequest) (*GetTranscriptResponse, error)
 	mustEmbedUnimplementedConversationServiceServer()
 }
 
@@ -168,12 +418,54 @@ func (UnimplementedConversationServiceServer) UpdateConversation(context.Context
 func (UnimplementedConversationServiceServer) DeleteConversation(context.Context, *DeleteConversationRequest) (*DeleteConversationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteConversation not implemented")
 }
+func (UnimplementedConversationServiceServer) RestoreConversation(context.Context, *RestoreConversationRequest) (*RestoreConversationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreConversation not implemented")
+}
+func (UnimplementedConversationServiceServer) ArchiveConversation(context.Context, *ArchiveConversationRequest) (*ArchiveConversationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveConversation not implemented")
+}
+func (UnimplementedConversationServiceServer) UnarchiveConversation(context.Context, *UnarchiveConversationRequest) (*UnarchiveConversationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnarchiveConversation not implemented")
+}
+func (UnimplementedConversationServiceServer) SearchConversations(context.Context, *SearchConversationsRequest) (*SearchConversationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchConversations not implemented")
+}
+func (UnimplementedConversationServiceServer) ExportConversation(context.Context, *ExportConversationRequest) (*ExportConversationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportConversation not implemented")
+}
+func (UnimplementedConversationServiceServer) ImportConversation(context.Context, *ImportConversationRequest) (*ImportConversationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportConversation not implemented")
+}
 func (UnimplementedConversationServiceServer) Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error {
 	return status.Error(codes.Unimplemented, "method Chat not implemented")
 }
+func (UnimplementedConversationServiceServer) ChatSession(grpc.BidiStreamingServer[ChatSessionRequest, ChatSessionResponse]) error {
+	return status.Error(codes.Unimplemented, "method ChatSession not implemented")
+}
 func (UnimplementedConversationServiceServer) SubmitFeedback(context.Context, *SubmitFeedbackRequest) (*SubmitFeedbackResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitFeedback not implemented")
 }
+func (UnimplementedConversationServiceServer) ListFeedback(context.Context, *ListFeedbackRequest) (*ListFeedbackResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFeedback not implemented")
+}
+func (UnimplementedConversationServiceServer) GetFeedbackReport(context.Context, *GetFeedbackReportRequest) (*GetFeedbackReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeedbackReport not implemented")
+}
+func (UnimplementedConversationServiceServer) ExportDataset(*ExportDatasetRequest, grpc.ServerStreamingServer[ExportDatasetResponse]) error {
+	return status.Error(codes.Unimplemented, "method ExportDataset not implemented")
+}
+func (UnimplementedConversationServiceServer) ReplayTurn(context.Context, *ReplayTurnRequest) (*ReplayTurnResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayTurn not implemented")
+}
+func (UnimplementedConversationServiceServer) GetMessageTrace(context.Context, *GetMessageTraceRequest) (*GetMessageTraceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMessageTrace not implemented")
+}
+func (UnimplementedConversationServiceServer) ListTranscripts(context.Context, *ListTranscriptsRequest) (*ListTranscriptsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTranscripts not implemented")
+}
+func (UnimplementedConversationServiceServer) GetTranscript(context.Context, *GetTranscriptRequest) (*GetTranscriptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTranscript not implemented")
+}
 func (UnimplementedConversationServiceServer) mustEmbedUnimplementedConversationServiceServer() {}
 func (UnimplementedConversationServiceServer) testEmbeddedByValue()                             {}
 
@@ -285,6 +577,114 @@ func _ConversationService_DeleteConversation_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConversationService_RestoreConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).RestoreConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_RestoreConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).RestoreConversation(ctx, req.(*RestoreConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_ArchiveConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ArchiveConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ArchiveConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ArchiveConversation(ctx, req.(*ArchiveConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_UnarchiveConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).UnarchiveConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_UnarchiveConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).UnarchiveConversation(ctx, req.(*UnarchiveConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_SearchConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).SearchConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_SearchConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).SearchConversations(ctx, req.(*SearchConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_ExportConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ExportConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ExportConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ExportConversation(ctx, req.(*ExportConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_ImportConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ImportConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ImportConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ImportConversation(ctx, req.(*ImportConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ConversationService_Chat_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ChatRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -296,6 +696,13 @@ func _ConversationService_Chat_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ConversationService_ChatServer = grpc.ServerStreamingServer[ChatResponse]
 
+func _ConversationService_ChatSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConversationServiceServer).ChatSession(&grpc.GenericServerStream[ChatSessionRequest, ChatSessionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversationService_ChatSessionServer = grpc.BidiStreamingServer[ChatSessionRequest, ChatSessionResponse]
+
 func _ConversationService_SubmitFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitFeedbackRequest)
 	if err := dec(in); err != nil {
@@ -314,6 +721,125 @@ func _ConversationService_SubmitFeedback_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConversationService_ListFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeedbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ListFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ListFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ListFeedback(ctx, req.(*ListFeedbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_GetFeedbackReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedbackReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).GetFeedbackReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_GetFeedbackReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).GetFeedbackReport(ctx, req.(*GetFeedbackReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_ExportDataset_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDatasetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConversationServiceServer).ExportDataset(m, &grpc.GenericServerStream[ExportDatasetRequest, ExportDatasetResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConversationService_ExportDatasetServer = grpc.ServerStreamingServer[ExportDatasetResponse]
+
+func _ConversationService_ReplayTurn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayTurnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ReplayTurn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ReplayTurn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ReplayTurn(ctx, req.(*ReplayTurnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_GetMessageTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessageTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).GetMessageTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_GetMessageTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).GetMessageTrace(ctx, req.(*GetMessageTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_ListTranscripts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTranscriptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).ListTranscripts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_ListTranscripts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).ListTranscripts(ctx, req.(*ListTranscriptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_GetTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).GetTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_GetTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).GetTranscript(ctx, req.(*GetTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConversationService_ServiceDesc is the grpc.ServiceDesc for ConversationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -341,10 +867,58 @@ var ConversationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteConversation",
 			Handler:    _ConversationService_DeleteConversation_Handler,
 		},
+		{
+			MethodName: "RestoreConversation",
+			Handler:    _ConversationService_RestoreConversation_Handler,
+		},
+		{
+			MethodName: "ArchiveConversation",
+			Handler:    _ConversationService_ArchiveConversation_Handler,
+		},
+		{
+			MethodName: "UnarchiveConversation",
+			Handler:    _ConversationService_UnarchiveConversation_Handler,
+		},
+		{
+			MethodName: "SearchConversations",
+			Handler:    _ConversationService_SearchConversations_Handler,
+		},
+		{
+			MethodName: "ExportConversation",
+			Handler:    _ConversationService_ExportConversation_Handler,
+		},
+		{
+			MethodName: "ImportConversation",
+			Handler:    _ConversationService_ImportConversation_Handler,
+		},
 		{
 			MethodName: "SubmitFeedback",
 			Handler:    _ConversationService_SubmitFeedback_Handler,
 		},
+		{
+			MethodName: "ListFeedback",
+			Handler:    _ConversationService_ListFeedback_Handler,
+		},
+		{
+			MethodName: "GetFeedbackReport",
+			Handler:    _ConversationService_GetFeedbackReport_Handler,
+		},
+		{
+			MethodName: "ReplayTurn",
+			Handler:    _ConversationService_ReplayTurn_Handler,
+		},
+		{
+			MethodName: "GetMessageTrace",
+			Handler:    _ConversationService_GetMessageTrace_Handler,
+		},
+		{
+			MethodName: "ListTranscripts",
+			Handler:    _ConversationService_ListTranscripts_Handler,
+		},
+		{
+			MethodName: "GetTranscript",
+			Handler:    _ConversationService_GetTranscript_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -352,6 +926,17 @@ var ConversationService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ConversationService_Chat_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ChatSession",
+			Handler:       _ConversationService_ChatSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportDataset",
+			Handler:       _ConversationService_ExportDataset_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "schemas/greyseal/v1/services/conversation.proto",
 }
@@ -0,0 +1,384 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/services/model.proto
+
+package servicesv1
+
+import (
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListModelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{0}
+}
+
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*v1.Model            `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListModelsResponse) GetData() []*v1.Model {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type PullModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullModelRequest) Reset() {
+	*x = PullModelRequest{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullModelRequest) ProtoMessage() {}
+
+func (x *PullModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullModelRequest.ProtoReflect.Descriptor instead.
+func (*PullModelRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PullModelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// PullModelResponse is streamed; each message reports the latest pull status.
+type PullModelResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Digest string                 `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	// total and completed are byte counts for the layer named by digest.
+	Total         int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int64 `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullModelResponse) Reset() {
+	*x = PullModelResponse{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullModelResponse) ProtoMessage() {}
+
+func (x *PullModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullModelResponse.ProtoReflect.Descriptor instead.
+func (*PullModelResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullModelResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PullModelResponse) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *PullModelResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PullModelResponse) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+type UnloadModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadModelRequest) Reset() {
+	*x = UnloadModelRequest{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadModelRequest) ProtoMessage() {}
+
+func (x *UnloadModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadModelRequest.ProtoReflect.Descriptor instead.
+func (*UnloadModelRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UnloadModelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnloadModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadModelResponse) Reset() {
+	*x = UnloadModelResponse{}
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadModelResponse) ProtoMessage() {}
+
+func (x *UnloadModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_model_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadModelResponse.ProtoReflect.Descriptor instead.
+func (*UnloadModelResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_model_proto_rawDescGZIP(), []int{5}
+}
+
+var File_schemas_greyseal_v1_services_model_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_services_model_proto_rawDesc = "" +
+	"\n" +
+	"(schemas/greyseal/v1/services/model.proto\x12\x1cschemas.greyseal.services.v1\x1a\x1fschemas/greyseal/v1/model.proto\"\x13\n" +
+	"\x11ListModelsRequest\"D\n" +
+	"\x12ListModelsResponse\x12.\n" +
+	"\x04data\x18\x01 \x03(\v2\x1a.schemas.greyseal.v1.ModelR\x04data\"&\n" +
+	"\x10PullModelRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"w\n" +
+	"\x11PullModelResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x16\n" +
+	"\x06digest\x18\x02 \x01(\tR\x06digest\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\x03R\tcompleted\"(\n" +
+	"\x12UnloadModelRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x15\n" +
+	"\x13UnloadModelResponse2\xe9\x02\n" +
+	"\fModelService\x12q\n" +
+	"\n" +
+	"ListModels\x12/.schemas.greyseal.services.v1.ListModelsRequest\x1a0.schemas.greyseal.services.v1.ListModelsResponse\"\x00\x12p\n" +
+	"\tPullModel\x12..schemas.greyseal.services.v1.PullModelRequest\x1a/.schemas.greyseal.services.v1.PullModelResponse\"\x000\x01\x12t\n" +
+	"\vUnloadModel\x120.schemas.greyseal.services.v1.UnloadModelRequest\x1a1.schemas.greyseal.services.v1.UnloadModelResponse\"\x00B\x8c\x02\n" +
+	" com.schemas.greyseal.services.v1B\n" +
+	"ModelProtoP\x01ZIgithub.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services;servicesv1\xa2\x02\x03SGS\xaa\x02\x1cSchemas.Greyseal.Services.V1\xca\x02\x1cSchemas\\Greyseal\\Services\\V1\xe2\x02(Schemas\\Greyseal\\Services\\V1\\GPBMetadata\xea\x02\x1fSchemas::Greyseal::Services::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_services_model_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_services_model_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_services_model_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_services_model_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_services_model_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_model_proto_rawDesc), len(file_schemas_greyseal_v1_services_model_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_services_model_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_services_model_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_schemas_greyseal_v1_services_model_proto_goTypes = []any{
+	(*ListModelsRequest)(nil),   // 0: schemas.greyseal.services.v1.ListModelsRequest
+	(*ListModelsResponse)(nil),  // 1: schemas.greyseal.services.v1.ListModelsResponse
+	(*PullModelRequest)(nil),    // 2: schemas.greyseal.services.v1.PullModelRequest
+	(*PullModelResponse)(nil),   // 3: schemas.greyseal.services.v1.PullModelResponse
+	(*UnloadModelRequest)(nil),  // 4: schemas.greyseal.services.v1.UnloadModelRequest
+	(*UnloadModelResponse)(nil), // 5: schemas.greyseal.services.v1.UnloadModelResponse
+	(*v1.Model)(nil),            // 6: schemas.greyseal.v1.Model
+}
+var file_schemas_greyseal_v1_services_model_proto_depIdxs = []int32{
+	6, // 0: schemas.greyseal.services.v1.ListModelsResponse.data:type_name -> schemas.greyseal.v1.Model
+	0, // 1: schemas.greyseal.services.v1.ModelService.ListModels:input_type -> schemas.greyseal.services.v1.ListModelsRequest
+	2, // 2: schemas.greyseal.services.v1.ModelService.PullModel:input_type -> schemas.greyseal.services.v1.PullModelRequest
+	4, // 3: schemas.greyseal.services.v1.ModelService.UnloadModel:input_type -> schemas.greyseal.services.v1.UnloadModelRequest
+	1, // 4: schemas.greyseal.services.v1.ModelService.ListModels:output_type -> schemas.greyseal.services.v1.ListModelsResponse
+	3, // 5: schemas.greyseal.services.v1.ModelService.PullModel:output_type -> schemas.greyseal.services.v1.PullModelResponse
+	5, // 6: schemas.greyseal.services.v1.ModelService.UnloadModel:output_type -> schemas.greyseal.services.v1.UnloadModelResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_services_model_proto_init() }
+func file_schemas_greyseal_v1_services_model_proto_init() {
+	if File_schemas_greyseal_v1_services_model_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_model_proto_rawDesc), len(file_schemas_greyseal_v1_services_model_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schemas_greyseal_v1_services_model_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_services_model_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_services_model_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_services_model_proto = out.File
+	file_schemas_greyseal_v1_services_model_proto_goTypes = nil
+	file_schemas_greyseal_v1_services_model_proto_depIdxs = nil
+}
@@ -199,9 +199,18 @@ func (x *GetResourceResponse) GetData() *v1.Resource {
 }
 
 type ListResourcesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         *int32                 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
-	Cursor        *string                `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200).
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor *string    `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	Source *v1.Source `protobuf:"varint,3,opt,name=source,proto3,enum=schemas.greyseal.v1.Source,oneof" json:"source,omitempty"`
+	// indexed selects resources that have (true) or have not (false) been indexed.
+	Indexed    *bool   `protobuf:"varint,4,opt,name=indexed,proto3,oneof" json:"indexed,omitempty"`
+	Service    *string `protobuf:"bytes,5,opt,name=service,proto3,oneof" json:"service,omitempty"`
+	Collection *string `protobuf:"bytes,6,opt,name=collection,proto3,oneof" json:"collection,omitempty"`
+	// tag selects resources carrying this tag.
+	Tag           *string `protobuf:"bytes,7,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -250,11 +259,48 @@ func (x *ListResourcesRequest) GetCursor() string {
 	return ""
 }
 
+func (x *ListResourcesRequest) GetSource() v1.Source {
+	if x != nil && x.Source != nil {
+		return *x.Source
+	}
+	return v1.Source(0)
+}
+
+func (x *ListResourcesRequest) GetIndexed() bool {
+	if x != nil && x.Indexed != nil {
+		return *x.Indexed
+	}
+	return false
+}
+
+func (x *ListResourcesRequest) GetService() string {
+	if x != nil && x.Service != nil {
+		return *x.Service
+	}
+	return ""
+}
+
+func (x *ListResourcesRequest) GetCollection() string {
+	if x != nil && x.Collection != nil {
+		return *x.Collection
+	}
+	return ""
+}
+
+func (x *ListResourcesRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
 type ListResourcesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []*v1.Resource         `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
-	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Data []*v1.Resource `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -402,12 +448,26 @@ const file_schemas_greyseal_v1_services_resource_proto_rawDesc = "" +
 	"\x12GetResourceRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"H\n" +
 	"\x13GetResourceResponse\x121\n" +
-	"\x04data\x18\x01 \x01(\v2\x1d.schemas.greyseal.v1.ResourceR\x04data\"c\n" +
+	"\x04data\x18\x01 \x01(\v2\x1d.schemas.greyseal.v1.ResourceR\x04data\"\xd1\x02\n" +
 	"\x14ListResourcesRequest\x12\x19\n" +
 	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
-	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01\x128\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x1b.schemas.greyseal.v1.SourceH\x02R\x06source\x88\x01\x01\x12\x1d\n" +
+	"\aindexed\x18\x04 \x01(\bH\x03R\aindexed\x88\x01\x01\x12\x1d\n" +
+	"\aservice\x18\x05 \x01(\tH\x04R\aservice\x88\x01\x01\x12#\n" +
+	"\n" +
+	"collection\x18\x06 \x01(\tH\x05R\n" +
+	"collection\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\a \x01(\tH\x06R\x03tag\x88\x01\x01B\b\n" +
 	"\x06_countB\t\n" +
-	"\a_cursor\"x\n" +
+	"\a_cursorB\t\n" +
+	"\a_sourceB\n" +
+	"\n" +
+	"\b_indexedB\n" +
+	"\n" +
+	"\b_serviceB\r\n" +
+	"\v_collectionB\x06\n" +
+	"\x04_tag\"x\n" +
 	"\x15ListResourcesResponse\x121\n" +
 	"\x04data\x18\x01 \x03(\v2\x1d.schemas.greyseal.v1.ResourceR\x04data\x12\x16\n" +
 	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
@@ -445,25 +505,27 @@ var file_schemas_greyseal_v1_services_resource_proto_goTypes = []any{
 	(*DeleteResourceRequest)(nil),  // 6: schemas.greyseal.services.v1.DeleteResourceRequest
 	(*DeleteResourceResponse)(nil), // 7: schemas.greyseal.services.v1.DeleteResourceResponse
 	(*v1.Resource)(nil),            // 8: schemas.greyseal.v1.Resource
+	(v1.Source)(0),                 // 9: schemas.greyseal.v1.Source
 }
 var file_schemas_greyseal_v1_services_resource_proto_depIdxs = []int32{
 	8, // 0: schemas.greyseal.services.v1.IngestResourceRequest.data:type_name -> schemas.greyseal.v1.Resource
 	8, // 1: schemas.greyseal.services.v1.IngestResourceResponse.data:type_name -> schemas.greyseal.v1.Resource
 	8, // 2: schemas.greyseal.services.v1.GetResourceResponse.data:type_name -> schemas.greyseal.v1.Resource
-	8, // 3: schemas.greyseal.services.v1.ListResourcesResponse.data:type_name -> schemas.greyseal.v1.Resource
-	0, // 4: schemas.greyseal.services.v1.ResourceService.IngestResource:input_type -> schemas.greyseal.services.v1.IngestResourceRequest
-	2, // 5: schemas.greyseal.services.v1.ResourceService.GetResource:input_type -> schemas.greyseal.services.v1.GetResourceRequest
-	4, // 6: schemas.greyseal.services.v1.ResourceService.ListResources:input_type -> schemas.greyseal.services.v1.ListResourcesRequest
-	6, // 7: schemas.greyseal.services.v1.ResourceService.DeleteResource:input_type -> schemas.greyseal.services.v1.DeleteResourceRequest
-	1, // 8: schemas.greyseal.services.v1.ResourceService.IngestResource:output_type -> schemas.greyseal.services.v1.IngestResourceResponse
-	3, // 9: schemas.greyseal.services.v1.ResourceService.GetResource:output_type -> schemas.greyseal.services.v1.GetResourceResponse
-	5, // 10: schemas.greyseal.services.v1.ResourceService.ListResources:output_type -> schemas.greyseal.services.v1.ListResourcesResponse
-	7, // 11: schemas.greyseal.services.v1.ResourceService.DeleteResource:output_type -> schemas.greyseal.services.v1.DeleteResourceResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	9, // 3: schemas.greyseal.services.v1.ListResourcesRequest.source:type_name -> schemas.greyseal.v1.Source
+	8, // 4: schemas.greyseal.services.v1.ListResourcesResponse.data:type_name -> schemas.greyseal.v1.Resource
+	0, // 5: schemas.greyseal.services.v1.ResourceService.IngestResource:input_type -> schemas.greyseal.services.v1.IngestResourceRequest
+	2, // 6: schemas.greyseal.services.v1.ResourceService.GetResource:input_type -> schemas.greyseal.services.v1.GetResourceRequest
+	4, // 7: schemas.greyseal.services.v1.ResourceService.ListResources:input_type -> schemas.greyseal.services.v1.ListResourcesRequest
+	6, // 8: schemas.greyseal.services.v1.ResourceService.DeleteResource:input_type -> schemas.greyseal.services.v1.DeleteResourceRequest
+	1, // 9: schemas.greyseal.services.v1.ResourceService.IngestResource:output_type -> schemas.greyseal.services.v1.IngestResourceResponse
+	3, // 10: schemas.greyseal.services.v1.ResourceService.GetResource:output_type -> schemas.greyseal.services.v1.GetResourceResponse
+	5, // 11: schemas.greyseal.services.v1.ResourceService.ListResources:output_type -> schemas.greyseal.services.v1.ListResourcesResponse
+	7, // 12: schemas.greyseal.services.v1.ResourceService.DeleteResource:output_type -> schemas.greyseal.services.v1.DeleteResourceResponse
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_schemas_greyseal_v1_services_resource_proto_init() }
@@ -199,9 +199,11 @@ func (x *GetRoleResponse) GetData() *v1.Role {
 }
 
 type ListRolesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         *int32                 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
-	Cursor        *string                `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200).
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor        *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -251,10 +253,12 @@ func (x *ListRolesRequest) GetCursor() string {
 }
 
 type ListRolesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []*v1.Role             `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
-	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Data []*v1.Role `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -486,6 +490,412 @@ func (*DeleteRoleResponse) Descriptor() ([]byte, []int) {
 	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{9}
 }
 
+type ListRoleVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleUuid      string                 `protobuf:"bytes,1,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleVersionsRequest) Reset() {
+	*x = ListRoleVersionsRequest{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleVersionsRequest) ProtoMessage() {}
+
+func (x *ListRoleVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRoleVersionsRequest) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+type ListRoleVersionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Data          []*v1.RoleVersion `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleVersionsResponse) Reset() {
+	*x = ListRoleVersionsResponse{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleVersionsResponse) ProtoMessage() {}
+
+func (x *ListRoleVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRoleVersionsResponse) GetData() []*v1.RoleVersion {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetRoleVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleUuid      string                 `protobuf:"bytes,1,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleVersionRequest) Reset() {
+	*x = GetRoleVersionRequest{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleVersionRequest) ProtoMessage() {}
+
+func (x *GetRoleVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetRoleVersionRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetRoleVersionRequest) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *GetRoleVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetRoleVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.RoleVersion        `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleVersionResponse) Reset() {
+	*x = GetRoleVersionResponse{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleVersionResponse) ProtoMessage() {}
+
+func (x *GetRoleVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetRoleVersionResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRoleVersionResponse) GetData() *v1.RoleVersion {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DiffRoleVersionsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RoleUuid    string                 `protobuf:"bytes,1,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	FromVersion int32                  `protobuf:"varint,2,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+	// to_version defaults to the latest version.
+	ToVersion     *int32 `protobuf:"varint,3,opt,name=to_version,json=toVersion,proto3,oneof" json:"to_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRoleVersionsRequest) Reset() {
+	*x = DiffRoleVersionsRequest{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRoleVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRoleVersionsRequest) ProtoMessage() {}
+
+func (x *DiffRoleVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRoleVersionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffRoleVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DiffRoleVersionsRequest) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *DiffRoleVersionsRequest) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+func (x *DiffRoleVersionsRequest) GetToVersion() int32 {
+	if x != nil && x.ToVersion != nil {
+		return *x.ToVersion
+	}
+	return 0
+}
+
+type DiffRoleVersionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *v1.RoleVersion        `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *v1.RoleVersion        `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// diff is a unified diff of the two system prompts, preceded by the name
+	// change if any.
+	Diff          string `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRoleVersionsResponse) Reset() {
+	*x = DiffRoleVersionsResponse{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRoleVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRoleVersionsResponse) ProtoMessage() {}
+
+func (x *DiffRoleVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRoleVersionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffRoleVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DiffRoleVersionsResponse) GetFrom() *v1.RoleVersion {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DiffRoleVersionsResponse) GetTo() *v1.RoleVersion {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *DiffRoleVersionsResponse) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+type RollbackRoleRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoleUuid string                 `protobuf:"bytes,1,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	// version is the version whose name and system prompt are restored.
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackRoleRequest) Reset() {
+	*x = RollbackRoleRequest{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRoleRequest) ProtoMessage() {}
+
+func (x *RollbackRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRoleRequest.ProtoReflect.Descriptor instead.
+func (*RollbackRoleRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RollbackRoleRequest) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *RollbackRoleRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RollbackRoleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// data is the role at its new latest version.
+	Data          *v1.Role `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackRoleResponse) Reset() {
+	*x = RollbackRoleResponse{}
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRoleResponse) ProtoMessage() {}
+
+func (x *RollbackRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_role_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRoleResponse.ProtoReflect.Descriptor instead.
+func (*RollbackRoleResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_role_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RollbackRoleResponse) GetData() *v1.Role {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 var File_schemas_greyseal_v1_services_role_proto protoreflect.FileDescriptor
 
 const file_schemas_greyseal_v1_services_role_proto_rawDesc = "" +
@@ -515,7 +925,31 @@ const file_schemas_greyseal_v1_services_role_proto_rawDesc = "" +
 	"\x04data\x18\x01 \x01(\v2\x19.schemas.greyseal.v1.RoleR\x04data\"'\n" +
 	"\x11DeleteRoleRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x14\n" +
-	"\x12DeleteRoleResponse2\xc0\x04\n" +
+	"\x12DeleteRoleResponse\"6\n" +
+	"\x17ListRoleVersionsRequest\x12\x1b\n" +
+	"\trole_uuid\x18\x01 \x01(\tR\broleUuid\"P\n" +
+	"\x18ListRoleVersionsResponse\x124\n" +
+	"\x04data\x18\x01 \x03(\v2 .schemas.greyseal.v1.RoleVersionR\x04data\"N\n" +
+	"\x15GetRoleVersionRequest\x12\x1b\n" +
+	"\trole_uuid\x18\x01 \x01(\tR\broleUuid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"N\n" +
+	"\x16GetRoleVersionResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2 .schemas.greyseal.v1.RoleVersionR\x04data\"\x8c\x01\n" +
+	"\x17DiffRoleVersionsRequest\x12\x1b\n" +
+	"\trole_uuid\x18\x01 \x01(\tR\broleUuid\x12!\n" +
+	"\ffrom_version\x18\x02 \x01(\x05R\vfromVersion\x12\"\n" +
+	"\n" +
+	"to_version\x18\x03 \x01(\x05H\x00R\ttoVersion\x88\x01\x01B\r\n" +
+	"\v_to_version\"\x96\x01\n" +
+	"\x18DiffRoleVersionsResponse\x124\n" +
+	"\x04from\x18\x01 \x01(\v2 .schemas.greyseal.v1.RoleVersionR\x04from\x120\n" +
+	"\x02to\x18\x02 \x01(\v2 .schemas.greyseal.v1.RoleVersionR\x02to\x12\x12\n" +
+	"\x04diff\x18\x03 \x01(\tR\x04diff\"L\n" +
+	"\x13RollbackRoleRequest\x12\x1b\n" +
+	"\trole_uuid\x18\x01 \x01(\tR\broleUuid\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"E\n" +
+	"\x14RollbackRoleResponse\x12-\n" +
+	"\x04data\x18\x01 \x01(\v2\x19.schemas.greyseal.v1.RoleR\x04data2\xc4\b\n" +
 	"\vRoleService\x12q\n" +
 	"\n" +
 	"CreateRole\x12/.schemas.greyseal.services.v1.CreateRoleRequest\x1a0.schemas.greyseal.services.v1.CreateRoleResponse\"\x00\x12h\n" +
@@ -524,7 +958,11 @@ const file_schemas_greyseal_v1_services_role_proto_rawDesc = "" +
 	"\n" +
 	"UpdateRole\x12/.schemas.greyseal.services.v1.UpdateRoleRequest\x1a0.schemas.greyseal.services.v1.UpdateRoleResponse\"\x00\x12q\n" +
 	"\n" +
-	"DeleteRole\x12/.schemas.greyseal.services.v1.DeleteRoleRequest\x1a0.schemas.greyseal.services.v1.DeleteRoleResponse\"\x00B\x8b\x02\n" +
+	"DeleteRole\x12/.schemas.greyseal.services.v1.DeleteRoleRequest\x1a0.schemas.greyseal.services.v1.DeleteRoleResponse\"\x00\x12\x83\x01\n" +
+	"\x10ListRoleVersions\x125.schemas.greyseal.services.v1.ListRoleVersionsRequest\x1a6.schemas.greyseal.services.v1.ListRoleVersionsResponse\"\x00\x12}\n" +
+	"\x0eGetRoleVersion\x123.schemas.greyseal.services.v1.GetRoleVersionRequest\x1a4.schemas.greyseal.services.v1.GetRoleVersionResponse\"\x00\x12\x83\x01\n" +
+	"\x10DiffRoleVersions\x125.schemas.greyseal.services.v1.DiffRoleVersionsRequest\x1a6.schemas.greyseal.services.v1.DiffRoleVersionsResponse\"\x00\x12w\n" +
+	"\fRollbackRole\x121.schemas.greyseal.services.v1.RollbackRoleRequest\x1a2.schemas.greyseal.services.v1.RollbackRoleResponse\"\x00B\x8b\x02\n" +
 	" com.schemas.greyseal.services.v1B\tRoleProtoP\x01ZIgithub.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services;servicesv1\xa2\x02\x03SGS\xaa\x02\x1cSchemas.Greyseal.Services.V1\xca\x02\x1cSchemas\\Greyseal\\Services\\V1\xe2\x02(Schemas\\Greyseal\\Services\\V1\\GPBMetadata\xea\x02\x1fSchemas::Greyseal::Services::V1b\x06proto3"
 
 var (
@@ -539,42 +977,64 @@ func file_schemas_greyseal_v1_services_role_proto_rawDescGZIP() []byte {
 	return file_schemas_greyseal_v1_services_role_proto_rawDescData
 }
 
-var file_schemas_greyseal_v1_services_role_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_schemas_greyseal_v1_services_role_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_schemas_greyseal_v1_services_role_proto_goTypes = []any{
-	(*CreateRoleRequest)(nil),  // 0: schemas.greyseal.services.v1.CreateRoleRequest
-	(*CreateRoleResponse)(nil), // 1: schemas.greyseal.services.v1.CreateRoleResponse
-	(*GetRoleRequest)(nil),     // 2: schemas.greyseal.services.v1.GetRoleRequest
-	(*GetRoleResponse)(nil),    // 3: schemas.greyseal.services.v1.GetRoleResponse
-	(*ListRolesRequest)(nil),   // 4: schemas.greyseal.services.v1.ListRolesRequest
-	(*ListRolesResponse)(nil),  // 5: schemas.greyseal.services.v1.ListRolesResponse
-	(*UpdateRoleRequest)(nil),  // 6: schemas.greyseal.services.v1.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil), // 7: schemas.greyseal.services.v1.UpdateRoleResponse
-	(*DeleteRoleRequest)(nil),  // 8: schemas.greyseal.services.v1.DeleteRoleRequest
-	(*DeleteRoleResponse)(nil), // 9: schemas.greyseal.services.v1.DeleteRoleResponse
-	(*v1.Role)(nil),            // 10: schemas.greyseal.v1.Role
+	(*CreateRoleRequest)(nil),        // 0: schemas.greyseal.services.v1.CreateRoleRequest
+	(*CreateRoleResponse)(nil),       // 1: schemas.greyseal.services.v1.CreateRoleResponse
+	(*GetRoleRequest)(nil),           // 2: schemas.greyseal.services.v1.GetRoleRequest
+	(*GetRoleResponse)(nil),          // 3: schemas.greyseal.services.v1.GetRoleResponse
+	(*ListRolesRequest)(nil),         // 4: schemas.greyseal.services.v1.ListRolesRequest
+	(*ListRolesResponse)(nil),        // 5: schemas.greyseal.services.v1.ListRolesResponse
+	(*UpdateRoleRequest)(nil),        // 6: schemas.greyseal.services.v1.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),       // 7: schemas.greyseal.services.v1.UpdateRoleResponse
+	(*DeleteRoleRequest)(nil),        // 8: schemas.greyseal.services.v1.DeleteRoleRequest
+	(*DeleteRoleResponse)(nil),       // 9: schemas.greyseal.services.v1.DeleteRoleResponse
+	(*ListRoleVersionsRequest)(nil),  // 10: schemas.greyseal.services.v1.ListRoleVersionsRequest
+	(*ListRoleVersionsResponse)(nil), // 11: schemas.greyseal.services.v1.ListRoleVersionsResponse
+	(*GetRoleVersionRequest)(nil),    // 12: schemas.greyseal.services.v1.GetRoleVersionRequest
+	(*GetRoleVersionResponse)(nil),   // 13: schemas.greyseal.services.v1.GetRoleVersionResponse
+	(*DiffRoleVersionsRequest)(nil),  // 14: schemas.greyseal.services.v1.DiffRoleVersionsRequest
+	(*DiffRoleVersionsResponse)(nil), // 15: schemas.greyseal.services.v1.DiffRoleVersionsResponse
+	(*RollbackRoleRequest)(nil),      // 16: schemas.greyseal.services.v1.RollbackRoleRequest
+	(*RollbackRoleResponse)(nil),     // 17: schemas.greyseal.services.v1.RollbackRoleResponse
+	(*v1.Role)(nil),                  // 18: schemas.greyseal.v1.Role
+	(*v1.RoleVersion)(nil),           // 19: schemas.greyseal.v1.RoleVersion
 }
 var file_schemas_greyseal_v1_services_role_proto_depIdxs = []int32{
-	10, // 0: schemas.greyseal.services.v1.CreateRoleRequest.data:type_name -> schemas.greyseal.v1.Role
-	10, // 1: schemas.greyseal.services.v1.CreateRoleResponse.data:type_name -> schemas.greyseal.v1.Role
-	10, // 2: schemas.greyseal.services.v1.GetRoleResponse.data:type_name -> schemas.greyseal.v1.Role
-	10, // 3: schemas.greyseal.services.v1.ListRolesResponse.data:type_name -> schemas.greyseal.v1.Role
-	10, // 4: schemas.greyseal.services.v1.UpdateRoleRequest.data:type_name -> schemas.greyseal.v1.Role
-	10, // 5: schemas.greyseal.services.v1.UpdateRoleResponse.data:type_name -> schemas.greyseal.v1.Role
-	0,  // 6: schemas.greyseal.services.v1.RoleService.CreateRole:input_type -> schemas.greyseal.services.v1.CreateRoleRequest
-	2,  // 7: schemas.greyseal.services.v1.RoleService.GetRole:input_type -> schemas.greyseal.services.v1.GetRoleRequest
-	4,  // 8: schemas.greyseal.services.v1.RoleService.ListRoles:input_type -> schemas.greyseal.services.v1.ListRolesRequest
-	6,  // 9: schemas.greyseal.services.v1.RoleService.UpdateRole:input_type -> schemas.greyseal.services.v1.UpdateRoleRequest
-	8,  // 10: schemas.greyseal.services.v1.RoleService.DeleteRole:input_type -> schemas.greyseal.services.v1.DeleteRoleRequest
-	1,  // 11: schemas.greyseal.services.v1.RoleService.CreateRole:output_type -> schemas.greyseal.services.v1.CreateRoleResponse
-	3,  // 12: schemas.greyseal.services.v1.RoleService.GetRole:output_type -> schemas.greyseal.services.v1.GetRoleResponse
-	5,  // 13: schemas.greyseal.services.v1.RoleService.ListRoles:output_type -> schemas.greyseal.services.v1.ListRolesResponse
-	7,  // 14: schemas.greyseal.services.v1.RoleService.UpdateRole:output_type -> schemas.greyseal.services.v1.UpdateRoleResponse
-	9,  // 15: schemas.greyseal.services.v1.RoleService.DeleteRole:output_type -> schemas.greyseal.services.v1.DeleteRoleResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	18, // 0: schemas.greyseal.services.v1.CreateRoleRequest.data:type_name -> schemas.greyseal.v1.Role
+	18, // 1: schemas.greyseal.services.v1.CreateRoleResponse.data:type_name -> schemas.greyseal.v1.Role
+	18, // 2: schemas.greyseal.services.v1.GetRoleResponse.data:type_name -> schemas.greyseal.v1.Role
+	18, // 3: schemas.greyseal.services.v1.ListRolesResponse.data:type_name -> schemas.greyseal.v1.Role
+	18, // 4: schemas.greyseal.services.v1.UpdateRoleRequest.data:type_name -> schemas.greyseal.v1.Role
+	18, // 5: schemas.greyseal.services.v1.UpdateRoleResponse.data:type_name -> schemas.greyseal.v1.Role
+	19, // 6: schemas.greyseal.services.v1.ListRoleVersionsResponse.data:type_name -> schemas.greyseal.v1.RoleVersion
+	19, // 7: schemas.greyseal.services.v1.GetRoleVersionResponse.data:type_name -> schemas.greyseal.v1.RoleVersion
+	19, // 8: schemas.greyseal.services.v1.DiffRoleVersionsResponse.from:type_name -> schemas.greyseal.v1.RoleVersion
+	19, // 9: schemas.greyseal.services.v1.DiffRoleVersionsResponse.to:type_name -> schemas.greyseal.v1.RoleVersion
+	18, // 10: schemas.greyseal.services.v1.RollbackRoleResponse.data:type_name -> schemas.greyseal.v1.Role
+	0,  // 11: schemas.greyseal.services.v1.RoleService.CreateRole:input_type -> schemas.greyseal.services.v1.CreateRoleRequest
+	2,  // 12: schemas.greyseal.services.v1.RoleService.GetRole:input_type -> schemas.greyseal.services.v1.GetRoleRequest
+	4,  // 13: schemas.greyseal.services.v1.RoleService.ListRoles:input_type -> schemas.greyseal.services.v1.ListRolesRequest
+	6,  // 14: schemas.greyseal.services.v1.RoleService.UpdateRole:input_type -> schemas.greyseal.services.v1.UpdateRoleRequest
+	8,  // 15: schemas.greyseal.services.v1.RoleService.DeleteRole:input_type -> schemas.greyseal.services.v1.DeleteRoleRequest
+	10, // 16: schemas.greyseal.services.v1.RoleService.ListRoleVersions:input_type -> schemas.greyseal.services.v1.ListRoleVersionsRequest
+	12, // 17: schemas.greyseal.services.v1.RoleService.GetRoleVersion:input_type -> schemas.greyseal.services.v1.GetRoleVersionRequest
+	14, // 18: schemas.greyseal.services.v1.RoleService.DiffRoleVersions:input_type -> schemas.greyseal.services.v1.DiffRoleVersionsRequest
+	16, // 19: schemas.greyseal.services.v1.RoleService.RollbackRole:input_type -> schemas.greyseal.services.v1.RollbackRoleRequest
+	1,  // 20: schemas.greyseal.services.v1.RoleService.CreateRole:output_type -> schemas.greyseal.services.v1.CreateRoleResponse
+	3,  // 21: schemas.greyseal.services.v1.RoleService.GetRole:output_type -> schemas.greyseal.services.v1.GetRoleResponse
+	5,  // 22: schemas.greyseal.services.v1.RoleService.ListRoles:output_type -> schemas.greyseal.services.v1.ListRolesResponse
+	7,  // 23: schemas.greyseal.services.v1.RoleService.UpdateRole:output_type -> schemas.greyseal.services.v1.UpdateRoleResponse
+	9,  // 24: schemas.greyseal.services.v1.RoleService.DeleteRole:output_type -> schemas.greyseal.services.v1.DeleteRoleResponse
+	11, // 25: schemas.greyseal.services.v1.RoleService.ListRoleVersions:output_type -> schemas.greyseal.services.v1.ListRoleVersionsResponse
+	13, // 26: schemas.greyseal.services.v1.RoleService.GetRoleVersion:output_type -> schemas.greyseal.services.v1.GetRoleVersionResponse
+	15, // 27: schemas.greyseal.services.v1.RoleService.DiffRoleVersions:output_type -> schemas.greyseal.services.v1.DiffRoleVersionsResponse
+	17, // 28: schemas.greyseal.services.v1.RoleService.RollbackRole:output_type -> schemas.greyseal.services.v1.RollbackRoleResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_schemas_greyseal_v1_services_role_proto_init() }
@@ -583,13 +1043,14 @@ func file_schemas_greyseal_v1_services_role_proto_init() {
 		return
 	}
 	file_schemas_greyseal_v1_services_role_proto_msgTypes[4].OneofWrappers = []any{}
+	file_schemas_greyseal_v1_services_role_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_role_proto_rawDesc), len(file_schemas_greyseal_v1_services_role_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
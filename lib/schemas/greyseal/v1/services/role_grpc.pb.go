@@ -19,11 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RoleService_CreateRole_FullMethodName = "/schemas.greyseal.services.v1.RoleService/CreateRole"
-	RoleService_GetRole_FullMethodName    = "/schemas.greyseal.services.v1.RoleService/GetRole"
-	RoleService_ListRoles_FullMethodName  = "/schemas.greyseal.services.v1.RoleService/ListRoles"
-	RoleService_UpdateRole_FullMethodName = "/schemas.greyseal.services.v1.RoleService/UpdateRole"
-	RoleService_DeleteRole_FullMethodName = "/schemas.greyseal.services.v1.RoleService/DeleteRole"
+	RoleService_CreateRole_FullMethodName       = "/schemas.greyseal.services.v1.RoleService/CreateRole"
+	RoleService_GetRole_FullMethodName          = "/schemas.greyseal.services.v1.RoleService/GetRole"
+	RoleService_ListRoles_FullMethodName        = "/schemas.greyseal.services.v1.RoleService/ListRoles"
+	RoleService_UpdateRole_FullMethodName       = "/schemas.greyseal.services.v1.RoleService/UpdateRole"
+	RoleService_DeleteRole_FullMethodName       = "/schemas.greyseal.services.v1.RoleService/DeleteRole"
+	RoleService_ListRoleVersions_FullMethodName = "/schemas.greyseal.services.v1.RoleService/ListRoleVersions"
+	RoleService_GetRoleVersion_FullMethodName   = "/schemas.greyseal.services.v1.RoleService/GetRoleVersion"
+	RoleService_DiffRoleVersions_FullMethodName = "/schemas.greyseal.services.v1.RoleService/DiffRoleVersions"
+	RoleService_RollbackRole_FullMethodName     = "/schemas.greyseal.services.v1.RoleService/RollbackRole"
 )
 
 // RoleServiceClient is the client API for RoleService service.
@@ -35,6 +39,13 @@ type RoleServiceClient interface {
 	ListRoles(ctx context.Context, in *ListRolesRequest, opts ...grpc.CallOption) (*ListRolesResponse, error)
 	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*UpdateRoleResponse, error)
 	DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*DeleteRoleResponse, error)
+	// ListRoleVersions returns every version of a role, newest first.
+	ListRoleVersions(ctx context.Context, in *ListRoleVersionsRequest, opts ...grpc.CallOption) (*ListRoleVersionsResponse, error)
+	GetRoleVersion(ctx context.Context, in *GetRoleVersionRequest, opts ...grpc.CallOption) (*GetRoleVersionResponse, error)
+	// DiffRoleVersions compares the name and system prompt of two versions.
+	DiffRoleVersions(ctx context.Context, in *DiffRoleVersionsRequest, opts ...grpc.CallOption) (*DiffRoleVersionsResponse, error)
+	// RollbackRole writes a new version with the content of an earlier one.
+	RollbackRole(ctx context.Context, in *RollbackRoleRequest, opts ...grpc.CallOption) (*RollbackRoleResponse, error)
 }
 
 type roleServiceClient struct {
@@ -95,6 +106,46 @@ func (c *roleServiceClient) DeleteRole(ctx context.Context, in *DeleteRoleReques
 	return out, nil
 }
 
+func (c *roleServiceClient) ListRoleVersions(ctx context.Context, in *ListRoleVersionsRequest, opts ...grpc.CallOption) (*ListRoleVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleVersionsResponse)
+	err := c.cc.Invoke(ctx, RoleService_ListRoleVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) GetRoleVersion(ctx context.Context, in *GetRoleVersionRequest, opts ...grpc.CallOption) (*GetRoleVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoleVersionResponse)
+	err := c.cc.Invoke(ctx, RoleService_GetRoleVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) DiffRoleVersions(ctx context.Context, in *DiffRoleVersionsRequest, opts ...grpc.CallOption) (*DiffRoleVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffRoleVersionsResponse)
+	err := c.cc.Invoke(ctx, RoleService_DiffRoleVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) RollbackRole(ctx context.Context, in *RollbackRoleRequest, opts ...grpc.CallOption) (*RollbackRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackRoleResponse)
+	err := c.cc.Invoke(ctx, RoleService_RollbackRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoleServiceServer is the server API for RoleService service.
 // All implementations must embed UnimplementedRoleServiceServer
 // for forward compatibility.
@@ -104,6 +155,13 @@ type RoleServiceServer interface {
 	ListRoles(context.Context, *ListRolesRequest) (*ListRolesResponse, error)
 	UpdateRole(context.Context, *UpdateRoleRequest) (*UpdateRoleResponse, error)
 	DeleteRole(context.Context, *DeleteRoleRequest) (*DeleteRoleResponse, error)
+	// ListRoleVersions returns every version of a role, newest first.
+	ListRoleVersions(context.Context, *ListRoleVersionsRequest) (*ListRoleVersionsResponse, error)
+	GetRoleVersion(context.Context, *GetRoleVersionRequest) (*GetRoleVersionResponse, error)
+	// DiffRoleVersions compares the name and system prompt of two versions.
+	DiffRoleVersions(context.Context, *DiffRoleVersionsRequest) (*DiffRoleVersionsResponse, error)
+	// RollbackRole writes a new version with the content of an earlier one.
+	RollbackRole(context.Context, *RollbackRoleRequest) (*RollbackRoleResponse, error)
 	mustEmbedUnimplementedRoleServiceServer()
 }
 
@@ -129,6 +187,18 @@ func (UnimplementedRoleServiceServer) UpdateRole(context.Context, *UpdateRoleReq
 func (UnimplementedRoleServiceServer) DeleteRole(context.Context, *DeleteRoleRequest) (*DeleteRoleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteRole not implemented")
 }
+func (UnimplementedRoleServiceServer) ListRoleVersions(context.Context, *ListRoleVersionsRequest) (*ListRoleVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRoleVersions not implemented")
+}
+func (UnimplementedRoleServiceServer) GetRoleVersion(context.Context, *GetRoleVersionRequest) (*GetRoleVersionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRoleVersion not implemented")
+}
+func (UnimplementedRoleServiceServer) DiffRoleVersions(context.Context, *DiffRoleVersionsRequest) (*DiffRoleVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffRoleVersions not implemented")
+}
+func (UnimplementedRoleServiceServer) RollbackRole(context.Context, *RollbackRoleRequest) (*RollbackRoleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackRole not implemented")
+}
 func (UnimplementedRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {}
 func (UnimplementedRoleServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +310,78 @@ func _RoleService_DeleteRole_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoleService_ListRoleVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ListRoleVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_ListRoleVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ListRoleVersions(ctx, req.(*ListRoleVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_GetRoleVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).GetRoleVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_GetRoleVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).GetRoleVersion(ctx, req.(*GetRoleVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_DiffRoleVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRoleVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).DiffRoleVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_DiffRoleVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).DiffRoleVersions(ctx, req.(*DiffRoleVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_RollbackRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).RollbackRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_RollbackRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).RollbackRole(ctx, req.(*RollbackRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoleService_ServiceDesc is the grpc.ServiceDesc for RoleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +409,22 @@ var RoleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRole",
 			Handler:    _RoleService_DeleteRole_Handler,
 		},
+		{
+			MethodName: "ListRoleVersions",
+			Handler:    _RoleService_ListRoleVersions_Handler,
+		},
+		{
+			MethodName: "GetRoleVersion",
+			Handler:    _RoleService_GetRoleVersion_Handler,
+		},
+		{
+			MethodName: "DiffRoleVersions",
+			Handler:    _RoleService_DiffRoleVersions_Handler,
+		},
+		{
+			MethodName: "RollbackRole",
+			Handler:    _RoleService_RollbackRole_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "schemas/greyseal/v1/services/role.proto",
@@ -48,12 +48,54 @@ const (
 	// ConversationServiceDeleteConversationProcedure is the fully-qualified name of the
 	// ConversationService's DeleteConversation RPC.
 	ConversationServiceDeleteConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/DeleteConversation"
+	// ConversationServiceRestoreConversationProcedure is the fully-qualified name of the
+	// ConversationService's RestoreConversation RPC.
+	ConversationServiceRestoreConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/RestoreConversation"
+	// ConversationServiceArchiveConversationProcedure is the fully-qualified name of the
+	// ConversationService's ArchiveConversation RPC.
+	ConversationServiceArchiveConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/ArchiveConversation"
+	// ConversationServiceUnarchiveConversationProcedure is the fully-qualified name of the
+	// ConversationService's UnarchiveConversation RPC.
+	ConversationServiceUnarchiveConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/UnarchiveConversation"
+	// ConversationServiceSearchConversationsProcedure is the fully-qualified name of the
+	// ConversationService's SearchConversations RPC.
+	ConversationServiceSearchConversationsProcedure = "/schemas.greyseal.services.v1.ConversationService/SearchConversations"
+	// ConversationServiceExportConversationProcedure is the fully-qualified name of the
+	// ConversationService's ExportConversation RPC.
+	ConversationServiceExportConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/ExportConversation"
+	// ConversationServiceImportConversationProcedure is the fully-qualified name of the
+	// ConversationService's ImportConversation RPC.
+	ConversationServiceImportConversationProcedure = "/schemas.greyseal.services.v1.ConversationService/ImportConversation"
 	// ConversationServiceChatProcedure is the fully-qualified name of the ConversationService's Chat
 	// RPC.
 	ConversationServiceChatProcedure = "/schemas.greyseal.services.v1.ConversationService/Chat"
+	// ConversationServiceChatSessionProcedure is the fully-qualified name of the ConversationService's
+	// ChatSession RPC.
+	ConversationServiceChatSessionProcedure = "/schemas.greyseal.services.v1.ConversationService/ChatSession"
 	// ConversationServiceSubmitFeedbackProcedure is the fully-qualified name of the
 	// ConversationService's SubmitFeedback RPC.
 	ConversationServiceSubmitFeedbackProcedure = "/schemas.greyseal.services.v1.ConversationService/SubmitFeedback"
+	// ConversationServiceListFeedbackProcedure is the fully-qualified name of the ConversationService's
+	// ListFeedback RPC.
+	ConversationServiceListFeedbackProcedure = "/schemas.greyseal.services.v1.ConversationService/ListFeedback"
+	// ConversationServiceGetFeedbackReportProcedure is the fully-qualified name of the
+	// ConversationService's GetFeedbackReport RPC.
+	ConversationServiceGetFeedbackReportProcedure = "/schemas.greyseal.services.v1.ConversationService/GetFeedbackReport"
+	// ConversationServiceExportDatasetProcedure is the fully-qualified name of the
+	// ConversationService's ExportDataset RPC.
+	ConversationServiceExportDatasetProcedure = "/schemas.greyseal.services.v1.ConversationService/ExportDataset"
+	// ConversationServiceReplayTurnProcedure is the fully-qualified name of the ConversationService's
+	// ReplayTurn RPC.
+	ConversationServiceReplayTurnProcedure = "/schemas.greyseal.services.v1.ConversationService/ReplayTurn"
+	// ConversationServiceGetMessageTraceProcedure is the fully-qualified name of the
+	// ConversationService's GetMessageTrace RPC.
+	ConversationServiceGetMessageTraceProcedure = "/schemas.greyseal.services.v1.ConversationService/GetMessageTrace"
+	// ConversationServiceListTranscriptsProcedure is the fully-qualified name of the
+	// ConversationService's ListTranscripts RPC.
+	ConversationServiceListTranscriptsProcedure = "/schemas.greyseal.services.v1.ConversationService/ListTranscripts"
+	// ConversationServiceGetTranscriptProcedure is the fully-qualified name of the
+	// ConversationService's GetTranscript RPC.
+	ConversationServiceGetTranscriptProcedure = "/schemas.greyseal.services.v1.ConversationService/GetTranscript"
 )
 
 // ConversationServiceClient is a client for the schemas.greyseal.services.v1.ConversationService
@@ -63,11 +105,53 @@ type ConversationServiceClient interface {
 	GetConversation(context.Context, *connect.Request[services.GetConversationRequest]) (*connect.Response[services.GetConversationResponse], error)
 	ListConversations(context.Context, *connect.Request[services.ListConversationsRequest]) (*connect.Response[services.ListConversationsResponse], error)
 	UpdateConversation(context.Context, *connect.Request[services.UpdateConversationRequest]) (*connect.Response[services.UpdateConversationResponse], error)
+	// DeleteConversation moves a conversation to the trash. It can be restored
+	// with RestoreConversation until the restore window passes.
 	DeleteConversation(context.Context, *connect.Request[services.DeleteConversationRequest]) (*connect.Response[services.DeleteConversationResponse], error)
+	// RestoreConversation undoes DeleteConversation within the restore window.
+	RestoreConversation(context.Context, *connect.Request[services.RestoreConversationRequest]) (*connect.Response[services.RestoreConversationResponse], error)
+	// ArchiveConversation hides a conversation from the default list and
+	// exempts it from retention. UnarchiveConversation reverses it.
+	ArchiveConversation(context.Context, *connect.Request[services.ArchiveConversationRequest]) (*connect.Response[services.ArchiveConversationResponse], error)
+	UnarchiveConversation(context.Context, *connect.Request[services.UnarchiveConversationRequest]) (*connect.Response[services.UnarchiveConversationResponse], error)
+	// SearchConversations finds conversations by full-text search over their
+	// messages, ranked by relevance.
+	SearchConversations(context.Context, *connect.Request[services.SearchConversationsRequest]) (*connect.Response[services.SearchConversationsResponse], error)
+	// ExportConversation returns a portable copy of a conversation, rendered in
+	// the requested format.
+	ExportConversation(context.Context, *connect.Request[services.ExportConversationRequest]) (*connect.Response[services.ExportConversationResponse], error)
+	// ImportConversation recreates an exported conversation, preserving
+	// timestamps and feedback. UUIDs already in use are replaced.
+	ImportConversation(context.Context, *connect.Request[services.ImportConversationRequest]) (*connect.Response[services.ImportConversationResponse], error)
 	// Chat sends a user message and streams back the assistant response token by token.
 	Chat(context.Context, *connect.Request[services.ChatRequest]) (*connect.ServerStreamForClient[services.ChatResponse], error)
+	// ChatSession keeps one conversation open over a long-lived stream. The
+	// client sends messages, stops or regenerates answers and switches role or
+	// scope between turns; the server streams tokens and turn results back.
+	ChatSession(context.Context) *connect.BidiStreamForClient[services.ChatSessionRequest, services.ChatSessionResponse]
 	// SubmitFeedback records user feedback on an assistant message.
 	SubmitFeedback(context.Context, *connect.Request[services.SubmitFeedbackRequest]) (*connect.Response[services.SubmitFeedbackResponse], error)
+	// ListFeedback returns feedback records, newest first. Callers see their
+	// own feedback; admins see everyone's.
+	ListFeedback(context.Context, *connect.Request[services.ListFeedbackRequest]) (*connect.Response[services.ListFeedbackResponse], error)
+	// GetFeedbackReport aggregates ratings by role, model, cited resource and
+	// time period.
+	GetFeedbackReport(context.Context, *connect.Request[services.GetFeedbackReportRequest]) (*connect.Response[services.GetFeedbackReportResponse], error)
+	// ExportDataset streams rated assistant turns with their prompt context,
+	// newest first.
+	ExportDataset(context.Context, *connect.Request[services.ExportDatasetRequest]) (*connect.ServerStreamForClient[services.ExportDatasetResponse], error)
+	// ReplayTurn re-runs a recorded turn with a different prompt, model or
+	// retrieval setting and returns both responses. Nothing is saved.
+	ReplayTurn(context.Context, *connect.Request[services.ReplayTurnRequest]) (*connect.Response[services.ReplayTurnResponse], error)
+	// GetMessageTrace returns what went into the prompt that produced an
+	// assistant message.
+	GetMessageTrace(context.Context, *connect.Request[services.GetMessageTraceRequest]) (*connect.Response[services.GetMessageTraceResponse], error)
+	// ListTranscripts lists the caller's conversations that have recorded
+	// transcripts.
+	ListTranscripts(context.Context, *connect.Request[services.ListTranscriptsRequest]) (*connect.Response[services.ListTranscriptsResponse], error)
+	// GetTranscript returns a conversation's recorded turns and their combined
+	// Markdown rendering.
+	GetTranscript(context.Context, *connect.Request[services.GetTranscriptRequest]) (*connect.Response[services.GetTranscriptResponse], error)
 }
 
 // NewConversationServiceClient constructs a client for the
@@ -112,30 +196,128 @@ func NewConversationServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(conversationServiceMethods.ByName("DeleteConversation")),
 			connect.WithClientOptions(opts...),
 		),
+		restoreConversation: connect.NewClient[services.RestoreConversationRequest, services.RestoreConversationResponse](
+			httpClient,
+			baseURL+ConversationServiceRestoreConversationProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("RestoreConversation")),
+			connect.WithClientOptions(opts...),
+		),
+		archiveConversation: connect.NewClient[services.ArchiveConversationRequest, services.ArchiveConversationResponse](
+			httpClient,
+			baseURL+ConversationServiceArchiveConversationProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ArchiveConversation")),
+			connect.WithClientOptions(opts...),
+		),
+		unarchiveConversation: connect.NewClient[services.UnarchiveConversationRequest, services.UnarchiveConversationResponse](
+			httpClient,
+			baseURL+ConversationServiceUnarchiveConversationProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("UnarchiveConversation")),
+			connect.WithClientOptions(opts...),
+		),
+		searchConversations: connect.NewClient[services.SearchConversationsRequest, services.SearchConversationsResponse](
+			httpClient,
+			baseURL+ConversationServiceSearchConversationsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("SearchConversations")),
+			connect.WithClientOptions(opts...),
+		),
+		exportConversation: connect.NewClient[services.ExportConversationRequest, services.ExportConversationResponse](
+			httpClient,
+			baseURL+ConversationServiceExportConversationProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ExportConversation")),
+			connect.WithClientOptions(opts...),
+		),
+		importConversation: connect.NewClient[services.ImportConversationRequest, services.ImportConversationResponse](
+			httpClient,
+			baseURL+ConversationServiceImportConversationProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ImportConversation")),
+			connect.WithClientOptions(opts...),
+		),
 		chat: connect.NewClient[services.ChatRequest, services.ChatResponse](
 			httpClient,
 			baseURL+ConversationServiceChatProcedure,
 			connect.WithSchema(conversationServiceMethods.ByName("Chat")),
 			connect.WithClientOptions(opts...),
 		),
+		chatSession: connect.NewClient[services.ChatSessionRequest, services.ChatSessionResponse](
+			httpClient,
+			baseURL+ConversationServiceChatSessionProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ChatSession")),
+			connect.WithClientOptions(opts...),
+		),
 		submitFeedback: connect.NewClient[services.SubmitFeedbackRequest, services.SubmitFeedbackResponse](
 			httpClient,
 			baseURL+ConversationServiceSubmitFeedbackProcedure,
 			connect.WithSchema(conversationServiceMethods.ByName("SubmitFeedback")),
 			connect.WithClientOptions(opts...),
 		),
+		listFeedback: connect.NewClient[services.ListFeedbackRequest, services.ListFeedbackResponse](
+			httpClient,
+			baseURL+ConversationServiceListFeedbackProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ListFeedback")),
+			connect.WithClientOptions(opts...),
+		),
+		getFeedbackReport: connect.NewClient[services.GetFeedbackReportRequest, services.GetFeedbackReportResponse](
+			httpClient,
+			baseURL+ConversationServiceGetFeedbackReportProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("GetFeedbackReport")),
+			connect.WithClientOptions(opts...),
+		),
+		exportDataset: connect.NewClient[services.ExportDatasetRequest, services.ExportDatasetResponse](
+			httpClient,
+			baseURL+ConversationServiceExportDatasetProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ExportDataset")),
+			connect.WithClientOptions(opts...),
+		),
+		replayTurn: connect.NewClient[services.ReplayTurnRequest, services.ReplayTurnResponse](
+			httpClient,
+			baseURL+ConversationServiceReplayTurnProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ReplayTurn")),
+			connect.WithClientOptions(opts...),
+		),
+		getMessageTrace: connect.NewClient[services.GetMessageTraceRequest, services.GetMessageTraceResponse](
+			httpClient,
+			baseURL+ConversationServiceGetMessageTraceProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("GetMessageTrace")),
+			connect.WithClientOptions(opts...),
+		),
+		listTranscripts: connect.NewClient[services.ListTranscriptsRequest, services.ListTranscriptsResponse](
+			httpClient,
+			baseURL+ConversationServiceListTranscriptsProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("ListTranscripts")),
+			connect.WithClientOptions(opts...),
+		),
+		getTranscript: connect.NewClient[services.GetTranscriptRequest, services.GetTranscriptResponse](
+			httpClient,
+			baseURL+ConversationServiceGetTranscriptProcedure,
+			connect.WithSchema(conversationServiceMethods.ByName("GetTranscript")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // conversationServiceClient implements ConversationServiceClient.
 type conversationServiceClient struct {
-	createConversation *connect.Client[services.CreateConversationRequest, services.CreateConversationResponse]
-	getConversation    *connect.Client[services.GetConversationRequest, services.GetConversationResponse]
-	listConversations  *connect.Client[services.ListConversationsRequest, services.ListConversationsResponse]
-	updateConversation *connect.Client[services.UpdateConversationRequest, services.UpdateConversationResponse]
-	deleteConversation *connect.Client[services.DeleteConversationRequest, services.DeleteConversationResponse]
-	chat               *connect.Client[services.ChatRequest, services.ChatResponse]
-	submitFeedback     *connect.Client[services.SubmitFeedbackRequest, services.SubmitFeedbackResponse]
+	createConversation    *connect.Client[services.CreateConversationRequest, services.CreateConversationResponse]
+	getConversation       *connect.Client[services.GetConversationRequest, services.GetConversationResponse]
+	listConversations     *connect.Client[services.ListConversationsRequest, services.ListConversationsResponse]
+	updateConversation    *connect.Client[services.UpdateConversationRequest, services.UpdateConversationResponse]
+	deleteConversation    *connect.Client[services.DeleteConversationRequest, services.DeleteConversationResponse]
+	restoreConversation   *connect.Client[services.RestoreConversationRequest, services.RestoreConversationResponse]
+	archiveConversation   *connect.Client[services.ArchiveConversationRequest, services.ArchiveConversationResponse]
+	unarchiveConversation *connect.Client[services.UnarchiveConversationRequest, services.UnarchiveConversationResponse]
+	searchConversations   *connect.Client[services.SearchConversationsRequest, services.SearchConversationsResponse]
+	exportConversation    *connect.Client[services.ExportConversationRequest, services.ExportConversationResponse]
+	importConversation    *connect.Client[services.ImportConversationRequest, services.ImportConversationResponse]
+	chat                  *connect.Client[services.ChatRequest, services.ChatResponse]
+	chatSession           *connect.Client[services.ChatSessionRequest, services.ChatSessionResponse]
+	submitFeedback        *connect.Client[services.SubmitFeedbackRequest, services.SubmitFeedbackResponse]
+	listFeedback          *connect.Client[services.ListFeedbackRequest, services.ListFeedbackResponse]
+	getFeedbackReport     *connect.Client[services.GetFeedbackReportRequest, services.GetFeedbackReportResponse]
+	exportDataset         *connect.Client[services.ExportDatasetRequest, services.ExportDatasetResponse]
+	replayTurn            *connect.Client[services.ReplayTurnRequest, services.ReplayTurnResponse]
+	getMessageTrace       *connect.Client[services.GetMessageTraceRequest, services.GetMessageTraceResponse]
+	listTranscripts       *connect.Client[services.ListTranscriptsRequest, services.ListTranscriptsResponse]
+	getTranscript         *connect.Client[services.GetTranscriptRequest, services.GetTranscriptResponse]
 }
 
 // CreateConversation calls schemas.greyseal.services.v1.ConversationService.CreateConversation.
@@ -163,16 +345,87 @@ func (c *conversationServiceClient) DeleteConversation(ctx context.Context, req
 	return c.deleteConversation.CallUnary(ctx, req)
 }
 
+// RestoreConversation calls schemas.greyseal.services.v1.ConversationService.RestoreConversation.
+func (c *conversationServiceClient) RestoreConversation(ctx context.Context, req *connect.Request[services.RestoreConversationRequest]) (*connect.Response[services.RestoreConversationResponse], error) {
+	return c.restoreConversation.CallUnary(ctx, req)
+}
+
+// ArchiveConversation calls schemas.greyseal.services.v1.ConversationService.ArchiveConversation.
+func (c *conversationServiceClient) ArchiveConversation(ctx context.Context, req *connect.Request[services.ArchiveConversationRequest]) (*connect.Response[services.ArchiveConversationResponse], error) {
+	return c.archiveConversation.CallUnary(ctx, req)
+}
+
+// UnarchiveConversation calls
+// schemas.greyseal.services.v1.ConversationService.UnarchiveConversation.
+func (c *conversationServiceClient) UnarchiveConversation(ctx context.Context, req *connect.Request[services.UnarchiveConversationRequest]) (*connect.Response[services.UnarchiveConversationResponse], error) {
+	return c.unarchiveConversation.CallUnary(ctx, req)
+}
+
+// SearchConversations calls schemas.greyseal.services.v1.ConversationService.SearchConversations.
+func (c *conversationServiceClient) SearchConversations(ctx context.Context, req *connect.Request[services.SearchConversationsRequest]) (*connect.Response[services.SearchConversationsResponse], error) {
+	return c.searchConversations.CallUnary(ctx, req)
+}
+
+// ExportConversation calls schemas.greyseal.services.v1.ConversationService.ExportConversation.
+func (c *conversationServiceClient) ExportConversation(ctx context.Context, req *connect.Request[services.ExportConversationRequest]) (*connect.Response[services.ExportConversationResponse], error) {
+	return c.exportConversation.CallUnary(ctx, req)
+}
+
+// ImportConversation calls schemas.greyseal.services.v1.ConversationService.ImportConversation.
+func (c *conversationServiceClient) ImportConversation(ctx context.Context, req *connect.Request[services.ImportConversationRequest]) (*connect.Response[services.ImportConversationResponse], error) {
+	return c.importConversation.CallUnary(ctx, req)
+}
+
 // Chat calls schemas.greyseal.services.v1.ConversationService.Chat.
 func (c *conversationServiceClient) Chat(ctx context.Context, req *connect.Request[services.ChatRequest]) (*connect.ServerStreamForClient[services.ChatResponse], error) {
 	return c.chat.CallServerStream(ctx, req)
 }
 
+// ChatSession calls schemas.greyseal.services.v1.ConversationService.ChatSession.
+func (c *conversationServiceClient) ChatSession(ctx context.Context) *connect.BidiStreamForClient[services.ChatSessionRequest, services.ChatSessionResponse] {
+	return c.chatSession.CallBidiStream(ctx)
+}
+
 // SubmitFeedback calls schemas.greyseal.services.v1.ConversationService.SubmitFeedback.
 func (c *conversationServiceClient) SubmitFeedback(ctx context.Context, req *connect.Request[services.SubmitFeedbackRequest]) (*connect.Response[services.SubmitFeedbackResponse], error) {
 	return c.submitFeedback.CallUnary(ctx, req)
 }
 
+// ListFeedback calls schemas.greyseal.services.v1.ConversationService.ListFeedback.
+func (c *conversationServiceClient) ListFeedback(ctx context.Context, req *connect.Request[services.ListFeedbackRequest]) (*connect.Response[services.ListFeedbackResponse], error) {
+	return c.listFeedback.CallUnary(ctx, req)
+}
+
+// GetFeedbackReport calls schemas.greyseal.services.v1.ConversationService.GetFeedbackReport.
+func (c *conversationServiceClient) GetFeedbackReport(ctx context.Context, req *connect.Request[services.GetFeedbackReportRequest]) (*connect.Response[services.GetFeedbackReportResponse], error) {
+	return c.getFeedbackReport.CallUnary(ctx, req)
+}
+
+// ExportDataset calls schemas.greyseal.services.v1.ConversationService.ExportDataset.
+func (c *conversationServiceClient) ExportDataset(ctx context.Context, req *connect.Request[services.ExportDatasetRequest]) (*connect.ServerStreamForClient[services.ExportDatasetResponse], error) {
+	return c.exportDataset.CallServerStream(ctx, req)
+}
+
+// ReplayTurn calls schemas.greyseal.services.v1.ConversationService.ReplayTurn.
+func (c *conversationServiceClient) ReplayTurn(ctx context.Context, req *connect.Request[services.ReplayTurnRequest]) (*connect.Response[services.ReplayTurnResponse], error) {
+	return c.replayTurn.CallUnary(ctx, req)
+}
+
+// GetMessageTrace calls schemas.greyseal.services.v1.ConversationService.GetMessageTrace.
+func (c *conversationServiceClient) GetMessageTrace(ctx context.Context, req *connect.Request[services.GetMessageTraceRequest]) (*connect.Response[services.GetMessageTraceResponse], error) {
+	return c.getMessageTrace.CallUnary(ctx, req)
+}
+
+// ListTranscripts calls schemas.greyseal.services.v1.ConversationService.ListTranscripts.
+func (c *conversationServiceClient) ListTranscripts(ctx context.Context, req *connect.Request[services.ListTranscriptsRequest]) (*connect.Response[services.ListTranscriptsResponse], error) {
+	return c.listTranscripts.CallUnary(ctx, req)
+}
+
+// GetTranscript calls schemas.greyseal.services.v1.ConversationService.GetTranscript.
+func (c *conversationServiceClient) GetTranscript(ctx context.Context, req *connect.Request[services.GetTranscriptRequest]) (*connect.Response[services.GetTranscriptResponse], error) {
+	return c.getTranscript.CallUnary(ctx, req)
+}
+
 // ConversationServiceHandler is an implementation of the
 // schemas.greyseal.services.v1.ConversationService service.
 type ConversationServiceHandler interface {
@@ -180,11 +433,53 @@ type ConversationServiceHandler interface {
 	GetConversation(context.Context, *connect.Request[services.GetConversationRequest]) (*connect.Response[services.GetConversationResponse], error)
 	ListConversations(context.Context, *connect.Request[services.ListConversationsRequest]) (*connect.Response[services.ListConversationsResponse], error)
 	UpdateConversation(context.Context, *connect.Request[services.UpdateConversationRequest]) (*connect.Response[services.UpdateConversationResponse], error)
+	// DeleteConversation moves a conversation to the trash. It can be restored
+	// with RestoreConversation until the restore window passes.
 	DeleteConversation(context.Context, *connect.Request[services.DeleteConversationRequest]) (*connect.Response[services.DeleteConversationResponse], error)
+	// RestoreConversation undoes DeleteConversation within the restore window.
+	RestoreConversation(context.Context, *connect.Request[services.RestoreConversationRequest]) (*connect.Response[services.RestoreConversationResponse], error)
+	// ArchiveConversation hides a conversation from the default list and
+	// exempts it from retention. UnarchiveConversation reverses it.
+	ArchiveConversation(context.Context, *connect.Request[services.ArchiveConversationRequest]) (*connect.Response[services.ArchiveConversationResponse], error)
+	UnarchiveConversation(context.Context, *connect.Request[services.UnarchiveConversationRequest]) (*connect.Response[services.UnarchiveConversationResponse], error)
+	// SearchConversations finds conversations by full-text search over their
+	// messages, ranked by relevance.
+	SearchConversations(context.Context, *connect.Request[services.SearchConversationsRequest]) (*connect.Response[services.SearchConversationsResponse], error)
+	// ExportConversation returns a portable copy of a conversation, rendered in
+	// the requested format.
+	ExportConversation(context.Context, *connect.Request[services.ExportConversationRequest]) (*connect.Response[services.ExportConversationResponse], error)
+	// ImportConversation recreates an exported conversation, preserving
+	// timestamps and feedback. UUIDs already in use are replaced.
+	ImportConversation(context.Context, *connect.Request[services.ImportConversationRequest]) (*connect.Response[services.ImportConversationResponse], error)
 	// Chat sends a user message and streams back the assistant response token by token.
 	Chat(context.Context, *connect.Request[services.ChatRequest], *connect.ServerStream[services.ChatResponse]) error
+	// ChatSession keeps one conversation open over a long-lived stream. The
+	// client sends messages, stops or regenerates answers and switches role or
+	// scope between turns; the server streams tokens and turn results back.
+	ChatSession(context.Context, *connect.BidiStream[services.ChatSessionRequest, services.ChatSessionResponse]) error
 	// SubmitFeedback records user feedback on an assistant message.
 	SubmitFeedback(context.Context, *connect.Request[services.SubmitFeedbackRequest]) (*connect.Response[services.SubmitFeedbackResponse], error)
+	// ListFeedback returns feedback records, newest first. Callers see their
+	// own feedback; admins see everyone's.
+	ListFeedback(context.Context, *connect.Request[services.ListFeedbackRequest]) (*connect.Response[services.ListFeedbackResponse], error)
+	// GetFeedbackReport aggregates ratings by role, model, cited resource and
+	// time period.
+	GetFeedbackReport(context.Context, *connect.Request[services.GetFeedbackReportRequest]) (*connect.Response[services.GetFeedbackReportResponse], error)
+	// ExportDataset streams rated assistant turns with their prompt context,
+	// newest first.
+	ExportDataset(context.Context, *connect.Request[services.ExportDatasetRequest], *connect.ServerStream[services.ExportDatasetResponse]) error
+	// ReplayTurn re-runs a recorded turn with a different prompt, model or
+	// retrieval setting and returns both responses. Nothing is saved.
+	ReplayTurn(context.Context, *connect.Request[services.ReplayTurnRequest]) (*connect.Response[services.ReplayTurnResponse], error)
+	// GetMessageTrace returns what went into the prompt that produced an
+	// assistant message.
+	GetMessageTrace(context.Context, *connect.Request[services.GetMessageTraceRequest]) (*connect.Response[services.GetMessageTraceResponse], error)
+	// ListTranscripts lists the caller's conversations that have recorded
+	// transcripts.
+	ListTranscripts(context.Context, *connect.Request[services.ListTranscriptsRequest]) (*connect.Response[services.ListTranscriptsResponse], error)
+	// GetTranscript returns a conversation's recorded turns and their combined
+	// Markdown rendering.
+	GetTranscript(context.Context, *connect.Request[services.GetTranscriptRequest]) (*connect.Response[services.GetTranscriptResponse], error)
 }
 
 // NewConversationServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -224,18 +519,102 @@ func NewConversationServiceHandler(svc ConversationServiceHandler, opts ...conne
 		connect.WithSchema(conversationServiceMethods.ByName("DeleteConversation")),
 		connect.WithHandlerOptions(opts...),
 	)
+	conversationServiceRestoreConversationHandler := connect.NewUnaryHandler(
+		ConversationServiceRestoreConversationProcedure,
+		svc.RestoreConversation,
+		connect.WithSchema(conversationServiceMethods.ByName("RestoreConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceArchiveConversationHandler := connect.NewUnaryHandler(
+		ConversationServiceArchiveConversationProcedure,
+		svc.ArchiveConversation,
+		connect.WithSchema(conversationServiceMethods.ByName("ArchiveConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceUnarchiveConversationHandler := connect.NewUnaryHandler(
+		ConversationServiceUnarchiveConversationProcedure,
+		svc.UnarchiveConversation,
+		connect.WithSchema(conversationServiceMethods.ByName("UnarchiveConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceSearchConversationsHandler := connect.NewUnaryHandler(
+		ConversationServiceSearchConversationsProcedure,
+		svc.SearchConversations,
+		connect.WithSchema(conversationServiceMethods.ByName("SearchConversations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceExportConversationHandler := connect.NewUnaryHandler(
+		ConversationServiceExportConversationProcedure,
+		svc.ExportConversation,
+		connect.WithSchema(conversationServiceMethods.ByName("ExportConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceImportConversationHandler := connect.NewUnaryHandler(
+		ConversationServiceImportConversationProcedure,
+		svc.ImportConversation,
+		connect.WithSchema(conversationServiceMethods.ByName("ImportConversation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	conversationServiceChatHandler := connect.NewServerStreamHandler(
 		ConversationServiceChatProcedure,
 		svc.Chat,
 		connect.WithSchema(conversationServiceMethods.ByName("Chat")),
 		connect.WithHandlerOptions(opts...),
 	)
+	conversationServiceChatSessionHandler := connect.NewBidiStreamHandler(
+		ConversationServiceChatSessionProcedure,
+		svc.ChatSession,
+		connect.WithSchema(conversationServiceMethods.ByName("ChatSession")),
+		connect.WithHandlerOptions(opts...),
+	)
 	conversationServiceSubmitFeedbackHandler := connect.NewUnaryHandler(
 		ConversationServiceSubmitFeedbackProcedure,
 		svc.SubmitFeedback,
 		connect.WithSchema(conversationServiceMethods.ByName("SubmitFeedback")),
 		connect.WithHandlerOptions(opts...),
 	)
+	conversationServiceListFeedbackHandler := connect.NewUnaryHandler(
+		ConversationServiceListFeedbackProcedure,
+		svc.ListFeedback,
+		connect.WithSchema(conversationServiceMethods.ByName("ListFeedback")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceGetFeedbackReportHandler := connect.NewUnaryHandler(
+		ConversationServiceGetFeedbackReportProcedure,
+		svc.GetFeedbackReport,
+		connect.WithSchema(conversationServiceMethods.ByName("GetFeedbackReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceExportDatasetHandler := connect.NewServerStreamHandler(
+		ConversationServiceExportDatasetProcedure,
+		svc.ExportDataset,
+		connect.WithSchema(conversationServiceMethods.ByName("ExportDataset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceReplayTurnHandler := connect.NewUnaryHandler(
+		ConversationServiceReplayTurnProcedure,
+		svc.ReplayTurn,
+		connect.WithSchema(conversationServiceMethods.ByName("ReplayTurn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceGetMessageTraceHandler := connect.NewUnaryHandler(
+		ConversationServiceGetMessageTraceProcedure,
+		svc.GetMessageTrace,
+		connect.WithSchema(conversationServiceMethods.ByName("GetMessageTrace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceListTranscriptsHandler := connect.NewUnaryHandler(
+		ConversationServiceListTranscriptsProcedure,
+		svc.ListTranscripts,
+		connect.WithSchema(conversationServiceMethods.ByName("ListTranscripts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	conversationServiceGetTranscriptHandler := connect.NewUnaryHandler(
+		ConversationServiceGetTranscriptProcedure,
+		svc.GetTranscript,
+		connect.WithSchema(conversationServiceMethods.ByName("GetTranscript")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/schemas.greyseal.services.v1.ConversationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ConversationServiceCreateConversationProcedure:
@@ -248,10 +627,38 @@ func NewConversationServiceHandler(svc ConversationServiceHandler, opts ...conne
 			conversationServiceUpdateConversationHandler.ServeHTTP(w, r)
 		case ConversationServiceDeleteConversationProcedure:
 			conversationServiceDeleteConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceRestoreConversationProcedure:
+			conversationServiceRestoreConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceArchiveConversationProcedure:
+			conversationServiceArchiveConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceUnarchiveConversationProcedure:
+			conversationServiceUnarchiveConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceSearchConversationsProcedure:
+			conversationServiceSearchConversationsHandler.ServeHTTP(w, r)
+		case ConversationServiceExportConversationProcedure:
+			conversationServiceExportConversationHandler.ServeHTTP(w, r)
+		case ConversationServiceImportConversationProcedure:
+			conversationServiceImportConversationHandler.ServeHTTP(w, r)
 		case ConversationServiceChatProcedure:
 			conversationServiceChatHandler.ServeHTTP(w, r)
+		case ConversationServiceChatSessionProcedure:
+			conversationServiceChatSessionHandler.ServeHTTP(w, r)
 		case ConversationServiceSubmitFeedbackProcedure:
 			conversationServiceSubmitFeedbackHandler.ServeHTTP(w, r)
+		case ConversationServiceListFeedbackProcedure:
+			conversationServiceListFeedbackHandler.ServeHTTP(w, r)
+		case ConversationServiceGetFeedbackReportProcedure:
+			conversationServiceGetFeedbackReportHandler.ServeHTTP(w, r)
+		case ConversationServiceExportDatasetProcedure:
+			conversationServiceExportDatasetHandler.ServeHTTP(w, r)
+		case ConversationServiceReplayTurnProcedure:
+			conversationServiceReplayTurnHandler.ServeHTTP(w, r)
+		case ConversationServiceGetMessageTraceProcedure:
+			conversationServiceGetMessageTraceHandler.ServeHTTP(w, r)
+		case ConversationServiceListTranscriptsProcedure:
+			conversationServiceListTranscriptsHandler.ServeHTTP(w, r)
+		case ConversationServiceGetTranscriptProcedure:
+			conversationServiceGetTranscriptHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -281,10 +688,66 @@ func (UnimplementedConversationServiceHandler) DeleteConversation(context.Contex
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.DeleteConversation is not implemented"))
 }
 
+func (UnimplementedConversationServiceHandler) RestoreConversation(context.Context, *connect.Request[services.RestoreConversationRequest]) (*connect.Response[services.RestoreConversationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.RestoreConversation is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ArchiveConversation(context.Context, *connect.Request[services.ArchiveConversationRequest]) (*connect.Response[services.ArchiveConversationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ArchiveConversation is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) UnarchiveConversation(context.Context, *connect.Request[services.UnarchiveConversationRequest]) (*connect.Response[services.UnarchiveConversationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.UnarchiveConversation is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) SearchConversations(context.Context, *connect.Request[services.SearchConversationsRequest]) (*connect.Response[services.SearchConversationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.SearchConversations is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ExportConversation(context.Context, *connect.Request[services.ExportConversationRequest]) (*connect.Response[services.ExportConversationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ExportConversation is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ImportConversation(context.Context, *connect.Request[services.ImportConversationRequest]) (*connect.Response[services.ImportConversationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ImportConversation is not implemented"))
+}
+
 func (UnimplementedConversationServiceHandler) Chat(context.Context, *connect.Request[services.ChatRequest], *connect.ServerStream[services.ChatResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.Chat is not implemented"))
 }
 
+func (UnimplementedConversationServiceHandler) ChatSession(context.Context, *connect.BidiStream[services.ChatSessionRequest, services.ChatSessionResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ChatSession is not implemented"))
+}
+
 func (UnimplementedConversationServiceHandler) SubmitFeedback(context.Context, *connect.Request[services.SubmitFeedbackRequest]) (*connect.Response[services.SubmitFeedbackResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.SubmitFeedback is not implemented"))
 }
+
+func (UnimplementedConversationServiceHandler) ListFeedback(context.Context, *connect.Request[services.ListFeedbackRequest]) (*connect.Response[services.ListFeedbackResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ListFeedback is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) GetFeedbackReport(context.Context, *connect.Request[services.GetFeedbackReportRequest]) (*connect.Response[services.GetFeedbackReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.GetFeedbackReport is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ExportDataset(context.Context, *connect.Request[services.ExportDatasetRequest], *connect.ServerStream[services.ExportDatasetResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ExportDataset is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ReplayTurn(context.Context, *connect.Request[services.ReplayTurnRequest]) (*connect.Response[services.ReplayTurnResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ReplayTurn is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) GetMessageTrace(context.Context, *connect.Request[services.GetMessageTraceRequest]) (*connect.Response[services.GetMessageTraceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.GetMessageTrace is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) ListTranscripts(context.Context, *connect.Request[services.ListTranscriptsRequest]) (*connect.Response[services.ListTranscriptsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.ListTranscripts is not implemented"))
+}
+
+func (UnimplementedConversationServiceHandler) GetTranscript(context.Context, *connect.Request[services.GetTranscriptRequest]) (*connect.Response[services.GetTranscriptResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ConversationService.GetTranscript is not implemented"))
+}
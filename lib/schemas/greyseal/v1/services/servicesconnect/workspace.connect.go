@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: schemas/greyseal/v1/services/workspace.proto
+
+package servicesconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WorkspaceServiceName is the fully-qualified name of the WorkspaceService service.
+	WorkspaceServiceName = "schemas.greyseal.services.v1.WorkspaceService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WorkspaceServiceCreateWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// CreateWorkspace RPC.
+	WorkspaceServiceCreateWorkspaceProcedure = "/schemas.greyseal.services.v1.WorkspaceService/CreateWorkspace"
+	// WorkspaceServiceGetWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// GetWorkspace RPC.
+	WorkspaceServiceGetWorkspaceProcedure = "/schemas.greyseal.services.v1.WorkspaceService/GetWorkspace"
+	// WorkspaceServiceListWorkspacesProcedure is the fully-qualified name of the WorkspaceService's
+	// ListWorkspaces RPC.
+	WorkspaceServiceListWorkspacesProcedure = "/schemas.greyseal.services.v1.WorkspaceService/ListWorkspaces"
+	// WorkspaceServiceUpdateWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// UpdateWorkspace RPC.
+	WorkspaceServiceUpdateWorkspaceProcedure = "/schemas.greyseal.services.v1.WorkspaceService/UpdateWorkspace"
+	// WorkspaceServiceDeleteWorkspaceProcedure is the fully-qualified name of the WorkspaceService's
+	// DeleteWorkspace RPC.
+	WorkspaceServiceDeleteWorkspaceProcedure = "/schemas.greyseal.services.v1.WorkspaceService/DeleteWorkspace"
+)
+
+// WorkspaceServiceClient is a client for the schemas.greyseal.services.v1.WorkspaceService service.
+type WorkspaceServiceClient interface {
+	CreateWorkspace(context.Context, *connect.Request[services.CreateWorkspaceRequest]) (*connect.Response[services.CreateWorkspaceResponse], error)
+	GetWorkspace(context.Context, *connect.Request[services.GetWorkspaceRequest]) (*connect.Response[services.GetWorkspaceResponse], error)
+	ListWorkspaces(context.Context, *connect.Request[services.ListWorkspacesRequest]) (*connect.Response[services.ListWorkspacesResponse], error)
+	UpdateWorkspace(context.Context, *connect.Request[services.UpdateWorkspaceRequest]) (*connect.Response[services.UpdateWorkspaceResponse], error)
+	DeleteWorkspace(context.Context, *connect.Request[services.DeleteWorkspaceRequest]) (*connect.Response[services.DeleteWorkspaceResponse], error)
+}
+
+// NewWorkspaceServiceClient constructs a client for the
+// schemas.greyseal.services.v1.WorkspaceService service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWorkspaceServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WorkspaceServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	workspaceServiceMethods := services.File_schemas_greyseal_v1_services_workspace_proto.Services().ByName("WorkspaceService").Methods()
+	return &workspaceServiceClient{
+		createWorkspace: connect.NewClient[services.CreateWorkspaceRequest, services.CreateWorkspaceResponse](
+			httpClient,
+			baseURL+WorkspaceServiceCreateWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("CreateWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		getWorkspace: connect.NewClient[services.GetWorkspaceRequest, services.GetWorkspaceResponse](
+			httpClient,
+			baseURL+WorkspaceServiceGetWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("GetWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		listWorkspaces: connect.NewClient[services.ListWorkspacesRequest, services.ListWorkspacesResponse](
+			httpClient,
+			baseURL+WorkspaceServiceListWorkspacesProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaces")),
+			connect.WithClientOptions(opts...),
+		),
+		updateWorkspace: connect.NewClient[services.UpdateWorkspaceRequest, services.UpdateWorkspaceResponse](
+			httpClient,
+			baseURL+WorkspaceServiceUpdateWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("UpdateWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWorkspace: connect.NewClient[services.DeleteWorkspaceRequest, services.DeleteWorkspaceResponse](
+			httpClient,
+			baseURL+WorkspaceServiceDeleteWorkspaceProcedure,
+			connect.WithSchema(workspaceServiceMethods.ByName("DeleteWorkspace")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// workspaceServiceClient implements WorkspaceServiceClient.
+type workspaceServiceClient struct {
+	createWorkspace *connect.Client[services.CreateWorkspaceRequest, services.CreateWorkspaceResponse]
+	getWorkspace    *connect.Client[services.GetWorkspaceRequest, services.GetWorkspaceResponse]
+	listWorkspaces  *connect.Client[services.ListWorkspacesRequest, services.ListWorkspacesResponse]
+	updateWorkspace *connect.Client[services.UpdateWorkspaceRequest, services.UpdateWorkspaceResponse]
+	deleteWorkspace *connect.Client[services.DeleteWorkspaceRequest, services.DeleteWorkspaceResponse]
+}
+
+// CreateWorkspace calls schemas.greyseal.services.v1.WorkspaceService.CreateWorkspace.
+func (c *workspaceServiceClient) CreateWorkspace(ctx context.Context, req *connect.Request[services.CreateWorkspaceRequest]) (*connect.Response[services.CreateWorkspaceResponse], error) {
+	return c.createWorkspace.CallUnary(ctx, req)
+}
+
+// GetWorkspace calls schemas.greyseal.services.v1.WorkspaceService.GetWorkspace.
+func (c *workspaceServiceClient) GetWorkspace(ctx context.Context, req *connect.Request[services.GetWorkspaceRequest]) (*connect.Response[services.GetWorkspaceResponse], error) {
+	return c.getWorkspace.CallUnary(ctx, req)
+}
+
+// ListWorkspaces calls schemas.greyseal.services.v1.WorkspaceService.ListWorkspaces.
+func (c *workspaceServiceClient) ListWorkspaces(ctx context.Context, req *connect.Request[services.ListWorkspacesRequest]) (*connect.Response[services.ListWorkspacesResponse], error) {
+	return c.listWorkspaces.CallUnary(ctx, req)
+}
+
+// UpdateWorkspace calls schemas.greyseal.services.v1.WorkspaceService.UpdateWorkspace.
+func (c *workspaceServiceClient) UpdateWorkspace(ctx context.Context, req *connect.Request[services.UpdateWorkspaceRequest]) (*connect.Response[services.UpdateWorkspaceResponse], error) {
+	return c.updateWorkspace.CallUnary(ctx, req)
+}
+
+// DeleteWorkspace calls schemas.greyseal.services.v1.WorkspaceService.DeleteWorkspace.
+func (c *workspaceServiceClient) DeleteWorkspace(ctx context.Context, req *connect.Request[services.DeleteWorkspaceRequest]) (*connect.Response[services.DeleteWorkspaceResponse], error) {
+	return c.deleteWorkspace.CallUnary(ctx, req)
+}
+
+// WorkspaceServiceHandler is an implementation of the schemas.greyseal.services.v1.WorkspaceService
+// service.
+type WorkspaceServiceHandler interface {
+	CreateWorkspace(context.Context, *connect.Request[services.CreateWorkspaceRequest]) (*connect.Response[services.CreateWorkspaceResponse], error)
+	GetWorkspace(context.Context, *connect.Request[services.GetWorkspaceRequest]) (*connect.Response[services.GetWorkspaceResponse], error)
+	ListWorkspaces(context.Context, *connect.Request[services.ListWorkspacesRequest]) (*connect.Response[services.ListWorkspacesResponse], error)
+	UpdateWorkspace(context.Context, *connect.Request[services.UpdateWorkspaceRequest]) (*connect.Response[services.UpdateWorkspaceResponse], error)
+	DeleteWorkspace(context.Context, *connect.Request[services.DeleteWorkspaceRequest]) (*connect.Response[services.DeleteWorkspaceResponse], error)
+}
+
+// NewWorkspaceServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWorkspaceServiceHandler(svc WorkspaceServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	workspaceServiceMethods := services.File_schemas_greyseal_v1_services_workspace_proto.Services().ByName("WorkspaceService").Methods()
+	workspaceServiceCreateWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceCreateWorkspaceProcedure,
+		svc.CreateWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("CreateWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceGetWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceGetWorkspaceProcedure,
+		svc.GetWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("GetWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceListWorkspacesHandler := connect.NewUnaryHandler(
+		WorkspaceServiceListWorkspacesProcedure,
+		svc.ListWorkspaces,
+		connect.WithSchema(workspaceServiceMethods.ByName("ListWorkspaces")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceUpdateWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceUpdateWorkspaceProcedure,
+		svc.UpdateWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("UpdateWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	workspaceServiceDeleteWorkspaceHandler := connect.NewUnaryHandler(
+		WorkspaceServiceDeleteWorkspaceProcedure,
+		svc.DeleteWorkspace,
+		connect.WithSchema(workspaceServiceMethods.ByName("DeleteWorkspace")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/schemas.greyseal.services.v1.WorkspaceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WorkspaceServiceCreateWorkspaceProcedure:
+			workspaceServiceCreateWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceGetWorkspaceProcedure:
+			workspaceServiceGetWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceListWorkspacesProcedure:
+			workspaceServiceListWorkspacesHandler.ServeHTTP(w, r)
+		case WorkspaceServiceUpdateWorkspaceProcedure:
+			workspaceServiceUpdateWorkspaceHandler.ServeHTTP(w, r)
+		case WorkspaceServiceDeleteWorkspaceProcedure:
+			workspaceServiceDeleteWorkspaceHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWorkspaceServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWorkspaceServiceHandler struct{}
+
+func (UnimplementedWorkspaceServiceHandler) CreateWorkspace(context.Context, *connect.Request[services.CreateWorkspaceRequest]) (*connect.Response[services.CreateWorkspaceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.WorkspaceService.CreateWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) GetWorkspace(context.Context, *connect.Request[services.GetWorkspaceRequest]) (*connect.Response[services.GetWorkspaceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.WorkspaceService.GetWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) ListWorkspaces(context.Context, *connect.Request[services.ListWorkspacesRequest]) (*connect.Response[services.ListWorkspacesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.WorkspaceService.ListWorkspaces is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) UpdateWorkspace(context.Context, *connect.Request[services.UpdateWorkspaceRequest]) (*connect.Response[services.UpdateWorkspaceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.WorkspaceService.UpdateWorkspace is not implemented"))
+}
+
+func (UnimplementedWorkspaceServiceHandler) DeleteWorkspace(context.Context, *connect.Request[services.DeleteWorkspaceRequest]) (*connect.Response[services.DeleteWorkspaceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.WorkspaceService.DeleteWorkspace is not implemented"))
+}
@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: schemas/greyseal/v1/services/model.proto
+
+package servicesv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	services "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ModelServiceName is the fully-qualified name of the ModelService service.
+	ModelServiceName = "schemas.greyseal.services.v1.ModelService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ModelServiceListModelsProcedure is the fully-qualified name of the ModelService's ListModels RPC.
+	ModelServiceListModelsProcedure = "/schemas.greyseal.services.v1.ModelService/ListModels"
+	// ModelServicePullModelProcedure is the fully-qualified name of the ModelService's PullModel RPC.
+	ModelServicePullModelProcedure = "/schemas.greyseal.services.v1.ModelService/PullModel"
+	// ModelServiceUnloadModelProcedure is the fully-qualified name of the ModelService's UnloadModel
+	// RPC.
+	ModelServiceUnloadModelProcedure = "/schemas.greyseal.services.v1.ModelService/UnloadModel"
+)
+
+// ModelServiceClient is a client for the schemas.greyseal.services.v1.ModelService service.
+type ModelServiceClient interface {
+	ListModels(context.Context, *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error)
+	// PullModel downloads a model into the backend and streams download progress.
+	PullModel(context.Context, *connect.Request[services.PullModelRequest]) (*connect.ServerStreamForClient[services.PullModelResponse], error)
+	// UnloadModel evicts a model from backend memory without deleting it.
+	UnloadModel(context.Context, *connect.Request[services.UnloadModelRequest]) (*connect.Response[services.UnloadModelResponse], error)
+}
+
+// NewModelServiceClient constructs a client for the schemas.greyseal.services.v1.ModelService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewModelServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ModelServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	modelServiceMethods := services.File_schemas_greyseal_v1_services_model_proto.Services().ByName("ModelService").Methods()
+	return &modelServiceClient{
+		listModels: connect.NewClient[services.ListModelsRequest, services.ListModelsResponse](
+			httpClient,
+			baseURL+ModelServiceListModelsProcedure,
+			connect.WithSchema(modelServiceMethods.ByName("ListModels")),
+			connect.WithClientOptions(opts...),
+		),
+		pullModel: connect.NewClient[services.PullModelRequest, services.PullModelResponse](
+			httpClient,
+			baseURL+ModelServicePullModelProcedure,
+			connect.WithSchema(modelServiceMethods.ByName("PullModel")),
+			connect.WithClientOptions(opts...),
+		),
+		unloadModel: connect.NewClient[services.UnloadModelRequest, services.UnloadModelResponse](
+			httpClient,
+			baseURL+ModelServiceUnloadModelProcedure,
+			connect.WithSchema(modelServiceMethods.ByName("UnloadModel")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// modelServiceClient implements ModelServiceClient.
+type modelServiceClient struct {
+	listModels  *connect.Client[services.ListModelsRequest, services.ListModelsResponse]
+	pullModel   *connect.Client[services.PullModelRequest, services.PullModelResponse]
+	unloadModel *connect.Client[services.UnloadModelRequest, services.UnloadModelResponse]
+}
+
+// ListModels calls schemas.greyseal.services.v1.ModelService.ListModels.
+func (c *modelServiceClient) ListModels(ctx context.Context, req *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error) {
+	return c.listModels.CallUnary(ctx, req)
+}
+
+// PullModel calls schemas.greyseal.services.v1.ModelService.PullModel.
+func (c *modelServiceClient) PullModel(ctx context.Context, req *connect.Request[services.PullModelRequest]) (*connect.ServerStreamForClient[services.PullModelResponse], error) {
+	return c.pullModel.CallServerStream(ctx, req)
+}
+
+// UnloadModel calls schemas.greyseal.services.v1.ModelService.UnloadModel.
+func (c *modelServiceClient) UnloadModel(ctx context.Context, req *connect.Request[services.UnloadModelRequest]) (*connect.Response[services.UnloadModelResponse], error) {
+	return c.unloadModel.CallUnary(ctx, req)
+}
+
+// ModelServiceHandler is an implementation of the schemas.greyseal.services.v1.ModelService
+// service.
+type ModelServiceHandler interface {
+	ListModels(context.Context, *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error)
+	// PullModel downloads a model into the backend and streams download progress.
+	PullModel(context.Context, *connect.Request[services.PullModelRequest], *connect.ServerStream[services.PullModelResponse]) error
+	// UnloadModel evicts a model from backend memory without deleting it.
+	UnloadModel(context.Context, *connect.Request[services.UnloadModelRequest]) (*connect.Response[services.UnloadModelResponse], error)
+}
+
+// NewModelServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewModelServiceHandler(svc ModelServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	modelServiceMethods := services.File_schemas_greyseal_v1_services_model_proto.Services().ByName("ModelService").Methods()
+	modelServiceListModelsHandler := connect.NewUnaryHandler(
+		ModelServiceListModelsProcedure,
+		svc.ListModels,
+		connect.WithSchema(modelServiceMethods.ByName("ListModels")),
+		connect.WithHandlerOptions(opts...),
+	)
+	modelServicePullModelHandler := connect.NewServerStreamHandler(
+		ModelServicePullModelProcedure,
+		svc.PullModel,
+		connect.WithSchema(modelServiceMethods.ByName("PullModel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	modelServiceUnloadModelHandler := connect.NewUnaryHandler(
+		ModelServiceUnloadModelProcedure,
+		svc.UnloadModel,
+		connect.WithSchema(modelServiceMethods.ByName("UnloadModel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/schemas.greyseal.services.v1.ModelService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ModelServiceListModelsProcedure:
+			modelServiceListModelsHandler.ServeHTTP(w, r)
+		case ModelServicePullModelProcedure:
+			modelServicePullModelHandler.ServeHTTP(w, r)
+		case ModelServiceUnloadModelProcedure:
+			modelServiceUnloadModelHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedModelServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedModelServiceHandler struct{}
+
+func (UnimplementedModelServiceHandler) ListModels(context.Context, *connect.Request[services.ListModelsRequest]) (*connect.Response[services.ListModelsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ModelService.ListModels is not implemented"))
+}
+
+func (UnimplementedModelServiceHandler) PullModel(context.Context, *connect.Request[services.PullModelRequest], *connect.ServerStream[services.PullModelResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ModelService.PullModel is not implemented"))
+}
+
+func (UnimplementedModelServiceHandler) UnloadModel(context.Context, *connect.Request[services.UnloadModelRequest]) (*connect.Response[services.UnloadModelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.ModelService.UnloadModel is not implemented"))
+}
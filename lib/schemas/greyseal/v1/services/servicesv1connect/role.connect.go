@@ -43,6 +43,18 @@ const (
 	RoleServiceUpdateRoleProcedure = "/schemas.greyseal.services.v1.RoleService/UpdateRole"
 	// RoleServiceDeleteRoleProcedure is the fully-qualified name of the RoleService's DeleteRole RPC.
 	RoleServiceDeleteRoleProcedure = "/schemas.greyseal.services.v1.RoleService/DeleteRole"
+	// RoleServiceListRoleVersionsProcedure is the fully-qualified name of the RoleService's
+	// ListRoleVersions RPC.
+	RoleServiceListRoleVersionsProcedure = "/schemas.greyseal.services.v1.RoleService/ListRoleVersions"
+	// RoleServiceGetRoleVersionProcedure is the fully-qualified name of the RoleService's
+	// GetRoleVersion RPC.
+	RoleServiceGetRoleVersionProcedure = "/schemas.greyseal.services.v1.RoleService/GetRoleVersion"
+	// RoleServiceDiffRoleVersionsProcedure is the fully-qualified name of the RoleService's
+	// DiffRoleVersions RPC.
+	RoleServiceDiffRoleVersionsProcedure = "/schemas.greyseal.services.v1.RoleService/DiffRoleVersions"
+	// RoleServiceRollbackRoleProcedure is the fully-qualified name of the RoleService's RollbackRole
+	// RPC.
+	RoleServiceRollbackRoleProcedure = "/schemas.greyseal.services.v1.RoleService/RollbackRole"
 )
 
 // RoleServiceClient is a client for the schemas.greyseal.services.v1.RoleService service.
@@ -52,6 +64,13 @@ type RoleServiceClient interface {
 	ListRoles(context.Context, *connect.Request[services.ListRolesRequest]) (*connect.Response[services.ListRolesResponse], error)
 	UpdateRole(context.Context, *connect.Request[services.UpdateRoleRequest]) (*connect.Response[services.UpdateRoleResponse], error)
 	DeleteRole(context.Context, *connect.Request[services.DeleteRoleRequest]) (*connect.Response[services.DeleteRoleResponse], error)
+	// ListRoleVersions returns every version of a role, newest first.
+	ListRoleVersions(context.Context, *connect.Request[services.ListRoleVersionsRequest]) (*connect.Response[services.ListRoleVersionsResponse], error)
+	GetRoleVersion(context.Context, *connect.Request[services.GetRoleVersionRequest]) (*connect.Response[services.GetRoleVersionResponse], error)
+	// DiffRoleVersions compares the name and system prompt of two versions.
+	DiffRoleVersions(context.Context, *connect.Request[services.DiffRoleVersionsRequest]) (*connect.Response[services.DiffRoleVersionsResponse], error)
+	// RollbackRole writes a new version with the content of an earlier one.
+	RollbackRole(context.Context, *connect.Request[services.RollbackRoleRequest]) (*connect.Response[services.RollbackRoleResponse], error)
 }
 
 // NewRoleServiceClient constructs a client for the schemas.greyseal.services.v1.RoleService
@@ -95,16 +114,44 @@ func NewRoleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(roleServiceMethods.ByName("DeleteRole")),
 			connect.WithClientOptions(opts...),
 		),
+		listRoleVersions: connect.NewClient[services.ListRoleVersionsRequest, services.ListRoleVersionsResponse](
+			httpClient,
+			baseURL+RoleServiceListRoleVersionsProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("ListRoleVersions")),
+			connect.WithClientOptions(opts...),
+		),
+		getRoleVersion: connect.NewClient[services.GetRoleVersionRequest, services.GetRoleVersionResponse](
+			httpClient,
+			baseURL+RoleServiceGetRoleVersionProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("GetRoleVersion")),
+			connect.WithClientOptions(opts...),
+		),
+		diffRoleVersions: connect.NewClient[services.DiffRoleVersionsRequest, services.DiffRoleVersionsResponse](
+			httpClient,
+			baseURL+RoleServiceDiffRoleVersionsProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("DiffRoleVersions")),
+			connect.WithClientOptions(opts...),
+		),
+		rollbackRole: connect.NewClient[services.RollbackRoleRequest, services.RollbackRoleResponse](
+			httpClient,
+			baseURL+RoleServiceRollbackRoleProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("RollbackRole")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // roleServiceClient implements RoleServiceClient.
 type roleServiceClient struct {
-	createRole *connect.Client[services.CreateRoleRequest, services.CreateRoleResponse]
-	getRole    *connect.Client[services.GetRoleRequest, services.GetRoleResponse]
-	listRoles  *connect.Client[services.ListRolesRequest, services.ListRolesResponse]
-	updateRole *connect.Client[services.UpdateRoleRequest, services.UpdateRoleResponse]
-	deleteRole *connect.Client[services.DeleteRoleRequest, services.DeleteRoleResponse]
+	createRole       *connect.Client[services.CreateRoleRequest, services.CreateRoleResponse]
+	getRole          *connect.Client[services.GetRoleRequest, services.GetRoleResponse]
+	listRoles        *connect.Client[services.ListRolesRequest, services.ListRolesResponse]
+	updateRole       *connect.Client[services.UpdateRoleRequest, services.UpdateRoleResponse]
+	deleteRole       *connect.Client[services.DeleteRoleRequest, services.DeleteRoleResponse]
+	listRoleVersions *connect.Client[services.ListRoleVersionsRequest, services.ListRoleVersionsResponse]
+	getRoleVersion   *connect.Client[services.GetRoleVersionRequest, services.GetRoleVersionResponse]
+	diffRoleVersions *connect.Client[services.DiffRoleVersionsRequest, services.DiffRoleVersionsResponse]
+	rollbackRole     *connect.Client[services.RollbackRoleRequest, services.RollbackRoleResponse]
 }
 
 // CreateRole calls schemas.greyseal.services.v1.RoleService.CreateRole.
@@ -132,6 +179,26 @@ func (c *roleServiceClient) DeleteRole(ctx context.Context, req *connect.Request
 	return c.deleteRole.CallUnary(ctx, req)
 }
 
+// ListRoleVersions calls schemas.greyseal.services.v1.RoleService.ListRoleVersions.
+func (c *roleServiceClient) ListRoleVersions(ctx context.Context, req *connect.Request[services.ListRoleVersionsRequest]) (*connect.Response[services.ListRoleVersionsResponse], error) {
+	return c.listRoleVersions.CallUnary(ctx, req)
+}
+
+// GetRoleVersion calls schemas.greyseal.services.v1.RoleService.GetRoleVersion.
+func (c *roleServiceClient) GetRoleVersion(ctx context.Context, req *connect.Request[services.GetRoleVersionRequest]) (*connect.Response[services.GetRoleVersionResponse], error) {
+	return c.getRoleVersion.CallUnary(ctx, req)
+}
+
+// DiffRoleVersions calls schemas.greyseal.services.v1.RoleService.DiffRoleVersions.
+func (c *roleServiceClient) DiffRoleVersions(ctx context.Context, req *connect.Request[services.DiffRoleVersionsRequest]) (*connect.Response[services.DiffRoleVersionsResponse], error) {
+	return c.diffRoleVersions.CallUnary(ctx, req)
+}
+
+// RollbackRole calls schemas.greyseal.services.v1.RoleService.RollbackRole.
+func (c *roleServiceClient) RollbackRole(ctx context.Context, req *connect.Request[services.RollbackRoleRequest]) (*connect.Response[services.RollbackRoleResponse], error) {
+	return c.rollbackRole.CallUnary(ctx, req)
+}
+
 // RoleServiceHandler is an implementation of the schemas.greyseal.services.v1.RoleService service.
 type RoleServiceHandler interface {
 	CreateRole(context.Context, *connect.Request[services.CreateRoleRequest]) (*connect.Response[services.CreateRoleResponse], error)
@@ -139,6 +206,13 @@ type RoleServiceHandler interface {
 	ListRoles(context.Context, *connect.Request[services.ListRolesRequest]) (*connect.Response[services.ListRolesResponse], error)
 	UpdateRole(context.Context, *connect.Request[services.UpdateRoleRequest]) (*connect.Response[services.UpdateRoleResponse], error)
 	DeleteRole(context.Context, *connect.Request[services.DeleteRoleRequest]) (*connect.Response[services.DeleteRoleResponse], error)
+	// ListRoleVersions returns every version of a role, newest first.
+	ListRoleVersions(context.Context, *connect.Request[services.ListRoleVersionsRequest]) (*connect.Response[services.ListRoleVersionsResponse], error)
+	GetRoleVersion(context.Context, *connect.Request[services.GetRoleVersionRequest]) (*connect.Response[services.GetRoleVersionResponse], error)
+	// DiffRoleVersions compares the name and system prompt of two versions.
+	DiffRoleVersions(context.Context, *connect.Request[services.DiffRoleVersionsRequest]) (*connect.Response[services.DiffRoleVersionsResponse], error)
+	// RollbackRole writes a new version with the content of an earlier one.
+	RollbackRole(context.Context, *connect.Request[services.RollbackRoleRequest]) (*connect.Response[services.RollbackRoleResponse], error)
 }
 
 // NewRoleServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -178,6 +252,30 @@ func NewRoleServiceHandler(svc RoleServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(roleServiceMethods.ByName("DeleteRole")),
 		connect.WithHandlerOptions(opts...),
 	)
+	roleServiceListRoleVersionsHandler := connect.NewUnaryHandler(
+		RoleServiceListRoleVersionsProcedure,
+		svc.ListRoleVersions,
+		connect.WithSchema(roleServiceMethods.ByName("ListRoleVersions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceGetRoleVersionHandler := connect.NewUnaryHandler(
+		RoleServiceGetRoleVersionProcedure,
+		svc.GetRoleVersion,
+		connect.WithSchema(roleServiceMethods.ByName("GetRoleVersion")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceDiffRoleVersionsHandler := connect.NewUnaryHandler(
+		RoleServiceDiffRoleVersionsProcedure,
+		svc.DiffRoleVersions,
+		connect.WithSchema(roleServiceMethods.ByName("DiffRoleVersions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceRollbackRoleHandler := connect.NewUnaryHandler(
+		RoleServiceRollbackRoleProcedure,
+		svc.RollbackRole,
+		connect.WithSchema(roleServiceMethods.ByName("RollbackRole")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/schemas.greyseal.services.v1.RoleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RoleServiceCreateRoleProcedure:
@@ -190,6 +288,14 @@ func NewRoleServiceHandler(svc RoleServiceHandler, opts ...connect.HandlerOption
 			roleServiceUpdateRoleHandler.ServeHTTP(w, r)
 		case RoleServiceDeleteRoleProcedure:
 			roleServiceDeleteRoleHandler.ServeHTTP(w, r)
+		case RoleServiceListRoleVersionsProcedure:
+			roleServiceListRoleVersionsHandler.ServeHTTP(w, r)
+		case RoleServiceGetRoleVersionProcedure:
+			roleServiceGetRoleVersionHandler.ServeHTTP(w, r)
+		case RoleServiceDiffRoleVersionsProcedure:
+			roleServiceDiffRoleVersionsHandler.ServeHTTP(w, r)
+		case RoleServiceRollbackRoleProcedure:
+			roleServiceRollbackRoleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -218,3 +324,19 @@ func (UnimplementedRoleServiceHandler) UpdateRole(context.Context, *connect.Requ
 func (UnimplementedRoleServiceHandler) DeleteRole(context.Context, *connect.Request[services.DeleteRoleRequest]) (*connect.Response[services.DeleteRoleResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.RoleService.DeleteRole is not implemented"))
 }
+
+func (UnimplementedRoleServiceHandler) ListRoleVersions(context.Context, *connect.Request[services.ListRoleVersionsRequest]) (*connect.Response[services.ListRoleVersionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.RoleService.ListRoleVersions is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) GetRoleVersion(context.Context, *connect.Request[services.GetRoleVersionRequest]) (*connect.Response[services.GetRoleVersionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.RoleService.GetRoleVersion is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) DiffRoleVersions(context.Context, *connect.Request[services.DiffRoleVersionsRequest]) (*connect.Response[services.DiffRoleVersionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.RoleService.DiffRoleVersions is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) RollbackRole(context.Context, *connect.Request[services.RollbackRoleRequest]) (*connect.Response[services.RollbackRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schemas.greyseal.services.v1.RoleService.RollbackRole is not implemented"))
+}
@@ -0,0 +1,604 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/services/workspace.proto
+
+package servicesv1
+
+import (
+	v1 "github.com/holmes89/grey-seal/lib/schemas/greyseal/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Workspace          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateWorkspaceRequest) GetData() *v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CreateWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Workspace          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceResponse) Reset() {
+	*x = CreateWorkspaceResponse{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceResponse) ProtoMessage() {}
+
+func (x *CreateWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateWorkspaceResponse) GetData() *v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceRequest) Reset() {
+	*x = GetWorkspaceRequest{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceRequest) ProtoMessage() {}
+
+func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWorkspaceRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type GetWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Workspace          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceResponse) Reset() {
+	*x = GetWorkspaceResponse{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceResponse) ProtoMessage() {}
+
+func (x *GetWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWorkspaceResponse) GetData() *v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListWorkspacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the page size (default 50, max 200).
+	Count *int32 `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	// cursor is the opaque cursor returned with the previous page.
+	Cursor        *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListWorkspacesRequest) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ListWorkspacesRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListWorkspacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Data []*v1.Workspace `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// cursor fetches the next page; empty on the last page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListWorkspacesResponse) GetData() []*v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListWorkspacesResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListWorkspacesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UpdateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data          *v1.Workspace          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWorkspaceRequest) Reset() {
+	*x = UpdateWorkspaceRequest{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWorkspaceRequest) ProtoMessage() {}
+
+func (x *UpdateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateWorkspaceRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateWorkspaceRequest) GetData() *v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *v1.Workspace          `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWorkspaceResponse) Reset() {
+	*x = UpdateWorkspaceResponse{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWorkspaceResponse) ProtoMessage() {}
+
+func (x *UpdateWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateWorkspaceResponse) GetData() *v1.Workspace {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkspaceRequest) Reset() {
+	*x = DeleteWorkspaceRequest{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkspaceRequest) ProtoMessage() {}
+
+func (x *DeleteWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteWorkspaceRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWorkspaceResponse) Reset() {
+	*x = DeleteWorkspaceResponse{}
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkspaceResponse) ProtoMessage() {}
+
+func (x *DeleteWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_services_workspace_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP(), []int{9}
+}
+
+var File_schemas_greyseal_v1_services_workspace_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_services_workspace_proto_rawDesc = "" +
+	"\n" +
+	",schemas/greyseal/v1/services/workspace.proto\x12\x1cschemas.greyseal.services.v1\x1a#schemas/greyseal/v1/workspace.proto\"L\n" +
+	"\x16CreateWorkspaceRequest\x122\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\"M\n" +
+	"\x17CreateWorkspaceResponse\x122\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\")\n" +
+	"\x13GetWorkspaceRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"J\n" +
+	"\x14GetWorkspaceResponse\x122\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\"d\n" +
+	"\x15ListWorkspacesRequest\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_countB\t\n" +
+	"\a_cursor\"z\n" +
+	"\x16ListWorkspacesResponse\x122\n" +
+	"\x04data\x18\x01 \x03(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"`\n" +
+	"\x16UpdateWorkspaceRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x122\n" +
+	"\x04data\x18\x02 \x01(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\"M\n" +
+	"\x17UpdateWorkspaceResponse\x122\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.schemas.greyseal.v1.WorkspaceR\x04data\",\n" +
+	"\x16DeleteWorkspaceRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x19\n" +
+	"\x17DeleteWorkspaceResponse2\x93\x05\n" +
+	"\x10WorkspaceService\x12\x80\x01\n" +
+	"\x0fCreateWorkspace\x124.schemas.greyseal.services.v1.CreateWorkspaceRequest\x1a5.schemas.greyseal.services.v1.CreateWorkspaceResponse\"\x00\x12w\n" +
+	"\fGetWorkspace\x121.schemas.greyseal.services.v1.GetWorkspaceRequest\x1a2.schemas.greyseal.services.v1.GetWorkspaceResponse\"\x00\x12}\n" +
+	"\x0eListWorkspaces\x123.schemas.greyseal.services.v1.ListWorkspacesRequest\x1a4.schemas.greyseal.services.v1.ListWorkspacesResponse\"\x00\x12\x80\x01\n" +
+	"\x0fUpdateWorkspace\x124.schemas.greyseal.services.v1.UpdateWorkspaceRequest\x1a5.schemas.greyseal.services.v1.UpdateWorkspaceResponse\"\x00\x12\x80\x01\n" +
+	"\x0fDeleteWorkspace\x124.schemas.greyseal.services.v1.DeleteWorkspaceRequest\x1a5.schemas.greyseal.services.v1.DeleteWorkspaceResponse\"\x00B\x90\x02\n" +
+	" com.schemas.greyseal.services.v1B\x0eWorkspaceProtoP\x01ZIgithub.com/holmes89/grey-seal/lib/schemas/greyseal/v1/services;servicesv1\xa2\x02\x03SGS\xaa\x02\x1cSchemas.Greyseal.Services.V1\xca\x02\x1cSchemas\\Greyseal\\Services\\V1\xe2\x02(Schemas\\Greyseal\\Services\\V1\\GPBMetadata\xea\x02\x1fSchemas::Greyseal::Services::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_services_workspace_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_services_workspace_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_services_workspace_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_services_workspace_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_services_workspace_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_workspace_proto_rawDesc), len(file_schemas_greyseal_v1_services_workspace_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_services_workspace_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_services_workspace_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_schemas_greyseal_v1_services_workspace_proto_goTypes = []any{
+	(*CreateWorkspaceRequest)(nil),  // 0: schemas.greyseal.services.v1.CreateWorkspaceRequest
+	(*CreateWorkspaceResponse)(nil), // 1: schemas.greyseal.services.v1.CreateWorkspaceResponse
+	(*GetWorkspaceRequest)(nil),     // 2: schemas.greyseal.services.v1.GetWorkspaceRequest
+	(*GetWorkspaceResponse)(nil),    // 3: schemas.greyseal.services.v1.GetWorkspaceResponse
+	(*ListWorkspacesRequest)(nil),   // 4: schemas.greyseal.services.v1.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),  // 5: schemas.greyseal.services.v1.ListWorkspacesResponse
+	(*UpdateWorkspaceRequest)(nil),  // 6: schemas.greyseal.services.v1.UpdateWorkspaceRequest
+	(*UpdateWorkspaceResponse)(nil), // 7: schemas.greyseal.services.v1.UpdateWorkspaceResponse
+	(*DeleteWorkspaceRequest)(nil),  // 8: schemas.greyseal.services.v1.DeleteWorkspaceRequest
+	(*DeleteWorkspaceResponse)(nil), // 9: schemas.greyseal.services.v1.DeleteWorkspaceResponse
+	(*v1.Workspace)(nil),            // 10: schemas.greyseal.v1.Workspace
+}
+var file_schemas_greyseal_v1_services_workspace_proto_depIdxs = []int32{
+	10, // 0: schemas.greyseal.services.v1.CreateWorkspaceRequest.data:type_name -> schemas.greyseal.v1.Workspace
+	10, // 1: schemas.greyseal.services.v1.CreateWorkspaceResponse.data:type_name -> schemas.greyseal.v1.Workspace
+	10, // 2: schemas.greyseal.services.v1.GetWorkspaceResponse.data:type_name -> schemas.greyseal.v1.Workspace
+	10, // 3: schemas.greyseal.services.v1.ListWorkspacesResponse.data:type_name -> schemas.greyseal.v1.Workspace
+	10, // 4: schemas.greyseal.services.v1.UpdateWorkspaceRequest.data:type_name -> schemas.greyseal.v1.Workspace
+	10, // 5: schemas.greyseal.services.v1.UpdateWorkspaceResponse.data:type_name -> schemas.greyseal.v1.Workspace
+	0,  // 6: schemas.greyseal.services.v1.WorkspaceService.CreateWorkspace:input_type -> schemas.greyseal.services.v1.CreateWorkspaceRequest
+	2,  // 7: schemas.greyseal.services.v1.WorkspaceService.GetWorkspace:input_type -> schemas.greyseal.services.v1.GetWorkspaceRequest
+	4,  // 8: schemas.greyseal.services.v1.WorkspaceService.ListWorkspaces:input_type -> schemas.greyseal.services.v1.ListWorkspacesRequest
+	6,  // 9: schemas.greyseal.services.v1.WorkspaceService.UpdateWorkspace:input_type -> schemas.greyseal.services.v1.UpdateWorkspaceRequest
+	8,  // 10: schemas.greyseal.services.v1.WorkspaceService.DeleteWorkspace:input_type -> schemas.greyseal.services.v1.DeleteWorkspaceRequest
+	1,  // 11: schemas.greyseal.services.v1.WorkspaceService.CreateWorkspace:output_type -> schemas.greyseal.services.v1.CreateWorkspaceResponse
+	3,  // 12: schemas.greyseal.services.v1.WorkspaceService.GetWorkspace:output_type -> schemas.greyseal.services.v1.GetWorkspaceResponse
+	5,  // 13: schemas.greyseal.services.v1.WorkspaceService.ListWorkspaces:output_type -> schemas.greyseal.services.v1.ListWorkspacesResponse
+	7,  // 14: schemas.greyseal.services.v1.WorkspaceService.UpdateWorkspace:output_type -> schemas.greyseal.services.v1.UpdateWorkspaceResponse
+	9,  // 15: schemas.greyseal.services.v1.WorkspaceService.DeleteWorkspace:output_type -> schemas.greyseal.services.v1.DeleteWorkspaceResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_services_workspace_proto_init() }
+func file_schemas_greyseal_v1_services_workspace_proto_init() {
+	if File_schemas_greyseal_v1_services_workspace_proto != nil {
+		return
+	}
+	file_schemas_greyseal_v1_services_workspace_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_services_workspace_proto_rawDesc), len(file_schemas_greyseal_v1_services_workspace_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schemas_greyseal_v1_services_workspace_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_services_workspace_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_services_workspace_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_services_workspace_proto = out.File
+	file_schemas_greyseal_v1_services_workspace_proto_goTypes = nil
+	file_schemas_greyseal_v1_services_workspace_proto_depIdxs = nil
+}
@@ -0,0 +1,361 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/trace.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TraceTimings is how long each phase of a chat turn took, in milliseconds.
+type TraceTimings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// load covers the conversation, role and message history.
+	LoadMs int64 `protobuf:"varint,1,opt,name=load_ms,json=loadMs,proto3" json:"load_ms,omitempty"`
+	// summarize is zero unless the history overflowed and was summarised.
+	SummarizeMs int64 `protobuf:"varint,2,opt,name=summarize_ms,json=summarizeMs,proto3" json:"summarize_ms,omitempty"`
+	RetrievalMs int64 `protobuf:"varint,3,opt,name=retrieval_ms,json=retrievalMs,proto3" json:"retrieval_ms,omitempty"`
+	// first_token is measured from the start of the LLM call.
+	FirstTokenMs  int64 `protobuf:"varint,4,opt,name=first_token_ms,json=firstTokenMs,proto3" json:"first_token_ms,omitempty"`
+	GenerationMs  int64 `protobuf:"varint,5,opt,name=generation_ms,json=generationMs,proto3" json:"generation_ms,omitempty"`
+	TotalMs       int64 `protobuf:"varint,6,opt,name=total_ms,json=totalMs,proto3" json:"total_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceTimings) Reset() {
+	*x = TraceTimings{}
+	mi := &file_schemas_greyseal_v1_trace_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceTimings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceTimings) ProtoMessage() {}
+
+func (x *TraceTimings) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_trace_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceTimings.ProtoReflect.Descriptor instead.
+func (*TraceTimings) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_trace_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TraceTimings) GetLoadMs() int64 {
+	if x != nil {
+		return x.LoadMs
+	}
+	return 0
+}
+
+func (x *TraceTimings) GetSummarizeMs() int64 {
+	if x != nil {
+		return x.SummarizeMs
+	}
+	return 0
+}
+
+func (x *TraceTimings) GetRetrievalMs() int64 {
+	if x != nil {
+		return x.RetrievalMs
+	}
+	return 0
+}
+
+func (x *TraceTimings) GetFirstTokenMs() int64 {
+	if x != nil {
+		return x.FirstTokenMs
+	}
+	return 0
+}
+
+func (x *TraceTimings) GetGenerationMs() int64 {
+	if x != nil {
+		return x.GenerationMs
+	}
+	return 0
+}
+
+func (x *TraceTimings) GetTotalMs() int64 {
+	if x != nil {
+		return x.TotalMs
+	}
+	return 0
+}
+
+// TurnTrace records exactly what went into the prompt that produced an
+// assistant message.
+type TurnTrace struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MessageUuid      string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	ConversationUuid string                 `protobuf:"bytes,2,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	RoleUuid         string                 `protobuf:"bytes,3,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	Model            string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	SearchQuery      string                 `protobuf:"bytes,5,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	// results are the search results injected into the prompt, in rank order.
+	Results []*DatasetSnippet `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	// summary is the conversation summary the prompt included, if any.
+	Summary string `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	// history_depth is how many earlier messages the prompt included.
+	HistoryDepth int32 `protobuf:"varint,8,opt,name=history_depth,json=historyDepth,proto3" json:"history_depth,omitempty"`
+	// messages are the assembled prompt, as sent to the LLM.
+	Messages []*DatasetMessage `protobuf:"bytes,9,rep,name=messages,proto3" json:"messages,omitempty"`
+	// options are the generation settings: the retrieval limit and whatever
+	// the LLM reports, such as Ollama's think and keep_alive.
+	Options       map[string]string      `protobuf:"bytes,10,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timings       *TraceTimings          `protobuf:"bytes,11,opt,name=timings,proto3" json:"timings,omitempty"`
+	Owner         string                 `protobuf:"bytes,12,opt,name=owner,proto3" json:"owner,omitempty"`
+	WorkspaceUuid string                 `protobuf:"bytes,13,opt,name=workspace_uuid,json=workspaceUuid,proto3" json:"workspace_uuid,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TurnTrace) Reset() {
+	*x = TurnTrace{}
+	mi := &file_schemas_greyseal_v1_trace_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TurnTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TurnTrace) ProtoMessage() {}
+
+func (x *TurnTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_trace_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TurnTrace.ProtoReflect.Descriptor instead.
+func (*TurnTrace) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_trace_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TurnTrace) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetSearchQuery() string {
+	if x != nil {
+		return x.SearchQuery
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetResults() []*DatasetSnippet {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *TurnTrace) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetHistoryDepth() int32 {
+	if x != nil {
+		return x.HistoryDepth
+	}
+	return 0
+}
+
+func (x *TurnTrace) GetMessages() []*DatasetMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *TurnTrace) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *TurnTrace) GetTimings() *TraceTimings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+func (x *TurnTrace) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetWorkspaceUuid() string {
+	if x != nil {
+		return x.WorkspaceUuid
+	}
+	return ""
+}
+
+func (x *TurnTrace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_trace_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_trace_proto_rawDesc = "" +
+	"\n" +
+	"\x1fschemas/greyseal/v1/trace.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!schemas/greyseal/v1/dataset.proto\"\xd3\x01\n" +
+	"\fTraceTimings\x12\x17\n" +
+	"\aload_ms\x18\x01 \x01(\x03R\x06loadMs\x12!\n" +
+	"\fsummarize_ms\x18\x02 \x01(\x03R\vsummarizeMs\x12!\n" +
+	"\fretrieval_ms\x18\x03 \x01(\x03R\vretrievalMs\x12$\n" +
+	"\x0efirst_token_ms\x18\x04 \x01(\x03R\ffirstTokenMs\x12#\n" +
+	"\rgeneration_ms\x18\x05 \x01(\x03R\fgenerationMs\x12\x19\n" +
+	"\btotal_ms\x18\x06 \x01(\x03R\atotalMs\"\xa8\x05\n" +
+	"\tTurnTrace\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x12+\n" +
+	"\x11conversation_uuid\x18\x02 \x01(\tR\x10conversationUuid\x12\x1b\n" +
+	"\trole_uuid\x18\x03 \x01(\tR\broleUuid\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12!\n" +
+	"\fsearch_query\x18\x05 \x01(\tR\vsearchQuery\x12=\n" +
+	"\aresults\x18\x06 \x03(\v2#.schemas.greyseal.v1.DatasetSnippetR\aresults\x12\x18\n" +
+	"\asummary\x18\a \x01(\tR\asummary\x12#\n" +
+	"\rhistory_depth\x18\b \x01(\x05R\fhistoryDepth\x12?\n" +
+	"\bmessages\x18\t \x03(\v2#.schemas.greyseal.v1.DatasetMessageR\bmessages\x12E\n" +
+	"\aoptions\x18\n" +
+	" \x03(\v2+.schemas.greyseal.v1.TurnTrace.OptionsEntryR\aoptions\x12;\n" +
+	"\atimings\x18\v \x01(\v2!.schemas.greyseal.v1.TraceTimingsR\atimings\x12\x14\n" +
+	"\x05owner\x18\f \x01(\tR\x05owner\x12%\n" +
+	"\x0eworkspace_uuid\x18\r \x01(\tR\rworkspaceUuid\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a:\n" +
+	"\fOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\xd5\x01\n" +
+	"\x17com.schemas.greyseal.v1B\n" +
+	"TraceProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_trace_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_trace_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_trace_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_trace_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_trace_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_trace_proto_rawDesc), len(file_schemas_greyseal_v1_trace_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_trace_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_trace_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_schemas_greyseal_v1_trace_proto_goTypes = []any{
+	(*TraceTimings)(nil),          // 0: schemas.greyseal.v1.TraceTimings
+	(*TurnTrace)(nil),             // 1: schemas.greyseal.v1.TurnTrace
+	nil,                           // 2: schemas.greyseal.v1.TurnTrace.OptionsEntry
+	(*DatasetSnippet)(nil),        // 3: schemas.greyseal.v1.DatasetSnippet
+	(*DatasetMessage)(nil),        // 4: schemas.greyseal.v1.DatasetMessage
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_schemas_greyseal_v1_trace_proto_depIdxs = []int32{
+	3, // 0: schemas.greyseal.v1.TurnTrace.results:type_name -> schemas.greyseal.v1.DatasetSnippet
+	4, // 1: schemas.greyseal.v1.TurnTrace.messages:type_name -> schemas.greyseal.v1.DatasetMessage
+	2, // 2: schemas.greyseal.v1.TurnTrace.options:type_name -> schemas.greyseal.v1.TurnTrace.OptionsEntry
+	0, // 3: schemas.greyseal.v1.TurnTrace.timings:type_name -> schemas.greyseal.v1.TraceTimings
+	5, // 4: schemas.greyseal.v1.TurnTrace.created_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_trace_proto_init() }
+func file_schemas_greyseal_v1_trace_proto_init() {
+	if File_schemas_greyseal_v1_trace_proto != nil {
+		return
+	}
+	file_schemas_greyseal_v1_dataset_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_trace_proto_rawDesc), len(file_schemas_greyseal_v1_trace_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_trace_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_trace_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_trace_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_trace_proto = out.File
+	file_schemas_greyseal_v1_trace_proto_goTypes = nil
+	file_schemas_greyseal_v1_trace_proto_depIdxs = nil
+}
@@ -0,0 +1,403 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: schemas/greyseal/v1/transcript.proto
+
+package greysealv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TranscriptTurn is one recorded user→assistant exchange, stored as its own
+// object in the transcript bucket.
+type TranscriptTurn struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message_uuid is the assistant message the turn produced.
+	MessageUuid         string                 `protobuf:"bytes,1,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	RoleUuid            string                 `protobuf:"bytes,2,opt,name=role_uuid,json=roleUuid,proto3" json:"role_uuid,omitempty"`
+	Model               string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	TurnIndex           int32                  `protobuf:"varint,4,opt,name=turn_index,json=turnIndex,proto3" json:"turn_index,omitempty"`
+	Timestamp           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UserMessage         string                 `protobuf:"bytes,6,opt,name=user_message,json=userMessage,proto3" json:"user_message,omitempty"`
+	SystemPrompt        string                 `protobuf:"bytes,7,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	ConversationSummary string                 `protobuf:"bytes,8,opt,name=conversation_summary,json=conversationSummary,proto3" json:"conversation_summary,omitempty"`
+	HistoryDepth        int32                  `protobuf:"varint,9,opt,name=history_depth,json=historyDepth,proto3" json:"history_depth,omitempty"`
+	SearchQuery         string                 `protobuf:"bytes,10,opt,name=search_query,json=searchQuery,proto3" json:"search_query,omitempty"`
+	SearchResults       []*DatasetSnippet      `protobuf:"bytes,11,rep,name=search_results,json=searchResults,proto3" json:"search_results,omitempty"`
+	AssembledMessages   []*DatasetMessage      `protobuf:"bytes,12,rep,name=assembled_messages,json=assembledMessages,proto3" json:"assembled_messages,omitempty"`
+	Response            string                 `protobuf:"bytes,13,opt,name=response,proto3" json:"response,omitempty"`
+	ResourceUuids       []string               `protobuf:"bytes,14,rep,name=resource_uuids,json=resourceUuids,proto3" json:"resource_uuids,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TranscriptTurn) Reset() {
+	*x = TranscriptTurn{}
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptTurn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptTurn) ProtoMessage() {}
+
+func (x *TranscriptTurn) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptTurn.ProtoReflect.Descriptor instead.
+func (*TranscriptTurn) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_transcript_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TranscriptTurn) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetRoleUuid() string {
+	if x != nil {
+		return x.RoleUuid
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetTurnIndex() int32 {
+	if x != nil {
+		return x.TurnIndex
+	}
+	return 0
+}
+
+func (x *TranscriptTurn) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TranscriptTurn) GetUserMessage() string {
+	if x != nil {
+		return x.UserMessage
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetConversationSummary() string {
+	if x != nil {
+		return x.ConversationSummary
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetHistoryDepth() int32 {
+	if x != nil {
+		return x.HistoryDepth
+	}
+	return 0
+}
+
+func (x *TranscriptTurn) GetSearchQuery() string {
+	if x != nil {
+		return x.SearchQuery
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetSearchResults() []*DatasetSnippet {
+	if x != nil {
+		return x.SearchResults
+	}
+	return nil
+}
+
+func (x *TranscriptTurn) GetAssembledMessages() []*DatasetMessage {
+	if x != nil {
+		return x.AssembledMessages
+	}
+	return nil
+}
+
+func (x *TranscriptTurn) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *TranscriptTurn) GetResourceUuids() []string {
+	if x != nil {
+		return x.ResourceUuids
+	}
+	return nil
+}
+
+// Transcript is a conversation's recorded turns with the combined Markdown
+// rendering.
+type Transcript struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationUuid string                 `protobuf:"bytes,1,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	// turns are in the order they happened. Conversations recorded only as
+	// Markdown, before turns were stored separately, have no turns.
+	Turns         []*TranscriptTurn `protobuf:"bytes,2,rep,name=turns,proto3" json:"turns,omitempty"`
+	Markdown      string            `protobuf:"bytes,3,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transcript) Reset() {
+	*x = Transcript{}
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transcript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transcript) ProtoMessage() {}
+
+func (x *Transcript) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transcript.ProtoReflect.Descriptor instead.
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_transcript_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Transcript) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *Transcript) GetTurns() []*TranscriptTurn {
+	if x != nil {
+		return x.Turns
+	}
+	return nil
+}
+
+func (x *Transcript) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+// TranscriptInfo describes a conversation with a recorded transcript.
+type TranscriptInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationUuid string                 `protobuf:"bytes,1,opt,name=conversation_uuid,json=conversationUuid,proto3" json:"conversation_uuid,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	TurnCount        int32                  `protobuf:"varint,3,opt,name=turn_count,json=turnCount,proto3" json:"turn_count,omitempty"`
+	// updated_at is when the latest turn was written.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptInfo) Reset() {
+	*x = TranscriptInfo{}
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptInfo) ProtoMessage() {}
+
+func (x *TranscriptInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_schemas_greyseal_v1_transcript_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptInfo.ProtoReflect.Descriptor instead.
+func (*TranscriptInfo) Descriptor() ([]byte, []int) {
+	return file_schemas_greyseal_v1_transcript_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TranscriptInfo) GetConversationUuid() string {
+	if x != nil {
+		return x.ConversationUuid
+	}
+	return ""
+}
+
+func (x *TranscriptInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TranscriptInfo) GetTurnCount() int32 {
+	if x != nil {
+		return x.TurnCount
+	}
+	return 0
+}
+
+func (x *TranscriptInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_schemas_greyseal_v1_transcript_proto protoreflect.FileDescriptor
+
+const file_schemas_greyseal_v1_transcript_proto_rawDesc = "" +
+	"\n" +
+	"$schemas/greyseal/v1/transcript.proto\x12\x13schemas.greyseal.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!schemas/greyseal/v1/dataset.proto\"\xe5\x04\n" +
+	"\x0eTranscriptTurn\x12!\n" +
+	"\fmessage_uuid\x18\x01 \x01(\tR\vmessageUuid\x12\x1b\n" +
+	"\trole_uuid\x18\x02 \x01(\tR\broleUuid\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"turn_index\x18\x04 \x01(\x05R\tturnIndex\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12!\n" +
+	"\fuser_message\x18\x06 \x01(\tR\vuserMessage\x12#\n" +
+	"\rsystem_prompt\x18\a \x01(\tR\fsystemPrompt\x121\n" +
+	"\x14conversation_summary\x18\b \x01(\tR\x13conversationSummary\x12#\n" +
+	"\rhistory_depth\x18\t \x01(\x05R\fhistoryDepth\x12!\n" +
+	"\fsearch_query\x18\n" +
+	" \x01(\tR\vsearchQuery\x12J\n" +
+	"\x0esearch_results\x18\v \x03(\v2#.schemas.greyseal.v1.DatasetSnippetR\rsearchResults\x12R\n" +
+	"\x12assembled_messages\x18\f \x03(\v2#.schemas.greyseal.v1.DatasetMessageR\x11assembledMessages\x12\x1a\n" +
+	"\bresponse\x18\r \x01(\tR\bresponse\x12%\n" +
+	"\x0eresource_uuids\x18\x0e \x03(\tR\rresourceUuids\"\x90\x01\n" +
+	"\n" +
+	"Transcript\x12+\n" +
+	"\x11conversation_uuid\x18\x01 \x01(\tR\x10conversationUuid\x129\n" +
+	"\x05turns\x18\x02 \x03(\v2#.schemas.greyseal.v1.TranscriptTurnR\x05turns\x12\x1a\n" +
+	"\bmarkdown\x18\x03 \x01(\tR\bmarkdown\"\xad\x01\n" +
+	"\x0eTranscriptInfo\x12+\n" +
+	"\x11conversation_uuid\x18\x01 \x01(\tR\x10conversationUuid\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"turn_count\x18\x03 \x01(\x05R\tturnCount\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\xda\x01\n" +
+	"\x17com.schemas.greyseal.v1B\x0fTranscriptProtoP\x01Z@github.com/holmes89/grey-seal/lib/schemas/greyseal/v1;greysealv1\xa2\x02\x03SGX\xaa\x02\x13Schemas.Greyseal.V1\xca\x02\x13Schemas\\Greyseal\\V1\xe2\x02\x1fSchemas\\Greyseal\\V1\\GPBMetadata\xea\x02\x15Schemas::Greyseal::V1b\x06proto3"
+
+var (
+	file_schemas_greyseal_v1_transcript_proto_rawDescOnce sync.Once
+	file_schemas_greyseal_v1_transcript_proto_rawDescData []byte
+)
+
+func file_schemas_greyseal_v1_transcript_proto_rawDescGZIP() []byte {
+	file_schemas_greyseal_v1_transcript_proto_rawDescOnce.Do(func() {
+		file_schemas_greyseal_v1_transcript_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_transcript_proto_rawDesc), len(file_schemas_greyseal_v1_transcript_proto_rawDesc)))
+	})
+	return file_schemas_greyseal_v1_transcript_proto_rawDescData
+}
+
+var file_schemas_greyseal_v1_transcript_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_schemas_greyseal_v1_transcript_proto_goTypes = []any{
+	(*TranscriptTurn)(nil),        // 0: schemas.greyseal.v1.TranscriptTurn
+	(*Transcript)(nil),            // 1: schemas.greyseal.v1.Transcript
+	(*TranscriptInfo)(nil),        // 2: schemas.greyseal.v1.TranscriptInfo
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*DatasetSnippet)(nil),        // 4: schemas.greyseal.v1.DatasetSnippet
+	(*DatasetMessage)(nil),        // 5: schemas.greyseal.v1.DatasetMessage
+}
+var file_schemas_greyseal_v1_transcript_proto_depIdxs = []int32{
+	3, // 0: schemas.greyseal.v1.TranscriptTurn.timestamp:type_name -> google.protobuf.Timestamp
+	4, // 1: schemas.greyseal.v1.TranscriptTurn.search_results:type_name -> schemas.greyseal.v1.DatasetSnippet
+	5, // 2: schemas.greyseal.v1.TranscriptTurn.assembled_messages:type_name -> schemas.greyseal.v1.DatasetMessage
+	0, // 3: schemas.greyseal.v1.Transcript.turns:type_name -> schemas.greyseal.v1.TranscriptTurn
+	3, // 4: schemas.greyseal.v1.TranscriptInfo.updated_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_schemas_greyseal_v1_transcript_proto_init() }
+func file_schemas_greyseal_v1_transcript_proto_init() {
+	if File_schemas_greyseal_v1_transcript_proto != nil {
+		return
+	}
+	file_schemas_greyseal_v1_dataset_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemas_greyseal_v1_transcript_proto_rawDesc), len(file_schemas_greyseal_v1_transcript_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schemas_greyseal_v1_transcript_proto_goTypes,
+		DependencyIndexes: file_schemas_greyseal_v1_transcript_proto_depIdxs,
+		MessageInfos:      file_schemas_greyseal_v1_transcript_proto_msgTypes,
+	}.Build()
+	File_schemas_greyseal_v1_transcript_proto = out.File
+	file_schemas_greyseal_v1_transcript_proto_goTypes = nil
+	file_schemas_greyseal_v1_transcript_proto_depIdxs = nil
+}